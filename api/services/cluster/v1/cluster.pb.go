@@ -519,6 +519,171 @@ func (m *HealthResponse) GetNodes() []*NodeHealth {
 	return nil
 }
 
+type RebalanceRequest struct {
+	// dry_run returns the planned moves without executing them
+	DryRun               bool     `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebalanceRequest) Reset()         { *m = RebalanceRequest{} }
+func (m *RebalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*RebalanceRequest) ProtoMessage()    {}
+func (*RebalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c077b095128b9733, []int{13}
+}
+func (m *RebalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceRequest.Unmarshal(m, b)
+}
+func (m *RebalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *RebalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceRequest.Merge(m, src)
+}
+func (m *RebalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_RebalanceRequest.Size(m)
+}
+func (m *RebalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceRequest proto.InternalMessageInfo
+
+func (m *RebalanceRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// Move is a replica moved from one node to another
+type Move struct {
+	Application string `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Service     string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	ContainerID string `protobuf:"bytes,3,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	FromNodeID  string `protobuf:"bytes,4,opt,name=from_node_id,json=fromNodeId,proto3" json:"from_node_id,omitempty"`
+	ToNodeID    string `protobuf:"bytes,5,opt,name=to_node_id,json=toNodeId,proto3" json:"to_node_id,omitempty"`
+	Error       string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// new_container_id is the id of the replica created on the target node
+	NewContainerID       string   `protobuf:"bytes,7,opt,name=new_container_id,json=newContainerId,proto3" json:"new_container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Move) Reset()         { *m = Move{} }
+func (m *Move) String() string { return proto.CompactTextString(m) }
+func (*Move) ProtoMessage()    {}
+func (*Move) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c077b095128b9733, []int{14}
+}
+func (m *Move) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Move.Unmarshal(m, b)
+}
+func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+}
+func (m *Move) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Move.Merge(m, src)
+}
+func (m *Move) XXX_Size() int {
+	return xxx_messageInfo_Move.Size(m)
+}
+func (m *Move) XXX_DiscardUnknown() {
+	xxx_messageInfo_Move.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Move proto.InternalMessageInfo
+
+func (m *Move) GetApplication() string {
+	if m != nil {
+		return m.Application
+	}
+	return ""
+}
+
+func (m *Move) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Move) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *Move) GetFromNodeID() string {
+	if m != nil {
+		return m.FromNodeID
+	}
+	return ""
+}
+
+func (m *Move) GetToNodeID() string {
+	if m != nil {
+		return m.ToNodeID
+	}
+	return ""
+}
+
+func (m *Move) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Move) GetNewContainerID() string {
+	if m != nil {
+		return m.NewContainerID
+	}
+	return ""
+}
+
+type RebalanceResponse struct {
+	Moves                []*Move  `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebalanceResponse) Reset()         { *m = RebalanceResponse{} }
+func (m *RebalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*RebalanceResponse) ProtoMessage()    {}
+func (*RebalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c077b095128b9733, []int{15}
+}
+func (m *RebalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceResponse.Unmarshal(m, b)
+}
+func (m *RebalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *RebalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceResponse.Merge(m, src)
+}
+func (m *RebalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_RebalanceResponse.Size(m)
+}
+func (m *RebalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceResponse proto.InternalMessageInfo
+
+func (m *RebalanceResponse) GetMoves() []*Move {
+	if m != nil {
+		return m.Moves
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*InfoRequest)(nil), "stellar.services.cluster.v1.InfoRequest")
 	proto.RegisterType((*InfoResponse)(nil), "stellar.services.cluster.v1.InfoResponse")
@@ -534,6 +699,9 @@ func init() {
 	proto.RegisterType((*HealthRequest)(nil), "stellar.services.cluster.v1.HealthRequest")
 	proto.RegisterType((*NodeHealth)(nil), "stellar.services.cluster.v1.NodeHealth")
 	proto.RegisterType((*HealthResponse)(nil), "stellar.services.cluster.v1.HealthResponse")
+	proto.RegisterType((*RebalanceRequest)(nil), "stellar.services.cluster.v1.RebalanceRequest")
+	proto.RegisterType((*Move)(nil), "stellar.services.cluster.v1.Move")
+	proto.RegisterType((*RebalanceResponse)(nil), "stellar.services.cluster.v1.RebalanceResponse")
 }
 
 func init() {
@@ -541,47 +709,60 @@ func init() {
 }
 
 var fileDescriptor_c077b095128b9733 = []byte{
-	// 636 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xc5, 0x69, 0xea, 0x2a, 0xd3, 0x0f, 0xe8, 0x52, 0xa1, 0x60, 0x90, 0x28, 0x8b, 0x28, 0x6d,
-	0x51, 0x6d, 0xb5, 0x08, 0x01, 0xad, 0x8a, 0x44, 0x3f, 0x50, 0x2b, 0x55, 0x20, 0xf9, 0x84, 0x80,
-	0xcb, 0x36, 0xd9, 0x3a, 0x16, 0x8e, 0xb7, 0x78, 0x37, 0x91, 0xc2, 0x11, 0x2e, 0xdc, 0x91, 0xf8,
-	0x31, 0xfc, 0x0d, 0xee, 0x1c, 0x10, 0x3f, 0x04, 0xed, 0xee, 0xd8, 0x71, 0x44, 0x49, 0x0c, 0xb7,
-	0xd9, 0xf1, 0x9b, 0x37, 0x6f, 0x9f, 0x67, 0x6c, 0x78, 0x16, 0xc5, 0xaa, 0xd3, 0x3b, 0xf5, 0x5b,
-	0xa2, 0x1b, 0xf0, 0x0e, 0xfb, 0x90, 0x70, 0xa5, 0x02, 0xa9, 0x78, 0x92, 0xb0, 0x2c, 0x60, 0xe7,
-	0x71, 0x20, 0x79, 0xd6, 0x8f, 0x5b, 0x5c, 0x06, 0xad, 0xa4, 0x27, 0x15, 0xcf, 0x82, 0xfe, 0x66,
-	0x1e, 0xfa, 0xe7, 0x99, 0x50, 0x82, 0xdc, 0x40, 0xb8, 0x9f, 0x43, 0xfd, 0xfc, 0x79, 0x7f, 0xd3,
-	0xbb, 0x19, 0x09, 0x11, 0x25, 0xdc, 0x50, 0xb1, 0x34, 0x15, 0x8a, 0xa9, 0x58, 0xa4, 0xd2, 0x96,
-	0x7a, 0x4b, 0x91, 0x88, 0x84, 0x09, 0x03, 0x1d, 0x61, 0xf6, 0xee, 0x48, 0xdf, 0xac, 0x97, 0xaa,
-	0xb8, 0xcb, 0x75, 0x5f, 0x0c, 0x11, 0x76, 0x67, 0x04, 0xd6, 0xe1, 0x2c, 0x51, 0x1d, 0x8d, 0xb2,
-	0x91, 0x05, 0xd1, 0x79, 0x98, 0x3d, 0x4e, 0xcf, 0x44, 0xc8, 0xdf, 0xf7, 0xb8, 0x54, 0x74, 0x05,
-	0xe6, 0xec, 0x51, 0x9e, 0x8b, 0x54, 0x72, 0x72, 0x0d, 0x6a, 0x71, 0xbb, 0xe9, 0x2c, 0x3b, 0xab,
-	0x8d, 0x3d, 0xf7, 0xe7, 0x8f, 0x5b, 0xb5, 0xe3, 0x83, 0xb0, 0x16, 0xb7, 0xe9, 0x06, 0x2c, 0xee,
-	0x8b, 0x54, 0xb1, 0x38, 0xe5, 0x99, 0xc4, 0x62, 0xd2, 0x84, 0x99, 0xb3, 0x38, 0x51, 0x3c, 0x93,
-	0x4d, 0x67, 0x79, 0x6a, 0xb5, 0x11, 0xe6, 0x47, 0x7a, 0x19, 0xe6, 0x8f, 0xbb, 0x2c, 0xe2, 0x39,
-	0x94, 0x2e, 0xc0, 0xdc, 0x0b, 0xd1, 0x1e, 0x9e, 0xdf, 0x02, 0x29, 0xf3, 0x61, 0xf7, 0xe7, 0x00,
-	0xad, 0x22, 0x6b, 0x38, 0x67, 0xb7, 0x56, 0xfc, 0x31, 0x76, 0xfa, 0x05, 0x49, 0x58, 0xaa, 0xa4,
-	0x27, 0xb0, 0x90, 0xb7, 0x47, 0xe6, 0x6d, 0x70, 0x63, 0x93, 0x41, 0x56, 0xfa, 0x27, 0x6b, 0x6e,
-	0x66, 0x7f, 0xd3, 0x37, 0xc5, 0x21, 0x56, 0xd0, 0x6f, 0x0e, 0xd4, 0xb5, 0xf8, 0xbf, 0x99, 0xa3,
-	0x7d, 0x60, 0xed, 0x76, 0xc6, 0xa5, 0x6c, 0xd6, 0xf4, 0xc3, 0x30, 0x3f, 0x92, 0x43, 0x70, 0x13,
-	0x76, 0xca, 0x13, 0xd9, 0x9c, 0x32, 0x6d, 0x37, 0xc6, 0x5e, 0x46, 0x37, 0xf1, 0x4f, 0x0c, 0xfe,
-	0x30, 0x55, 0xd9, 0x20, 0xc4, 0x62, 0xef, 0x09, 0xcc, 0x96, 0xd2, 0xe4, 0x0a, 0x4c, 0xbd, 0xe3,
-	0x03, 0x2b, 0x24, 0xd4, 0x21, 0x59, 0x82, 0xe9, 0x3e, 0x4b, 0x7a, 0x1c, 0xfb, 0xdb, 0xc3, 0x76,
-	0xed, 0xb1, 0x43, 0x8f, 0x60, 0x1e, 0x8d, 0x47, 0x27, 0x1e, 0xc1, 0x74, 0xaa, 0x13, 0x68, 0xc4,
-	0xed, 0x89, 0x8a, 0x42, 0x8b, 0xa7, 0x9f, 0x1d, 0x68, 0x14, 0x76, 0x93, 0x03, 0x68, 0x14, 0x86,
-	0x1b, 0x25, 0x17, 0xbe, 0xa9, 0x92, 0xa7, 0xc3, 0x37, 0x35, 0x2c, 0x24, 0x0f, 0xa1, 0xae, 0xc9,
-	0x8d, 0xec, 0x4a, 0x5a, 0x0c, 0x5c, 0x8f, 0xd7, 0x91, 0x19, 0xea, 0x7c, 0x9c, 0x3e, 0x39, 0x00,
-	0xfa, 0xb9, 0xcd, 0x16, 0xb4, 0xce, 0x3f, 0xd1, 0x92, 0xa7, 0xe0, 0xda, 0x5d, 0x41, 0x3d, 0x17,
-	0x5c, 0x08, 0x77, 0x09, 0xeb, 0x50, 0x04, 0x56, 0xd1, 0x97, 0xb0, 0x90, 0xcb, 0x42, 0xb3, 0x77,
-	0x47, 0xcd, 0xbe, 0x37, 0x51, 0x09, 0xd6, 0xdb, 0xaa, 0xad, 0xaf, 0x75, 0x98, 0xd9, 0xb7, 0x00,
-	0xf2, 0x06, 0xea, 0x7a, 0x53, 0xc9, 0xea, 0x58, 0x8e, 0xd2, 0x6e, 0x7b, 0x6b, 0x15, 0x90, 0xa8,
-	0xb3, 0x0b, 0x30, 0x5c, 0x47, 0xe2, 0x57, 0x5b, 0xb9, 0x7c, 0x99, 0xbd, 0xa0, 0x32, 0x1e, 0xdb,
-	0x31, 0x70, 0xed, 0x7e, 0x92, 0xf5, 0xf1, 0x1a, 0xcb, 0xdf, 0x10, 0xef, 0x7e, 0x25, 0x2c, 0xb6,
-	0x18, 0xc0, 0xb4, 0x99, 0x7b, 0xb2, 0x36, 0xd1, 0xf3, 0xa2, 0xc1, 0x7a, 0x15, 0xa8, 0xe5, 0xa7,
-	0xd7, 0x3f, 0x7e, 0xff, 0xf5, 0xa5, 0x76, 0x95, 0x2c, 0x96, 0xbe, 0xff, 0x81, 0x79, 0x6b, 0xfa,
-	0x76, 0x38, 0x87, 0xe3, 0x09, 0x47, 0x46, 0x78, 0xc2, 0xed, 0x46, 0xe7, 0x6a, 0x6f, 0xf7, 0xf5,
-	0xce, 0x7f, 0xfc, 0xa7, 0x76, 0x30, 0x7c, 0x75, 0xe9, 0xd4, 0x35, 0x7f, 0x83, 0x07, 0xbf, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0xdd, 0xa7, 0xbe, 0x4e, 0xef, 0x06, 0x00, 0x00,
+	// 845 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x6e, 0xeb, 0x44,
+	0x10, 0xc6, 0x4e, 0xe2, 0x34, 0x93, 0x34, 0xa7, 0x5d, 0x2a, 0x30, 0x06, 0x29, 0xc5, 0x88, 0x43,
+	0x4f, 0xab, 0x63, 0xd3, 0x22, 0x04, 0xb4, 0x14, 0x89, 0x36, 0xad, 0x1a, 0xa9, 0x14, 0xc9, 0xe2,
+	0x02, 0x01, 0x52, 0xe4, 0xc4, 0x9b, 0xc4, 0xe0, 0x78, 0xc3, 0x7a, 0x93, 0x2a, 0x5c, 0x96, 0x1b,
+	0xee, 0x79, 0x09, 0x9e, 0x81, 0xd7, 0xe0, 0x3e, 0x17, 0x11, 0x0f, 0x82, 0xf6, 0xc7, 0x8e, 0x03,
+	0x34, 0x35, 0xdc, 0xcd, 0xec, 0x7e, 0x33, 0xf3, 0xed, 0xe7, 0x99, 0x49, 0xe0, 0xf3, 0x61, 0xc8,
+	0x46, 0xd3, 0x9e, 0xd3, 0x27, 0x63, 0x17, 0x8f, 0xfc, 0x9f, 0x22, 0xcc, 0x98, 0x9b, 0x30, 0x1c,
+	0x45, 0x3e, 0x75, 0xfd, 0x49, 0xe8, 0x26, 0x98, 0xce, 0xc2, 0x3e, 0x4e, 0xdc, 0x7e, 0x34, 0x4d,
+	0x18, 0xa6, 0xee, 0xec, 0x38, 0x35, 0x9d, 0x09, 0x25, 0x8c, 0xa0, 0x37, 0x15, 0xdc, 0x49, 0xa1,
+	0x4e, 0x7a, 0x3f, 0x3b, 0xb6, 0xde, 0x1a, 0x12, 0x32, 0x8c, 0xb0, 0x48, 0xe5, 0xc7, 0x31, 0x61,
+	0x3e, 0x0b, 0x49, 0x9c, 0xc8, 0x50, 0x6b, 0x6f, 0x48, 0x86, 0x44, 0x98, 0x2e, 0xb7, 0xd4, 0xe9,
+	0xbb, 0x6b, 0x75, 0xe9, 0x34, 0x66, 0xe1, 0x18, 0xf3, 0xba, 0xca, 0x54, 0xb0, 0x77, 0xd6, 0x60,
+	0x23, 0xec, 0x47, 0x6c, 0xc4, 0x51, 0xd2, 0x92, 0x20, 0x7b, 0x1b, 0xea, 0x9d, 0x78, 0x40, 0x3c,
+	0xfc, 0xe3, 0x14, 0x27, 0xcc, 0x7e, 0x0e, 0x0d, 0xe9, 0x26, 0x13, 0x12, 0x27, 0x18, 0xbd, 0x06,
+	0x7a, 0x18, 0x98, 0xda, 0xbe, 0x76, 0x50, 0xbb, 0x30, 0x96, 0x8b, 0x96, 0xde, 0x69, 0x7b, 0x7a,
+	0x18, 0xd8, 0x2f, 0x61, 0xf7, 0x92, 0xc4, 0xcc, 0x0f, 0x63, 0x4c, 0x13, 0x15, 0x8c, 0x4c, 0xa8,
+	0x0e, 0xc2, 0x88, 0x61, 0x9a, 0x98, 0xda, 0x7e, 0xe9, 0xa0, 0xe6, 0xa5, 0xae, 0xfd, 0x0c, 0xb6,
+	0x3b, 0x63, 0x7f, 0x88, 0x53, 0xa8, 0xdd, 0x84, 0xc6, 0x1d, 0x09, 0x56, 0xfe, 0x77, 0x80, 0xf2,
+	0xf9, 0x54, 0xf5, 0x6b, 0x80, 0x7e, 0x76, 0x2a, 0x72, 0xd6, 0x4f, 0x9e, 0x3b, 0x1b, 0xe4, 0x74,
+	0xb2, 0x24, 0x5e, 0x2e, 0xd2, 0xbe, 0x85, 0x66, 0x5a, 0x5e, 0x65, 0x3e, 0x05, 0x23, 0x14, 0x27,
+	0x2a, 0xab, 0xfd, 0xcf, 0xac, 0xa9, 0x98, 0xb3, 0x63, 0x47, 0x04, 0x7b, 0x2a, 0xc2, 0xfe, 0x5d,
+	0x83, 0x32, 0x27, 0xff, 0x98, 0x38, 0x5c, 0x07, 0x3f, 0x08, 0x28, 0x4e, 0x12, 0x53, 0xe7, 0x97,
+	0x5e, 0xea, 0xa2, 0x2b, 0x30, 0x22, 0xbf, 0x87, 0xa3, 0xc4, 0x2c, 0x89, 0xb2, 0x2f, 0x37, 0x3e,
+	0x86, 0x17, 0x71, 0x6e, 0x05, 0xfe, 0x2a, 0x66, 0x74, 0xee, 0xa9, 0x60, 0xeb, 0x13, 0xa8, 0xe7,
+	0x8e, 0xd1, 0x0e, 0x94, 0x7e, 0xc0, 0x73, 0x49, 0xc4, 0xe3, 0x26, 0xda, 0x83, 0xca, 0xcc, 0x8f,
+	0xa6, 0x58, 0xd5, 0x97, 0xce, 0xa9, 0xfe, 0xb1, 0x66, 0xdf, 0xc0, 0xb6, 0x12, 0x5e, 0x29, 0xf1,
+	0x11, 0x54, 0x62, 0x12, 0x64, 0x42, 0xbc, 0xfd, 0x24, 0x23, 0x4f, 0xe2, 0xed, 0x5f, 0x34, 0xa8,
+	0x65, 0x72, 0xa3, 0x36, 0xd4, 0x32, 0xc1, 0x05, 0x93, 0x7f, 0xfd, 0x52, 0x39, 0x4d, 0x57, 0x5f,
+	0x6a, 0x15, 0x88, 0x3e, 0x84, 0x32, 0x4f, 0x2e, 0x68, 0x17, 0xe2, 0x22, 0xe0, 0xbc, 0xbd, 0x6e,
+	0x44, 0x53, 0xa7, 0xed, 0xf4, 0xb3, 0x06, 0xc0, 0xef, 0xe5, 0x69, 0x96, 0x56, 0xfb, 0x4f, 0x69,
+	0xd1, 0x67, 0x60, 0xc8, 0x59, 0x31, 0xf5, 0xc7, 0x1e, 0x24, 0xef, 0xd3, 0x38, 0x45, 0x42, 0x45,
+	0xd9, 0x5f, 0x42, 0x53, 0x9d, 0xa4, 0x62, 0x9f, 0xaf, 0x8b, 0xfd, 0xde, 0x93, 0x4c, 0x54, 0xbc,
+	0x92, 0xfc, 0x08, 0x76, 0x3c, 0xdc, 0xf3, 0x23, 0x3f, 0xee, 0xe3, 0x74, 0xe8, 0x5e, 0x87, 0x6a,
+	0x40, 0xe7, 0x5d, 0x3a, 0x8d, 0xc5, 0xf3, 0xb6, 0x3c, 0x23, 0xa0, 0x73, 0x6f, 0x1a, 0xdb, 0xbf,
+	0xe9, 0x50, 0xfe, 0x82, 0xcc, 0x30, 0xda, 0x87, 0xba, 0x3f, 0x99, 0x44, 0x61, 0x5f, 0xac, 0x16,
+	0xd5, 0x26, 0xf9, 0x23, 0xde, 0xb0, 0x8a, 0x40, 0xda, 0xb0, 0xca, 0x45, 0x27, 0xd0, 0xc8, 0xbe,
+	0x4e, 0x37, 0x0c, 0xcc, 0x92, 0x68, 0xf6, 0x67, 0xcb, 0x45, 0xab, 0x9e, 0x7d, 0xc0, 0x4e, 0xdb,
+	0xab, 0x67, 0xa0, 0x4e, 0x80, 0xde, 0x87, 0xc6, 0x80, 0x92, 0x71, 0x97, 0x73, 0xe6, 0x31, 0x65,
+	0x11, 0xd3, 0x5c, 0x2e, 0x5a, 0x70, 0x4d, 0xc9, 0x98, 0x3f, 0xab, 0xd3, 0xf6, 0x60, 0x90, 0xda,
+	0x01, 0x3a, 0x04, 0x60, 0x24, 0xc3, 0x57, 0x04, 0xbe, 0xb1, 0x5c, 0xb4, 0xb6, 0xbe, 0x22, 0x0a,
+	0xbd, 0xc5, 0x88, 0xc2, 0xee, 0x41, 0x05, 0x53, 0x4a, 0xa8, 0x69, 0xc8, 0xd6, 0x16, 0x0e, 0xfa,
+	0x14, 0x76, 0x62, 0x7c, 0xdf, 0x5d, 0xe3, 0x5a, 0x15, 0x79, 0xd0, 0x72, 0xd1, 0x6a, 0xde, 0xe1,
+	0xfb, 0x3c, 0xdd, 0x66, 0x9c, 0xf7, 0x03, 0xfb, 0x16, 0x76, 0x73, 0xba, 0xae, 0x06, 0x63, 0x4c,
+	0x66, 0x05, 0x07, 0x83, 0x0b, 0xed, 0x49, 0xfc, 0xc9, 0x43, 0x05, 0xaa, 0x97, 0xf2, 0x0a, 0x7d,
+	0x0b, 0x65, 0xbe, 0x4f, 0xd1, 0xc1, 0xc6, 0xe8, 0xdc, 0x06, 0xb6, 0x5e, 0x14, 0x40, 0x2a, 0x86,
+	0x63, 0x80, 0xd5, 0xd2, 0x44, 0x4e, 0xb1, 0xc5, 0x98, 0xae, 0x5c, 0xcb, 0x2d, 0x8c, 0x57, 0xe5,
+	0x7c, 0x30, 0xe4, 0x16, 0x45, 0x87, 0x9b, 0x39, 0xe6, 0x37, 0xbd, 0x75, 0x54, 0x08, 0xab, 0x4a,
+	0xcc, 0xa1, 0x22, 0xb6, 0x13, 0x7a, 0xf1, 0xe4, 0x64, 0x64, 0x05, 0x0e, 0x8b, 0x40, 0x65, 0x7e,
+	0xfb, 0x8d, 0x87, 0x3f, 0xfe, 0xfc, 0x55, 0x7f, 0x15, 0xed, 0xe6, 0x7e, 0xa5, 0x5d, 0x31, 0x5b,
+	0xfc, 0x75, 0x6a, 0x5b, 0x6c, 0x4e, 0xb8, 0xb6, 0x68, 0xac, 0xa3, 0x42, 0x58, 0xf5, 0xba, 0xef,
+	0xa1, 0x96, 0xb5, 0x19, 0xda, 0xbc, 0xfa, 0xff, 0x3e, 0xe6, 0x96, 0x53, 0x14, 0x2e, 0x6b, 0x5d,
+	0x9c, 0x7f, 0x73, 0xf6, 0x3f, 0xfe, 0xb9, 0x9c, 0x29, 0xf3, 0xeb, 0x57, 0x7a, 0x86, 0xf8, 0x7f,
+	0xf0, 0xc1, 0x5f, 0x03, 0x00, 0x69, 0x02, 0x6d, 0x37, 0x01, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -601,6 +782,7 @@ type ClusterClient interface {
 	Images(ctx context.Context, in *ImagesRequest, opts ...grpc.CallOption) (*ImagesResponse, error)
 	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error)
 }
 
 type clusterClient struct {
@@ -656,6 +838,15 @@ func (c *clusterClient) Health(ctx context.Context, in *HealthRequest, opts ...g
 	return out, nil
 }
 
+func (c *clusterClient) Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error) {
+	out := new(RebalanceResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.cluster.v1.Cluster/Rebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClusterServer is the server API for Cluster service.
 type ClusterServer interface {
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
@@ -663,6 +854,7 @@ type ClusterServer interface {
 	Images(context.Context, *ImagesRequest) (*ImagesResponse, error)
 	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	Rebalance(context.Context, *RebalanceRequest) (*RebalanceResponse, error)
 }
 
 func RegisterClusterServer(s *grpc.Server, srv ClusterServer) {
@@ -759,6 +951,24 @@ func _Cluster_Health_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cluster_Rebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).Rebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.cluster.v1.Cluster/Rebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).Rebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cluster_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "stellar.services.cluster.v1.Cluster",
 	HandlerType: (*ClusterServer)(nil),
@@ -783,6 +993,10 @@ var _Cluster_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _Cluster_Health_Handler,
 		},
+		{
+			MethodName: "Rebalance",
+			Handler:    _Cluster_Rebalance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/ehazlett/stellar/api/services/cluster/v1/cluster.proto",
@@ -49,6 +49,142 @@ func (SyncAction) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_704b2444211b6092, []int{0}
 }
 
+type PeerLockRequest_Action int32
+
+const (
+	// GRANT reserves the lock for the owner if free
+	PeerLockRequest_GRANT PeerLockRequest_Action = 0
+	// CONFIRM assigns the fencing token to a granted lease
+	PeerLockRequest_CONFIRM PeerLockRequest_Action = 1
+	PeerLockRequest_RENEW   PeerLockRequest_Action = 2
+	PeerLockRequest_RELEASE PeerLockRequest_Action = 3
+)
+
+var PeerLockRequest_Action_name = map[int32]string{
+	0: "GRANT",
+	1: "CONFIRM",
+	2: "RENEW",
+	3: "RELEASE",
+}
+
+var PeerLockRequest_Action_value = map[string]int32{
+	"GRANT":   0,
+	"CONFIRM": 1,
+	"RENEW":   2,
+	"RELEASE": 3,
+}
+
+func (x PeerLockRequest_Action) String() string {
+	return proto.EnumName(PeerLockRequest_Action_name, int32(x))
+}
+
+func (PeerLockRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{8, 0}
+}
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_PUT    WatchEvent_Type = 0
+	WatchEvent_DELETE WatchEvent_Type = 1
+)
+
+var WatchEvent_Type_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+}
+
+var WatchEvent_Type_value = map[string]int32{
+	"PUT":    0,
+	"DELETE": 1,
+}
+
+func (x WatchEvent_Type) String() string {
+	return proto.EnumName(WatchEvent_Type_name, int32(x))
+}
+
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{39, 0}
+}
+
+type Compare_Target int32
+
+const (
+	Compare_VALUE   Compare_Target = 0
+	Compare_VERSION Compare_Target = 1
+	Compare_EXISTS  Compare_Target = 2
+)
+
+var Compare_Target_name = map[int32]string{
+	0: "VALUE",
+	1: "VERSION",
+	2: "EXISTS",
+}
+
+var Compare_Target_value = map[string]int32{
+	"VALUE":   0,
+	"VERSION": 1,
+	"EXISTS":  2,
+}
+
+func (x Compare_Target) String() string {
+	return proto.EnumName(Compare_Target_name, int32(x))
+}
+
+func (Compare_Target) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{40, 0}
+}
+
+type Compare_Result int32
+
+const (
+	Compare_EQUAL     Compare_Result = 0
+	Compare_NOT_EQUAL Compare_Result = 1
+)
+
+var Compare_Result_name = map[int32]string{
+	0: "EQUAL",
+	1: "NOT_EQUAL",
+}
+
+var Compare_Result_value = map[string]int32{
+	"EQUAL":     0,
+	"NOT_EQUAL": 1,
+}
+
+func (x Compare_Result) String() string {
+	return proto.EnumName(Compare_Result_name, int32(x))
+}
+
+func (Compare_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{40, 1}
+}
+
+type TxnOp_Type int32
+
+const (
+	TxnOp_PUT    TxnOp_Type = 0
+	TxnOp_DELETE TxnOp_Type = 1
+)
+
+var TxnOp_Type_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+}
+
+var TxnOp_Type_value = map[string]int32{
+	"PUT":    0,
+	"DELETE": 1,
+}
+
+func (x TxnOp_Type) String() string {
+	return proto.EnumName(TxnOp_Type_name, int32(x))
+}
+
+func (TxnOp_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{41, 0}
+}
+
 type InfoRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -117,8 +253,78 @@ func (m *InfoResponse) GetID() string {
 	return ""
 }
 
+// Lease is a named lock held by an owner until it expires or is released
+type Lease struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// token is the fencing token; it increases each time the lock is acquired
+	Token                uint64          `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`
+	TTL                  *types.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{2}
+}
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Lease) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Lease) GetToken() uint64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *Lease) GetTTL() *types.Duration {
+	if m != nil {
+		return m.TTL
+	}
+	return nil
+}
+
 type AcquireLockRequest struct {
-	Timeout              *types.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// timeout is how long to wait for the lock
+	Timeout *types.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Name    string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// owner identifies the holder; it must be unique per holder
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// ttl is the lease duration; the lock is released if not renewed
+	TTL                  *types.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -128,7 +334,7 @@ func (m *AcquireLockRequest) Reset()         { *m = AcquireLockRequest{} }
 func (m *AcquireLockRequest) String() string { return proto.CompactTextString(m) }
 func (*AcquireLockRequest) ProtoMessage()    {}
 func (*AcquireLockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{2}
+	return fileDescriptor_704b2444211b6092, []int{3}
 }
 func (m *AcquireLockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AcquireLockRequest.Unmarshal(m, b)
@@ -155,679 +361,2504 @@ func (m *AcquireLockRequest) GetTimeout() *types.Duration {
 	return nil
 }
 
-type ReleaseLockRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AcquireLockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *ReleaseLockRequest) Reset()         { *m = ReleaseLockRequest{} }
-func (m *ReleaseLockRequest) String() string { return proto.CompactTextString(m) }
-func (*ReleaseLockRequest) ProtoMessage()    {}
-func (*ReleaseLockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{3}
-}
-func (m *ReleaseLockRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReleaseLockRequest.Unmarshal(m, b)
-}
-func (m *ReleaseLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReleaseLockRequest.Marshal(b, m, deterministic)
-}
-func (m *ReleaseLockRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseLockRequest.Merge(m, src)
-}
-func (m *ReleaseLockRequest) XXX_Size() int {
-	return xxx_messageInfo_ReleaseLockRequest.Size(m)
-}
-func (m *ReleaseLockRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseLockRequest.DiscardUnknown(m)
+func (m *AcquireLockRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
 }
 
-var xxx_messageInfo_ReleaseLockRequest proto.InternalMessageInfo
+func (m *AcquireLockRequest) GetTTL() *types.Duration {
+	if m != nil {
+		return m.TTL
+	}
+	return nil
+}
 
-type CreateBucketRequest struct {
-	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+type AcquireLockResponse struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateBucketRequest) Reset()         { *m = CreateBucketRequest{} }
-func (m *CreateBucketRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateBucketRequest) ProtoMessage()    {}
-func (*CreateBucketRequest) Descriptor() ([]byte, []int) {
+func (m *AcquireLockResponse) Reset()         { *m = AcquireLockResponse{} }
+func (m *AcquireLockResponse) String() string { return proto.CompactTextString(m) }
+func (*AcquireLockResponse) ProtoMessage()    {}
+func (*AcquireLockResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_704b2444211b6092, []int{4}
 }
-func (m *CreateBucketRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateBucketRequest.Unmarshal(m, b)
+func (m *AcquireLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcquireLockResponse.Unmarshal(m, b)
 }
-func (m *CreateBucketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateBucketRequest.Marshal(b, m, deterministic)
+func (m *AcquireLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcquireLockResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateBucketRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateBucketRequest.Merge(m, src)
+func (m *AcquireLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLockResponse.Merge(m, src)
 }
-func (m *CreateBucketRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateBucketRequest.Size(m)
+func (m *AcquireLockResponse) XXX_Size() int {
+	return xxx_messageInfo_AcquireLockResponse.Size(m)
 }
-func (m *CreateBucketRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateBucketRequest.DiscardUnknown(m)
+func (m *AcquireLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLockResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateBucketRequest proto.InternalMessageInfo
+var xxx_messageInfo_AcquireLockResponse proto.InternalMessageInfo
 
-func (m *CreateBucketRequest) GetBucket() string {
+func (m *AcquireLockResponse) GetLease() *Lease {
 	if m != nil {
-		return m.Bucket
+		return m.Lease
 	}
-	return ""
+	return nil
 }
 
-type SetRequest struct {
-	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	Sync                 bool     `protobuf:"varint,4,opt,name=sync,proto3" json:"sync,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type RenewLockRequest struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner                string          `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Token                uint64          `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`
+	TTL                  *types.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *SetRequest) Reset()         { *m = SetRequest{} }
-func (m *SetRequest) String() string { return proto.CompactTextString(m) }
-func (*SetRequest) ProtoMessage()    {}
-func (*SetRequest) Descriptor() ([]byte, []int) {
+func (m *RenewLockRequest) Reset()         { *m = RenewLockRequest{} }
+func (m *RenewLockRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewLockRequest) ProtoMessage()    {}
+func (*RenewLockRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_704b2444211b6092, []int{5}
 }
-func (m *SetRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetRequest.Unmarshal(m, b)
+func (m *RenewLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewLockRequest.Unmarshal(m, b)
 }
-func (m *SetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetRequest.Marshal(b, m, deterministic)
+func (m *RenewLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewLockRequest.Marshal(b, m, deterministic)
 }
-func (m *SetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetRequest.Merge(m, src)
+func (m *RenewLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewLockRequest.Merge(m, src)
 }
-func (m *SetRequest) XXX_Size() int {
-	return xxx_messageInfo_SetRequest.Size(m)
+func (m *RenewLockRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewLockRequest.Size(m)
 }
-func (m *SetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetRequest.DiscardUnknown(m)
+func (m *RenewLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewLockRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetRequest proto.InternalMessageInfo
+var xxx_messageInfo_RenewLockRequest proto.InternalMessageInfo
 
-func (m *SetRequest) GetBucket() string {
+func (m *RenewLockRequest) GetName() string {
 	if m != nil {
-		return m.Bucket
+		return m.Name
 	}
 	return ""
 }
 
-func (m *SetRequest) GetKey() string {
+func (m *RenewLockRequest) GetOwner() string {
 	if m != nil {
-		return m.Key
+		return m.Owner
 	}
 	return ""
 }
 
-func (m *SetRequest) GetValue() []byte {
+func (m *RenewLockRequest) GetToken() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Token
 	}
-	return nil
+	return 0
 }
 
-func (m *SetRequest) GetSync() bool {
+func (m *RenewLockRequest) GetTTL() *types.Duration {
 	if m != nil {
-		return m.Sync
+		return m.TTL
 	}
-	return false
+	return nil
 }
 
-type KeyValue struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type RenewLockResponse struct {
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KeyValue) Reset()         { *m = KeyValue{} }
-func (m *KeyValue) String() string { return proto.CompactTextString(m) }
-func (*KeyValue) ProtoMessage()    {}
-func (*KeyValue) Descriptor() ([]byte, []int) {
+func (m *RenewLockResponse) Reset()         { *m = RenewLockResponse{} }
+func (m *RenewLockResponse) String() string { return proto.CompactTextString(m) }
+func (*RenewLockResponse) ProtoMessage()    {}
+func (*RenewLockResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_704b2444211b6092, []int{6}
 }
-func (m *KeyValue) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
+func (m *RenewLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewLockResponse.Unmarshal(m, b)
 }
-func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+func (m *RenewLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewLockResponse.Marshal(b, m, deterministic)
 }
-func (m *KeyValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyValue.Merge(m, src)
+func (m *RenewLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewLockResponse.Merge(m, src)
 }
-func (m *KeyValue) XXX_Size() int {
-	return xxx_messageInfo_KeyValue.Size(m)
+func (m *RenewLockResponse) XXX_Size() int {
+	return xxx_messageInfo_RenewLockResponse.Size(m)
 }
-func (m *KeyValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+func (m *RenewLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewLockResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyValue proto.InternalMessageInfo
-
-func (m *KeyValue) GetKey() string {
-	if m != nil {
-		return m.Key
-	}
-	return ""
-}
+var xxx_messageInfo_RenewLockResponse proto.InternalMessageInfo
 
-func (m *KeyValue) GetValue() []byte {
+func (m *RenewLockResponse) GetLease() *Lease {
 	if m != nil {
-		return m.Value
+		return m.Lease
 	}
 	return nil
 }
 
-type GetRequest struct {
-	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+type ReleaseLockRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Token                uint64   `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRequest) Reset()         { *m = GetRequest{} }
-func (m *GetRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRequest) ProtoMessage()    {}
-func (*GetRequest) Descriptor() ([]byte, []int) {
+func (m *ReleaseLockRequest) Reset()         { *m = ReleaseLockRequest{} }
+func (m *ReleaseLockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseLockRequest) ProtoMessage()    {}
+func (*ReleaseLockRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_704b2444211b6092, []int{7}
 }
-func (m *GetRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+func (m *ReleaseLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseLockRequest.Unmarshal(m, b)
 }
-func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+func (m *ReleaseLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseLockRequest.Marshal(b, m, deterministic)
 }
-func (m *GetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRequest.Merge(m, src)
+func (m *ReleaseLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseLockRequest.Merge(m, src)
 }
-func (m *GetRequest) XXX_Size() int {
-	return xxx_messageInfo_GetRequest.Size(m)
+func (m *ReleaseLockRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseLockRequest.Size(m)
 }
-func (m *GetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+func (m *ReleaseLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseLockRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReleaseLockRequest proto.InternalMessageInfo
 
-func (m *GetRequest) GetBucket() string {
+func (m *ReleaseLockRequest) GetName() string {
 	if m != nil {
-		return m.Bucket
+		return m.Name
 	}
 	return ""
 }
 
-func (m *GetRequest) GetKey() string {
+func (m *ReleaseLockRequest) GetOwner() string {
 	if m != nil {
-		return m.Key
+		return m.Owner
 	}
 	return ""
 }
 
-type SearchRequest struct {
-	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+func (m *ReleaseLockRequest) GetToken() uint64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+type PeerLockRequest struct {
+	Action               PeerLockRequest_Action `protobuf:"varint,1,opt,name=action,proto3,enum=stellar.services.datastore.v1.PeerLockRequest_Action" json:"action,omitempty"`
+	Lease                *Lease                 `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PeerLockRequest) Reset()         { *m = PeerLockRequest{} }
+func (m *PeerLockRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerLockRequest) ProtoMessage()    {}
+func (*PeerLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{8}
+}
+func (m *PeerLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLockRequest.Unmarshal(m, b)
+}
+func (m *PeerLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLockRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLockRequest.Merge(m, src)
+}
+func (m *PeerLockRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerLockRequest.Size(m)
+}
+func (m *PeerLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLockRequest proto.InternalMessageInfo
+
+func (m *PeerLockRequest) GetAction() PeerLockRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return PeerLockRequest_GRANT
+}
+
+func (m *PeerLockRequest) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type PeerLockResponse struct {
+	Granted bool `protobuf:"varint,1,opt,name=granted,proto3" json:"granted,omitempty"`
+	// holder is the current lease for the lock on the peer
+	Holder *Lease `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	// token is the highest fencing token issued for the lock known to the peer
+	Token                uint64   `protobuf:"varint,3,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLockResponse) Reset()         { *m = PeerLockResponse{} }
+func (m *PeerLockResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerLockResponse) ProtoMessage()    {}
+func (*PeerLockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{9}
+}
+func (m *PeerLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLockResponse.Unmarshal(m, b)
+}
+func (m *PeerLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLockResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLockResponse.Merge(m, src)
+}
+func (m *PeerLockResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerLockResponse.Size(m)
+}
+func (m *PeerLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLockResponse proto.InternalMessageInfo
+
+func (m *PeerLockResponse) GetGranted() bool {
+	if m != nil {
+		return m.Granted
+	}
+	return false
+}
+
+func (m *PeerLockResponse) GetHolder() *Lease {
+	if m != nil {
+		return m.Holder
+	}
+	return nil
+}
+
+func (m *PeerLockResponse) GetToken() uint64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+type CreateBucketRequest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateBucketRequest) Reset()         { *m = CreateBucketRequest{} }
+func (m *CreateBucketRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateBucketRequest) ProtoMessage()    {}
+func (*CreateBucketRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{10}
+}
+func (m *CreateBucketRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateBucketRequest.Unmarshal(m, b)
+}
+func (m *CreateBucketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateBucketRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateBucketRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateBucketRequest.Merge(m, src)
+}
+func (m *CreateBucketRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateBucketRequest.Size(m)
+}
+func (m *CreateBucketRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateBucketRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateBucketRequest proto.InternalMessageInfo
+
+func (m *CreateBucketRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+// Version is a hybrid logical clock timestamp along with the node that
+// originated the write.  Versions are used to resolve conflicts (last writer wins).
+type Version struct {
+	// wall is the physical component in unix nanoseconds
+	Wall                 int64    `protobuf:"varint,1,opt,name=wall,proto3" json:"wall,omitempty"`
+	Logical              uint32   `protobuf:"varint,2,opt,name=logical,proto3" json:"logical,omitempty"`
+	NodeID               string   `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Version) Reset()         { *m = Version{} }
+func (m *Version) String() string { return proto.CompactTextString(m) }
+func (*Version) ProtoMessage()    {}
+func (*Version) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{11}
+}
+func (m *Version) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Version.Unmarshal(m, b)
+}
+func (m *Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Version.Marshal(b, m, deterministic)
+}
+func (m *Version) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Version.Merge(m, src)
+}
+func (m *Version) XXX_Size() int {
+	return xxx_messageInfo_Version.Size(m)
+}
+func (m *Version) XXX_DiscardUnknown() {
+	xxx_messageInfo_Version.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Version proto.InternalMessageInfo
+
+func (m *Version) GetWall() int64 {
+	if m != nil {
+		return m.Wall
+	}
+	return 0
+}
+
+func (m *Version) GetLogical() uint32 {
+	if m != nil {
+		return m.Logical
+	}
+	return 0
+}
+
+func (m *Version) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+// Entry is the versioned value stored in the datastore
+type Entry struct {
+	Version *Version `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Value   []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// deleted marks the entry as a tombstone
+	Deleted bool `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// expires_at is the time in unix nanoseconds after which the entry is
+	// removed; zero never expires
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// sequence is the local change log position of the write; it is not
+	// replicated
+	Sequence             uint64   `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Entry) Reset()         { *m = Entry{} }
+func (m *Entry) String() string { return proto.CompactTextString(m) }
+func (*Entry) ProtoMessage()    {}
+func (*Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{12}
+}
+func (m *Entry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Entry.Unmarshal(m, b)
+}
+func (m *Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Entry.Marshal(b, m, deterministic)
+}
+func (m *Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entry.Merge(m, src)
+}
+func (m *Entry) XXX_Size() int {
+	return xxx_messageInfo_Entry.Size(m)
+}
+func (m *Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entry proto.InternalMessageInfo
+
+func (m *Entry) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *Entry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Entry) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *Entry) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Entry) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type SetRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Sync   bool   `protobuf:"varint,4,opt,name=sync,proto3" json:"sync,omitempty"`
+	// version is set when replicating a write from a peer
+	Version *Version `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// ttl expires the key after the duration
+	TTL *types.Duration `protobuf:"bytes,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// expires_at is set when replicating a write from a peer
+	ExpiresAt            int64    `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetRequest) Reset()         { *m = SetRequest{} }
+func (m *SetRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRequest) ProtoMessage()    {}
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{13}
+}
+func (m *SetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRequest.Unmarshal(m, b)
+}
+func (m *SetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRequest.Merge(m, src)
+}
+func (m *SetRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRequest.Size(m)
+}
+func (m *SetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRequest proto.InternalMessageInfo
+
+func (m *SetRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SetRequest) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *SetRequest) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *SetRequest) GetTTL() *types.Duration {
+	if m != nil {
+		return m.TTL
+	}
+	return nil
+}
+
+func (m *SetRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type KeyValue struct {
+	Key     string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value   []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Version *Version `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// expires_at is the expiry in unix nanoseconds if the key has a ttl
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()    {}
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{14}
+}
+func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
+}
+func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+}
+func (m *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(m, src)
+}
+func (m *KeyValue) XXX_Size() int {
+	return xxx_messageInfo_KeyValue.Size(m)
+}
+func (m *KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValue proto.InternalMessageInfo
+
+func (m *KeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *KeyValue) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *KeyValue) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type GetRequest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{15}
+}
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *GetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// prefix matches the keys with the prefix; * matches all keys
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// limit is the maximum number of keys returned; zero returns all keys
+	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// start_after returns the keys after the key
+	StartAfter string `protobuf:"bytes,4,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	// keys_only omits the values
+	KeysOnly             bool     `protobuf:"varint,5,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{16}
+}
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetStartAfter() string {
+	if m != nil {
+		return m.StartAfter
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetKeysOnly() bool {
+	if m != nil {
+		return m.KeysOnly
+	}
+	return false
+}
+
+type SearchResponse struct {
+	Bucket string      `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Data   []*KeyValue `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	// next is the start_after key for the next page; it is empty when
+	// there are no more keys
+	Next                 string   `protobuf:"bytes,3,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{17}
+}
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetData() []*KeyValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+type GetResponse struct {
+	Bucket               string    `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Data                 *KeyValue `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{18}
+}
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
+}
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+}
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
+}
+func (m *GetResponse) XXX_Size() int {
+	return xxx_messageInfo_GetResponse.Size(m)
+}
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+
+func (m *GetResponse) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *GetResponse) GetData() *KeyValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	Bucket      string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key         string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Sync        bool   `protobuf:"varint,3,opt,name=sync,proto3" json:"sync,omitempty"`
+	NoTombstone bool   `protobuf:"varint,4,opt,name=no_tombstone,json=noTombstone,proto3" json:"no_tombstone,omitempty"`
+	// version is set when replicating a delete from a peer
+	Version              *Version `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{19}
+}
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetNoTombstone() bool {
+	if m != nil {
+		return m.NoTombstone
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{20}
+}
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+// BackupResponse is the backup data; when streaming each response is a
+// chunk of the gzip compressed backup
+type BackupResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{21}
+}
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// RestoreRequest is the backup data to restore; when streaming each request
+// is a chunk of the backup.  The data may be gzip compressed.
+type RestoreRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// snapshot restores the named snapshot from the node snapshot directory
+	Snapshot             string   `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{22}
+}
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RestoreRequest) GetSnapshot() string {
+	if m != nil {
+		return m.Snapshot
+	}
+	return ""
+}
+
+type Snapshot struct {
+	Name                 string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size_                int64            `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt            *types.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{23}
+}
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+}
+func (m *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(m, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return xxx_messageInfo_Snapshot.Size(m)
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Snapshot) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *Snapshot) GetCreatedAt() *types.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type SnapshotsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotsRequest) Reset()         { *m = SnapshotsRequest{} }
+func (m *SnapshotsRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotsRequest) ProtoMessage()    {}
+func (*SnapshotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{24}
+}
+func (m *SnapshotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotsRequest.Unmarshal(m, b)
+}
+func (m *SnapshotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotsRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotsRequest.Merge(m, src)
+}
+func (m *SnapshotsRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotsRequest.Size(m)
+}
+func (m *SnapshotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotsRequest proto.InternalMessageInfo
+
+type SnapshotsResponse struct {
+	Snapshots            []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SnapshotsResponse) Reset()         { *m = SnapshotsResponse{} }
+func (m *SnapshotsResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotsResponse) ProtoMessage()    {}
+func (*SnapshotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{25}
+}
+func (m *SnapshotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotsResponse.Unmarshal(m, b)
+}
+func (m *SnapshotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotsResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotsResponse.Merge(m, src)
+}
+func (m *SnapshotsResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotsResponse.Size(m)
+}
+func (m *SnapshotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotsResponse proto.InternalMessageInfo
+
+func (m *SnapshotsResponse) GetSnapshots() []*Snapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type RotateKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateKeyRequest) Reset()         { *m = RotateKeyRequest{} }
+func (m *RotateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateKeyRequest) ProtoMessage()    {}
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{26}
+}
+func (m *RotateKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateKeyRequest.Unmarshal(m, b)
+}
+func (m *RotateKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateKeyRequest.Merge(m, src)
+}
+func (m *RotateKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateKeyRequest.Size(m)
+}
+func (m *RotateKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateKeyRequest proto.InternalMessageInfo
+
+type RotateKeyResponse struct {
+	// key_id identifies the primary data key after the rotation
+	KeyID string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// reencrypted is the number of values encrypted with the primary key
+	Reencrypted          uint64   `protobuf:"varint,2,opt,name=reencrypted,proto3" json:"reencrypted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateKeyResponse) Reset()         { *m = RotateKeyResponse{} }
+func (m *RotateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateKeyResponse) ProtoMessage()    {}
+func (*RotateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{27}
+}
+func (m *RotateKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateKeyResponse.Unmarshal(m, b)
+}
+func (m *RotateKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *RotateKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateKeyResponse.Merge(m, src)
+}
+func (m *RotateKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateKeyResponse.Size(m)
+}
+func (m *RotateKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateKeyResponse proto.InternalMessageInfo
+
+func (m *RotateKeyResponse) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *RotateKeyResponse) GetReencrypted() uint64 {
+	if m != nil {
+		return m.Reencrypted
+	}
+	return 0
+}
+
+type SyncRequest struct {
+	// delta streams the change log after the since sequence
+	Delta bool   `protobuf:"varint,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	Since uint64 `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	// bucket and keys stream only the specified keys
+	Bucket string   `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Keys   []string `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	// node_id identifies the requesting node for the sync status
+	NodeID               string   `protobuf:"bytes,5,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{28}
+}
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(m, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+func (m *SyncRequest) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
+func (m *SyncRequest) GetSince() uint64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *SyncRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SyncRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SyncRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+type SyncOperation struct {
+	Action  SyncAction `protobuf:"varint,1,opt,name=action,proto3,enum=stellar.services.datastore.v1.SyncAction" json:"action,omitempty"`
+	Bucket  string     `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key     string     `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value   []byte     `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Version *Version   `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// sequence is the change log sequence when streaming a delta
+	Sequence             uint64   `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncOperation) Reset()         { *m = SyncOperation{} }
+func (m *SyncOperation) String() string { return proto.CompactTextString(m) }
+func (*SyncOperation) ProtoMessage()    {}
+func (*SyncOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{29}
+}
+func (m *SyncOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncOperation.Unmarshal(m, b)
+}
+func (m *SyncOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncOperation.Marshal(b, m, deterministic)
+}
+func (m *SyncOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncOperation.Merge(m, src)
+}
+func (m *SyncOperation) XXX_Size() int {
+	return xxx_messageInfo_SyncOperation.Size(m)
+}
+func (m *SyncOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncOperation proto.InternalMessageInfo
+
+func (m *SyncOperation) GetAction() SyncAction {
+	if m != nil {
+		return m.Action
+	}
+	return SyncAction_SET
+}
+
+func (m *SyncOperation) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SyncOperation) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SyncOperation) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SyncOperation) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *SyncOperation) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *SyncOperation) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type PeerSyncRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
-func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
-func (*SearchRequest) ProtoMessage()    {}
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{8}
+func (m *PeerSyncRequest) Reset()         { *m = PeerSyncRequest{} }
+func (m *PeerSyncRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerSyncRequest) ProtoMessage()    {}
+func (*PeerSyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{30}
 }
-func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+func (m *PeerSyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerSyncRequest.Unmarshal(m, b)
 }
-func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+func (m *PeerSyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerSyncRequest.Marshal(b, m, deterministic)
 }
-func (m *SearchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchRequest.Merge(m, src)
+func (m *PeerSyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerSyncRequest.Merge(m, src)
 }
-func (m *SearchRequest) XXX_Size() int {
-	return xxx_messageInfo_SearchRequest.Size(m)
+func (m *PeerSyncRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerSyncRequest.Size(m)
+}
+func (m *PeerSyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerSyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerSyncRequest proto.InternalMessageInfo
+
+func (m *PeerSyncRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *PeerSyncRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type DigestRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DigestRequest) Reset()         { *m = DigestRequest{} }
+func (m *DigestRequest) String() string { return proto.CompactTextString(m) }
+func (*DigestRequest) ProtoMessage()    {}
+func (*DigestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{31}
+}
+func (m *DigestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DigestRequest.Unmarshal(m, b)
+}
+func (m *DigestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DigestRequest.Marshal(b, m, deterministic)
+}
+func (m *DigestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DigestRequest.Merge(m, src)
+}
+func (m *DigestRequest) XXX_Size() int {
+	return xxx_messageInfo_DigestRequest.Size(m)
+}
+func (m *DigestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DigestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DigestRequest proto.InternalMessageInfo
+
+type BucketDigest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Digest               []byte   `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Keys                 uint64   `protobuf:"varint,3,opt,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketDigest) Reset()         { *m = BucketDigest{} }
+func (m *BucketDigest) String() string { return proto.CompactTextString(m) }
+func (*BucketDigest) ProtoMessage()    {}
+func (*BucketDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{32}
+}
+func (m *BucketDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketDigest.Unmarshal(m, b)
+}
+func (m *BucketDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketDigest.Marshal(b, m, deterministic)
+}
+func (m *BucketDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketDigest.Merge(m, src)
+}
+func (m *BucketDigest) XXX_Size() int {
+	return xxx_messageInfo_BucketDigest.Size(m)
+}
+func (m *BucketDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketDigest proto.InternalMessageInfo
+
+func (m *BucketDigest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *BucketDigest) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *BucketDigest) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+type DigestResponse struct {
+	// log_id identifies the change log of the node; it changes if the log is reset
+	LogID string `protobuf:"bytes,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	// sequence is the latest change log sequence
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// first_sequence is the oldest change log sequence retained
+	FirstSequence        uint64          `protobuf:"varint,3,opt,name=first_sequence,json=firstSequence,proto3" json:"first_sequence,omitempty"`
+	Buckets              []*BucketDigest `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DigestResponse) Reset()         { *m = DigestResponse{} }
+func (m *DigestResponse) String() string { return proto.CompactTextString(m) }
+func (*DigestResponse) ProtoMessage()    {}
+func (*DigestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{33}
+}
+func (m *DigestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DigestResponse.Unmarshal(m, b)
+}
+func (m *DigestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DigestResponse.Marshal(b, m, deterministic)
+}
+func (m *DigestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DigestResponse.Merge(m, src)
+}
+func (m *DigestResponse) XXX_Size() int {
+	return xxx_messageInfo_DigestResponse.Size(m)
+}
+func (m *DigestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DigestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DigestResponse proto.InternalMessageInfo
+
+func (m *DigestResponse) GetLogID() string {
+	if m != nil {
+		return m.LogID
+	}
+	return ""
+}
+
+func (m *DigestResponse) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *DigestResponse) GetFirstSequence() uint64 {
+	if m != nil {
+		return m.FirstSequence
+	}
+	return 0
+}
+
+func (m *DigestResponse) GetBuckets() []*BucketDigest {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type VersionsRequest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VersionsRequest) Reset()         { *m = VersionsRequest{} }
+func (m *VersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*VersionsRequest) ProtoMessage()    {}
+func (*VersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{34}
+}
+func (m *VersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionsRequest.Unmarshal(m, b)
+}
+func (m *VersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionsRequest.Marshal(b, m, deterministic)
+}
+func (m *VersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionsRequest.Merge(m, src)
+}
+func (m *VersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_VersionsRequest.Size(m)
+}
+func (m *VersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionsRequest proto.InternalMessageInfo
+
+func (m *VersionsRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+type KeyVersion struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version              *Version `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyVersion) Reset()         { *m = KeyVersion{} }
+func (m *KeyVersion) String() string { return proto.CompactTextString(m) }
+func (*KeyVersion) ProtoMessage()    {}
+func (*KeyVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{35}
+}
+func (m *KeyVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyVersion.Unmarshal(m, b)
+}
+func (m *KeyVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyVersion.Marshal(b, m, deterministic)
+}
+func (m *KeyVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyVersion.Merge(m, src)
+}
+func (m *KeyVersion) XXX_Size() int {
+	return xxx_messageInfo_KeyVersion.Size(m)
+}
+func (m *KeyVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyVersion proto.InternalMessageInfo
+
+func (m *KeyVersion) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyVersion) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+type VersionsResponse struct {
+	Versions             []*KeyVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *VersionsResponse) Reset()         { *m = VersionsResponse{} }
+func (m *VersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*VersionsResponse) ProtoMessage()    {}
+func (*VersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{36}
+}
+func (m *VersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionsResponse.Unmarshal(m, b)
+}
+func (m *VersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionsResponse.Marshal(b, m, deterministic)
+}
+func (m *VersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionsResponse.Merge(m, src)
+}
+func (m *VersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_VersionsResponse.Size(m)
+}
+func (m *VersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionsResponse proto.InternalMessageInfo
+
+func (m *VersionsResponse) GetVersions() []*KeyVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+// Change is a change log record
+type Change struct {
+	Sequence             uint64   `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Bucket               string   `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Entry                *Entry   `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{37}
+}
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (m *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(m, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Change) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *Change) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Change) GetEntry() *Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// revision resumes the watch after the change log sequence; if zero only
+	// new changes are sent
+	Revision             uint64   `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{38}
+}
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type WatchEvent struct {
+	Type   WatchEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=stellar.services.datastore.v1.WatchEvent_Type" json:"type,omitempty"`
+	Bucket string          `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Data   *KeyValue       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// revision is the change log sequence of the event
+	Revision             uint64   `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{39}
+}
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEvent.Unmarshal(m, b)
+}
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+}
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
 }
-func (m *SearchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+func (m *WatchEvent) XXX_Size() int {
+	return xxx_messageInfo_WatchEvent.Size(m)
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
 
-func (m *SearchRequest) GetBucket() string {
+func (m *WatchEvent) GetType() WatchEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return WatchEvent_PUT
+}
+
+func (m *WatchEvent) GetBucket() string {
 	if m != nil {
 		return m.Bucket
 	}
 	return ""
 }
 
-func (m *SearchRequest) GetPrefix() string {
+func (m *WatchEvent) GetData() *KeyValue {
 	if m != nil {
-		return m.Prefix
+		return m.Data
 	}
-	return ""
+	return nil
 }
 
-type SearchResponse struct {
-	Bucket               string      `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Data                 []*KeyValue `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *WatchEvent) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
 }
 
-func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
-func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
-func (*SearchResponse) ProtoMessage()    {}
-func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{9}
+// Compare is a guard on the current entry for a key in a transaction.
+// Deleted keys do not exist and have no value or version.
+type Compare struct {
+	Bucket               string         `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  string         `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Target               Compare_Target `protobuf:"varint,3,opt,name=target,proto3,enum=stellar.services.datastore.v1.Compare_Target" json:"target,omitempty"`
+	Result               Compare_Result `protobuf:"varint,4,opt,name=result,proto3,enum=stellar.services.datastore.v1.Compare_Result" json:"result,omitempty"`
+	Value                []byte         `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Version              *Version       `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	Exists               bool           `protobuf:"varint,7,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
-func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+
+func (m *Compare) Reset()         { *m = Compare{} }
+func (m *Compare) String() string { return proto.CompactTextString(m) }
+func (*Compare) ProtoMessage()    {}
+func (*Compare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{40}
 }
-func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+func (m *Compare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Compare.Unmarshal(m, b)
 }
-func (m *SearchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchResponse.Merge(m, src)
+func (m *Compare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Compare.Marshal(b, m, deterministic)
 }
-func (m *SearchResponse) XXX_Size() int {
-	return xxx_messageInfo_SearchResponse.Size(m)
+func (m *Compare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Compare.Merge(m, src)
 }
-func (m *SearchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+func (m *Compare) XXX_Size() int {
+	return xxx_messageInfo_Compare.Size(m)
+}
+func (m *Compare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Compare.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+var xxx_messageInfo_Compare proto.InternalMessageInfo
 
-func (m *SearchResponse) GetBucket() string {
+func (m *Compare) GetBucket() string {
 	if m != nil {
 		return m.Bucket
 	}
 	return ""
 }
 
-func (m *SearchResponse) GetData() []*KeyValue {
+func (m *Compare) GetKey() string {
 	if m != nil {
-		return m.Data
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Compare) GetTarget() Compare_Target {
+	if m != nil {
+		return m.Target
+	}
+	return Compare_VALUE
+}
+
+func (m *Compare) GetResult() Compare_Result {
+	if m != nil {
+		return m.Result
+	}
+	return Compare_EQUAL
+}
+
+func (m *Compare) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
 	return nil
 }
 
-type GetResponse struct {
-	Bucket               string    `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Data                 *KeyValue `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *Compare) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
 }
 
-func (m *GetResponse) Reset()         { *m = GetResponse{} }
-func (m *GetResponse) String() string { return proto.CompactTextString(m) }
-func (*GetResponse) ProtoMessage()    {}
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{10}
+func (m *Compare) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
 }
-func (m *GetResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
+
+type TxnOp struct {
+	Type                 TxnOp_Type `protobuf:"varint,1,opt,name=type,proto3,enum=stellar.services.datastore.v1.TxnOp_Type" json:"type,omitempty"`
+	Bucket               string     `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  string     `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte     `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
-func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+
+func (m *TxnOp) Reset()         { *m = TxnOp{} }
+func (m *TxnOp) String() string { return proto.CompactTextString(m) }
+func (*TxnOp) ProtoMessage()    {}
+func (*TxnOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{41}
 }
-func (m *GetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetResponse.Merge(m, src)
+func (m *TxnOp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxnOp.Unmarshal(m, b)
 }
-func (m *GetResponse) XXX_Size() int {
-	return xxx_messageInfo_GetResponse.Size(m)
+func (m *TxnOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxnOp.Marshal(b, m, deterministic)
 }
-func (m *GetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+func (m *TxnOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnOp.Merge(m, src)
+}
+func (m *TxnOp) XXX_Size() int {
+	return xxx_messageInfo_TxnOp.Size(m)
+}
+func (m *TxnOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnOp.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+var xxx_messageInfo_TxnOp proto.InternalMessageInfo
 
-func (m *GetResponse) GetBucket() string {
+func (m *TxnOp) GetType() TxnOp_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TxnOp_PUT
+}
+
+func (m *TxnOp) GetBucket() string {
 	if m != nil {
 		return m.Bucket
 	}
 	return ""
 }
 
-func (m *GetResponse) GetData() *KeyValue {
+func (m *TxnOp) GetKey() string {
 	if m != nil {
-		return m.Data
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TxnOp) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
 	return nil
 }
 
-type DeleteRequest struct {
-	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Sync                 bool     `protobuf:"varint,3,opt,name=sync,proto3" json:"sync,omitempty"`
-	NoTombstone          bool     `protobuf:"varint,4,opt,name=no_tombstone,json=noTombstone,proto3" json:"no_tombstone,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// TxnRequest applies the success operations if all compares succeed and the
+// failure operations otherwise.  The operations are applied atomically on the
+// node and replicated to peers.
+type TxnRequest struct {
+	Compare              []*Compare `protobuf:"bytes,1,rep,name=compare,proto3" json:"compare,omitempty"`
+	Success              []*TxnOp   `protobuf:"bytes,2,rep,name=success,proto3" json:"success,omitempty"`
+	Failure              []*TxnOp   `protobuf:"bytes,3,rep,name=failure,proto3" json:"failure,omitempty"`
+	Sync                 bool       `protobuf:"varint,4,opt,name=sync,proto3" json:"sync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{11}
+func (m *TxnRequest) Reset()         { *m = TxnRequest{} }
+func (m *TxnRequest) String() string { return proto.CompactTextString(m) }
+func (*TxnRequest) ProtoMessage()    {}
+func (*TxnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{42}
 }
-func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+func (m *TxnRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxnRequest.Unmarshal(m, b)
 }
-func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+func (m *TxnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxnRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRequest.Merge(m, src)
+func (m *TxnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnRequest.Merge(m, src)
 }
-func (m *DeleteRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteRequest.Size(m)
+func (m *TxnRequest) XXX_Size() int {
+	return xxx_messageInfo_TxnRequest.Size(m)
 }
-func (m *DeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+func (m *TxnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_TxnRequest proto.InternalMessageInfo
 
-func (m *DeleteRequest) GetBucket() string {
+func (m *TxnRequest) GetCompare() []*Compare {
 	if m != nil {
-		return m.Bucket
+		return m.Compare
 	}
-	return ""
+	return nil
 }
 
-func (m *DeleteRequest) GetKey() string {
+func (m *TxnRequest) GetSuccess() []*TxnOp {
 	if m != nil {
-		return m.Key
+		return m.Success
 	}
-	return ""
+	return nil
 }
 
-func (m *DeleteRequest) GetSync() bool {
+func (m *TxnRequest) GetFailure() []*TxnOp {
 	if m != nil {
-		return m.Sync
+		return m.Failure
 	}
-	return false
+	return nil
 }
 
-func (m *DeleteRequest) GetNoTombstone() bool {
+func (m *TxnRequest) GetSync() bool {
 	if m != nil {
-		return m.NoTombstone
+		return m.Sync
 	}
 	return false
 }
 
-type BackupRequest struct {
+type TxnResponse struct {
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// data is the entry written by each applied operation in order
+	Data []*KeyValue `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	// revision is the change log sequence after the transaction
+	Revision             uint64   `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
-func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
-func (*BackupRequest) ProtoMessage()    {}
-func (*BackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{12}
+func (m *TxnResponse) Reset()         { *m = TxnResponse{} }
+func (m *TxnResponse) String() string { return proto.CompactTextString(m) }
+func (*TxnResponse) ProtoMessage()    {}
+func (*TxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{43}
 }
-func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+func (m *TxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxnResponse.Unmarshal(m, b)
 }
-func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+func (m *TxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxnResponse.Marshal(b, m, deterministic)
 }
-func (m *BackupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BackupRequest.Merge(m, src)
+func (m *TxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnResponse.Merge(m, src)
 }
-func (m *BackupRequest) XXX_Size() int {
-	return xxx_messageInfo_BackupRequest.Size(m)
+func (m *TxnResponse) XXX_Size() int {
+	return xxx_messageInfo_TxnResponse.Size(m)
 }
-func (m *BackupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+func (m *TxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+var xxx_messageInfo_TxnResponse proto.InternalMessageInfo
 
-type BackupResponse struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+func (m *TxnResponse) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *TxnResponse) GetData() []*KeyValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *TxnResponse) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type StatusRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
-func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
-func (*BackupResponse) ProtoMessage()    {}
-func (*BackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{13}
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{44}
+}
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusRequest.Unmarshal(m, b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+}
+func (m *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(m, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusRequest.Size(m)
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+// PeerStatus is the replication status with a peer since the node started
+type PeerStatus struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// last_sync is the last successful sync from the peer
+	LastSync    *types.Timestamp `protobuf:"bytes,2,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`
+	LastAttempt *types.Timestamp `protobuf:"bytes,3,opt,name=last_attempt,json=lastAttempt,proto3" json:"last_attempt,omitempty"`
+	// keys_sent is the number of keys streamed to the peer
+	KeysSent uint64 `protobuf:"varint,4,opt,name=keys_sent,json=keysSent,proto3" json:"keys_sent,omitempty"`
+	// keys_received is the number of keys applied from the peer
+	KeysReceived uint64           `protobuf:"varint,5,opt,name=keys_received,json=keysReceived,proto3" json:"keys_received,omitempty"`
+	Errors       uint64           `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	LastError    string           `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorAt  *types.Timestamp `protobuf:"bytes,8,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	// sequence is the position synced in the peer change log
+	Sequence uint64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// peer_sequence is the latest peer change log sequence at the last sync
+	PeerSequence uint64 `protobuf:"varint,10,opt,name=peer_sequence,json=peerSequence,proto3" json:"peer_sequence,omitempty"`
+	// acknowledged is the position in the local change log applied by the peer
+	Acknowledged         uint64   `protobuf:"varint,11,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+
+func (m *PeerStatus) Reset()         { *m = PeerStatus{} }
+func (m *PeerStatus) String() string { return proto.CompactTextString(m) }
+func (*PeerStatus) ProtoMessage()    {}
+func (*PeerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{45}
 }
-func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+func (m *PeerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStatus.Unmarshal(m, b)
 }
-func (m *BackupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BackupResponse.Merge(m, src)
+func (m *PeerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStatus.Marshal(b, m, deterministic)
 }
-func (m *BackupResponse) XXX_Size() int {
-	return xxx_messageInfo_BackupResponse.Size(m)
+func (m *PeerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStatus.Merge(m, src)
 }
-func (m *BackupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+func (m *PeerStatus) XXX_Size() int {
+	return xxx_messageInfo_PeerStatus.Size(m)
+}
+func (m *PeerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+var xxx_messageInfo_PeerStatus proto.InternalMessageInfo
 
-func (m *BackupResponse) GetData() []byte {
+func (m *PeerStatus) GetID() string {
 	if m != nil {
-		return m.Data
+		return m.ID
+	}
+	return ""
+}
+
+func (m *PeerStatus) GetLastSync() *types.Timestamp {
+	if m != nil {
+		return m.LastSync
 	}
 	return nil
 }
 
-type RestoreRequest struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PeerStatus) GetLastAttempt() *types.Timestamp {
+	if m != nil {
+		return m.LastAttempt
+	}
+	return nil
 }
 
-func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
-func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
-func (*RestoreRequest) ProtoMessage()    {}
-func (*RestoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{14}
+func (m *PeerStatus) GetKeysSent() uint64 {
+	if m != nil {
+		return m.KeysSent
+	}
+	return 0
 }
-func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+
+func (m *PeerStatus) GetKeysReceived() uint64 {
+	if m != nil {
+		return m.KeysReceived
+	}
+	return 0
 }
-func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+
+func (m *PeerStatus) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
 }
-func (m *RestoreRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreRequest.Merge(m, src)
+
+func (m *PeerStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
 }
-func (m *RestoreRequest) XXX_Size() int {
-	return xxx_messageInfo_RestoreRequest.Size(m)
+
+func (m *PeerStatus) GetLastErrorAt() *types.Timestamp {
+	if m != nil {
+		return m.LastErrorAt
+	}
+	return nil
 }
-func (m *RestoreRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+
+func (m *PeerStatus) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *PeerStatus) GetPeerSequence() uint64 {
+	if m != nil {
+		return m.PeerSequence
+	}
+	return 0
 }
 
-var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
-
-func (m *RestoreRequest) GetData() []byte {
+func (m *PeerStatus) GetAcknowledged() uint64 {
 	if m != nil {
-		return m.Data
+		return m.Acknowledged
 	}
-	return nil
+	return 0
 }
 
-type SyncRequest struct {
+type BucketStatus struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Keys   uint64 `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	// tombstones is the number of deletes waiting to be pruned
+	Tombstones uint64 `protobuf:"varint,3,opt,name=tombstones,proto3" json:"tombstones,omitempty"`
+	// size is the size in bytes of the stored keys and values
+	Size_                uint64   `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
-func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
-func (*SyncRequest) ProtoMessage()    {}
-func (*SyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{15}
-}
-func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
-}
-func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
-}
-func (m *SyncRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SyncRequest.Merge(m, src)
-}
-func (m *SyncRequest) XXX_Size() int {
-	return xxx_messageInfo_SyncRequest.Size(m)
-}
-func (m *SyncRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
-
-type SyncOperation struct {
-	Action               SyncAction `protobuf:"varint,1,opt,name=action,proto3,enum=stellar.services.datastore.v1.SyncAction" json:"action,omitempty"`
-	Bucket               string     `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Key                  string     `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte     `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
-}
-
-func (m *SyncOperation) Reset()         { *m = SyncOperation{} }
-func (m *SyncOperation) String() string { return proto.CompactTextString(m) }
-func (*SyncOperation) ProtoMessage()    {}
-func (*SyncOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{16}
+func (m *BucketStatus) Reset()         { *m = BucketStatus{} }
+func (m *BucketStatus) String() string { return proto.CompactTextString(m) }
+func (*BucketStatus) ProtoMessage()    {}
+func (*BucketStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{46}
 }
-func (m *SyncOperation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SyncOperation.Unmarshal(m, b)
+func (m *BucketStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketStatus.Unmarshal(m, b)
 }
-func (m *SyncOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SyncOperation.Marshal(b, m, deterministic)
+func (m *BucketStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketStatus.Marshal(b, m, deterministic)
 }
-func (m *SyncOperation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SyncOperation.Merge(m, src)
+func (m *BucketStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketStatus.Merge(m, src)
 }
-func (m *SyncOperation) XXX_Size() int {
-	return xxx_messageInfo_SyncOperation.Size(m)
+func (m *BucketStatus) XXX_Size() int {
+	return xxx_messageInfo_BucketStatus.Size(m)
 }
-func (m *SyncOperation) XXX_DiscardUnknown() {
-	xxx_messageInfo_SyncOperation.DiscardUnknown(m)
+func (m *BucketStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SyncOperation proto.InternalMessageInfo
+var xxx_messageInfo_BucketStatus proto.InternalMessageInfo
 
-func (m *SyncOperation) GetAction() SyncAction {
+func (m *BucketStatus) GetBucket() string {
 	if m != nil {
-		return m.Action
+		return m.Bucket
 	}
-	return SyncAction_SET
+	return ""
 }
 
-func (m *SyncOperation) GetBucket() string {
+func (m *BucketStatus) GetKeys() uint64 {
 	if m != nil {
-		return m.Bucket
+		return m.Keys
 	}
-	return ""
+	return 0
 }
 
-func (m *SyncOperation) GetKey() string {
+func (m *BucketStatus) GetTombstones() uint64 {
 	if m != nil {
-		return m.Key
+		return m.Tombstones
 	}
-	return ""
+	return 0
 }
 
-func (m *SyncOperation) GetValue() []byte {
+func (m *BucketStatus) GetSize_() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Size_
 	}
-	return nil
+	return 0
 }
 
-type PeerSyncRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+type StatusResponse struct {
+	NodeID   string          `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	LogID    string          `protobuf:"bytes,2,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	Sequence uint64          `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Peers    []*PeerStatus   `protobuf:"bytes,4,rep,name=peers,proto3" json:"peers,omitempty"`
+	Buckets  []*BucketStatus `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// tombstones is the tombstone backlog across the buckets
+	Tombstones           uint64   `protobuf:"varint,6,opt,name=tombstones,proto3" json:"tombstones,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeerSyncRequest) Reset()         { *m = PeerSyncRequest{} }
-func (m *PeerSyncRequest) String() string { return proto.CompactTextString(m) }
-func (*PeerSyncRequest) ProtoMessage()    {}
-func (*PeerSyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_704b2444211b6092, []int{17}
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_704b2444211b6092, []int{47}
 }
-func (m *PeerSyncRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PeerSyncRequest.Unmarshal(m, b)
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusResponse.Unmarshal(m, b)
 }
-func (m *PeerSyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PeerSyncRequest.Marshal(b, m, deterministic)
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
 }
-func (m *PeerSyncRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeerSyncRequest.Merge(m, src)
+func (m *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(m, src)
 }
-func (m *PeerSyncRequest) XXX_Size() int {
-	return xxx_messageInfo_PeerSyncRequest.Size(m)
+func (m *StatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusResponse.Size(m)
 }
-func (m *PeerSyncRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeerSyncRequest.DiscardUnknown(m)
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PeerSyncRequest proto.InternalMessageInfo
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
 
-func (m *PeerSyncRequest) GetID() string {
+func (m *StatusResponse) GetNodeID() string {
 	if m != nil {
-		return m.ID
+		return m.NodeID
 	}
 	return ""
 }
 
-func (m *PeerSyncRequest) GetAddress() string {
+func (m *StatusResponse) GetLogID() string {
 	if m != nil {
-		return m.Address
+		return m.LogID
 	}
 	return ""
 }
 
+func (m *StatusResponse) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetPeers() []*PeerStatus {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetBuckets() []*BucketStatus {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetTombstones() uint64 {
+	if m != nil {
+		return m.Tombstones
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("stellar.services.datastore.v1.SyncAction", SyncAction_name, SyncAction_value)
+	proto.RegisterEnum("stellar.services.datastore.v1.PeerLockRequest_Action", PeerLockRequest_Action_name, PeerLockRequest_Action_value)
+	proto.RegisterEnum("stellar.services.datastore.v1.WatchEvent_Type", WatchEvent_Type_name, WatchEvent_Type_value)
+	proto.RegisterEnum("stellar.services.datastore.v1.Compare_Target", Compare_Target_name, Compare_Target_value)
+	proto.RegisterEnum("stellar.services.datastore.v1.Compare_Result", Compare_Result_name, Compare_Result_value)
+	proto.RegisterEnum("stellar.services.datastore.v1.TxnOp_Type", TxnOp_Type_name, TxnOp_Type_value)
 	proto.RegisterType((*InfoRequest)(nil), "stellar.services.datastore.v1.InfoRequest")
 	proto.RegisterType((*InfoResponse)(nil), "stellar.services.datastore.v1.InfoResponse")
+	proto.RegisterType((*Lease)(nil), "stellar.services.datastore.v1.Lease")
 	proto.RegisterType((*AcquireLockRequest)(nil), "stellar.services.datastore.v1.AcquireLockRequest")
+	proto.RegisterType((*AcquireLockResponse)(nil), "stellar.services.datastore.v1.AcquireLockResponse")
+	proto.RegisterType((*RenewLockRequest)(nil), "stellar.services.datastore.v1.RenewLockRequest")
+	proto.RegisterType((*RenewLockResponse)(nil), "stellar.services.datastore.v1.RenewLockResponse")
 	proto.RegisterType((*ReleaseLockRequest)(nil), "stellar.services.datastore.v1.ReleaseLockRequest")
+	proto.RegisterType((*PeerLockRequest)(nil), "stellar.services.datastore.v1.PeerLockRequest")
+	proto.RegisterType((*PeerLockResponse)(nil), "stellar.services.datastore.v1.PeerLockResponse")
 	proto.RegisterType((*CreateBucketRequest)(nil), "stellar.services.datastore.v1.CreateBucketRequest")
+	proto.RegisterType((*Version)(nil), "stellar.services.datastore.v1.Version")
+	proto.RegisterType((*Entry)(nil), "stellar.services.datastore.v1.Entry")
 	proto.RegisterType((*SetRequest)(nil), "stellar.services.datastore.v1.SetRequest")
 	proto.RegisterType((*KeyValue)(nil), "stellar.services.datastore.v1.KeyValue")
 	proto.RegisterType((*GetRequest)(nil), "stellar.services.datastore.v1.GetRequest")
@@ -838,9 +2869,31 @@ func init() {
 	proto.RegisterType((*BackupRequest)(nil), "stellar.services.datastore.v1.BackupRequest")
 	proto.RegisterType((*BackupResponse)(nil), "stellar.services.datastore.v1.BackupResponse")
 	proto.RegisterType((*RestoreRequest)(nil), "stellar.services.datastore.v1.RestoreRequest")
+	proto.RegisterType((*Snapshot)(nil), "stellar.services.datastore.v1.Snapshot")
+	proto.RegisterType((*SnapshotsRequest)(nil), "stellar.services.datastore.v1.SnapshotsRequest")
+	proto.RegisterType((*SnapshotsResponse)(nil), "stellar.services.datastore.v1.SnapshotsResponse")
+	proto.RegisterType((*RotateKeyRequest)(nil), "stellar.services.datastore.v1.RotateKeyRequest")
+	proto.RegisterType((*RotateKeyResponse)(nil), "stellar.services.datastore.v1.RotateKeyResponse")
 	proto.RegisterType((*SyncRequest)(nil), "stellar.services.datastore.v1.SyncRequest")
 	proto.RegisterType((*SyncOperation)(nil), "stellar.services.datastore.v1.SyncOperation")
 	proto.RegisterType((*PeerSyncRequest)(nil), "stellar.services.datastore.v1.PeerSyncRequest")
+	proto.RegisterType((*DigestRequest)(nil), "stellar.services.datastore.v1.DigestRequest")
+	proto.RegisterType((*BucketDigest)(nil), "stellar.services.datastore.v1.BucketDigest")
+	proto.RegisterType((*DigestResponse)(nil), "stellar.services.datastore.v1.DigestResponse")
+	proto.RegisterType((*VersionsRequest)(nil), "stellar.services.datastore.v1.VersionsRequest")
+	proto.RegisterType((*KeyVersion)(nil), "stellar.services.datastore.v1.KeyVersion")
+	proto.RegisterType((*VersionsResponse)(nil), "stellar.services.datastore.v1.VersionsResponse")
+	proto.RegisterType((*Change)(nil), "stellar.services.datastore.v1.Change")
+	proto.RegisterType((*WatchRequest)(nil), "stellar.services.datastore.v1.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "stellar.services.datastore.v1.WatchEvent")
+	proto.RegisterType((*Compare)(nil), "stellar.services.datastore.v1.Compare")
+	proto.RegisterType((*TxnOp)(nil), "stellar.services.datastore.v1.TxnOp")
+	proto.RegisterType((*TxnRequest)(nil), "stellar.services.datastore.v1.TxnRequest")
+	proto.RegisterType((*TxnResponse)(nil), "stellar.services.datastore.v1.TxnResponse")
+	proto.RegisterType((*StatusRequest)(nil), "stellar.services.datastore.v1.StatusRequest")
+	proto.RegisterType((*PeerStatus)(nil), "stellar.services.datastore.v1.PeerStatus")
+	proto.RegisterType((*BucketStatus)(nil), "stellar.services.datastore.v1.BucketStatus")
+	proto.RegisterType((*StatusResponse)(nil), "stellar.services.datastore.v1.StatusResponse")
 }
 
 func init() {
@@ -848,56 +2901,154 @@ func init() {
 }
 
 var fileDescriptor_704b2444211b6092 = []byte{
-	// 781 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0x6d, 0x4f, 0xdb, 0x48,
-	0x10, 0xc7, 0xcf, 0x71, 0xce, 0x09, 0x93, 0x07, 0xd0, 0x1e, 0x8a, 0x72, 0x39, 0xdd, 0x5d, 0xb0,
-	0xd0, 0x5d, 0x8e, 0x03, 0xbb, 0x04, 0xa9, 0x6f, 0x78, 0x81, 0x12, 0x12, 0x51, 0x54, 0xd4, 0x22,
-	0x07, 0x55, 0x15, 0xaa, 0x44, 0x1d, 0x67, 0x08, 0x56, 0x12, 0xaf, 0xb1, 0xd7, 0x51, 0xd3, 0x8f,
-	0xd1, 0xcf, 0xd5, 0xcf, 0xd0, 0x17, 0xfd, 0x24, 0x95, 0xed, 0x35, 0x31, 0x90, 0x74, 0x03, 0xef,
-	0x76, 0x76, 0x67, 0xfe, 0x33, 0xbb, 0x33, 0xfe, 0xc9, 0xd0, 0x1d, 0xda, 0xec, 0x26, 0xe8, 0x6b,
-	0x16, 0x9d, 0xe8, 0x78, 0x63, 0x7e, 0x1e, 0x23, 0x63, 0xba, 0xcf, 0x70, 0x3c, 0x36, 0x3d, 0xdd,
-	0x74, 0x6d, 0xdd, 0x47, 0x6f, 0x6a, 0x5b, 0xe8, 0xeb, 0x03, 0x93, 0x99, 0x3e, 0xa3, 0x1e, 0xea,
-	0xd3, 0xfd, 0xb9, 0xa1, 0xb9, 0x1e, 0x65, 0x94, 0xfc, 0xc9, 0x43, 0xb4, 0xc4, 0x5d, 0x9b, 0x7b,
-	0x4c, 0xf7, 0x6b, 0x9b, 0x43, 0x3a, 0xa4, 0x91, 0xa7, 0x1e, 0xae, 0xe2, 0xa0, 0xda, 0x1f, 0x43,
-	0x4a, 0x87, 0x63, 0xd4, 0x23, 0xab, 0x1f, 0x5c, 0xeb, 0x38, 0x71, 0xd9, 0x8c, 0x1f, 0xfe, 0xf5,
-	0xf0, 0x70, 0x10, 0x78, 0x26, 0xb3, 0xa9, 0x13, 0x9f, 0xab, 0x25, 0x28, 0x9c, 0x3a, 0xd7, 0xd4,
-	0xc0, 0xdb, 0x00, 0x7d, 0xa6, 0xfe, 0x03, 0xc5, 0xd8, 0xf4, 0x5d, 0xea, 0xf8, 0x48, 0x2a, 0x90,
-	0xb1, 0x07, 0x55, 0xa9, 0x2e, 0x35, 0xd6, 0xda, 0xca, 0xf7, 0x6f, 0x7f, 0x67, 0x4e, 0x3b, 0x46,
-	0xc6, 0x1e, 0xa8, 0xa7, 0x40, 0x5a, 0xd6, 0x6d, 0x60, 0x7b, 0x78, 0x46, 0xad, 0x11, 0x8f, 0x26,
-	0x07, 0x90, 0x63, 0xf6, 0x04, 0x69, 0xc0, 0xa2, 0x90, 0x42, 0xf3, 0x77, 0x2d, 0x4e, 0xaf, 0x25,
-	0xe9, 0xb5, 0x0e, 0x4f, 0x6f, 0x24, 0x9e, 0xea, 0x26, 0x10, 0x03, 0xc7, 0x68, 0xfa, 0x69, 0x29,
-	0x75, 0x0f, 0x7e, 0x3b, 0xf6, 0xd0, 0x64, 0xd8, 0x0e, 0xac, 0x11, 0xb2, 0x24, 0x43, 0x05, 0x94,
-	0x7e, 0xb4, 0x11, 0xd7, 0x64, 0x70, 0x4b, 0xfd, 0x08, 0xd0, 0x13, 0x7a, 0x91, 0x0d, 0x90, 0x47,
-	0x38, 0xab, 0x66, 0xa2, 0xcd, 0x70, 0x49, 0x36, 0xe1, 0xd7, 0xa9, 0x39, 0x0e, 0xb0, 0x2a, 0xd7,
-	0xa5, 0x46, 0xd1, 0x88, 0x0d, 0x42, 0x20, 0xeb, 0xcf, 0x1c, 0xab, 0x9a, 0xad, 0x4b, 0x8d, 0xbc,
-	0x11, 0xad, 0xd5, 0x26, 0xe4, 0x5f, 0xe3, 0xec, 0x5d, 0x74, 0xce, 0x75, 0xa4, 0x05, 0x3a, 0x99,
-	0x94, 0x8e, 0xfa, 0x12, 0xe0, 0xe4, 0x19, 0x55, 0xa9, 0x47, 0x50, 0xea, 0xa1, 0xe9, 0x59, 0x37,
-	0xa2, 0xd0, 0x0a, 0x28, 0xae, 0x87, 0xd7, 0xf6, 0x27, 0x1e, 0xcd, 0x2d, 0x15, 0xa1, 0x9c, 0x08,
-	0xdc, 0x35, 0x72, 0xb1, 0xc2, 0x21, 0x64, 0xc3, 0x11, 0xab, 0x66, 0xea, 0x72, 0xa3, 0xd0, 0xfc,
-	0x57, 0xfb, 0xe9, 0x00, 0x6a, 0xc9, 0x0b, 0x18, 0x51, 0x90, 0xda, 0x87, 0x42, 0x74, 0xbf, 0x95,
-	0x73, 0x48, 0x4f, 0xcf, 0xe1, 0x42, 0xa9, 0x83, 0x63, 0x64, 0xf8, 0xf4, 0xe6, 0x26, 0x6d, 0x94,
-	0xe7, 0x6d, 0x24, 0x5b, 0x50, 0x74, 0xe8, 0x15, 0xa3, 0x93, 0xbe, 0xcf, 0xa8, 0x83, 0xbc, 0xc5,
-	0x05, 0x87, 0x5e, 0x24, 0x5b, 0xea, 0x3a, 0x94, 0xda, 0xa6, 0x35, 0x0a, 0xdc, 0x64, 0x16, 0xb7,
-	0xa1, 0x9c, 0x6c, 0xf0, 0x9b, 0x12, 0x7e, 0x23, 0x29, 0xea, 0x76, 0x5c, 0xe8, 0x36, 0x94, 0x0d,
-	0x8c, 0x6e, 0x91, 0x54, 0xba, 0xc8, 0xab, 0x04, 0x85, 0xde, 0xcc, 0xb1, 0x12, 0xe9, 0x2f, 0x12,
-	0x94, 0x42, 0xfb, 0xad, 0x8b, 0xf1, 0x77, 0x41, 0x5a, 0xa0, 0x98, 0x56, 0xb8, 0x8a, 0xc2, 0xca,
-	0xcd, 0xff, 0x04, 0xcf, 0x15, 0x46, 0xb7, 0xa2, 0x00, 0x83, 0x07, 0xa6, 0x5e, 0x28, 0xb3, 0xe8,
-	0x85, 0xe4, 0x05, 0x63, 0x9b, 0x4d, 0x8f, 0xed, 0x31, 0xac, 0x9f, 0x23, 0x7a, 0xa9, 0x3a, 0x97,
-	0x71, 0x80, 0x54, 0x21, 0x67, 0x0e, 0x06, 0x1e, 0xfa, 0x3e, 0xcf, 0x95, 0x98, 0x3b, 0x5b, 0x00,
-	0xf3, 0xd2, 0x48, 0x0e, 0xe4, 0x5e, 0xf7, 0x62, 0xe3, 0x17, 0x02, 0xa0, 0x74, 0xba, 0x67, 0xdd,
-	0x8b, 0xee, 0x86, 0xd4, 0xfc, 0x9a, 0x87, 0xb5, 0x4e, 0x72, 0x17, 0x72, 0x05, 0xd9, 0x10, 0x3d,
-	0x64, 0x47, 0x70, 0xe1, 0x14, 0xae, 0x6a, 0xff, 0xaf, 0xe4, 0xcb, 0x9b, 0xf6, 0x1e, 0x0a, 0x29,
-	0x66, 0x91, 0x7d, 0x41, 0xec, 0x63, 0xbe, 0xd5, 0x2a, 0x8f, 0x70, 0xd6, 0x0d, 0x51, 0x1b, 0x2a,
-	0xa7, 0x10, 0x26, 0x54, 0x7e, 0x8c, 0xbb, 0xa5, 0xca, 0x97, 0x50, 0x4c, 0x63, 0x90, 0x34, 0x05,
-	0xd2, 0x0b, 0x98, 0xb9, 0x54, 0xfb, 0x15, 0xc8, 0x3d, 0x64, 0x44, 0x38, 0x60, 0x62, 0xa5, 0x0f,
-	0x20, 0x9f, 0xac, 0xa0, 0x34, 0x67, 0x61, 0x6d, 0x67, 0x15, 0x57, 0xde, 0x37, 0x04, 0x25, 0x86,
-	0x19, 0xd9, 0x15, 0x96, 0x9a, 0x82, 0x66, 0x6d, 0x6f, 0x45, 0x6f, 0x9e, 0xe6, 0x0d, 0x28, 0x31,
-	0x68, 0x84, 0x69, 0xee, 0xf1, 0x68, 0xe9, 0xa3, 0x20, 0x28, 0x31, 0x35, 0x84, 0x7a, 0xf7, 0x68,
-	0x23, 0x2c, 0xfb, 0x01, 0x8a, 0xce, 0x21, 0xc7, 0xb1, 0x43, 0xf6, 0x84, 0x73, 0x97, 0xc6, 0xd3,
-	0xd2, 0xc2, 0xfb, 0x90, 0x0d, 0xbf, 0x5c, 0xe1, 0x87, 0x98, 0xe2, 0x43, 0x6d, 0x77, 0x05, 0xdf,
-	0x3b, 0xc6, 0xbd, 0x90, 0x88, 0x01, 0xf9, 0x04, 0x31, 0x44, 0x13, 0xc4, 0x3e, 0x60, 0xd1, 0xb2,
-	0xba, 0xdb, 0xad, 0xcb, 0xa3, 0x67, 0xfd, 0x85, 0x1d, 0xde, 0x19, 0x7d, 0x25, 0x92, 0x3c, 0xf8,
-	0x11, 0x00, 0x00, 0xff, 0xff, 0xc8, 0x93, 0xb7, 0x66, 0xcf, 0x09, 0x00, 0x00,
+	// 2351 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0xcd, 0x6f, 0xdb, 0xc8,
+	0xf5, 0x4b, 0x51, 0xa2, 0xa4, 0x27, 0xcb, 0x51, 0x66, 0x83, 0x40, 0x3f, 0xe5, 0xb7, 0x6b, 0x2f,
+	0xb3, 0x4d, 0xf3, 0x65, 0xc9, 0xf1, 0xf6, 0x73, 0xb7, 0xbb, 0x1b, 0x39, 0x56, 0x53, 0x23, 0x5e,
+	0x3b, 0x3b, 0x52, 0x3e, 0x1a, 0x2c, 0xe0, 0xd2, 0xe2, 0x58, 0x26, 0x44, 0x91, 0x5a, 0x72, 0xe4,
+	0x58, 0x0b, 0x14, 0x28, 0xd0, 0x1e, 0x8a, 0x5e, 0x7a, 0xe8, 0xb1, 0xc7, 0x9e, 0x7a, 0x2d, 0x7a,
+	0x2a, 0xfa, 0x5f, 0x14, 0xe8, 0x31, 0x87, 0xfc, 0x13, 0xbd, 0x16, 0xf3, 0x45, 0xd1, 0x8c, 0x65,
+	0x52, 0x4e, 0x80, 0xde, 0xe6, 0x3d, 0xbe, 0x37, 0xef, 0x63, 0xe6, 0x7d, 0xcc, 0x93, 0xa0, 0x33,
+	0x70, 0xe8, 0xd1, 0xe4, 0xa0, 0xd9, 0xf7, 0x47, 0x2d, 0x72, 0x64, 0x7d, 0xe7, 0x12, 0x4a, 0x5b,
+	0x21, 0x25, 0xae, 0x6b, 0x05, 0x2d, 0x6b, 0xec, 0xb4, 0x42, 0x12, 0x1c, 0x3b, 0x7d, 0x12, 0xb6,
+	0x6c, 0x8b, 0x5a, 0x21, 0xf5, 0x03, 0xd2, 0x3a, 0xbe, 0x37, 0x03, 0x9a, 0xe3, 0xc0, 0xa7, 0x3e,
+	0xfa, 0x40, 0xb2, 0x34, 0x15, 0x79, 0x73, 0x46, 0x71, 0x7c, 0xaf, 0x71, 0x65, 0xe0, 0x0f, 0x7c,
+	0x4e, 0xd9, 0x62, 0x2b, 0xc1, 0xd4, 0xb8, 0x36, 0xf0, 0xfd, 0x81, 0x4b, 0x5a, 0x1c, 0x3a, 0x98,
+	0x1c, 0xb6, 0xc8, 0x68, 0x4c, 0xa7, 0xf2, 0xe3, 0x87, 0xc9, 0x8f, 0xf6, 0x24, 0xb0, 0xa8, 0xe3,
+	0x7b, 0xf2, 0xfb, 0x4a, 0xf2, 0x3b, 0x75, 0x46, 0x24, 0xa4, 0xd6, 0x68, 0x2c, 0x08, 0xcc, 0x2a,
+	0x54, 0xb6, 0xbd, 0x43, 0x1f, 0x93, 0x6f, 0x27, 0x24, 0xa4, 0xe6, 0x0d, 0x58, 0x12, 0x60, 0x38,
+	0xf6, 0xbd, 0x90, 0xa0, 0xab, 0x90, 0x73, 0xec, 0xba, 0xb6, 0xaa, 0xdd, 0x2c, 0x6f, 0x1a, 0xaf,
+	0x5f, 0xad, 0xe4, 0xb6, 0xb7, 0x70, 0xce, 0xb1, 0xcd, 0x5f, 0x43, 0x61, 0x87, 0x58, 0x21, 0x41,
+	0x08, 0xf2, 0x9e, 0x35, 0x22, 0x82, 0x04, 0xf3, 0x35, 0xba, 0x02, 0x05, 0xff, 0xa5, 0x47, 0x82,
+	0x7a, 0x8e, 0x23, 0x05, 0xc0, 0xb0, 0xd4, 0x1f, 0x12, 0xaf, 0xae, 0xaf, 0x6a, 0x37, 0xf3, 0x58,
+	0x00, 0xe8, 0x07, 0xa0, 0x53, 0xea, 0xd6, 0xf3, 0xab, 0xda, 0xcd, 0xca, 0xc6, 0xff, 0x35, 0x85,
+	0xba, 0x4d, 0xa5, 0x6e, 0x73, 0x4b, 0x9a, 0xb3, 0x59, 0x7c, 0xfd, 0x6a, 0x45, 0xef, 0xf5, 0x76,
+	0x30, 0x23, 0x37, 0xff, 0xaa, 0x01, 0x6a, 0xf7, 0xbf, 0x9d, 0x38, 0x01, 0xd9, 0xf1, 0xfb, 0x43,
+	0xa9, 0x3d, 0xfa, 0x04, 0x8a, 0xcc, 0x3e, 0x7f, 0x42, 0xeb, 0x5a, 0xca, 0x86, 0x58, 0x51, 0x46,
+	0x16, 0xe4, 0xce, 0xb2, 0x40, 0x8f, 0x5b, 0x70, 0x31, 0x5d, 0xbf, 0x86, 0xf7, 0x4f, 0xa9, 0x2a,
+	0x3d, 0xfb, 0x29, 0x14, 0x5c, 0x62, 0x85, 0x44, 0x6a, 0xfa, 0x71, 0xf3, 0xdc, 0xbb, 0xd1, 0xe4,
+	0xde, 0xc6, 0x82, 0xc5, 0xfc, 0xbd, 0x06, 0x35, 0x4c, 0x3c, 0xf2, 0x32, 0x6e, 0xfc, 0xff, 0xe6,
+	0x24, 0xf6, 0xe0, 0x72, 0x4c, 0x93, 0x77, 0x60, 0x5b, 0x0f, 0x10, 0x26, 0x7c, 0xf9, 0x0e, 0x8d,
+	0x33, 0xff, 0xad, 0xc1, 0xa5, 0xc7, 0x84, 0x04, 0xf1, 0x3d, 0xbf, 0x02, 0xc3, 0xea, 0x33, 0x93,
+	0xf8, 0xae, 0xcb, 0x1b, 0x3f, 0x4c, 0x51, 0x33, 0xc1, 0xdf, 0x6c, 0x73, 0x66, 0x2c, 0x37, 0x99,
+	0x19, 0x9d, 0x5b, 0xdc, 0xe8, 0x9f, 0x80, 0x21, 0x76, 0x43, 0x65, 0x28, 0x3c, 0xc4, 0xed, 0xdd,
+	0x5e, 0xed, 0x3d, 0x54, 0x81, 0xe2, 0x83, 0xbd, 0xdd, 0x9f, 0x6f, 0xe3, 0xaf, 0x6a, 0x1a, 0xc3,
+	0xe3, 0xce, 0x6e, 0xe7, 0x59, 0x2d, 0xc7, 0xf0, 0xb8, 0xb3, 0xd3, 0x69, 0x77, 0x3b, 0x35, 0xdd,
+	0xfc, 0x8d, 0x06, 0xb5, 0x99, 0x62, 0xd2, 0xff, 0x75, 0x28, 0x0e, 0x02, 0xcb, 0xa3, 0x44, 0x84,
+	0x6e, 0x09, 0x2b, 0x10, 0xfd, 0x0c, 0x8c, 0x23, 0xdf, 0xb5, 0x49, 0xb0, 0x90, 0x96, 0x92, 0x67,
+	0x8e, 0x6f, 0xd7, 0xe0, 0xfd, 0x07, 0x01, 0xb1, 0x28, 0xd9, 0x9c, 0xf4, 0x87, 0x84, 0x2a, 0xf7,
+	0x5e, 0x05, 0xe3, 0x80, 0x23, 0xe4, 0xa1, 0x49, 0xc8, 0xfc, 0x06, 0x8a, 0x4f, 0x49, 0x10, 0x32,
+	0x63, 0x11, 0xe4, 0x5f, 0x5a, 0xae, 0xcb, 0x09, 0x74, 0xcc, 0xd7, 0x4c, 0x77, 0xd7, 0x1f, 0x38,
+	0x7d, 0xcb, 0xe5, 0x2a, 0x56, 0xb1, 0x02, 0xd1, 0x75, 0x28, 0x7a, 0xbe, 0x4d, 0xf6, 0x1d, 0x5b,
+	0x84, 0xe5, 0x26, 0xbc, 0x7e, 0xb5, 0x62, 0xec, 0xfa, 0x36, 0xd9, 0xde, 0xc2, 0x06, 0xfb, 0xb4,
+	0x6d, 0x9b, 0x7f, 0xd7, 0xa0, 0xd0, 0xf1, 0x68, 0x30, 0x45, 0xf7, 0xa1, 0x78, 0x2c, 0xe4, 0xc8,
+	0x6b, 0x78, 0x23, 0xc5, 0x56, 0xa9, 0x15, 0x56, 0x6c, 0xcc, 0xdc, 0x63, 0xcb, 0x9d, 0x88, 0x13,
+	0x5d, 0xc2, 0x02, 0x60, 0x0a, 0xda, 0xc4, 0x25, 0x94, 0x08, 0x35, 0x4a, 0x58, 0x81, 0xe8, 0x03,
+	0x00, 0x72, 0x32, 0x76, 0x02, 0x12, 0xee, 0x5b, 0x94, 0x07, 0x92, 0x8e, 0xcb, 0x12, 0xd3, 0xa6,
+	0xa8, 0x01, 0xa5, 0x90, 0xf9, 0xc6, 0xeb, 0x93, 0x7a, 0x81, 0x3b, 0x30, 0x82, 0xcd, 0xff, 0x68,
+	0x00, 0xdd, 0x54, 0xdf, 0xa1, 0x1a, 0xe8, 0x43, 0x32, 0x95, 0x17, 0x9e, 0x2d, 0x67, 0x3a, 0xea,
+	0x71, 0x1d, 0x11, 0xe4, 0xc3, 0xa9, 0xd7, 0xe7, 0x3a, 0x94, 0x30, 0x5f, 0xc7, 0xfd, 0x51, 0xb8,
+	0x98, 0x3f, 0x64, 0x86, 0x30, 0x16, 0xca, 0x10, 0x09, 0xaf, 0x14, 0x13, 0x5e, 0x31, 0xff, 0xa4,
+	0x41, 0xe9, 0x11, 0x99, 0x3e, 0xe5, 0x7a, 0x4b, 0xfb, 0xb4, 0x33, 0xec, 0x3b, 0x75, 0x06, 0x31,
+	0x5b, 0xf4, 0x8b, 0xd9, 0x72, 0xfe, 0x59, 0x99, 0x3f, 0x02, 0x78, 0x78, 0x81, 0xe3, 0x60, 0xd6,
+	0x54, 0xbb, 0xc4, 0x0a, 0xfa, 0x47, 0x69, 0xbc, 0x57, 0xc1, 0x18, 0x07, 0xe4, 0xd0, 0x39, 0x91,
+	0xec, 0x12, 0x62, 0x06, 0xbb, 0xce, 0xc8, 0xa1, 0x2a, 0xc6, 0x38, 0x80, 0x56, 0xa0, 0x12, 0x52,
+	0x2b, 0xa0, 0xfb, 0xd6, 0x21, 0x25, 0x01, 0xd7, 0xb7, 0x8c, 0x81, 0xa3, 0xda, 0x0c, 0x83, 0xae,
+	0x41, 0x79, 0x48, 0xa6, 0xe1, 0xbe, 0xef, 0xb9, 0x53, 0x7e, 0xbe, 0x25, 0x5c, 0x62, 0x88, 0x3d,
+	0xcf, 0x9d, 0x9a, 0x53, 0x58, 0x56, 0x4a, 0x45, 0x75, 0xfd, 0x6c, 0xad, 0x3e, 0x83, 0x3c, 0xf3,
+	0x5b, 0x3d, 0xb7, 0xaa, 0xdf, 0xac, 0x6c, 0x7c, 0x3f, 0xc5, 0xab, 0xea, 0xdc, 0x30, 0x67, 0xe2,
+	0x49, 0x9a, 0x9c, 0x50, 0x59, 0x34, 0xf9, 0xda, 0x3c, 0x80, 0x0a, 0x77, 0x64, 0x66, 0xb9, 0xda,
+	0xc2, 0x72, 0xcd, 0xbf, 0x69, 0x50, 0xdd, 0xe2, 0x31, 0xb8, 0x78, 0xfc, 0xa8, 0x48, 0xd1, 0x63,
+	0x91, 0xf2, 0x11, 0x2c, 0x79, 0xfe, 0x3e, 0xf5, 0x47, 0x07, 0x21, 0xf5, 0x3d, 0x22, 0xa3, 0xa8,
+	0xe2, 0xf9, 0x3d, 0x85, 0x7a, 0xfb, 0x60, 0x32, 0x2f, 0x41, 0x75, 0xd3, 0xea, 0x0f, 0x27, 0x63,
+	0xd5, 0x7a, 0x7d, 0x0c, 0xcb, 0x0a, 0x21, 0x9d, 0x85, 0xa4, 0x53, 0x34, 0x7e, 0xf5, 0x85, 0xad,
+	0xf7, 0x61, 0x19, 0x13, 0xbe, 0x6b, 0xac, 0x34, 0x26, 0xa9, 0x78, 0xaa, 0xf1, 0xac, 0x71, 0x78,
+	0xe4, 0x53, 0x69, 0x6c, 0x04, 0x9b, 0x23, 0x28, 0x75, 0xe5, 0xfa, 0xcc, 0xb2, 0xca, 0x3c, 0xe2,
+	0x7c, 0x27, 0x02, 0x4e, 0xc7, 0x7c, 0x8d, 0x7e, 0x0a, 0xd0, 0xe7, 0x29, 0xde, 0x66, 0xd1, 0x22,
+	0x42, 0xae, 0xf1, 0x46, 0x02, 0xe8, 0xa9, 0xde, 0x12, 0x97, 0x25, 0x75, 0x9b, 0x9a, 0x08, 0x6a,
+	0x4a, 0x5c, 0xa8, 0x4c, 0x7d, 0x01, 0x97, 0x63, 0x38, 0x69, 0x6d, 0x07, 0xca, 0x4a, 0xc7, 0xb0,
+	0xae, 0x65, 0xba, 0x7f, 0x6a, 0x13, 0x3c, 0xe3, 0x64, 0xf2, 0xb0, 0x4f, 0x2d, 0x4a, 0x1e, 0x91,
+	0xa9, 0x92, 0xf7, 0x0c, 0x2e, 0xc7, 0x70, 0x52, 0xde, 0x2a, 0x18, 0x43, 0x32, 0xdd, 0x8f, 0xda,
+	0xdb, 0xf2, 0xeb, 0x57, 0x2b, 0x85, 0x47, 0x64, 0xba, 0xbd, 0x85, 0x0b, 0x43, 0x32, 0xdd, 0xb6,
+	0xd1, 0x2a, 0x54, 0x02, 0x42, 0xbc, 0x7e, 0x30, 0x1d, 0xb3, 0x6c, 0x9f, 0xe3, 0x01, 0x19, 0x47,
+	0x99, 0x7f, 0xd0, 0xa0, 0xd2, 0x9d, 0x7a, 0x7d, 0x75, 0x16, 0x57, 0xa0, 0x60, 0x13, 0x57, 0x1e,
+	0x46, 0x09, 0x0b, 0x80, 0x61, 0x43, 0x87, 0x65, 0x7d, 0xb1, 0x83, 0x00, 0x62, 0x77, 0x54, 0x3f,
+	0x75, 0x47, 0x11, 0xe4, 0x59, 0xe0, 0xd6, 0xf3, 0xab, 0x3a, 0x3b, 0x13, 0xb6, 0x8e, 0x97, 0xbe,
+	0xc2, 0xdc, 0xd2, 0xf7, 0xc7, 0x1c, 0x54, 0x99, 0x32, 0x7b, 0x63, 0x22, 0x12, 0x31, 0x6a, 0x27,
+	0x3a, 0x9c, 0x5b, 0x69, 0xfe, 0x9c, 0x7a, 0xfd, 0x44, 0x57, 0x33, 0xd3, 0x32, 0x77, 0x56, 0x24,
+	0xe9, 0x67, 0x64, 0xea, 0xfc, 0x9c, 0x4c, 0x7d, 0xc1, 0xaa, 0x13, 0x2f, 0x9b, 0xc6, 0xe9, 0xb2,
+	0x99, 0x56, 0x5b, 0x1e, 0x88, 0xa6, 0x2f, 0x7e, 0x42, 0x73, 0x1e, 0x34, 0xac, 0xaa, 0x5b, 0xb6,
+	0x1d, 0x90, 0x30, 0x94, 0x86, 0x2a, 0x90, 0x05, 0xea, 0x96, 0x33, 0x20, 0xa1, 0xaa, 0x06, 0x26,
+	0x86, 0x25, 0xd1, 0xe9, 0x08, 0xf4, 0x79, 0x19, 0xde, 0xe6, 0x14, 0xb2, 0x76, 0x49, 0x28, 0x3a,
+	0x60, 0x91, 0xe0, 0xf9, 0xda, 0xfc, 0xa7, 0x06, 0xcb, 0x4a, 0xca, 0xec, 0x7e, 0xba, 0xfe, 0x20,
+	0x71, 0x3f, 0x77, 0xfc, 0x01, 0xbb, 0x9f, 0xae, 0x3f, 0xd8, 0xb6, 0x4f, 0x79, 0x26, 0x97, 0xf0,
+	0xcc, 0xf7, 0x60, 0xf9, 0xd0, 0x09, 0x42, 0xba, 0x1f, 0x51, 0x08, 0x71, 0x55, 0x8e, 0xed, 0x2a,
+	0xb2, 0x0e, 0x14, 0x85, 0xb6, 0xe2, 0xbe, 0x55, 0x36, 0xee, 0xa4, 0x1c, 0x4f, 0xdc, 0x72, 0xac,
+	0x78, 0xcd, 0x5b, 0x70, 0x49, 0x9e, 0x5b, 0x98, 0xd6, 0xfe, 0xfd, 0x0a, 0x80, 0xa5, 0x6f, 0x79,
+	0xb8, 0x6f, 0x16, 0xfc, 0xd8, 0x85, 0xc9, 0x5d, 0x2c, 0xb3, 0xfe, 0x12, 0x6a, 0x33, 0x65, 0xa2,
+	0xe4, 0x52, 0x92, 0x9f, 0x55, 0x6e, 0xb9, 0x95, 0xa1, 0xc6, 0xc8, 0x9d, 0x23, 0x56, 0x16, 0xef,
+	0xc6, 0x83, 0x23, 0xcb, 0x1b, 0x90, 0x53, 0xce, 0xd7, 0x12, 0xce, 0xcf, 0x1e, 0x34, 0x9f, 0x42,
+	0x81, 0xb0, 0x6e, 0xb5, 0x9e, 0xcf, 0xd4, 0x8e, 0xf3, 0xce, 0x16, 0x0b, 0x16, 0xf3, 0x05, 0x2c,
+	0x3d, 0xb3, 0xe8, 0xc5, 0x3b, 0x8d, 0x06, 0x94, 0x02, 0x72, 0xec, 0x44, 0x5d, 0x54, 0x1e, 0x47,
+	0xb0, 0xf9, 0x4a, 0x03, 0xe0, 0x9b, 0x77, 0x8e, 0x89, 0x47, 0xd1, 0x26, 0xe4, 0xe9, 0x74, 0x4c,
+	0x64, 0x1a, 0x69, 0xa6, 0x68, 0x39, 0x63, 0x6c, 0xf6, 0xa6, 0x63, 0x82, 0x39, 0xef, 0x5c, 0xa7,
+	0xa8, 0xd2, 0xaf, 0x5f, 0xa0, 0xf4, 0x9f, 0xb2, 0x21, 0x9f, 0xb0, 0xe1, 0x1a, 0xe4, 0x99, 0x78,
+	0x54, 0x04, 0xfd, 0xf1, 0x13, 0xf6, 0xa0, 0x02, 0x30, 0xb6, 0x3a, 0x3b, 0x9d, 0x5e, 0xa7, 0xa6,
+	0x99, 0xbf, 0xd5, 0xa1, 0xf8, 0xc0, 0x1f, 0x8d, 0xad, 0x80, 0x2c, 0xd0, 0x2d, 0x74, 0xc0, 0xa0,
+	0x56, 0x30, 0x90, 0x39, 0x7b, 0x79, 0x63, 0x2d, 0x45, 0x5b, 0x29, 0xa1, 0xd9, 0xe3, 0x4c, 0x58,
+	0x32, 0xb3, 0x6d, 0x02, 0x12, 0x4e, 0x5c, 0xd1, 0x78, 0x66, 0xdf, 0x06, 0x73, 0x26, 0x2c, 0x99,
+	0x67, 0x19, 0xb7, 0x30, 0x27, 0xe3, 0x1a, 0x17, 0xcb, 0xb8, 0x57, 0xc1, 0x20, 0x27, 0x4e, 0x48,
+	0x43, 0x9e, 0x51, 0x4b, 0x58, 0x42, 0xe6, 0x5d, 0x30, 0x84, 0x21, 0xec, 0x35, 0xfa, 0xb4, 0xbd,
+	0xf3, 0xa4, 0x23, 0x5e, 0xa9, 0x4f, 0x3b, 0xb8, 0xbb, 0xbd, 0xb7, 0x5b, 0xd3, 0x98, 0x87, 0x3b,
+	0xcf, 0xb7, 0xbb, 0xbd, 0x6e, 0x2d, 0x67, 0x9a, 0x60, 0x08, 0x7d, 0x19, 0x75, 0xe7, 0xeb, 0x27,
+	0xed, 0x9d, 0xda, 0x7b, 0xa8, 0x0a, 0xe5, 0xdd, 0xbd, 0xde, 0xbe, 0x00, 0x35, 0xf3, 0x2f, 0x1a,
+	0x14, 0x7a, 0x27, 0xde, 0xde, 0x18, 0x7d, 0x7e, 0xea, 0x86, 0xa5, 0x05, 0x27, 0xe7, 0xc9, 0x72,
+	0xb9, 0x32, 0x96, 0xa9, 0xf3, 0xef, 0xca, 0x6b, 0x0d, 0xa0, 0x77, 0xe2, 0xa9, 0x38, 0xbb, 0x0f,
+	0xc5, 0xbe, 0x38, 0x10, 0x99, 0x4a, 0x6e, 0x64, 0x3b, 0x3e, 0xac, 0xd8, 0xd0, 0x17, 0x50, 0x0c,
+	0x27, 0xfd, 0xbe, 0x28, 0x36, 0x7a, 0x86, 0xb8, 0xe7, 0xf6, 0x62, 0xc5, 0xc4, 0xf8, 0x0f, 0x2d,
+	0xc7, 0x9d, 0x04, 0x2c, 0xab, 0x2f, 0xc0, 0x2f, 0x99, 0xce, 0x7a, 0x1e, 0x9a, 0xbf, 0xd3, 0xa0,
+	0xc2, 0x8d, 0x94, 0x19, 0xf3, 0xff, 0xa1, 0xcc, 0xc5, 0x11, 0x3b, 0x9a, 0x22, 0xcc, 0x10, 0x6f,
+	0xf7, 0x4e, 0x38, 0x2f, 0xf1, 0x5c, 0x82, 0x6a, 0x97, 0x5a, 0x74, 0x12, 0xf5, 0x8a, 0xff, 0xd0,
+	0x01, 0x78, 0x11, 0xe7, 0xd8, 0xb9, 0xf5, 0xfb, 0xc7, 0x50, 0x76, 0x2d, 0x56, 0xee, 0x98, 0x5d,
+	0xb9, 0xd4, 0x06, 0xb5, 0xc4, 0x88, 0x59, 0x5f, 0x80, 0x3e, 0x87, 0x25, 0xce, 0x68, 0x51, 0xca,
+	0x06, 0xab, 0x19, 0x9a, 0xdb, 0x0a, 0xa3, 0x6f, 0x0b, 0xf2, 0xe8, 0xdd, 0x15, 0x12, 0x8f, 0xaa,
+	0x0c, 0xc4, 0x10, 0x5d, 0x96, 0x36, 0xaf, 0x43, 0x95, 0x7f, 0x0c, 0x48, 0x9f, 0x38, 0xc7, 0xc4,
+	0x96, 0xcf, 0xfe, 0x25, 0x86, 0xc4, 0x12, 0xc7, 0xa3, 0x2d, 0x08, 0xfc, 0x20, 0x94, 0xdd, 0x8d,
+	0x84, 0x58, 0x6f, 0xc3, 0x15, 0xe3, 0x20, 0x8f, 0xc4, 0x32, 0xe6, 0x36, 0x76, 0x18, 0x02, 0x7d,
+	0x01, 0xd5, 0xd9, 0x67, 0xd6, 0xfd, 0x94, 0xb2, 0x29, 0xce, 0xb9, 0x13, 0xd3, 0x88, 0x72, 0xa2,
+	0x7e, 0x5d, 0x87, 0xea, 0x98, 0x90, 0x60, 0xd6, 0x3b, 0x80, 0xd0, 0x9b, 0x21, 0xa3, 0xd6, 0xc1,
+	0x84, 0x25, 0xab, 0x3f, 0xf4, 0xfc, 0x97, 0x2e, 0xb1, 0x07, 0xc4, 0xae, 0x57, 0x04, 0x4d, 0x1c,
+	0x67, 0x7a, 0xaa, 0x55, 0x8a, 0x4e, 0xef, 0xec, 0x4c, 0xab, 0x5a, 0xa2, 0xdc, 0xac, 0x25, 0x42,
+	0x1f, 0x02, 0x44, 0x4f, 0x30, 0xd5, 0x2c, 0xc5, 0x30, 0xd1, 0x3b, 0x45, 0x38, 0x9d, 0xaf, 0xcd,
+	0x3f, 0xe7, 0x60, 0x59, 0x5d, 0x1f, 0x79, 0x8f, 0x63, 0xad, 0xb3, 0x36, 0xaf, 0x75, 0x8e, 0xf5,
+	0x5a, 0xb9, 0x0c, 0xbd, 0x96, 0x9e, 0x70, 0xd7, 0x97, 0x50, 0x60, 0x9e, 0x51, 0x2d, 0xd4, 0xad,
+	0x0c, 0x73, 0x44, 0xa9, 0xa4, 0xe0, 0x8b, 0x77, 0x61, 0x85, 0x05, 0xba, 0x30, 0xb9, 0x89, 0xe2,
+	0x4d, 0x78, 0xcc, 0x48, 0x7a, 0xec, 0xf6, 0x47, 0x00, 0xb3, 0x0e, 0x9f, 0xa5, 0xba, 0x6e, 0x27,
+	0x91, 0xea, 0x36, 0xfe, 0x85, 0xa0, 0xbc, 0xa5, 0x24, 0xa1, 0x7d, 0xc8, 0xb3, 0x5f, 0x03, 0xd0,
+	0xed, 0x14, 0x75, 0x62, 0xbf, 0x20, 0x34, 0xee, 0x64, 0xa2, 0x95, 0x87, 0x43, 0xa1, 0x12, 0x9b,
+	0x8d, 0xa3, 0x7b, 0x29, 0xbc, 0x6f, 0x8e, 0xfc, 0x1b, 0x1b, 0x8b, 0xb0, 0x48, 0xa9, 0x1e, 0x94,
+	0xa3, 0x99, 0x35, 0x6a, 0xa5, 0x6c, 0x90, 0x9c, 0xb3, 0x37, 0xd6, 0xb3, 0x33, 0x48, 0x79, 0xcf,
+	0xa1, 0x12, 0x1b, 0x69, 0xa7, 0x5a, 0xf9, 0xe6, 0xf8, 0xbb, 0x71, 0xf5, 0x8d, 0xa8, 0xee, 0xb0,
+	0x1f, 0x81, 0xd0, 0x10, 0x4a, 0x6a, 0xf8, 0x8b, 0x9a, 0x8b, 0x8d, 0xaf, 0x1b, 0xad, 0xcc, 0xf4,
+	0xd2, 0x8c, 0x17, 0xb0, 0x14, 0x9f, 0xf3, 0xa2, 0x34, 0xd7, 0x9f, 0x31, 0x14, 0x9e, 0x6b, 0xc8,
+	0x2f, 0x40, 0xef, 0x12, 0x8a, 0x52, 0x1f, 0xa8, 0xe9, 0x3b, 0x7d, 0x03, 0xfa, 0xc3, 0x0c, 0x3b,
+	0xcd, 0xa6, 0x7b, 0x8d, 0xdb, 0x59, 0x48, 0xa5, 0x0f, 0x08, 0x18, 0x62, 0x92, 0x86, 0xee, 0xa6,
+	0xaa, 0x1a, 0x9b, 0x02, 0x36, 0xd6, 0x32, 0x52, 0x4b, 0x31, 0x03, 0x58, 0x12, 0x98, 0x2e, 0x0d,
+	0x88, 0x35, 0x5a, 0x50, 0x58, 0xd6, 0x72, 0xbc, 0xae, 0xa1, 0x5d, 0x30, 0xc4, 0xe4, 0x2c, 0x55,
+	0xc4, 0xa9, 0x01, 0xdb, 0x5c, 0xef, 0x13, 0x30, 0xc4, 0x10, 0x2b, 0x75, 0xbf, 0x53, 0xc3, 0xaf,
+	0xc6, 0x5a, 0x46, 0x6a, 0xe9, 0x9f, 0xc7, 0x50, 0x94, 0x53, 0x30, 0xb4, 0x96, 0x1a, 0x4d, 0xf1,
+	0x69, 0xd9, 0x5c, 0xc5, 0x47, 0xb0, 0x24, 0x64, 0x64, 0xf4, 0xf8, 0xdb, 0xa8, 0xbf, 0xae, 0xa1,
+	0xe7, 0x50, 0x95, 0x8a, 0x49, 0x79, 0xef, 0xc6, 0x8c, 0x9b, 0x1a, 0x4b, 0x6e, 0xd1, 0x6c, 0x2d,
+	0x35, 0xb9, 0x25, 0x27, 0x73, 0x8d, 0xf5, 0xec, 0x0c, 0xb1, 0x64, 0xaa, 0x66, 0x6b, 0xe9, 0xc9,
+	0x34, 0x31, 0x99, 0x6b, 0xac, 0x67, 0x67, 0x90, 0xf2, 0x0e, 0x20, 0xcf, 0xfb, 0xb6, 0xdb, 0x19,
+	0x66, 0x59, 0x4a, 0xca, 0xdd, 0x0c, 0xb4, 0xd1, 0xd4, 0x6c, 0x5d, 0x43, 0x58, 0xa4, 0x55, 0x2e,
+	0x27, 0x4b, 0x5a, 0x8d, 0xcb, 0x3a, 0x27, 0x32, 0xe4, 0xbc, 0x28, 0x35, 0xd2, 0xe2, 0xd3, 0xa6,
+	0xc6, 0x5a, 0x46, 0x6a, 0xe9, 0x9e, 0x21, 0x94, 0xd4, 0xf0, 0x23, 0x55, 0xf5, 0xc4, 0xc8, 0xa6,
+	0xd1, 0xca, 0x4c, 0x2f, 0x85, 0x59, 0x50, 0xe0, 0x6f, 0x7d, 0x74, 0x27, 0xcb, 0x44, 0x40, 0x89,
+	0xb9, 0x95, 0x79, 0x7c, 0xb0, 0xae, 0xb1, 0x74, 0xde, 0x3b, 0xf1, 0x50, 0x86, 0x07, 0x61, 0xd6,
+	0x74, 0x1e, 0x7f, 0xe4, 0xb0, 0x74, 0x2e, 0x3a, 0xd3, 0xd4, 0x2b, 0x12, 0x7f, 0x94, 0x34, 0xd6,
+	0x32, 0x52, 0x0b, 0x31, 0x9b, 0xed, 0x17, 0x5f, 0x5e, 0xe8, 0x0f, 0x24, 0x9f, 0x45, 0xc0, 0x81,
+	0xc1, 0xaf, 0xd3, 0x27, 0xff, 0x1d, 0x00, 0x6d, 0x85, 0xce, 0x99, 0x8a, 0x22, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -913,17 +3064,29 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type DatastoreClient interface {
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
-	AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error)
+	RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponse, error)
 	ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	PeerLock(ctx context.Context, in *PeerLockRequest, opts ...grpc.CallOption) (*PeerLockResponse, error)
 	CreateBucket(ctx context.Context, in *CreateBucketRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (Datastore_SearchStreamClient, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
 	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	BackupStream(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (Datastore_BackupStreamClient, error)
+	RestoreStream(ctx context.Context, opts ...grpc.CallOption) (Datastore_RestoreStreamClient, error)
+	Snapshots(ctx context.Context, in *SnapshotsRequest, opts ...grpc.CallOption) (*SnapshotsResponse, error)
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error)
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Datastore_SyncClient, error)
 	PeerSync(ctx context.Context, in *PeerSyncRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Digest(ctx context.Context, in *DigestRequest, opts ...grpc.CallOption) (*DigestResponse, error)
+	Versions(ctx context.Context, in *VersionsRequest, opts ...grpc.CallOption) (*VersionsResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Datastore_WatchClient, error)
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 }
 
 type datastoreClient struct {
@@ -943,8 +3106,8 @@ func (c *datastoreClient) Info(ctx context.Context, in *InfoRequest, opts ...grp
 	return out, nil
 }
 
-func (c *datastoreClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
+func (c *datastoreClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error) {
+	out := new(AcquireLockResponse)
 	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/AcquireLock", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -952,84 +3115,277 @@ func (c *datastoreClient) AcquireLock(ctx context.Context, in *AcquireLockReques
 	return out, nil
 }
 
+func (c *datastoreClient) RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponse, error) {
+	out := new(RenewLockResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/RenewLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *datastoreClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
 	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/ReleaseLock", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *datastoreClient) PeerLock(ctx context.Context, in *PeerLockRequest, opts ...grpc.CallOption) (*PeerLockResponse, error) {
+	out := new(PeerLockResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/PeerLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) CreateBucket(ctx context.Context, in *CreateBucketRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/CreateBucket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (Datastore_SearchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[0], "/stellar.services.datastore.v1.Datastore/SearchStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datastoreSearchStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Datastore_SearchStreamClient interface {
+	Recv() (*KeyValue, error)
+	grpc.ClientStream
+}
+
+type datastoreSearchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *datastoreSearchStreamClient) Recv() (*KeyValue, error) {
+	m := new(KeyValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datastoreClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) BackupStream(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (Datastore_BackupStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[1], "/stellar.services.datastore.v1.Datastore/BackupStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datastoreBackupStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Datastore_BackupStreamClient interface {
+	Recv() (*BackupResponse, error)
+	grpc.ClientStream
+}
+
+type datastoreBackupStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *datastoreBackupStreamClient) Recv() (*BackupResponse, error) {
+	m := new(BackupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datastoreClient) RestoreStream(ctx context.Context, opts ...grpc.CallOption) (Datastore_RestoreStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[2], "/stellar.services.datastore.v1.Datastore/RestoreStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datastoreRestoreStreamClient{stream}
+	return x, nil
+}
+
+type Datastore_RestoreStreamClient interface {
+	Send(*RestoreRequest) error
+	CloseAndRecv() (*types.Empty, error)
+	grpc.ClientStream
+}
+
+type datastoreRestoreStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *datastoreRestoreStreamClient) Send(m *RestoreRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *datastoreRestoreStreamClient) CloseAndRecv() (*types.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(types.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *datastoreClient) CreateBucket(ctx context.Context, in *CreateBucketRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/CreateBucket", in, out, opts...)
+func (c *datastoreClient) Snapshots(ctx context.Context, in *SnapshotsRequest, opts ...grpc.CallOption) (*SnapshotsResponse, error) {
+	out := new(SnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Snapshots", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *datastoreClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Set", in, out, opts...)
+func (c *datastoreClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error) {
+	out := new(RotateKeyResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/RotateKey", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *datastoreClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Get", in, out, opts...)
+func (c *datastoreClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Datastore_SyncClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[3], "/stellar.services.datastore.v1.Datastore/Sync", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &datastoreSyncClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *datastoreClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
-	out := new(SearchResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Search", in, out, opts...)
-	if err != nil {
+type Datastore_SyncClient interface {
+	Recv() (*SyncOperation, error)
+	grpc.ClientStream
+}
+
+type datastoreSyncClient struct {
+	grpc.ClientStream
+}
+
+func (x *datastoreSyncClient) Recv() (*SyncOperation, error) {
+	m := new(SyncOperation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *datastoreClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *datastoreClient) PeerSync(ctx context.Context, in *PeerSyncRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Delete", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/PeerSync", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *datastoreClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
-	out := new(BackupResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Backup", in, out, opts...)
+func (c *datastoreClient) Digest(ctx context.Context, in *DigestRequest, opts ...grpc.CallOption) (*DigestResponse, error) {
+	out := new(DigestResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Digest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *datastoreClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Restore", in, out, opts...)
+func (c *datastoreClient) Versions(ctx context.Context, in *VersionsRequest, opts ...grpc.CallOption) (*VersionsResponse, error) {
+	out := new(VersionsResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Versions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *datastoreClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Datastore_SyncClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[0], "/stellar.services.datastore.v1.Datastore/Sync", opts...)
+func (c *datastoreClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Datastore_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Datastore_serviceDesc.Streams[4], "/stellar.services.datastore.v1.Datastore/Watch", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &datastoreSyncClient{stream}
+	x := &datastoreWatchClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1039,26 +3395,35 @@ func (c *datastoreClient) Sync(ctx context.Context, in *SyncRequest, opts ...grp
 	return x, nil
 }
 
-type Datastore_SyncClient interface {
-	Recv() (*SyncOperation, error)
+type Datastore_WatchClient interface {
+	Recv() (*WatchEvent, error)
 	grpc.ClientStream
 }
 
-type datastoreSyncClient struct {
+type datastoreWatchClient struct {
 	grpc.ClientStream
 }
 
-func (x *datastoreSyncClient) Recv() (*SyncOperation, error) {
-	m := new(SyncOperation)
+func (x *datastoreWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *datastoreClient) PeerSync(ctx context.Context, in *PeerSyncRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/PeerSync", in, out, opts...)
+func (c *datastoreClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	out := new(TxnResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Txn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datastoreClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.datastore.v1.Datastore/Status", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1068,17 +3433,29 @@ func (c *datastoreClient) PeerSync(ctx context.Context, in *PeerSyncRequest, opt
 // DatastoreServer is the server API for Datastore service.
 type DatastoreServer interface {
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
-	AcquireLock(context.Context, *AcquireLockRequest) (*types.Empty, error)
+	AcquireLock(context.Context, *AcquireLockRequest) (*AcquireLockResponse, error)
+	RenewLock(context.Context, *RenewLockRequest) (*RenewLockResponse, error)
 	ReleaseLock(context.Context, *ReleaseLockRequest) (*types.Empty, error)
+	PeerLock(context.Context, *PeerLockRequest) (*PeerLockResponse, error)
 	CreateBucket(context.Context, *CreateBucketRequest) (*types.Empty, error)
 	Set(context.Context, *SetRequest) (*types.Empty, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	SearchStream(*SearchRequest, Datastore_SearchStreamServer) error
 	Delete(context.Context, *DeleteRequest) (*types.Empty, error)
 	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 	Restore(context.Context, *RestoreRequest) (*types.Empty, error)
+	BackupStream(*BackupRequest, Datastore_BackupStreamServer) error
+	RestoreStream(Datastore_RestoreStreamServer) error
+	Snapshots(context.Context, *SnapshotsRequest) (*SnapshotsResponse, error)
+	RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error)
 	Sync(*SyncRequest, Datastore_SyncServer) error
 	PeerSync(context.Context, *PeerSyncRequest) (*types.Empty, error)
+	Digest(context.Context, *DigestRequest) (*DigestResponse, error)
+	Versions(context.Context, *VersionsRequest) (*VersionsResponse, error)
+	Watch(*WatchRequest, Datastore_WatchServer) error
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
 }
 
 func RegisterDatastoreServer(s *grpc.Server, srv DatastoreServer) {
@@ -1121,6 +3498,24 @@ func _Datastore_AcquireLock_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Datastore_RenewLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).RenewLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/RenewLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).RenewLock(ctx, req.(*RenewLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Datastore_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReleaseLockRequest)
 	if err := dec(in); err != nil {
@@ -1139,6 +3534,24 @@ func _Datastore_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Datastore_PeerLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).PeerLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/PeerLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).PeerLock(ctx, req.(*PeerLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Datastore_CreateBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateBucketRequest)
 	if err := dec(in); err != nil {
@@ -1211,6 +3624,27 @@ func _Datastore_Search_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Datastore_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatastoreServer).SearchStream(m, &datastoreSearchStreamServer{stream})
+}
+
+type Datastore_SearchStreamServer interface {
+	Send(*KeyValue) error
+	grpc.ServerStream
+}
+
+type datastoreSearchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *datastoreSearchStreamServer) Send(m *KeyValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Datastore_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -1265,6 +3699,89 @@ func _Datastore_Restore_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Datastore_BackupStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatastoreServer).BackupStream(m, &datastoreBackupStreamServer{stream})
+}
+
+type Datastore_BackupStreamServer interface {
+	Send(*BackupResponse) error
+	grpc.ServerStream
+}
+
+type datastoreBackupStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *datastoreBackupStreamServer) Send(m *BackupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Datastore_RestoreStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatastoreServer).RestoreStream(&datastoreRestoreStreamServer{stream})
+}
+
+type Datastore_RestoreStreamServer interface {
+	SendAndClose(*types.Empty) error
+	Recv() (*RestoreRequest, error)
+	grpc.ServerStream
+}
+
+type datastoreRestoreStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *datastoreRestoreStreamServer) SendAndClose(m *types.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *datastoreRestoreStreamServer) Recv() (*RestoreRequest, error) {
+	m := new(RestoreRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Datastore_Snapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).Snapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/Snapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).Snapshots(ctx, req.(*SnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Datastore_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/RotateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Datastore_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SyncRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1304,6 +3821,99 @@ func _Datastore_PeerSync_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Datastore_Digest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).Digest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/Digest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).Digest(ctx, req.(*DigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Datastore_Versions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).Versions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/Versions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).Versions(ctx, req.(*VersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Datastore_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatastoreServer).Watch(m, &datastoreWatchServer{stream})
+}
+
+type Datastore_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type datastoreWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *datastoreWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Datastore_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/Txn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Datastore_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatastoreServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.datastore.v1.Datastore/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatastoreServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Datastore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "stellar.services.datastore.v1.Datastore",
 	HandlerType: (*DatastoreServer)(nil),
@@ -1316,10 +3926,18 @@ var _Datastore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AcquireLock",
 			Handler:    _Datastore_AcquireLock_Handler,
 		},
+		{
+			MethodName: "RenewLock",
+			Handler:    _Datastore_RenewLock_Handler,
+		},
 		{
 			MethodName: "ReleaseLock",
 			Handler:    _Datastore_ReleaseLock_Handler,
 		},
+		{
+			MethodName: "PeerLock",
+			Handler:    _Datastore_PeerLock_Handler,
+		},
 		{
 			MethodName: "CreateBucket",
 			Handler:    _Datastore_CreateBucket_Handler,
@@ -1348,17 +3966,61 @@ var _Datastore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Restore",
 			Handler:    _Datastore_Restore_Handler,
 		},
+		{
+			MethodName: "Snapshots",
+			Handler:    _Datastore_Snapshots_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _Datastore_RotateKey_Handler,
+		},
 		{
 			MethodName: "PeerSync",
 			Handler:    _Datastore_PeerSync_Handler,
 		},
+		{
+			MethodName: "Digest",
+			Handler:    _Datastore_Digest_Handler,
+		},
+		{
+			MethodName: "Versions",
+			Handler:    _Datastore_Versions_Handler,
+		},
+		{
+			MethodName: "Txn",
+			Handler:    _Datastore_Txn_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Datastore_Status_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchStream",
+			Handler:       _Datastore_SearchStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BackupStream",
+			Handler:       _Datastore_BackupStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreStream",
+			Handler:       _Datastore_RestoreStream_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Sync",
 			Handler:       _Datastore_Sync_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Datastore_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "github.com/ehazlett/stellar/api/services/datastore/v1/datastore.proto",
 }
@@ -0,0 +1,85 @@
+package datastore
+
+import "bytes"
+
+// CompareValue returns a compare that succeeds if the key has the value
+func CompareValue(bucket, key string, value []byte) *Compare {
+	return &Compare{
+		Bucket: bucket,
+		Key:    key,
+		Target: Compare_VALUE,
+		Result: Compare_EQUAL,
+		Value:  value,
+	}
+}
+
+// CompareVersion returns a compare that succeeds if the key is at the version
+func CompareVersion(bucket, key string, v *Version) *Compare {
+	return &Compare{
+		Bucket:  bucket,
+		Key:     key,
+		Target:  Compare_VERSION,
+		Result:  Compare_EQUAL,
+		Version: v,
+	}
+}
+
+// CompareExists returns a compare that succeeds if the key exists
+func CompareExists(bucket, key string) *Compare {
+	return &Compare{
+		Bucket: bucket,
+		Key:    key,
+		Target: Compare_EXISTS,
+		Result: Compare_EQUAL,
+		Exists: true,
+	}
+}
+
+// CompareMissing returns a compare that succeeds if the key does not exist
+func CompareMissing(bucket, key string) *Compare {
+	return &Compare{
+		Bucket: bucket,
+		Key:    key,
+		Target: Compare_EXISTS,
+		Result: Compare_EQUAL,
+		Exists: false,
+	}
+}
+
+// OpPut returns an operation setting the key to the value
+func OpPut(bucket, key string, value []byte) *TxnOp {
+	return &TxnOp{
+		Type:   TxnOp_PUT,
+		Bucket: bucket,
+		Key:    key,
+		Value:  value,
+	}
+}
+
+// OpDelete returns an operation deleting the key
+func OpDelete(bucket, key string) *TxnOp {
+	return &TxnOp{
+		Type:   TxnOp_DELETE,
+		Bucket: bucket,
+		Key:    key,
+	}
+}
+
+// Matches returns true if the compare succeeds for the current entry.  A nil
+// entry is a key that does not exist.
+func (c *Compare) Matches(e *Entry) bool {
+	exists := e != nil && !e.Deleted
+	equal := false
+	switch c.Target {
+	case Compare_VALUE:
+		equal = exists && bytes.Equal(e.Value, c.Value)
+	case Compare_VERSION:
+		equal = exists && e.Version.Compare(c.Version) == 0
+	case Compare_EXISTS:
+		equal = exists == c.Exists
+	}
+	if c.Result == Compare_NOT_EQUAL {
+		return !equal
+	}
+	return equal
+}
@@ -0,0 +1,35 @@
+package datastore
+
+import "strings"
+
+// Compare returns -1 if the version is older than o, 1 if it is newer and 0
+// if they are equal.  A nil version is older than any other version.  Ties on
+// the clock are broken by the node id so all nodes resolve conflicts the same.
+func (v *Version) Compare(o *Version) int {
+	switch {
+	case v == nil && o == nil:
+		return 0
+	case v == nil:
+		return -1
+	case o == nil:
+		return 1
+	}
+
+	switch {
+	case v.Wall < o.Wall:
+		return -1
+	case v.Wall > o.Wall:
+		return 1
+	case v.Logical < o.Logical:
+		return -1
+	case v.Logical > o.Logical:
+		return 1
+	}
+
+	return strings.Compare(v.NodeID, o.NodeID)
+}
+
+// Newer returns true if the version is newer than o
+func (v *Version) Newer(o *Version) bool {
+	return v.Compare(o) > 0
+}
@@ -32,6 +32,7 @@ const (
 	RecordType_MX      RecordType = 3
 	RecordType_TXT     RecordType = 4
 	RecordType_SRV     RecordType = 5
+	RecordType_AAAA    RecordType = 6
 )
 
 var RecordType_name = map[int32]string{
@@ -41,6 +42,7 @@ var RecordType_name = map[int32]string{
 	3: "MX",
 	4: "TXT",
 	5: "SRV",
+	6: "AAAA",
 }
 
 var RecordType_value = map[string]int32{
@@ -50,6 +52,7 @@ var RecordType_value = map[string]int32{
 	"MX":      3,
 	"TXT":     4,
 	"SRV":     5,
+	"AAAA":    6,
 }
 
 func (x RecordType) String() string {
@@ -452,41 +455,41 @@ func init() {
 }
 
 var fileDescriptor_1716b9904e0ef365 = []byte{
-	// 531 bytes of a gzipped FileDescriptorProto
+	// 537 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xe1, 0x6b, 0xd3, 0x40,
-	0x1c, 0x5d, 0xd2, 0x34, 0x65, 0xbf, 0xae, 0x23, 0x1c, 0x65, 0xd4, 0x0a, 0x5a, 0x0a, 0x8e, 0x32,
-	0xf5, 0xc2, 0xea, 0x47, 0x41, 0xec, 0xba, 0x22, 0xc3, 0xad, 0x93, 0x58, 0xb5, 0xf8, 0x2d, 0x6d,
-	0x7f, 0xcd, 0x82, 0x69, 0x2e, 0x4b, 0x2e, 0x85, 0xf8, 0xe7, 0xf8, 0x7f, 0xe9, 0x07, 0xff, 0x12,
-	0xb9, 0x5c, 0x62, 0x53, 0x45, 0x53, 0x06, 0xfb, 0x76, 0x8f, 0x7b, 0xef, 0xf7, 0xf2, 0x72, 0xef,
-	0x0e, 0xde, 0x38, 0x2e, 0xbf, 0x89, 0x67, 0x74, 0xce, 0x56, 0x26, 0xde, 0xd8, 0x5f, 0x3d, 0xe4,
-	0xdc, 0x8c, 0x38, 0x7a, 0x9e, 0x1d, 0x9a, 0x76, 0xe0, 0x9a, 0x11, 0x86, 0x6b, 0x77, 0x8e, 0x91,
-	0xe9, 0xdb, 0x2b, 0x14, 0x00, 0x43, 0x73, 0x7d, 0x5a, 0x40, 0x34, 0x08, 0x19, 0x67, 0xe4, 0x51,
-	0x26, 0xa2, 0xb9, 0x80, 0x16, 0x28, 0xeb, 0xd3, 0x76, 0xd3, 0x61, 0x0e, 0x4b, 0xa9, 0xa6, 0x58,
-	0x49, 0x55, 0xfb, 0xa1, 0xc3, 0x98, 0xe3, 0xa1, 0x99, 0xa2, 0x59, 0xbc, 0x34, 0x71, 0x15, 0xf0,
-	0x24, 0xdb, 0x7c, 0xf0, 0xe7, 0xa6, 0xed, 0x67, 0x5b, 0xdd, 0x06, 0xd4, 0x2f, 0xfc, 0x25, 0xb3,
-	0xf0, 0x36, 0xc6, 0x88, 0x77, 0x8f, 0xe1, 0x40, 0xc2, 0x28, 0x60, 0x7e, 0x84, 0xe4, 0x08, 0x54,
-	0x77, 0xd1, 0x52, 0x3a, 0x4a, 0x6f, 0xff, 0x4c, 0xff, 0xf9, 0xe3, 0xb1, 0x7a, 0x71, 0x6e, 0xa9,
-	0xee, 0xa2, 0xfb, 0x04, 0x1a, 0x97, 0x8c, 0x7d, 0x89, 0x83, 0x4c, 0x48, 0x9a, 0x50, 0xbd, 0x8d,
-	0x31, 0x4c, 0x24, 0xd7, 0x92, 0xa0, 0xfb, 0x4d, 0x01, 0xdd, 0xc2, 0x39, 0x0b, 0x17, 0xe4, 0x15,
-	0x68, 0x3c, 0x09, 0x30, 0xdd, 0x3f, 0xec, 0x9f, 0xd0, 0xff, 0xa7, 0xa4, 0x52, 0x35, 0x49, 0x02,
-	0xb4, 0x52, 0x1d, 0x21, 0xa0, 0x09, 0x46, 0x4b, 0x4d, 0xe7, 0xa7, 0x6b, 0x61, 0xba, 0xb6, 0xbd,
-	0x18, 0x5b, 0x15, 0x69, 0x9a, 0x02, 0x42, 0xa1, 0xc6, 0x02, 0xee, 0x32, 0x3f, 0x6a, 0x69, 0x1d,
-	0xa5, 0x57, 0xef, 0x37, 0xa9, 0xcc, 0x4f, 0xf3, 0xfc, 0x74, 0xe0, 0x27, 0x56, 0x4e, 0xea, 0x2e,
-	0xe1, 0x30, 0xcf, 0x92, 0xa5, 0xce, 0xbd, 0x94, 0x82, 0xd7, 0x6b, 0xa8, 0x85, 0xe9, 0x37, 0x45,
-	0x2d, 0xb5, 0x53, 0xe9, 0xd5, 0xfb, 0xc7, 0xbb, 0x45, 0xb0, 0x72, 0x99, 0xf8, 0xd5, 0x97, 0x6e,
-	0xc4, 0xf3, 0x5f, 0xfd, 0x0e, 0x0e, 0x24, 0xcc, 0x4c, 0x0b, 0x06, 0xca, 0xdd, 0x0c, 0x10, 0x1a,
-	0xc3, 0x10, 0x6d, 0x8e, 0xf9, 0xa1, 0xdc, 0x4f, 0x8e, 0x39, 0x34, 0xce, 0xd1, 0xc3, 0x8d, 0xcd,
-	0x3d, 0x1c, 0xed, 0xc9, 0x08, 0x60, 0xc3, 0x23, 0x75, 0xa8, 0x7d, 0x18, 0xbf, 0x1d, 0x5f, 0x7f,
-	0x1a, 0x1b, 0x7b, 0xa4, 0x0a, 0xca, 0xc0, 0x50, 0xc8, 0x3e, 0x54, 0x87, 0xe3, 0xc1, 0xd5, 0xc8,
-	0x50, 0x89, 0x0e, 0xea, 0xd5, 0xd4, 0xa8, 0x90, 0x1a, 0x54, 0x26, 0xd3, 0x89, 0xa1, 0x89, 0xc5,
-	0x7b, 0xeb, 0xa3, 0x51, 0xed, 0x7f, 0xaf, 0x00, 0x8c, 0x7f, 0xbb, 0x13, 0x1b, 0x34, 0x51, 0x6f,
-	0xf2, 0xb4, 0xec, 0x1b, 0x0b, 0x77, 0xa2, 0xfd, 0x6c, 0x37, 0x72, 0x76, 0x8c, 0x0e, 0xe8, 0xb2,
-	0x4d, 0xe4, 0x79, 0x99, 0x6e, 0xeb, 0x06, 0xb5, 0xe9, 0xae, 0xf4, 0xcc, 0xc8, 0x06, 0x4d, 0xf4,
-	0xa7, 0x3c, 0x4b, 0xa1, 0x74, 0xe5, 0x59, 0xb6, 0x2a, 0x79, 0x0d, 0xba, 0x2c, 0x54, 0x79, 0x96,
-	0xad, 0xe2, 0xb5, 0x8f, 0xfe, 0xba, 0x71, 0x23, 0xf1, 0x1c, 0x89, 0x81, 0xb2, 0x3a, 0xe5, 0x03,
-	0xb7, 0x2a, 0xf6, 0xaf, 0x81, 0x67, 0xc3, 0xcf, 0x83, 0xbb, 0xbd, 0xbb, 0x2f, 0x37, 0x68, 0xba,
-	0x37, 0xd3, 0xd3, 0xb1, 0x2f, 0x7e, 0x05, 0x00, 0x00, 0xff, 0xff, 0x39, 0xba, 0x2b, 0x5d, 0xc5,
-	0x05, 0x00, 0x00,
+	0x1c, 0x5d, 0xd2, 0x34, 0x75, 0xbf, 0xae, 0x23, 0x1c, 0x63, 0xd4, 0x08, 0x5a, 0x0a, 0x8e, 0x32,
+	0xe7, 0x85, 0xd5, 0x8f, 0x82, 0x98, 0x75, 0x43, 0x86, 0x5b, 0x2b, 0xb1, 0x6a, 0xf1, 0x5b, 0xda,
+	0xfe, 0x9a, 0x05, 0xd3, 0x5c, 0x96, 0x5c, 0x0a, 0xf5, 0xcf, 0xf1, 0xff, 0xd2, 0x0f, 0xfe, 0x25,
+	0x72, 0xb9, 0xc4, 0xa6, 0x8a, 0xb6, 0x0c, 0xf6, 0xed, 0x1e, 0xf7, 0xde, 0xef, 0xdd, 0xcb, 0xbd,
+	0x0b, 0xbc, 0xf1, 0x7c, 0x7e, 0x93, 0x8e, 0xe9, 0x84, 0xcd, 0x2d, 0xbc, 0x71, 0xbf, 0x06, 0xc8,
+	0xb9, 0x95, 0x70, 0x0c, 0x02, 0x37, 0xb6, 0xdc, 0xc8, 0xb7, 0x12, 0x8c, 0x17, 0xfe, 0x04, 0x13,
+	0x2b, 0x74, 0xe7, 0x28, 0x00, 0xc6, 0xd6, 0xe2, 0xb4, 0x84, 0x68, 0x14, 0x33, 0xce, 0xc8, 0xe3,
+	0x5c, 0x44, 0x0b, 0x01, 0x2d, 0x51, 0x16, 0xa7, 0xe6, 0x81, 0xc7, 0x3c, 0x96, 0x51, 0x2d, 0xb1,
+	0x92, 0x2a, 0xf3, 0x91, 0xc7, 0x98, 0x17, 0xa0, 0x95, 0xa1, 0x71, 0x3a, 0xb3, 0x70, 0x1e, 0xf1,
+	0x65, 0xbe, 0xf9, 0xf0, 0xcf, 0x4d, 0x37, 0xcc, 0xb7, 0xda, 0x0d, 0xa8, 0x5f, 0x86, 0x33, 0xe6,
+	0xe0, 0x6d, 0x8a, 0x09, 0x6f, 0x1f, 0xc1, 0x9e, 0x84, 0x49, 0xc4, 0xc2, 0x04, 0xc9, 0x21, 0xa8,
+	0xfe, 0xb4, 0xa9, 0xb4, 0x94, 0xce, 0xee, 0x99, 0xfe, 0xf3, 0xc7, 0x13, 0xf5, 0xf2, 0xdc, 0x51,
+	0xfd, 0x69, 0xfb, 0x29, 0x34, 0xae, 0x18, 0xfb, 0x92, 0x46, 0xb9, 0x90, 0x1c, 0x40, 0xf5, 0x36,
+	0xc5, 0x78, 0x29, 0xb9, 0x8e, 0x04, 0xed, 0x6f, 0x0a, 0xe8, 0x0e, 0x4e, 0x58, 0x3c, 0x25, 0xaf,
+	0x40, 0xe3, 0xcb, 0x08, 0xb3, 0xfd, 0xfd, 0xee, 0x31, 0xfd, 0x7f, 0x4a, 0x2a, 0x55, 0xc3, 0x65,
+	0x84, 0x4e, 0xa6, 0x23, 0x04, 0x34, 0xc1, 0x68, 0xaa, 0xd9, 0xfc, 0x6c, 0x2d, 0x4c, 0x17, 0x6e,
+	0x90, 0x62, 0xb3, 0x22, 0x4d, 0x33, 0x40, 0x28, 0xd4, 0x58, 0xc4, 0x7d, 0x16, 0x26, 0x4d, 0xad,
+	0xa5, 0x74, 0xea, 0xdd, 0x03, 0x2a, 0xf3, 0xd3, 0x22, 0x3f, 0xb5, 0xc3, 0xa5, 0x53, 0x90, 0xda,
+	0x33, 0xd8, 0x2f, 0xb2, 0xe4, 0xa9, 0x0b, 0x2f, 0xa5, 0xe4, 0xf5, 0x1a, 0x6a, 0x71, 0x76, 0xa6,
+	0xa4, 0xa9, 0xb6, 0x2a, 0x9d, 0x7a, 0xf7, 0x68, 0xbb, 0x08, 0x4e, 0x21, 0x13, 0x9f, 0xfa, 0xca,
+	0x4f, 0x78, 0xf1, 0xa9, 0xdf, 0xc1, 0x9e, 0x84, 0xb9, 0x69, 0xc9, 0x40, 0xb9, 0x9b, 0x01, 0x42,
+	0xa3, 0x17, 0xa3, 0xcb, 0xb1, 0xb8, 0x94, 0xfb, 0xc9, 0x31, 0x81, 0xc6, 0x39, 0x06, 0xb8, 0xb2,
+	0xb9, 0x87, 0xab, 0x3d, 0x1e, 0x00, 0xac, 0x78, 0xa4, 0x0e, 0xb5, 0x0f, 0xfd, 0xb7, 0xfd, 0xc1,
+	0xa7, 0xbe, 0xb1, 0x43, 0xaa, 0xa0, 0xd8, 0x86, 0x42, 0x76, 0xa1, 0xda, 0xeb, 0xdb, 0xd7, 0x17,
+	0x86, 0x4a, 0x74, 0x50, 0xaf, 0x47, 0x46, 0x85, 0xd4, 0xa0, 0x32, 0x1c, 0x0d, 0x0d, 0x4d, 0x2c,
+	0xde, 0x3b, 0x1f, 0x8d, 0x2a, 0x79, 0x00, 0x9a, 0x6d, 0xdb, 0xb6, 0xa1, 0x77, 0xbf, 0x57, 0x00,
+	0xfa, 0xbf, 0xcf, 0x41, 0x5c, 0xd0, 0x44, 0xd1, 0xc9, 0xb3, 0x4d, 0xa7, 0x2d, 0xbd, 0x0e, 0xf3,
+	0x64, 0x3b, 0x72, 0x7e, 0xa1, 0x1e, 0xe8, 0xb2, 0x57, 0xe4, 0xf9, 0x26, 0xdd, 0xda, 0x5b, 0x32,
+	0xe9, 0xb6, 0xf4, 0xdc, 0xc8, 0x05, 0x4d, 0x34, 0x69, 0x73, 0x96, 0x52, 0xfd, 0xcc, 0x93, 0xed,
+	0xc8, 0xb9, 0xc5, 0x00, 0x74, 0x59, 0xad, 0xcd, 0x59, 0xd6, 0x2a, 0x68, 0x1e, 0xfe, 0xf5, 0xf6,
+	0x2e, 0xc4, 0x8f, 0x49, 0x0c, 0x94, 0x25, 0xda, 0x3c, 0x70, 0xad, 0x6c, 0xff, 0x1a, 0x78, 0xd6,
+	0xfb, 0x6c, 0xdf, 0xed, 0x0f, 0xfc, 0x72, 0x85, 0x46, 0x3b, 0x63, 0x3d, 0x1b, 0xfb, 0xe2, 0xd7,
+	0x00, 0x92, 0xf5, 0xc9, 0xcd, 0xcf, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,6 +23,145 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type PolicyAction int32
+
+const (
+	PolicyAction_ALLOW PolicyAction = 0
+	PolicyAction_DENY  PolicyAction = 1
+)
+
+var PolicyAction_name = map[int32]string{
+	0: "ALLOW",
+	1: "DENY",
+}
+
+var PolicyAction_value = map[string]int32{
+	"ALLOW": 0,
+	"DENY":  1,
+}
+
+func (x PolicyAction) String() string {
+	return proto.EnumName(PolicyAction_name, int32(x))
+}
+
+func (PolicyAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{0}
+}
+
+type PolicyDirection int32
+
+const (
+	PolicyDirection_INGRESS PolicyDirection = 0
+	PolicyDirection_EGRESS  PolicyDirection = 1
+)
+
+var PolicyDirection_name = map[int32]string{
+	0: "INGRESS",
+	1: "EGRESS",
+}
+
+var PolicyDirection_value = map[string]int32{
+	"INGRESS": 0,
+	"EGRESS":  1,
+}
+
+func (x PolicyDirection) String() string {
+	return proto.EnumName(PolicyDirection_name, int32(x))
+}
+
+func (PolicyDirection) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{1}
+}
+
+type NetworkIsolation int32
+
+const (
+	// SHARED networks are routed to the other networks
+	NetworkIsolation_SHARED NetworkIsolation = 0
+	// ISOLATED networks only route traffic between their own containers
+	NetworkIsolation_ISOLATED NetworkIsolation = 1
+)
+
+var NetworkIsolation_name = map[int32]string{
+	0: "SHARED",
+	1: "ISOLATED",
+}
+
+var NetworkIsolation_value = map[string]int32{
+	"SHARED":   0,
+	"ISOLATED": 1,
+}
+
+func (x NetworkIsolation) String() string {
+	return proto.EnumName(NetworkIsolation_name, int32(x))
+}
+
+func (NetworkIsolation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{2}
+}
+
+type OrphanKind int32
+
+const (
+	OrphanKind_IP          OrphanKind = 0
+	OrphanKind_SUBNET      OrphanKind = 1
+	OrphanKind_RESERVATION OrphanKind = 2
+)
+
+var OrphanKind_name = map[int32]string{
+	0: "IP",
+	1: "SUBNET",
+	2: "RESERVATION",
+}
+
+var OrphanKind_value = map[string]int32{
+	"IP":          0,
+	"SUBNET":      1,
+	"RESERVATION": 2,
+}
+
+func (x OrphanKind) String() string {
+	return proto.EnumName(OrphanKind_name, int32(x))
+}
+
+func (OrphanKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{3}
+}
+
+type IssueKind int32
+
+const (
+	IssueKind_MISSING_ROUTE  IssueKind = 0
+	IssueKind_STALE_ROUTE    IssueKind = 1
+	IssueKind_BRIDGE_ADDRESS IssueKind = 2
+	IssueKind_DUPLICATE_IP   IssueKind = 3
+	IssueKind_UNREACHABLE    IssueKind = 4
+)
+
+var IssueKind_name = map[int32]string{
+	0: "MISSING_ROUTE",
+	1: "STALE_ROUTE",
+	2: "BRIDGE_ADDRESS",
+	3: "DUPLICATE_IP",
+	4: "UNREACHABLE",
+}
+
+var IssueKind_value = map[string]int32{
+	"MISSING_ROUTE":  0,
+	"STALE_ROUTE":    1,
+	"BRIDGE_ADDRESS": 2,
+	"DUPLICATE_IP":   3,
+	"UNREACHABLE":    4,
+}
+
+func (x IssueKind) String() string {
+	return proto.EnumName(IssueKind_name, int32(x))
+}
+
+func (IssueKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{4}
+}
+
 type InfoRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -130,8 +269,10 @@ func (m *AllocateSubnetRequest) GetNode() string {
 }
 
 type AllocateSubnetResponse struct {
-	SubnetCIDR           string   `protobuf:"bytes,1,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
-	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	SubnetCIDR string `protobuf:"bytes,1,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
+	Node       string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	// subnet6_cidr is the ipv6 subnet for the node if ipv6 is enabled
+	Subnet6CIDR          string   `protobuf:"bytes,3,opt,name=subnet6_cidr,json=subnet6Cidr,proto3" json:"subnet6_cidr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -175,8 +316,17 @@ func (m *AllocateSubnetResponse) GetNode() string {
 	return ""
 }
 
+func (m *AllocateSubnetResponse) GetSubnet6CIDR() string {
+	if m != nil {
+		return m.Subnet6CIDR
+	}
+	return ""
+}
+
 type GetSubnetRequest struct {
-	Node                 string   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// network is the named network; the default network if empty
+	Network              string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -213,8 +363,16 @@ func (m *GetSubnetRequest) GetNode() string {
 	return ""
 }
 
+func (m *GetSubnetRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
 type GetSubnetResponse struct {
 	SubnetCIDR           string   `protobuf:"bytes,1,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
+	Subnet6CIDR          string   `protobuf:"bytes,2,opt,name=subnet6_cidr,json=subnet6Cidr,proto3" json:"subnet6_cidr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -251,6 +409,13 @@ func (m *GetSubnetResponse) GetSubnetCIDR() string {
 	return ""
 }
 
+func (m *GetSubnetResponse) GetSubnet6CIDR() string {
+	if m != nil {
+		return m.Subnet6CIDR
+	}
+	return ""
+}
+
 type DeallocateSubnetRequest struct {
 	SubnetCIDR           string   `protobuf:"bytes,1,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
 	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
@@ -298,9 +463,14 @@ func (m *DeallocateSubnetRequest) GetNode() string {
 }
 
 type AllocateIPRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SubnetCIDR           string   `protobuf:"bytes,2,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
-	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	ID         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubnetCIDR string `protobuf:"bytes,2,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
+	Node       string `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Network    string `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	// ip is a specific address to allocate from the subnet
+	IP string `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	// pool is the reservation pool to allocate the address from
+	Pool                 string   `protobuf:"bytes,6,opt,name=pool,proto3" json:"pool,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -351,6 +521,27 @@ func (m *AllocateIPRequest) GetNode() string {
 	return ""
 }
 
+func (m *AllocateIPRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *AllocateIPRequest) GetIP() string {
+	if m != nil {
+		return m.IP
+	}
+	return ""
+}
+
+func (m *AllocateIPRequest) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
 type AllocateIPResponse struct {
 	IP                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
@@ -400,6 +591,7 @@ func (m *AllocateIPResponse) GetNode() string {
 type GetIPRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Network              string   `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -443,8 +635,16 @@ func (m *GetIPRequest) GetNode() string {
 	return ""
 }
 
+func (m *GetIPRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
 type GetIPResponse struct {
 	IP                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	IP6                  string   `protobuf:"bytes,2,opt,name=ip6,proto3" json:"ip6,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -481,10 +681,18 @@ func (m *GetIPResponse) GetIP() string {
 	return ""
 }
 
+func (m *GetIPResponse) GetIP6() string {
+	if m != nil {
+		return m.IP6
+	}
+	return ""
+}
+
 type ReleaseIPRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	IP                   string   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
 	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Network              string   `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -535,6 +743,13 @@ func (m *ReleaseIPRequest) GetNode() string {
 	return ""
 }
 
+func (m *ReleaseIPRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
 type SubnetsResponse struct {
 	Subnets              []*Subnet `protobuf:"bytes,1,rep,name=subnets,proto3" json:"subnets,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -576,6 +791,8 @@ func (m *SubnetsResponse) GetSubnets() []*Subnet {
 type Subnet struct {
 	CIDR                 string   `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
 	Gateway              string   `protobuf:"bytes,2,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	CIDR6                string   `protobuf:"bytes,3,opt,name=cidr6,proto3" json:"cidr6,omitempty"`
+	Gateway6             string   `protobuf:"bytes,4,opt,name=gateway6,proto3" json:"gateway6,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -619,6 +836,20 @@ func (m *Subnet) GetGateway() string {
 	return ""
 }
 
+func (m *Subnet) GetCIDR6() string {
+	if m != nil {
+		return m.CIDR6
+	}
+	return ""
+}
+
+func (m *Subnet) GetGateway6() string {
+	if m != nil {
+		return m.Gateway6
+	}
+	return ""
+}
+
 type ConfigureRequest struct {
 	Subnet               *Subnet  `protobuf:"bytes,1,opt,name=subnet,proto3" json:"subnet,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -658,8 +889,10 @@ func (m *ConfigureRequest) GetSubnet() *Subnet {
 }
 
 type AddRouteRequest struct {
-	CIDR                 string   `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	CIDR   string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// node is the node that owns the route
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -703,9 +936,18 @@ func (m *AddRouteRequest) GetTarget() string {
 	return ""
 }
 
+func (m *AddRouteRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type DeleteRouteRequest struct {
-	CIDR                 string   `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	CIDR   string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// node limits the delete to a route owned by the node
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -749,9 +991,18 @@ func (m *DeleteRouteRequest) GetTarget() string {
 	return ""
 }
 
+func (m *DeleteRouteRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type Route struct {
-	CIDR                 string   `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	CIDR   string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// node is empty for routes published before ownership was recorded
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -795,6 +1046,13 @@ func (m *Route) GetTarget() string {
 	return ""
 }
 
+func (m *Route) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type RoutesResponse struct {
 	Routes               []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -833,153 +1091,1692 @@ func (m *RoutesResponse) GetRoutes() []*Route {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*InfoRequest)(nil), "stellar.services.network.v1.InfoRequest")
-	proto.RegisterType((*InfoResponse)(nil), "stellar.services.network.v1.InfoResponse")
-	proto.RegisterType((*AllocateSubnetRequest)(nil), "stellar.services.network.v1.AllocateSubnetRequest")
-	proto.RegisterType((*AllocateSubnetResponse)(nil), "stellar.services.network.v1.AllocateSubnetResponse")
-	proto.RegisterType((*GetSubnetRequest)(nil), "stellar.services.network.v1.GetSubnetRequest")
-	proto.RegisterType((*GetSubnetResponse)(nil), "stellar.services.network.v1.GetSubnetResponse")
-	proto.RegisterType((*DeallocateSubnetRequest)(nil), "stellar.services.network.v1.DeallocateSubnetRequest")
-	proto.RegisterType((*AllocateIPRequest)(nil), "stellar.services.network.v1.AllocateIPRequest")
-	proto.RegisterType((*AllocateIPResponse)(nil), "stellar.services.network.v1.AllocateIPResponse")
-	proto.RegisterType((*GetIPRequest)(nil), "stellar.services.network.v1.GetIPRequest")
-	proto.RegisterType((*GetIPResponse)(nil), "stellar.services.network.v1.GetIPResponse")
-	proto.RegisterType((*ReleaseIPRequest)(nil), "stellar.services.network.v1.ReleaseIPRequest")
-	proto.RegisterType((*SubnetsResponse)(nil), "stellar.services.network.v1.SubnetsResponse")
-	proto.RegisterType((*Subnet)(nil), "stellar.services.network.v1.Subnet")
-	proto.RegisterType((*ConfigureRequest)(nil), "stellar.services.network.v1.ConfigureRequest")
-	proto.RegisterType((*AddRouteRequest)(nil), "stellar.services.network.v1.AddRouteRequest")
-	proto.RegisterType((*DeleteRouteRequest)(nil), "stellar.services.network.v1.DeleteRouteRequest")
-	proto.RegisterType((*Route)(nil), "stellar.services.network.v1.Route")
-	proto.RegisterType((*RoutesResponse)(nil), "stellar.services.network.v1.RoutesResponse")
+type IPsRequest struct {
+	// node limits the allocations to the node; all nodes if empty
+	Node                 string   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("github.com/ehazlett/stellar/api/services/network/v1/network.proto", fileDescriptor_5e613a22b6bc199d)
+func (m *IPsRequest) Reset()         { *m = IPsRequest{} }
+func (m *IPsRequest) String() string { return proto.CompactTextString(m) }
+func (*IPsRequest) ProtoMessage()    {}
+func (*IPsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{19}
 }
-
-var fileDescriptor_5e613a22b6bc199d = []byte{
-	// 717 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdb, 0x4e, 0xdb, 0x4c,
-	0x10, 0x56, 0x4c, 0x70, 0xc8, 0x84, 0x43, 0x58, 0xfd, 0x7f, 0x8a, 0x4c, 0x25, 0x90, 0x2b, 0x51,
-	0x28, 0xad, 0x2d, 0xa0, 0x57, 0x20, 0x24, 0x0e, 0xa9, 0x50, 0xaa, 0xb6, 0x44, 0xae, 0x84, 0xaa,
-	0x56, 0xa5, 0x75, 0xe2, 0xc1, 0xb8, 0x35, 0x59, 0xd7, 0xde, 0x80, 0xe8, 0xc3, 0x72, 0xc1, 0x13,
-	0xf4, 0x11, 0x2a, 0xbc, 0x6b, 0xc7, 0x58, 0xf1, 0xa1, 0x88, 0xbb, 0xf5, 0xe6, 0x3b, 0xcc, 0xce,
-	0xac, 0x3f, 0x07, 0xf6, 0x6d, 0x87, 0x9d, 0x0f, 0x7b, 0x5a, 0x9f, 0x5e, 0xe8, 0x78, 0x6e, 0xfe,
-	0x76, 0x91, 0x31, 0x3d, 0x60, 0xe8, 0xba, 0xa6, 0xaf, 0x9b, 0x9e, 0xa3, 0x07, 0xe8, 0x5f, 0x3a,
-	0x7d, 0x0c, 0xf4, 0x01, 0xb2, 0x2b, 0xea, 0xff, 0xd4, 0x2f, 0x37, 0xa2, 0xa5, 0xe6, 0xf9, 0x94,
-	0x51, 0xb2, 0x28, 0xe0, 0x5a, 0x04, 0xd5, 0xa2, 0xdf, 0x2f, 0x37, 0x94, 0x45, 0x9b, 0x52, 0xdb,
-	0x45, 0x3d, 0x84, 0xf6, 0x86, 0x67, 0x3a, 0x5e, 0x78, 0xec, 0x9a, 0x33, 0x95, 0xff, 0x6c, 0x6a,
-	0xd3, 0x70, 0xa9, 0xdf, 0xad, 0xf8, 0xae, 0x3a, 0x03, 0x8d, 0xce, 0xe0, 0x8c, 0x1a, 0xf8, 0x6b,
-	0x88, 0x01, 0x53, 0x57, 0x60, 0x9a, 0x3f, 0x06, 0x1e, 0x1d, 0x04, 0x48, 0x5a, 0x20, 0x39, 0xd6,
-	0x42, 0x65, 0xb9, 0xb2, 0x5a, 0x3f, 0x90, 0x6f, 0x6f, 0x96, 0xa4, 0x4e, 0xdb, 0x90, 0x1c, 0x4b,
-	0x5d, 0x87, 0xff, 0xf7, 0x5d, 0x97, 0xf6, 0x4d, 0x86, 0x1f, 0x87, 0xbd, 0x01, 0x32, 0x21, 0x40,
-	0x08, 0x54, 0x07, 0xd4, 0x42, 0x4e, 0x31, 0xc2, 0xb5, 0xfa, 0x15, 0x5a, 0x69, 0xb0, 0x90, 0xd7,
-	0xa1, 0x11, 0x84, 0x3b, 0xdf, 0xfa, 0x8e, 0xe5, 0x0b, 0x9f, 0xd9, 0xdb, 0x9b, 0x25, 0xe0, 0xc0,
-	0xc3, 0x4e, 0xdb, 0x30, 0x80, 0x43, 0x0e, 0x1d, 0xcb, 0x8f, 0xe5, 0xa5, 0x84, 0xfc, 0x0a, 0x34,
-	0x8f, 0x90, 0x15, 0x97, 0xd1, 0x86, 0xf9, 0x04, 0xee, 0x81, 0x15, 0xa8, 0xa7, 0xf0, 0xa4, 0x8d,
-	0xe6, 0xd8, 0xb3, 0x3f, 0xca, 0x69, 0x3c, 0x98, 0x8f, 0x9a, 0xd5, 0xe9, 0x46, 0xca, 0x19, 0x63,
-	0x48, 0x3b, 0x4a, 0xa5, 0x1d, 0x27, 0x12, 0x8e, 0x7b, 0x40, 0x92, 0x8e, 0x89, 0xc9, 0x7b, 0xf7,
-	0x2c, 0xbb, 0x86, 0xe4, 0x78, 0x63, 0x6b, 0xde, 0x86, 0xe9, 0x23, 0x64, 0xc5, 0xe5, 0x8e, 0xe3,
-	0x3e, 0x87, 0x19, 0xc1, 0xcd, 0x37, 0x56, 0x4f, 0xa0, 0x69, 0xa0, 0x8b, 0x66, 0x50, 0xa2, 0x2f,
-	0x5c, 0x43, 0xca, 0x2c, 0x3e, 0x79, 0xfc, 0x2e, 0xcc, 0xf1, 0x66, 0x05, 0x71, 0x09, 0xbb, 0x50,
-	0xe3, 0x3d, 0x0b, 0x16, 0x2a, 0xcb, 0x13, 0xab, 0x8d, 0xcd, 0x67, 0x5a, 0xce, 0x6b, 0xa7, 0x89,
-	0x5b, 0x10, 0x71, 0xd4, 0x3d, 0x90, 0xf9, 0x16, 0x79, 0x0a, 0xd5, 0xc4, 0x55, 0x98, 0xba, 0xbd,
-	0x59, 0xaa, 0x86, 0x23, 0x09, 0x77, 0xc9, 0x02, 0xd4, 0x6c, 0x93, 0xe1, 0x95, 0x79, 0x2d, 0x3a,
-	0x12, 0x3d, 0xaa, 0xc7, 0xd0, 0x3c, 0xa4, 0x83, 0x33, 0xc7, 0x1e, 0xfa, 0x18, 0x9d, 0x75, 0x07,
-	0x64, 0x6e, 0x10, 0xaa, 0x95, 0xac, 0x49, 0x50, 0xd4, 0x23, 0x98, 0xdb, 0xb7, 0x2c, 0x83, 0x0e,
-	0x59, 0xac, 0x97, 0x5f, 0x5b, 0x0b, 0x64, 0x66, 0xfa, 0x36, 0x32, 0x51, 0x9a, 0x78, 0x52, 0xdf,
-	0x02, 0x69, 0xa3, 0x8b, 0x0c, 0x1f, 0x41, 0x6b, 0x17, 0x26, 0x43, 0x95, 0x07, 0xd2, 0xdf, 0xc1,
-	0x6c, 0x48, 0x1f, 0xcd, 0x6d, 0x1b, 0x64, 0x3f, 0xdc, 0x11, 0x63, 0x53, 0x73, 0x5b, 0xc4, 0x4f,
-	0x20, 0x18, 0x9b, 0x7f, 0xa6, 0xa0, 0xf6, 0x81, 0xff, 0x48, 0xbe, 0x40, 0xf5, 0x2e, 0x05, 0xc9,
-	0x6a, 0x2e, 0x3f, 0x91, 0x9b, 0xca, 0x5a, 0x09, 0xa4, 0x28, 0xf2, 0x1a, 0x66, 0xef, 0xa7, 0x21,
-	0xd9, 0xcc, 0x25, 0x8f, 0xcd, 0x59, 0x65, 0xeb, 0x9f, 0x38, 0xc2, 0xfa, 0x07, 0xd4, 0xe3, 0x04,
-	0x24, 0xaf, 0x72, 0x15, 0xd2, 0x89, 0xaa, 0x68, 0x65, 0xe1, 0xc2, 0xeb, 0x3b, 0x34, 0xd3, 0x39,
-	0x49, 0x5e, 0xe7, 0x6a, 0x64, 0xc4, 0xaa, 0xd2, 0xd2, 0xf8, 0x67, 0x4d, 0x8b, 0x3e, 0x6b, 0xda,
-	0x9b, 0xbb, 0xcf, 0x1a, 0x39, 0x86, 0x9a, 0x78, 0x71, 0x49, 0x06, 0x44, 0x79, 0x59, 0xe2, 0x1d,
-	0x19, 0x5d, 0x9f, 0x0b, 0x80, 0x51, 0x10, 0x12, 0xad, 0x54, 0x87, 0xe3, 0x2c, 0x52, 0xf4, 0xd2,
-	0x78, 0x61, 0x77, 0x0a, 0x93, 0x61, 0xf2, 0x91, 0xb5, 0xa2, 0xd6, 0x8e, 0x4c, 0x5e, 0x94, 0x81,
-	0x0a, 0x7d, 0x03, 0xea, 0x71, 0x60, 0x16, 0x4c, 0x3b, 0x1d, 0xac, 0x99, 0x3d, 0x37, 0xa0, 0x1e,
-	0x07, 0x53, 0x81, 0x66, 0x3a, 0xc0, 0x32, 0x35, 0xbb, 0x30, 0x15, 0x65, 0x13, 0xc9, 0x1f, 0x58,
-	0x2a, 0xc2, 0x32, 0x15, 0x4f, 0xa0, 0x91, 0x08, 0x29, 0xa2, 0x17, 0x5c, 0xbb, 0x74, 0x9c, 0x65,
-	0xea, 0xbe, 0x07, 0x99, 0x27, 0x4e, 0xe6, 0x85, 0x5b, 0x2f, 0x4e, 0x9c, 0xf8, 0xbe, 0x1d, 0xec,
-	0x7e, 0xde, 0x79, 0xc0, 0x1f, 0xc2, 0x1d, 0xb1, 0xfc, 0x54, 0xe9, 0xc9, 0xa1, 0xfb, 0xd6, 0xdf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x7c, 0xce, 0x1c, 0x58, 0x0a, 0x00, 0x00,
+func (m *IPsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IPsRequest.Unmarshal(m, b)
+}
+func (m *IPsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IPsRequest.Marshal(b, m, deterministic)
+}
+func (m *IPsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IPsRequest.Merge(m, src)
+}
+func (m *IPsRequest) XXX_Size() int {
+	return xxx_messageInfo_IPsRequest.Size(m)
+}
+func (m *IPsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IPsRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_IPsRequest proto.InternalMessageInfo
 
-// NetworkClient is the client API for Network service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NetworkClient interface {
-	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
-	AllocateSubnet(ctx context.Context, in *AllocateSubnetRequest, opts ...grpc.CallOption) (*AllocateSubnetResponse, error)
-	GetSubnet(ctx context.Context, in *GetSubnetRequest, opts ...grpc.CallOption) (*GetSubnetResponse, error)
-	DeallocateSubnet(ctx context.Context, in *DeallocateSubnetRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Subnets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*SubnetsResponse, error)
-	AllocateIP(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error)
-	GetIP(ctx context.Context, in *GetIPRequest, opts ...grpc.CallOption) (*GetIPResponse, error)
-	ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	AddRoute(ctx context.Context, in *AddRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Routes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*RoutesResponse, error)
+func (m *IPsRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
 }
 
-type networkClient struct {
-	cc *grpc.ClientConn
+type IPAllocation struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	IP                   string   `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	IP6                  string   `protobuf:"bytes,4,opt,name=ip6,proto3" json:"ip6,omitempty"`
+	Network              string   `protobuf:"bytes,5,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewNetworkClient(cc *grpc.ClientConn) NetworkClient {
-	return &networkClient{cc}
+func (m *IPAllocation) Reset()         { *m = IPAllocation{} }
+func (m *IPAllocation) String() string { return proto.CompactTextString(m) }
+func (*IPAllocation) ProtoMessage()    {}
+func (*IPAllocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{20}
+}
+func (m *IPAllocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IPAllocation.Unmarshal(m, b)
+}
+func (m *IPAllocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IPAllocation.Marshal(b, m, deterministic)
+}
+func (m *IPAllocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IPAllocation.Merge(m, src)
+}
+func (m *IPAllocation) XXX_Size() int {
+	return xxx_messageInfo_IPAllocation.Size(m)
+}
+func (m *IPAllocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_IPAllocation.DiscardUnknown(m)
 }
 
-func (c *networkClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
-	out := new(InfoResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Info", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_IPAllocation proto.InternalMessageInfo
+
+func (m *IPAllocation) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *networkClient) AllocateSubnet(ctx context.Context, in *AllocateSubnetRequest, opts ...grpc.CallOption) (*AllocateSubnetResponse, error) {
-	out := new(AllocateSubnetResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AllocateSubnet", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IPAllocation) GetNode() string {
+	if m != nil {
+		return m.Node
 	}
-	return out, nil
+	return ""
 }
 
-func (c *networkClient) GetSubnet(ctx context.Context, in *GetSubnetRequest, opts ...grpc.CallOption) (*GetSubnetResponse, error) {
-	out := new(GetSubnetResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/GetSubnet", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IPAllocation) GetIP() string {
+	if m != nil {
+		return m.IP
 	}
-	return out, nil
+	return ""
 }
 
-func (c *networkClient) DeallocateSubnet(ctx context.Context, in *DeallocateSubnetRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeallocateSubnet", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IPAllocation) GetIP6() string {
+	if m != nil {
+		return m.IP6
 	}
-	return out, nil
+	return ""
 }
 
-func (c *networkClient) Subnets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*SubnetsResponse, error) {
-	out := new(SubnetsResponse)
+func (m *IPAllocation) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+type IPsResponse struct {
+	IPs                  []*IPAllocation `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *IPsResponse) Reset()         { *m = IPsResponse{} }
+func (m *IPsResponse) String() string { return proto.CompactTextString(m) }
+func (*IPsResponse) ProtoMessage()    {}
+func (*IPsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{21}
+}
+func (m *IPsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IPsResponse.Unmarshal(m, b)
+}
+func (m *IPsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IPsResponse.Marshal(b, m, deterministic)
+}
+func (m *IPsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IPsResponse.Merge(m, src)
+}
+func (m *IPsResponse) XXX_Size() int {
+	return xxx_messageInfo_IPsResponse.Size(m)
+}
+func (m *IPsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IPsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IPsResponse proto.InternalMessageInfo
+
+func (m *IPsResponse) GetIPs() []*IPAllocation {
+	if m != nil {
+		return m.IPs
+	}
+	return nil
+}
+
+// PolicyPeer selects containers by application and service or addresses by
+// cidr.  An empty peer matches all traffic.
+type PolicyPeer struct {
+	Application          string   `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Service              string   `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	CIDR                 string   `protobuf:"bytes,3,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolicyPeer) Reset()         { *m = PolicyPeer{} }
+func (m *PolicyPeer) String() string { return proto.CompactTextString(m) }
+func (*PolicyPeer) ProtoMessage()    {}
+func (*PolicyPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{22}
+}
+func (m *PolicyPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyPeer.Unmarshal(m, b)
+}
+func (m *PolicyPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyPeer.Marshal(b, m, deterministic)
+}
+func (m *PolicyPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyPeer.Merge(m, src)
+}
+func (m *PolicyPeer) XXX_Size() int {
+	return xxx_messageInfo_PolicyPeer.Size(m)
+}
+func (m *PolicyPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyPeer proto.InternalMessageInfo
+
+func (m *PolicyPeer) GetApplication() string {
+	if m != nil {
+		return m.Application
+	}
+	return ""
+}
+
+func (m *PolicyPeer) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *PolicyPeer) GetCIDR() string {
+	if m != nil {
+		return m.CIDR
+	}
+	return ""
+}
+
+type PolicyPort struct {
+	// protocol is tcp, udp or sctp
+	Protocol             string   `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolicyPort) Reset()         { *m = PolicyPort{} }
+func (m *PolicyPort) String() string { return proto.CompactTextString(m) }
+func (*PolicyPort) ProtoMessage()    {}
+func (*PolicyPort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{23}
+}
+func (m *PolicyPort) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyPort.Unmarshal(m, b)
+}
+func (m *PolicyPort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyPort.Marshal(b, m, deterministic)
+}
+func (m *PolicyPort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyPort.Merge(m, src)
+}
+func (m *PolicyPort) XXX_Size() int {
+	return xxx_messageInfo_PolicyPort.Size(m)
+}
+func (m *PolicyPort) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyPort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyPort proto.InternalMessageInfo
+
+func (m *PolicyPort) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *PolicyPort) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type PolicyRule struct {
+	Action PolicyAction `protobuf:"varint,1,opt,name=action,proto3,enum=stellar.services.network.v1.PolicyAction" json:"action,omitempty"`
+	Peer   *PolicyPeer  `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// ports limits the rule to the destination ports; all ports if empty
+	Ports                []*PolicyPort `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PolicyRule) Reset()         { *m = PolicyRule{} }
+func (m *PolicyRule) String() string { return proto.CompactTextString(m) }
+func (*PolicyRule) ProtoMessage()    {}
+func (*PolicyRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{24}
+}
+func (m *PolicyRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyRule.Unmarshal(m, b)
+}
+func (m *PolicyRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyRule.Marshal(b, m, deterministic)
+}
+func (m *PolicyRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyRule.Merge(m, src)
+}
+func (m *PolicyRule) XXX_Size() int {
+	return xxx_messageInfo_PolicyRule.Size(m)
+}
+func (m *PolicyRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyRule proto.InternalMessageInfo
+
+func (m *PolicyRule) GetAction() PolicyAction {
+	if m != nil {
+		return m.Action
+	}
+	return PolicyAction_ALLOW
+}
+
+func (m *PolicyRule) GetPeer() *PolicyPeer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *PolicyRule) GetPorts() []*PolicyPort {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+// NetworkPolicy filters the traffic to (ingress) or from (egress) the target
+// containers.  The rules are matched in order and traffic that does not match
+// a rule has the default action applied.
+type NetworkPolicy struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target               *PolicyPeer     `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Direction            PolicyDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=stellar.services.network.v1.PolicyDirection" json:"direction,omitempty"`
+	Rules                []*PolicyRule   `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	DefaultAction        PolicyAction    `protobuf:"varint,5,opt,name=default_action,json=defaultAction,proto3,enum=stellar.services.network.v1.PolicyAction" json:"default_action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NetworkPolicy) Reset()         { *m = NetworkPolicy{} }
+func (m *NetworkPolicy) String() string { return proto.CompactTextString(m) }
+func (*NetworkPolicy) ProtoMessage()    {}
+func (*NetworkPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{25}
+}
+func (m *NetworkPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkPolicy.Unmarshal(m, b)
+}
+func (m *NetworkPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkPolicy.Marshal(b, m, deterministic)
+}
+func (m *NetworkPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicy.Merge(m, src)
+}
+func (m *NetworkPolicy) XXX_Size() int {
+	return xxx_messageInfo_NetworkPolicy.Size(m)
+}
+func (m *NetworkPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkPolicy proto.InternalMessageInfo
+
+func (m *NetworkPolicy) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NetworkPolicy) GetTarget() *PolicyPeer {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (m *NetworkPolicy) GetDirection() PolicyDirection {
+	if m != nil {
+		return m.Direction
+	}
+	return PolicyDirection_INGRESS
+}
+
+func (m *NetworkPolicy) GetRules() []*PolicyRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *NetworkPolicy) GetDefaultAction() PolicyAction {
+	if m != nil {
+		return m.DefaultAction
+	}
+	return PolicyAction_ALLOW
+}
+
+type CreatePolicyRequest struct {
+	Policy               *NetworkPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreatePolicyRequest) Reset()         { *m = CreatePolicyRequest{} }
+func (m *CreatePolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreatePolicyRequest) ProtoMessage()    {}
+func (*CreatePolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{26}
+}
+func (m *CreatePolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreatePolicyRequest.Unmarshal(m, b)
+}
+func (m *CreatePolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreatePolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreatePolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePolicyRequest.Merge(m, src)
+}
+func (m *CreatePolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreatePolicyRequest.Size(m)
+}
+func (m *CreatePolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreatePolicyRequest proto.InternalMessageInfo
+
+func (m *CreatePolicyRequest) GetPolicy() *NetworkPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type PoliciesResponse struct {
+	Policies             []*NetworkPolicy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PoliciesResponse) Reset()         { *m = PoliciesResponse{} }
+func (m *PoliciesResponse) String() string { return proto.CompactTextString(m) }
+func (*PoliciesResponse) ProtoMessage()    {}
+func (*PoliciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{27}
+}
+func (m *PoliciesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoliciesResponse.Unmarshal(m, b)
+}
+func (m *PoliciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoliciesResponse.Marshal(b, m, deterministic)
+}
+func (m *PoliciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoliciesResponse.Merge(m, src)
+}
+func (m *PoliciesResponse) XXX_Size() int {
+	return xxx_messageInfo_PoliciesResponse.Size(m)
+}
+func (m *PoliciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoliciesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoliciesResponse proto.InternalMessageInfo
+
+func (m *PoliciesResponse) GetPolicies() []*NetworkPolicy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+type DeletePolicyRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePolicyRequest) Reset()         { *m = DeletePolicyRequest{} }
+func (m *DeletePolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePolicyRequest) ProtoMessage()    {}
+func (*DeletePolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{28}
+}
+func (m *DeletePolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePolicyRequest.Unmarshal(m, b)
+}
+func (m *DeletePolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *DeletePolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePolicyRequest.Merge(m, src)
+}
+func (m *DeletePolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePolicyRequest.Size(m)
+}
+func (m *DeletePolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePolicyRequest proto.InternalMessageInfo
+
+func (m *DeletePolicyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ClusterNetwork is a named network divided into node subnets the same way
+// as the default network
+type ClusterNetwork struct {
+	Name        string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SubnetCIDR  string           `protobuf:"bytes,2,opt,name=subnet_cidr,json=subnetCidr,proto3" json:"subnet_cidr,omitempty"`
+	Subnet6CIDR string           `protobuf:"bytes,3,opt,name=subnet6_cidr,json=subnet6Cidr,proto3" json:"subnet6_cidr,omitempty"`
+	Bridge      string           `protobuf:"bytes,4,opt,name=bridge,proto3" json:"bridge,omitempty"`
+	Isolation   NetworkIsolation `protobuf:"varint,5,opt,name=isolation,proto3,enum=stellar.services.network.v1.NetworkIsolation" json:"isolation,omitempty"`
+	// default is set for the network from the node configuration
+	Default              bool     `protobuf:"varint,6,opt,name=default,proto3" json:"default,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterNetwork) Reset()         { *m = ClusterNetwork{} }
+func (m *ClusterNetwork) String() string { return proto.CompactTextString(m) }
+func (*ClusterNetwork) ProtoMessage()    {}
+func (*ClusterNetwork) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{29}
+}
+func (m *ClusterNetwork) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterNetwork.Unmarshal(m, b)
+}
+func (m *ClusterNetwork) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterNetwork.Marshal(b, m, deterministic)
+}
+func (m *ClusterNetwork) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterNetwork.Merge(m, src)
+}
+func (m *ClusterNetwork) XXX_Size() int {
+	return xxx_messageInfo_ClusterNetwork.Size(m)
+}
+func (m *ClusterNetwork) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterNetwork.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterNetwork proto.InternalMessageInfo
+
+func (m *ClusterNetwork) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ClusterNetwork) GetSubnetCIDR() string {
+	if m != nil {
+		return m.SubnetCIDR
+	}
+	return ""
+}
+
+func (m *ClusterNetwork) GetSubnet6CIDR() string {
+	if m != nil {
+		return m.Subnet6CIDR
+	}
+	return ""
+}
+
+func (m *ClusterNetwork) GetBridge() string {
+	if m != nil {
+		return m.Bridge
+	}
+	return ""
+}
+
+func (m *ClusterNetwork) GetIsolation() NetworkIsolation {
+	if m != nil {
+		return m.Isolation
+	}
+	return NetworkIsolation_SHARED
+}
+
+func (m *ClusterNetwork) GetDefault() bool {
+	if m != nil {
+		return m.Default
+	}
+	return false
+}
+
+type CreateNetworkRequest struct {
+	Network              *ClusterNetwork `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CreateNetworkRequest) Reset()         { *m = CreateNetworkRequest{} }
+func (m *CreateNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateNetworkRequest) ProtoMessage()    {}
+func (*CreateNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{30}
+}
+func (m *CreateNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateNetworkRequest.Unmarshal(m, b)
+}
+func (m *CreateNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateNetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateNetworkRequest.Merge(m, src)
+}
+func (m *CreateNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateNetworkRequest.Size(m)
+}
+func (m *CreateNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateNetworkRequest proto.InternalMessageInfo
+
+func (m *CreateNetworkRequest) GetNetwork() *ClusterNetwork {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+type ListNetworksResponse struct {
+	Networks             []*ClusterNetwork `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListNetworksResponse) Reset()         { *m = ListNetworksResponse{} }
+func (m *ListNetworksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNetworksResponse) ProtoMessage()    {}
+func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{31}
+}
+func (m *ListNetworksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNetworksResponse.Unmarshal(m, b)
+}
+func (m *ListNetworksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNetworksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListNetworksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNetworksResponse.Merge(m, src)
+}
+func (m *ListNetworksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNetworksResponse.Size(m)
+}
+func (m *ListNetworksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNetworksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNetworksResponse proto.InternalMessageInfo
+
+func (m *ListNetworksResponse) GetNetworks() []*ClusterNetwork {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
+}
+
+type DeleteNetworkRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteNetworkRequest) Reset()         { *m = DeleteNetworkRequest{} }
+func (m *DeleteNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteNetworkRequest) ProtoMessage()    {}
+func (*DeleteNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{32}
+}
+func (m *DeleteNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteNetworkRequest.Unmarshal(m, b)
+}
+func (m *DeleteNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteNetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteNetworkRequest.Merge(m, src)
+}
+func (m *DeleteNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteNetworkRequest.Size(m)
+}
+func (m *DeleteNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteNetworkRequest proto.InternalMessageInfo
+
+func (m *DeleteNetworkRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GCRequest struct {
+	// dry_run returns the orphaned allocations without releasing them
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// grace_period is how long an allocation is orphaned before it is
+	// released; the service default is used if not set
+	GracePeriod          *types.Duration `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GCRequest) Reset()         { *m = GCRequest{} }
+func (m *GCRequest) String() string { return proto.CompactTextString(m) }
+func (*GCRequest) ProtoMessage()    {}
+func (*GCRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{33}
+}
+func (m *GCRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GCRequest.Unmarshal(m, b)
+}
+func (m *GCRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GCRequest.Marshal(b, m, deterministic)
+}
+func (m *GCRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GCRequest.Merge(m, src)
+}
+func (m *GCRequest) XXX_Size() int {
+	return xxx_messageInfo_GCRequest.Size(m)
+}
+func (m *GCRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GCRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GCRequest proto.InternalMessageInfo
+
+func (m *GCRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *GCRequest) GetGracePeriod() *types.Duration {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return nil
+}
+
+// Orphan is an allocation without a live container or cluster node
+type Orphan struct {
+	Kind    OrphanKind `protobuf:"varint,1,opt,name=kind,proto3,enum=stellar.services.network.v1.OrphanKind" json:"kind,omitempty"`
+	Network string     `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Node    string     `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	// id is the container id of an ip allocation
+	ID string `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	// address is the ip or the subnet
+	Address string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	// orphaned_at is when the allocation was first found orphaned
+	OrphanedAt *types.Timestamp `protobuf:"bytes,6,opt,name=orphaned_at,json=orphanedAt,proto3" json:"orphaned_at,omitempty"`
+	// released is true if the grace period has passed; in a dry run the
+	// allocation would be released
+	Released             bool     `protobuf:"varint,7,opt,name=released,proto3" json:"released,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Orphan) Reset()         { *m = Orphan{} }
+func (m *Orphan) String() string { return proto.CompactTextString(m) }
+func (*Orphan) ProtoMessage()    {}
+func (*Orphan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{34}
+}
+func (m *Orphan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Orphan.Unmarshal(m, b)
+}
+func (m *Orphan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Orphan.Marshal(b, m, deterministic)
+}
+func (m *Orphan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Orphan.Merge(m, src)
+}
+func (m *Orphan) XXX_Size() int {
+	return xxx_messageInfo_Orphan.Size(m)
+}
+func (m *Orphan) XXX_DiscardUnknown() {
+	xxx_messageInfo_Orphan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Orphan proto.InternalMessageInfo
+
+func (m *Orphan) GetKind() OrphanKind {
+	if m != nil {
+		return m.Kind
+	}
+	return OrphanKind_IP
+}
+
+func (m *Orphan) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *Orphan) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Orphan) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Orphan) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Orphan) GetOrphanedAt() *types.Timestamp {
+	if m != nil {
+		return m.OrphanedAt
+	}
+	return nil
+}
+
+func (m *Orphan) GetReleased() bool {
+	if m != nil {
+		return m.Released
+	}
+	return false
+}
+
+type GCResponse struct {
+	Orphans              []*Orphan `protobuf:"bytes,1,rep,name=orphans,proto3" json:"orphans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GCResponse) Reset()         { *m = GCResponse{} }
+func (m *GCResponse) String() string { return proto.CompactTextString(m) }
+func (*GCResponse) ProtoMessage()    {}
+func (*GCResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{35}
+}
+func (m *GCResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GCResponse.Unmarshal(m, b)
+}
+func (m *GCResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GCResponse.Marshal(b, m, deterministic)
+}
+func (m *GCResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GCResponse.Merge(m, src)
+}
+func (m *GCResponse) XXX_Size() int {
+	return xxx_messageInfo_GCResponse.Size(m)
+}
+func (m *GCResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GCResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GCResponse proto.InternalMessageInfo
+
+func (m *GCResponse) GetOrphans() []*Orphan {
+	if m != nil {
+		return m.Orphans
+	}
+	return nil
+}
+
+// IPPool is a named set of addresses reserved for the services using the
+// pool.  Pool addresses are not allocated to other containers.
+type IPPool struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// network is the named network of the addresses; the default network
+	// if empty
+	Network string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	// addresses are the reserved addresses or CIDR ranges
+	Addresses            []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IPPool) Reset()         { *m = IPPool{} }
+func (m *IPPool) String() string { return proto.CompactTextString(m) }
+func (*IPPool) ProtoMessage()    {}
+func (*IPPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{36}
+}
+func (m *IPPool) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IPPool.Unmarshal(m, b)
+}
+func (m *IPPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IPPool.Marshal(b, m, deterministic)
+}
+func (m *IPPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IPPool.Merge(m, src)
+}
+func (m *IPPool) XXX_Size() int {
+	return xxx_messageInfo_IPPool.Size(m)
+}
+func (m *IPPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_IPPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IPPool proto.InternalMessageInfo
+
+func (m *IPPool) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IPPool) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *IPPool) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type CreatePoolRequest struct {
+	Pool                 *IPPool  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreatePoolRequest) Reset()         { *m = CreatePoolRequest{} }
+func (m *CreatePoolRequest) String() string { return proto.CompactTextString(m) }
+func (*CreatePoolRequest) ProtoMessage()    {}
+func (*CreatePoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{37}
+}
+func (m *CreatePoolRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreatePoolRequest.Unmarshal(m, b)
+}
+func (m *CreatePoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreatePoolRequest.Marshal(b, m, deterministic)
+}
+func (m *CreatePoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePoolRequest.Merge(m, src)
+}
+func (m *CreatePoolRequest) XXX_Size() int {
+	return xxx_messageInfo_CreatePoolRequest.Size(m)
+}
+func (m *CreatePoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreatePoolRequest proto.InternalMessageInfo
+
+func (m *CreatePoolRequest) GetPool() *IPPool {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+type ListPoolsResponse struct {
+	Pools                []*IPPool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListPoolsResponse) Reset()         { *m = ListPoolsResponse{} }
+func (m *ListPoolsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPoolsResponse) ProtoMessage()    {}
+func (*ListPoolsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{38}
+}
+func (m *ListPoolsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPoolsResponse.Unmarshal(m, b)
+}
+func (m *ListPoolsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPoolsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPoolsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPoolsResponse.Merge(m, src)
+}
+func (m *ListPoolsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPoolsResponse.Size(m)
+}
+func (m *ListPoolsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPoolsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPoolsResponse proto.InternalMessageInfo
+
+func (m *ListPoolsResponse) GetPools() []*IPPool {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+type DeletePoolRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePoolRequest) Reset()         { *m = DeletePoolRequest{} }
+func (m *DeletePoolRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePoolRequest) ProtoMessage()    {}
+func (*DeletePoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{39}
+}
+func (m *DeletePoolRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePoolRequest.Unmarshal(m, b)
+}
+func (m *DeletePoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePoolRequest.Marshal(b, m, deterministic)
+}
+func (m *DeletePoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePoolRequest.Merge(m, src)
+}
+func (m *DeletePoolRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePoolRequest.Size(m)
+}
+func (m *DeletePoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePoolRequest proto.InternalMessageInfo
+
+func (m *DeletePoolRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// NodeRoute is a kernel route to a cluster network on the node
+type NodeRoute struct {
+	CIDR string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// gateway is empty for the routes of the local bridges
+	Gateway              string   `protobuf:"bytes,2,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Device               string   `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeRoute) Reset()         { *m = NodeRoute{} }
+func (m *NodeRoute) String() string { return proto.CompactTextString(m) }
+func (*NodeRoute) ProtoMessage()    {}
+func (*NodeRoute) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{40}
+}
+func (m *NodeRoute) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeRoute.Unmarshal(m, b)
+}
+func (m *NodeRoute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeRoute.Marshal(b, m, deterministic)
+}
+func (m *NodeRoute) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeRoute.Merge(m, src)
+}
+func (m *NodeRoute) XXX_Size() int {
+	return xxx_messageInfo_NodeRoute.Size(m)
+}
+func (m *NodeRoute) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeRoute.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeRoute proto.InternalMessageInfo
+
+func (m *NodeRoute) GetCIDR() string {
+	if m != nil {
+		return m.CIDR
+	}
+	return ""
+}
+
+func (m *NodeRoute) GetGateway() string {
+	if m != nil {
+		return m.Gateway
+	}
+	return ""
+}
+
+func (m *NodeRoute) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+// NodeBridge is the bridge of a network on the node
+type NodeBridge struct {
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Network   string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// exists is false if the bridge is not on the node
+	Exists               bool     `protobuf:"varint,4,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeBridge) Reset()         { *m = NodeBridge{} }
+func (m *NodeBridge) String() string { return proto.CompactTextString(m) }
+func (*NodeBridge) ProtoMessage()    {}
+func (*NodeBridge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{41}
+}
+func (m *NodeBridge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeBridge.Unmarshal(m, b)
+}
+func (m *NodeBridge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeBridge.Marshal(b, m, deterministic)
+}
+func (m *NodeBridge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeBridge.Merge(m, src)
+}
+func (m *NodeBridge) XXX_Size() int {
+	return xxx_messageInfo_NodeBridge.Size(m)
+}
+func (m *NodeBridge) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeBridge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeBridge proto.InternalMessageInfo
+
+func (m *NodeBridge) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeBridge) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NodeBridge) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NodeBridge) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+// NodeState is the network state of the node reported by netlink
+type NodeState struct {
+	NodeID      string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NetworkMode string `protobuf:"bytes,2,opt,name=network_mode,json=networkMode,proto3" json:"network_mode,omitempty"`
+	// addresses are the addresses of the node interfaces
+	Addresses            []string              `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Routes               []*NodeRoute          `protobuf:"bytes,4,rep,name=routes,proto3" json:"routes,omitempty"`
+	Bridges              []*NodeBridge         `protobuf:"bytes,5,rep,name=bridges,proto3" json:"bridges,omitempty"`
+	Bandwidths           []*ContainerBandwidth `protobuf:"bytes,6,rep,name=bandwidths,proto3" json:"bandwidths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *NodeState) Reset()         { *m = NodeState{} }
+func (m *NodeState) String() string { return proto.CompactTextString(m) }
+func (*NodeState) ProtoMessage()    {}
+func (*NodeState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{42}
+}
+func (m *NodeState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeState.Unmarshal(m, b)
+}
+func (m *NodeState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeState.Marshal(b, m, deterministic)
+}
+func (m *NodeState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeState.Merge(m, src)
+}
+func (m *NodeState) XXX_Size() int {
+	return xxx_messageInfo_NodeState.Size(m)
+}
+func (m *NodeState) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeState proto.InternalMessageInfo
+
+func (m *NodeState) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *NodeState) GetNetworkMode() string {
+	if m != nil {
+		return m.NetworkMode
+	}
+	return ""
+}
+
+func (m *NodeState) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NodeState) GetRoutes() []*NodeRoute {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+func (m *NodeState) GetBridges() []*NodeBridge {
+	if m != nil {
+		return m.Bridges
+	}
+	return nil
+}
+
+func (m *NodeState) GetBandwidths() []*ContainerBandwidth {
+	if m != nil {
+		return m.Bandwidths
+	}
+	return nil
+}
+
+// ContainerBandwidth is the bandwidth limit of a container on the node in
+// bits per second
+type ContainerBandwidth struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ingress              uint64   `protobuf:"varint,2,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress               uint64   `protobuf:"varint,3,opt,name=egress,proto3" json:"egress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerBandwidth) Reset()         { *m = ContainerBandwidth{} }
+func (m *ContainerBandwidth) String() string { return proto.CompactTextString(m) }
+func (*ContainerBandwidth) ProtoMessage()    {}
+func (*ContainerBandwidth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{43}
+}
+func (m *ContainerBandwidth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerBandwidth.Unmarshal(m, b)
+}
+func (m *ContainerBandwidth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerBandwidth.Marshal(b, m, deterministic)
+}
+func (m *ContainerBandwidth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerBandwidth.Merge(m, src)
+}
+func (m *ContainerBandwidth) XXX_Size() int {
+	return xxx_messageInfo_ContainerBandwidth.Size(m)
+}
+func (m *ContainerBandwidth) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerBandwidth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerBandwidth proto.InternalMessageInfo
+
+func (m *ContainerBandwidth) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ContainerBandwidth) GetIngress() uint64 {
+	if m != nil {
+		return m.Ingress
+	}
+	return 0
+}
+
+func (m *ContainerBandwidth) GetEgress() uint64 {
+	if m != nil {
+		return m.Egress
+	}
+	return 0
+}
+
+// NetworkIssue is a difference between the datastore and the node state
+type NetworkIssue struct {
+	Kind                 IssueKind `protobuf:"varint,1,opt,name=kind,proto3,enum=stellar.services.network.v1.IssueKind" json:"kind,omitempty"`
+	Node                 string    `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Network              string    `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	Message              string    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *NetworkIssue) Reset()         { *m = NetworkIssue{} }
+func (m *NetworkIssue) String() string { return proto.CompactTextString(m) }
+func (*NetworkIssue) ProtoMessage()    {}
+func (*NetworkIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{44}
+}
+func (m *NetworkIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkIssue.Unmarshal(m, b)
+}
+func (m *NetworkIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkIssue.Marshal(b, m, deterministic)
+}
+func (m *NetworkIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkIssue.Merge(m, src)
+}
+func (m *NetworkIssue) XXX_Size() int {
+	return xxx_messageInfo_NetworkIssue.Size(m)
+}
+func (m *NetworkIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkIssue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkIssue proto.InternalMessageInfo
+
+func (m *NetworkIssue) GetKind() IssueKind {
+	if m != nil {
+		return m.Kind
+	}
+	return IssueKind_MISSING_ROUTE
+}
+
+func (m *NetworkIssue) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NetworkIssue) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NetworkIssue) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type InspectResponse struct {
+	Nodes                []*NodeState    `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Issues               []*NetworkIssue `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *InspectResponse) Reset()         { *m = InspectResponse{} }
+func (m *InspectResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectResponse) ProtoMessage()    {}
+func (*InspectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5e613a22b6bc199d, []int{45}
+}
+func (m *InspectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectResponse.Unmarshal(m, b)
+}
+func (m *InspectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectResponse.Marshal(b, m, deterministic)
+}
+func (m *InspectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectResponse.Merge(m, src)
+}
+func (m *InspectResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectResponse.Size(m)
+}
+func (m *InspectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectResponse proto.InternalMessageInfo
+
+func (m *InspectResponse) GetNodes() []*NodeState {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *InspectResponse) GetIssues() []*NetworkIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("stellar.services.network.v1.PolicyAction", PolicyAction_name, PolicyAction_value)
+	proto.RegisterEnum("stellar.services.network.v1.PolicyDirection", PolicyDirection_name, PolicyDirection_value)
+	proto.RegisterEnum("stellar.services.network.v1.NetworkIsolation", NetworkIsolation_name, NetworkIsolation_value)
+	proto.RegisterEnum("stellar.services.network.v1.OrphanKind", OrphanKind_name, OrphanKind_value)
+	proto.RegisterEnum("stellar.services.network.v1.IssueKind", IssueKind_name, IssueKind_value)
+	proto.RegisterType((*InfoRequest)(nil), "stellar.services.network.v1.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "stellar.services.network.v1.InfoResponse")
+	proto.RegisterType((*AllocateSubnetRequest)(nil), "stellar.services.network.v1.AllocateSubnetRequest")
+	proto.RegisterType((*AllocateSubnetResponse)(nil), "stellar.services.network.v1.AllocateSubnetResponse")
+	proto.RegisterType((*GetSubnetRequest)(nil), "stellar.services.network.v1.GetSubnetRequest")
+	proto.RegisterType((*GetSubnetResponse)(nil), "stellar.services.network.v1.GetSubnetResponse")
+	proto.RegisterType((*DeallocateSubnetRequest)(nil), "stellar.services.network.v1.DeallocateSubnetRequest")
+	proto.RegisterType((*AllocateIPRequest)(nil), "stellar.services.network.v1.AllocateIPRequest")
+	proto.RegisterType((*AllocateIPResponse)(nil), "stellar.services.network.v1.AllocateIPResponse")
+	proto.RegisterType((*GetIPRequest)(nil), "stellar.services.network.v1.GetIPRequest")
+	proto.RegisterType((*GetIPResponse)(nil), "stellar.services.network.v1.GetIPResponse")
+	proto.RegisterType((*ReleaseIPRequest)(nil), "stellar.services.network.v1.ReleaseIPRequest")
+	proto.RegisterType((*SubnetsResponse)(nil), "stellar.services.network.v1.SubnetsResponse")
+	proto.RegisterType((*Subnet)(nil), "stellar.services.network.v1.Subnet")
+	proto.RegisterType((*ConfigureRequest)(nil), "stellar.services.network.v1.ConfigureRequest")
+	proto.RegisterType((*AddRouteRequest)(nil), "stellar.services.network.v1.AddRouteRequest")
+	proto.RegisterType((*DeleteRouteRequest)(nil), "stellar.services.network.v1.DeleteRouteRequest")
+	proto.RegisterType((*Route)(nil), "stellar.services.network.v1.Route")
+	proto.RegisterType((*RoutesResponse)(nil), "stellar.services.network.v1.RoutesResponse")
+	proto.RegisterType((*IPsRequest)(nil), "stellar.services.network.v1.IPsRequest")
+	proto.RegisterType((*IPAllocation)(nil), "stellar.services.network.v1.IPAllocation")
+	proto.RegisterType((*IPsResponse)(nil), "stellar.services.network.v1.IPsResponse")
+	proto.RegisterType((*PolicyPeer)(nil), "stellar.services.network.v1.PolicyPeer")
+	proto.RegisterType((*PolicyPort)(nil), "stellar.services.network.v1.PolicyPort")
+	proto.RegisterType((*PolicyRule)(nil), "stellar.services.network.v1.PolicyRule")
+	proto.RegisterType((*NetworkPolicy)(nil), "stellar.services.network.v1.NetworkPolicy")
+	proto.RegisterType((*CreatePolicyRequest)(nil), "stellar.services.network.v1.CreatePolicyRequest")
+	proto.RegisterType((*PoliciesResponse)(nil), "stellar.services.network.v1.PoliciesResponse")
+	proto.RegisterType((*DeletePolicyRequest)(nil), "stellar.services.network.v1.DeletePolicyRequest")
+	proto.RegisterType((*ClusterNetwork)(nil), "stellar.services.network.v1.ClusterNetwork")
+	proto.RegisterType((*CreateNetworkRequest)(nil), "stellar.services.network.v1.CreateNetworkRequest")
+	proto.RegisterType((*ListNetworksResponse)(nil), "stellar.services.network.v1.ListNetworksResponse")
+	proto.RegisterType((*DeleteNetworkRequest)(nil), "stellar.services.network.v1.DeleteNetworkRequest")
+	proto.RegisterType((*GCRequest)(nil), "stellar.services.network.v1.GCRequest")
+	proto.RegisterType((*Orphan)(nil), "stellar.services.network.v1.Orphan")
+	proto.RegisterType((*GCResponse)(nil), "stellar.services.network.v1.GCResponse")
+	proto.RegisterType((*IPPool)(nil), "stellar.services.network.v1.IPPool")
+	proto.RegisterType((*CreatePoolRequest)(nil), "stellar.services.network.v1.CreatePoolRequest")
+	proto.RegisterType((*ListPoolsResponse)(nil), "stellar.services.network.v1.ListPoolsResponse")
+	proto.RegisterType((*DeletePoolRequest)(nil), "stellar.services.network.v1.DeletePoolRequest")
+	proto.RegisterType((*NodeRoute)(nil), "stellar.services.network.v1.NodeRoute")
+	proto.RegisterType((*NodeBridge)(nil), "stellar.services.network.v1.NodeBridge")
+	proto.RegisterType((*NodeState)(nil), "stellar.services.network.v1.NodeState")
+	proto.RegisterType((*ContainerBandwidth)(nil), "stellar.services.network.v1.ContainerBandwidth")
+	proto.RegisterType((*NetworkIssue)(nil), "stellar.services.network.v1.NetworkIssue")
+	proto.RegisterType((*InspectResponse)(nil), "stellar.services.network.v1.InspectResponse")
+}
+
+func init() {
+	proto.RegisterFile("github.com/ehazlett/stellar/api/services/network/v1/network.proto", fileDescriptor_5e613a22b6bc199d)
+}
+
+var fileDescriptor_5e613a22b6bc199d = []byte{
+	// 2148 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x73, 0x1b, 0xc7,
+	0xf1, 0x17, 0xde, 0x40, 0x03, 0x24, 0xc1, 0xb1, 0xfe, 0x32, 0x0c, 0xbb, 0xfe, 0x64, 0x56, 0x55,
+	0x96, 0x44, 0xc9, 0x40, 0x44, 0xa7, 0x98, 0x4a, 0x28, 0x25, 0x06, 0x01, 0x04, 0xde, 0x88, 0x22,
+	0xe1, 0x01, 0xe8, 0x58, 0x52, 0x45, 0xc8, 0x92, 0x3b, 0x84, 0x36, 0x06, 0xb1, 0x9b, 0xdd, 0x85,
+	0x64, 0xa6, 0x2a, 0xe7, 0xe4, 0x9a, 0x7c, 0x98, 0xdc, 0x73, 0xc8, 0x29, 0xf7, 0x1c, 0x79, 0xe0,
+	0x07, 0x49, 0xa5, 0xe6, 0xb5, 0xbb, 0x78, 0xec, 0x83, 0x2a, 0xe5, 0x86, 0x99, 0xed, 0xfe, 0x75,
+	0x4f, 0x77, 0x4f, 0x4f, 0x77, 0x03, 0x5a, 0x63, 0xc3, 0x7d, 0x33, 0x3b, 0x6d, 0x9c, 0x99, 0x17,
+	0x4d, 0xf2, 0x46, 0xfb, 0xe3, 0x84, 0xb8, 0x6e, 0xd3, 0x71, 0xc9, 0x64, 0xa2, 0xd9, 0x4d, 0xcd,
+	0x32, 0x9a, 0x0e, 0xb1, 0xdf, 0x1a, 0x67, 0xc4, 0x69, 0x4e, 0x89, 0xfb, 0xce, 0xb4, 0xbf, 0x6f,
+	0xbe, 0x7d, 0x2c, 0x7f, 0x36, 0x2c, 0xdb, 0x74, 0x4d, 0xf4, 0xa9, 0x20, 0x6f, 0x48, 0xd2, 0x86,
+	0xfc, 0xfe, 0xf6, 0x71, 0xfd, 0xd3, 0xb1, 0x69, 0x8e, 0x27, 0xa4, 0xc9, 0x48, 0x4f, 0x67, 0xe7,
+	0x4d, 0x72, 0x61, 0xb9, 0x97, 0x9c, 0xb3, 0xfe, 0xff, 0x8b, 0x1f, 0xf5, 0x99, 0xad, 0xb9, 0x86,
+	0x39, 0x15, 0xdf, 0xb7, 0x16, 0xbf, 0xbb, 0xc6, 0x05, 0x71, 0x5c, 0xed, 0xc2, 0x12, 0x04, 0xb7,
+	0xc7, 0xe6, 0xd8, 0x64, 0x3f, 0x9b, 0xf4, 0x17, 0xdf, 0x55, 0xd6, 0xa0, 0xac, 0x4e, 0xcf, 0x4d,
+	0x4c, 0xfe, 0x30, 0x23, 0x8e, 0xab, 0x7c, 0x0e, 0x15, 0xbe, 0x74, 0x2c, 0x73, 0xea, 0x10, 0x74,
+	0x07, 0xd2, 0x86, 0x5e, 0x4b, 0x6d, 0xa7, 0xee, 0x97, 0x0e, 0xf2, 0xd7, 0x57, 0x5b, 0x69, 0xb5,
+	0x83, 0xd3, 0x86, 0xae, 0x3c, 0x84, 0xff, 0x6b, 0x4d, 0x26, 0xe6, 0x99, 0xe6, 0x92, 0xc1, 0xec,
+	0x74, 0x4a, 0x5c, 0x01, 0x80, 0x10, 0x64, 0xa7, 0xa6, 0x4e, 0x38, 0x0b, 0x66, 0xbf, 0x95, 0xbf,
+	0xa6, 0xe0, 0xce, 0x22, 0xb5, 0xc0, 0x6f, 0x42, 0xd9, 0x61, 0x3b, 0xa3, 0x33, 0x43, 0xb7, 0x85,
+	0xa0, 0xf5, 0xeb, 0xab, 0x2d, 0xe0, 0x84, 0x6d, 0xb5, 0x83, 0x31, 0x70, 0x92, 0xb6, 0xa1, 0xdb,
+	0x1e, 0x7e, 0xda, 0xc7, 0x47, 0xbb, 0x50, 0xe1, 0x14, 0x7b, 0x1c, 0x25, 0xc3, 0x50, 0x36, 0xae,
+	0xaf, 0xb6, 0xca, 0x1c, 0x65, 0x8f, 0xc1, 0x08, 0x49, 0x7b, 0x14, 0x47, 0xf9, 0x0a, 0xaa, 0x3d,
+	0xe2, 0xc6, 0xea, 0x8e, 0x6a, 0x50, 0x10, 0x1e, 0x12, 0x22, 0xe5, 0x52, 0xf9, 0x01, 0x36, 0x03,
+	0x08, 0xef, 0x7b, 0x9e, 0x45, 0xdd, 0xd3, 0x09, 0x74, 0x7f, 0x0d, 0x1f, 0x77, 0x88, 0xb6, 0xd2,
+	0xfc, 0x1f, 0xc2, 0x9e, 0xca, 0xdf, 0x53, 0xb0, 0x29, 0xfd, 0xa5, 0xf6, 0x25, 0x74, 0x48, 0x28,
+	0x2c, 0x8a, 0x4c, 0x27, 0x16, 0x99, 0x59, 0x6d, 0xe6, 0xec, 0x9c, 0x99, 0x99, 0x58, 0xab, 0x96,
+	0x0b, 0x88, 0xed, 0xe3, 0xb4, 0x61, 0x51, 0x14, 0xcb, 0x34, 0x27, 0xb5, 0x3c, 0x47, 0xa1, 0xbf,
+	0x95, 0xaf, 0x00, 0x05, 0xf5, 0x0e, 0xc4, 0xb0, 0x55, 0x4b, 0xad, 0x42, 0x58, 0x3a, 0xfa, 0x10,
+	0x2a, 0x3d, 0xe2, 0xc6, 0x1f, 0x7a, 0x55, 0x18, 0x06, 0xce, 0x90, 0x99, 0x0f, 0x95, 0x03, 0x58,
+	0x13, 0xa8, 0x31, 0x2a, 0x7d, 0x02, 0x19, 0xc3, 0xda, 0x13, 0x36, 0x2c, 0x5c, 0x5f, 0x6d, 0x65,
+	0xd4, 0xfe, 0x1e, 0xa6, 0x7b, 0x8a, 0x05, 0x55, 0x4c, 0x26, 0x44, 0x73, 0x12, 0xb8, 0x84, 0xc3,
+	0xa7, 0x43, 0x4f, 0x9c, 0xc8, 0xf2, 0x4a, 0x1f, 0x36, 0xb8, 0x07, 0x1d, 0x4f, 0xef, 0xa7, 0x50,
+	0xe0, 0x8e, 0x74, 0x6a, 0xa9, 0xed, 0xcc, 0xfd, 0xf2, 0xee, 0xdd, 0x46, 0x44, 0x42, 0x6b, 0x88,
+	0xd8, 0x94, 0x3c, 0xca, 0x9f, 0x20, 0xcf, 0xb7, 0xd0, 0x67, 0x90, 0x0d, 0x04, 0x68, 0xf1, 0xfa,
+	0x6a, 0x2b, 0xcb, 0xe2, 0x84, 0xed, 0x52, 0x9d, 0xc6, 0x9a, 0x4b, 0xde, 0x69, 0x97, 0xf2, 0xd2,
+	0x89, 0x25, 0xda, 0x82, 0x1c, 0xa5, 0xd8, 0x13, 0x77, 0xbc, 0x74, 0x7d, 0xb5, 0x95, 0xa3, 0x8c,
+	0x7b, 0x98, 0xef, 0xa3, 0x3a, 0x14, 0x05, 0xed, 0x9e, 0x38, 0x8f, 0xb7, 0x56, 0x8e, 0xa1, 0xda,
+	0x36, 0xa7, 0xe7, 0xc6, 0x78, 0x66, 0x13, 0x69, 0xc2, 0x7d, 0xc8, 0x73, 0xed, 0x98, 0x2a, 0x09,
+	0x0f, 0x24, 0x58, 0x94, 0x57, 0xb0, 0xd1, 0xd2, 0x75, 0x6c, 0xce, 0x5c, 0x0f, 0x2f, 0xfa, 0x60,
+	0x77, 0x20, 0xef, 0x6a, 0xf6, 0x98, 0xb8, 0xe2, 0x5c, 0x62, 0xb5, 0xca, 0x31, 0xca, 0x6b, 0x40,
+	0x1d, 0x32, 0x21, 0x2e, 0xf9, 0x1f, 0xe1, 0x7f, 0x03, 0x39, 0x86, 0xfc, 0x01, 0x21, 0x0f, 0x61,
+	0x9d, 0x41, 0xfa, 0x01, 0xf3, 0x73, 0xc8, 0xdb, 0x6c, 0x47, 0xc4, 0x8b, 0x12, 0x69, 0x5e, 0x7e,
+	0x52, 0xc1, 0xa1, 0x6c, 0x03, 0xa8, 0x7d, 0x27, 0xea, 0x61, 0xf9, 0x73, 0x0a, 0x2a, 0x6a, 0x5f,
+	0x5c, 0x79, 0xc3, 0x9c, 0xde, 0xe8, 0xba, 0xf2, 0x4b, 0x92, 0x09, 0xbb, 0x83, 0xd9, 0xe5, 0x3b,
+	0x18, 0xbc, 0x2b, 0xb9, 0xf9, 0xbb, 0x32, 0x80, 0xb2, 0xda, 0xf7, 0x8f, 0xdd, 0xa1, 0x18, 0xf2,
+	0xcc, 0x0f, 0x22, 0xcf, 0x1c, 0xd4, 0x5f, 0x8a, 0x73, 0xa8, 0x38, 0x47, 0x39, 0x07, 0xe8, 0x9b,
+	0x13, 0xe3, 0xec, 0xb2, 0x4f, 0x88, 0x8d, 0xb6, 0xa1, 0xac, 0x59, 0xd6, 0xc4, 0xe0, 0xa4, 0xc2,
+	0x0e, 0xc1, 0x2d, 0xaa, 0x9e, 0x90, 0x20, 0xaf, 0x8d, 0x58, 0x7a, 0x2e, 0xce, 0xac, 0x72, 0xb1,
+	0xf2, 0xc4, 0x93, 0x63, 0xda, 0x2e, 0xbd, 0x41, 0xac, 0x34, 0x38, 0x33, 0x27, 0x42, 0x88, 0xb7,
+	0xe6, 0x49, 0xd7, 0xe6, 0xa1, 0xb0, 0x86, 0xd9, 0x6f, 0xe5, 0x5f, 0x29, 0xc9, 0x8e, 0x67, 0x13,
+	0x82, 0x5a, 0x90, 0xd7, 0xce, 0x3c, 0x0d, 0xd7, 0x63, 0x4e, 0xcf, 0x19, 0x5b, 0x8c, 0x01, 0x0b,
+	0x46, 0xb4, 0x0f, 0x59, 0x8b, 0x10, 0xfe, 0x94, 0x94, 0x77, 0xef, 0x25, 0x00, 0xa0, 0x06, 0xc2,
+	0x8c, 0x09, 0x3d, 0x85, 0x1c, 0x55, 0xcb, 0xa9, 0x65, 0xb6, 0x33, 0x49, 0xb9, 0x4d, 0xdb, 0xc5,
+	0x9c, 0x4b, 0xf9, 0x47, 0x1a, 0xd6, 0x8e, 0x38, 0x01, 0xff, 0xc8, 0x62, 0x47, 0xbb, 0xf0, 0x03,
+	0x4f, 0xbb, 0x20, 0xe8, 0x97, 0x73, 0x97, 0xe2, 0x06, 0x3a, 0xca, 0xdb, 0xf3, 0x6b, 0x28, 0xe9,
+	0x86, 0x4d, 0xb8, 0xa1, 0x32, 0xcc, 0x50, 0x8f, 0x12, 0x60, 0x74, 0x24, 0x0f, 0xf6, 0xd9, 0xe9,
+	0x89, 0xed, 0xd9, 0x84, 0x38, 0xb5, 0x6c, 0xe2, 0x13, 0x53, 0x4f, 0x61, 0xce, 0x85, 0xfa, 0xb0,
+	0xae, 0x93, 0x73, 0x6d, 0x36, 0x71, 0x47, 0xc2, 0x71, 0xb9, 0x9b, 0x3a, 0x6e, 0x4d, 0x00, 0xf0,
+	0xa5, 0xf2, 0x02, 0x3e, 0x6a, 0xdb, 0x44, 0x73, 0x89, 0x10, 0x26, 0x6e, 0xf0, 0x01, 0xe4, 0x2d,
+	0xb6, 0x21, 0x52, 0xed, 0x4e, 0xa4, 0x80, 0x39, 0x27, 0x60, 0xc1, 0xa9, 0xbc, 0x84, 0x2a, 0xdb,
+	0x31, 0x02, 0x39, 0xe6, 0x57, 0x50, 0xb4, 0xc4, 0x9e, 0xb8, 0x71, 0x37, 0x41, 0xf6, 0x78, 0x95,
+	0x07, 0xf0, 0x11, 0x4f, 0xb8, 0xf3, 0x6a, 0xaf, 0xf0, 0xbf, 0xf2, 0x97, 0x34, 0xac, 0xb7, 0x27,
+	0x33, 0xc7, 0x25, 0xb6, 0x40, 0x5b, 0x19, 0x26, 0x37, 0x2e, 0x8d, 0xde, 0xa3, 0x92, 0xa5, 0x09,
+	0xfa, 0xd4, 0x36, 0xf4, 0x31, 0x11, 0xef, 0x9d, 0x58, 0xa1, 0x67, 0x50, 0x32, 0x1c, 0x73, 0xa2,
+	0x05, 0x5c, 0xfa, 0x45, 0x12, 0xbb, 0xa8, 0x92, 0x09, 0xfb, 0xfc, 0x34, 0xb5, 0x08, 0x1f, 0xb3,
+	0x82, 0xab, 0x88, 0xe5, 0x52, 0xf9, 0x2d, 0xdc, 0xe6, 0xce, 0x16, 0xec, 0xd2, 0x6c, 0x5d, 0x3f,
+	0x57, 0x72, 0x77, 0x3f, 0x8c, 0x14, 0x3e, 0x6f, 0x4d, 0x3f, 0xb1, 0x8e, 0xe0, 0xf6, 0xa1, 0xe1,
+	0xb8, 0x62, 0xdf, 0x77, 0x7a, 0x0f, 0x8a, 0x82, 0x44, 0x3a, 0xfd, 0x46, 0xf8, 0x1e, 0xb3, 0xb2,
+	0x03, 0xb7, 0xb9, 0xd7, 0x17, 0xf4, 0x5f, 0xe5, 0xf6, 0x53, 0x28, 0xf5, 0xda, 0x92, 0xe0, 0x63,
+	0x28, 0xe8, 0xf6, 0xe5, 0xc8, 0x9e, 0xf1, 0x4c, 0x57, 0xc4, 0x79, 0xdd, 0xbe, 0xc4, 0xb3, 0x29,
+	0x7a, 0x02, 0x95, 0xb1, 0xad, 0x9d, 0x91, 0x91, 0x45, 0x6c, 0xc3, 0xd4, 0x45, 0x8a, 0xf8, 0xa4,
+	0xc1, 0x1b, 0xb4, 0x86, 0x6c, 0xd0, 0x1a, 0x1d, 0xd1, 0xc0, 0xe1, 0x32, 0x23, 0xef, 0x33, 0x6a,
+	0xe5, 0x3f, 0x29, 0xc8, 0x1f, 0xdb, 0xd6, 0x1b, 0x8d, 0xe5, 0xc1, 0xef, 0x8d, 0xa9, 0x2e, 0x12,
+	0x69, 0xf4, 0xbd, 0xe6, 0x2c, 0xcf, 0x8c, 0xa9, 0x8e, 0x19, 0x53, 0x78, 0xe3, 0xb2, 0xb2, 0x0a,
+	0xe4, 0x0f, 0x67, 0x76, 0xe9, 0xe1, 0xac, 0x41, 0x41, 0xd3, 0x75, 0x9b, 0x38, 0x8e, 0x7c, 0xf1,
+	0xc4, 0x12, 0xed, 0x43, 0xd9, 0x64, 0x32, 0x89, 0x3e, 0xd2, 0x78, 0x54, 0x94, 0x77, 0xeb, 0x4b,
+	0x87, 0x1c, 0xca, 0x2e, 0x14, 0x83, 0x24, 0x6f, 0xb1, 0x37, 0xc6, 0xe6, 0xc5, 0xac, 0x5e, 0x2b,
+	0x30, 0xe3, 0x79, 0x6b, 0xe5, 0x19, 0x40, 0xaf, 0xed, 0xf9, 0xf9, 0x29, 0x14, 0x38, 0x5f, 0xb2,
+	0x8a, 0x93, 0x9b, 0x01, 0x4b, 0x1e, 0x65, 0x08, 0x79, 0xb5, 0xdf, 0x37, 0xf9, 0xd3, 0xb5, 0x74,
+	0x3f, 0xc3, 0x6d, 0xf4, 0x19, 0x94, 0xc4, 0x41, 0x09, 0x7f, 0x49, 0x4a, 0xd8, 0xdf, 0x50, 0x0e,
+	0x61, 0x53, 0x26, 0x38, 0x73, 0x22, 0xe3, 0xe1, 0xa7, 0xa2, 0x21, 0x49, 0x52, 0x47, 0x72, 0x9d,
+	0x44, 0xd7, 0x72, 0x04, 0x9b, 0x34, 0xc4, 0xe9, 0x8e, 0x1f, 0xdf, 0x3f, 0xa3, 0xcf, 0x98, 0x39,
+	0x49, 0x76, 0x6a, 0x01, 0xc7, 0x39, 0x94, 0x7b, 0xb0, 0x29, 0xf3, 0x98, 0xaf, 0xdd, 0xaa, 0x70,
+	0x7e, 0x05, 0xa5, 0x23, 0x53, 0x27, 0x49, 0xaa, 0xc0, 0xf0, 0x8a, 0xfc, 0x0e, 0xe4, 0x75, 0xc2,
+	0x6a, 0x0e, 0x1e, 0x4f, 0x62, 0xa5, 0x58, 0x00, 0x14, 0xfc, 0x80, 0x27, 0xa3, 0x0f, 0x68, 0x7d,
+	0x2a, 0x91, 0xfc, 0x60, 0x38, 0xae, 0xc3, 0xe2, 0xb5, 0x88, 0xc5, 0x4a, 0xf9, 0x67, 0x9a, 0x9f,
+	0x67, 0xe0, 0x6a, 0x2e, 0x41, 0x77, 0xa1, 0x40, 0x23, 0x7b, 0xe4, 0xd5, 0x83, 0x70, 0x7d, 0xb5,
+	0x95, 0xa7, 0xdf, 0xd5, 0x0e, 0xce, 0xd3, 0x4f, 0xaa, 0x8e, 0x7e, 0x04, 0x15, 0x21, 0x73, 0x74,
+	0xe1, 0xd7, 0x87, 0x65, 0xb1, 0xf7, 0x9c, 0xde, 0x8c, 0x68, 0x5d, 0x7e, 0xe1, 0xd5, 0xb7, 0xfc,
+	0xf1, 0xfd, 0x3c, 0x3a, 0xc3, 0x4a, 0x6b, 0xcb, 0x1a, 0x17, 0xb5, 0xa0, 0xc0, 0xd3, 0x35, 0xbd,
+	0x5f, 0xf1, 0xaf, 0xb7, 0x6f, 0x51, 0x2c, 0xf9, 0xd0, 0x31, 0xc0, 0xa9, 0x36, 0xd5, 0xdf, 0x19,
+	0xba, 0xfb, 0xc6, 0xa9, 0xe5, 0x19, 0x4a, 0x33, 0x3a, 0x17, 0x9a, 0x53, 0x57, 0x33, 0xa6, 0xc4,
+	0x3e, 0x90, 0x7c, 0x38, 0x00, 0x41, 0x1b, 0x8f, 0x65, 0x8a, 0xd0, 0xd2, 0xba, 0x06, 0x05, 0x63,
+	0x3a, 0x66, 0x19, 0x82, 0x5a, 0x2f, 0x8b, 0xe5, 0x92, 0xf9, 0x89, 0x7f, 0xc8, 0xb0, 0x0f, 0x62,
+	0xa5, 0xfc, 0x2d, 0x05, 0x15, 0xef, 0xad, 0x71, 0x66, 0xb4, 0x49, 0x08, 0xe6, 0xb9, 0x68, 0x13,
+	0x32, 0x8e, 0x40, 0x9a, 0xbb, 0x51, 0x23, 0x4e, 0xbf, 0x5c, 0x10, 0xc7, 0xd1, 0xbc, 0xc7, 0x52,
+	0x2e, 0xa9, 0x52, 0x1b, 0xea, 0xd4, 0xb1, 0xc8, 0x99, 0x3f, 0xcc, 0x79, 0x02, 0x39, 0x8a, 0x27,
+	0xef, 0x60, 0xbc, 0x6f, 0x59, 0xe4, 0x61, 0xce, 0x44, 0x0b, 0x61, 0x83, 0x2a, 0x4b, 0xed, 0x12,
+	0xdf, 0x06, 0x04, 0x0d, 0x82, 0x05, 0xe3, 0xce, 0x5d, 0xa8, 0x04, 0xeb, 0x2c, 0x54, 0x82, 0x5c,
+	0xeb, 0xf0, 0xf0, 0xf8, 0x37, 0xd5, 0x5b, 0xa8, 0x08, 0xd9, 0x4e, 0xf7, 0xe8, 0x45, 0x35, 0xb5,
+	0xb3, 0x03, 0x1b, 0x0b, 0xc5, 0x21, 0x2a, 0x43, 0x41, 0x3d, 0xea, 0xe1, 0xee, 0x60, 0x50, 0xbd,
+	0x85, 0x00, 0xf2, 0x5d, 0xfe, 0x3b, 0xb5, 0xf3, 0x08, 0xaa, 0x8b, 0xaf, 0x3c, 0xfd, 0x3e, 0xf8,
+	0xba, 0x85, 0xbb, 0x9d, 0xea, 0x2d, 0x54, 0x81, 0xa2, 0x3a, 0x38, 0x3e, 0x6c, 0x0d, 0xbb, 0x9d,
+	0x6a, 0x6a, 0xe7, 0x31, 0x80, 0xff, 0xac, 0xa0, 0x3c, 0xa4, 0xd5, 0x3e, 0xc7, 0x1b, 0x9c, 0x1c,
+	0x1c, 0x75, 0x87, 0xd5, 0x14, 0xda, 0x80, 0x32, 0xee, 0x0e, 0xba, 0xf8, 0xdb, 0xd6, 0x50, 0x3d,
+	0x3e, 0xaa, 0xa6, 0x77, 0xce, 0xa1, 0xe4, 0x79, 0x08, 0x6d, 0xc2, 0xda, 0x73, 0x75, 0x30, 0x50,
+	0x8f, 0x7a, 0x23, 0x7c, 0x7c, 0x32, 0xec, 0x56, 0x6f, 0x51, 0x86, 0xc1, 0xb0, 0x75, 0xd8, 0x15,
+	0x1b, 0x29, 0x84, 0x60, 0xfd, 0x00, 0xab, 0x9d, 0x5e, 0x77, 0xd4, 0xea, 0x74, 0x98, 0x96, 0x69,
+	0x54, 0x85, 0x4a, 0xe7, 0xa4, 0x7f, 0xa8, 0xb6, 0x5b, 0xc3, 0xee, 0x48, 0xed, 0x57, 0x33, 0x94,
+	0xed, 0xe4, 0x08, 0x77, 0x5b, 0xed, 0xaf, 0x5b, 0x07, 0x87, 0xdd, 0x6a, 0x76, 0xf7, 0xdf, 0x9b,
+	0x50, 0x90, 0x95, 0xd7, 0x2b, 0xc8, 0xd2, 0x99, 0x25, 0xba, 0x1f, 0x1d, 0x38, 0xfe, 0x94, 0xb3,
+	0xfe, 0x20, 0x01, 0xa5, 0x88, 0x81, 0x4b, 0x58, 0x9f, 0x1f, 0x5d, 0xa2, 0xdd, 0x48, 0xe6, 0x95,
+	0x53, 0xd1, 0xfa, 0x97, 0x37, 0xe2, 0x11, 0xa2, 0x7f, 0x0f, 0x25, 0x6f, 0xc0, 0x88, 0xa2, 0x4b,
+	0xb7, 0xc5, 0x51, 0x66, 0xbd, 0x91, 0x94, 0x5c, 0xc8, 0xfa, 0x1d, 0x54, 0x17, 0x47, 0x8a, 0xe8,
+	0x27, 0x91, 0x18, 0x21, 0x13, 0xc8, 0xfa, 0x9d, 0xa5, 0x12, 0xa0, 0x4b, 0xa7, 0xd8, 0xe8, 0x18,
+	0x0a, 0x9c, 0xd0, 0x41, 0x21, 0x24, 0xf5, 0x47, 0x09, 0x66, 0x2f, 0xfe, 0x0b, 0x79, 0x01, 0xe0,
+	0x0f, 0xfb, 0x50, 0x23, 0x91, 0x85, 0xbd, 0xd1, 0x59, 0xbd, 0x99, 0x98, 0x5e, 0x88, 0x7b, 0x0d,
+	0x39, 0x36, 0xc3, 0x43, 0x0f, 0xe2, 0x4c, 0xeb, 0x0b, 0xd9, 0x49, 0x42, 0x2a, 0xf0, 0x31, 0x94,
+	0xbc, 0xf9, 0x5e, 0x8c, 0xb7, 0x17, 0xe7, 0x80, 0xa1, 0x36, 0xc7, 0x50, 0xf2, 0x06, 0x5e, 0x31,
+	0x98, 0x8b, 0x83, 0xb1, 0x50, 0xcc, 0x3e, 0x14, 0xe5, 0xcc, 0x0b, 0x45, 0x3b, 0x6c, 0x61, 0x34,
+	0x16, 0x8a, 0xf8, 0x2d, 0x94, 0x03, 0x83, 0x2e, 0xd4, 0x8c, 0x09, 0xbb, 0xc5, 0x91, 0x58, 0x28,
+	0xee, 0x73, 0xc8, 0x63, 0xfe, 0xca, 0x86, 0x05, 0xdc, 0xc3, 0xf8, 0x69, 0x94, 0x1f, 0x6f, 0xdf,
+	0x01, 0x9d, 0xcc, 0xa0, 0x7b, 0x31, 0x95, 0x98, 0x1c, 0x58, 0xd5, 0xef, 0xc7, 0x13, 0x7a, 0xc8,
+	0x95, 0x60, 0xbf, 0x8c, 0x7e, 0x1c, 0xed, 0xa9, 0xe5, 0xd6, 0x3a, 0xd4, 0x04, 0xdf, 0x40, 0x51,
+	0xb6, 0xcb, 0xa1, 0x46, 0xf8, 0x22, 0xbe, 0xcf, 0x37, 0xe6, 0xcc, 0x50, 0x09, 0x76, 0xc9, 0x31,
+	0xca, 0xae, 0x68, 0xa8, 0x43, 0x95, 0x7d, 0x09, 0x6b, 0x73, 0x9d, 0x24, 0x7a, 0x9c, 0xc0, 0x0e,
+	0xf3, 0x5d, 0x5b, 0x28, 0xf6, 0x0b, 0xa8, 0x04, 0xdb, 0xc8, 0x50, 0x63, 0x44, 0x8b, 0x5c, 0xd9,
+	0x89, 0xbe, 0x84, 0xb5, 0xb9, 0x06, 0x32, 0x46, 0xed, 0x55, 0xcd, 0x66, 0xa8, 0xda, 0x27, 0x90,
+	0xee, 0xb5, 0x51, 0x74, 0xe1, 0xe1, 0x75, 0xa4, 0xf5, 0x7b, 0xb1, 0x74, 0x42, 0xe5, 0x21, 0x80,
+	0xdf, 0xbf, 0xc4, 0xa4, 0xce, 0xa5, 0x46, 0x27, 0x54, 0xd9, 0x01, 0x94, 0xbc, 0x3e, 0x26, 0xd4,
+	0xc0, 0x8d, 0x58, 0x03, 0xcf, 0xf7, 0x41, 0x43, 0x00, 0xbf, 0x99, 0x89, 0x51, 0x75, 0xa9, 0xeb,
+	0x09, 0x55, 0xb5, 0x07, 0x39, 0xde, 0x25, 0x84, 0xa9, 0x99, 0xb0, 0xd6, 0xa3, 0xaf, 0x9a, 0xa8,
+	0x1a, 0xdf, 0xf3, 0x55, 0x5b, 0xa8, 0x39, 0x0f, 0x9e, 0xbe, 0xdc, 0x7f, 0x8f, 0x7f, 0x99, 0xf7,
+	0xc5, 0xcf, 0xef, 0x32, 0xa7, 0x79, 0x26, 0xfe, 0xcb, 0xff, 0x0e, 0x00, 0xaa, 0x28, 0xd7, 0x5c,
+	0xad, 0x1e, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NetworkClient is the client API for Network service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NetworkClient interface {
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	AllocateSubnet(ctx context.Context, in *AllocateSubnetRequest, opts ...grpc.CallOption) (*AllocateSubnetResponse, error)
+	GetSubnet(ctx context.Context, in *GetSubnetRequest, opts ...grpc.CallOption) (*GetSubnetResponse, error)
+	DeallocateSubnet(ctx context.Context, in *DeallocateSubnetRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Subnets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*SubnetsResponse, error)
+	AllocateIP(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error)
+	GetIP(ctx context.Context, in *GetIPRequest, opts ...grpc.CallOption) (*GetIPResponse, error)
+	ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	AddRoute(ctx context.Context, in *AddRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Routes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*RoutesResponse, error)
+	IPs(ctx context.Context, in *IPsRequest, opts ...grpc.CallOption) (*IPsResponse, error)
+	CreatePolicy(ctx context.Context, in *CreatePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Policies(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*PoliciesResponse, error)
+	DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	CreateNetwork(ctx context.Context, in *CreateNetworkRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ListNetworks(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListNetworksResponse, error)
+	DeleteNetwork(ctx context.Context, in *DeleteNetworkRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCResponse, error)
+	CreatePool(ctx context.Context, in *CreatePoolRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ListPools(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListPoolsResponse, error)
+	DeletePool(ctx context.Context, in *DeletePoolRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	State(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*NodeState, error)
+	Inspect(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*InspectResponse, error)
+}
+
+type networkClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkClient(cc *grpc.ClientConn) NetworkClient {
+	return &networkClient{cc}
+}
+
+func (c *networkClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) AllocateSubnet(ctx context.Context, in *AllocateSubnetRequest, opts ...grpc.CallOption) (*AllocateSubnetResponse, error) {
+	out := new(AllocateSubnetResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AllocateSubnet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) GetSubnet(ctx context.Context, in *GetSubnetRequest, opts ...grpc.CallOption) (*GetSubnetResponse, error) {
+	out := new(GetSubnetResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/GetSubnet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeallocateSubnet(ctx context.Context, in *DeallocateSubnetRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeallocateSubnet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Subnets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*SubnetsResponse, error) {
+	out := new(SubnetsResponse)
 	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Subnets", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -987,63 +2784,180 @@ func (c *networkClient) Subnets(ctx context.Context, in *types.Empty, opts ...gr
 	return out, nil
 }
 
-func (c *networkClient) AllocateIP(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error) {
-	out := new(AllocateIPResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AllocateIP", in, out, opts...)
+func (c *networkClient) AllocateIP(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*AllocateIPResponse, error) {
+	out := new(AllocateIPResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AllocateIP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) GetIP(ctx context.Context, in *GetIPRequest, opts ...grpc.CallOption) (*GetIPResponse, error) {
+	out := new(GetIPResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/GetIP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/ReleaseIP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) AddRoute(ctx context.Context, in *AddRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AddRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeleteRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Routes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*RoutesResponse, error) {
+	out := new(RoutesResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Routes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) IPs(ctx context.Context, in *IPsRequest, opts ...grpc.CallOption) (*IPsResponse, error) {
+	out := new(IPsResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/IPs", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) GetIP(ctx context.Context, in *GetIPRequest, opts ...grpc.CallOption) (*GetIPResponse, error) {
-	out := new(GetIPResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/GetIP", in, out, opts...)
+func (c *networkClient) CreatePolicy(ctx context.Context, in *CreatePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/CreatePolicy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) ReleaseIP(ctx context.Context, in *ReleaseIPRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *networkClient) Policies(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*PoliciesResponse, error) {
+	out := new(PoliciesResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Policies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/ReleaseIP", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeletePolicy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *networkClient) CreateNetwork(ctx context.Context, in *CreateNetworkRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Configure", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/CreateNetwork", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) AddRoute(ctx context.Context, in *AddRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *networkClient) ListNetworks(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListNetworksResponse, error) {
+	out := new(ListNetworksResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/ListNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeleteNetwork(ctx context.Context, in *DeleteNetworkRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/AddRoute", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeleteNetwork", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *networkClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCResponse, error) {
+	out := new(GCResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/GC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) CreatePool(ctx context.Context, in *CreatePoolRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeleteRoute", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/CreatePool", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkClient) Routes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*RoutesResponse, error) {
-	out := new(RoutesResponse)
-	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Routes", in, out, opts...)
+func (c *networkClient) ListPools(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ListPoolsResponse, error) {
+	out := new(ListPoolsResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/ListPools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) DeletePool(ctx context.Context, in *DeletePoolRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/DeletePool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) State(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*NodeState, error) {
+	out := new(NodeState)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/State", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Inspect(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*InspectResponse, error) {
+	out := new(InspectResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.network.v1.Network/Inspect", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1064,6 +2978,19 @@ type NetworkServer interface {
 	AddRoute(context.Context, *AddRouteRequest) (*types.Empty, error)
 	DeleteRoute(context.Context, *DeleteRouteRequest) (*types.Empty, error)
 	Routes(context.Context, *types.Empty) (*RoutesResponse, error)
+	IPs(context.Context, *IPsRequest) (*IPsResponse, error)
+	CreatePolicy(context.Context, *CreatePolicyRequest) (*types.Empty, error)
+	Policies(context.Context, *types.Empty) (*PoliciesResponse, error)
+	DeletePolicy(context.Context, *DeletePolicyRequest) (*types.Empty, error)
+	CreateNetwork(context.Context, *CreateNetworkRequest) (*types.Empty, error)
+	ListNetworks(context.Context, *types.Empty) (*ListNetworksResponse, error)
+	DeleteNetwork(context.Context, *DeleteNetworkRequest) (*types.Empty, error)
+	GC(context.Context, *GCRequest) (*GCResponse, error)
+	CreatePool(context.Context, *CreatePoolRequest) (*types.Empty, error)
+	ListPools(context.Context, *types.Empty) (*ListPoolsResponse, error)
+	DeletePool(context.Context, *DeletePoolRequest) (*types.Empty, error)
+	State(context.Context, *types.Empty) (*NodeState, error)
+	Inspect(context.Context, *types.Empty) (*InspectResponse, error)
 }
 
 func RegisterNetworkServer(s *grpc.Server, srv NetworkServer) {
@@ -1286,6 +3213,240 @@ func _Network_Routes_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Network_IPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).IPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/IPs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).IPs(ctx, req.(*IPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_CreatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).CreatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/CreatePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).CreatePolicy(ctx, req.(*CreatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Policies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Policies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/Policies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Policies(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DeletePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DeletePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/DeletePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DeletePolicy(ctx, req.(*DeletePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_CreateNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).CreateNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/CreateNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).CreateNetwork(ctx, req.(*CreateNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ListNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/ListNetworks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ListNetworks(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DeleteNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DeleteNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/DeleteNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DeleteNetwork(ctx, req.(*DeleteNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_GC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).GC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/GC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).GC(ctx, req.(*GCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_CreatePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).CreatePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/CreatePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).CreatePool(ctx, req.(*CreatePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).ListPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/ListPools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).ListPools(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_DeletePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).DeletePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/DeletePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).DeletePool(ctx, req.(*DeletePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_State_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).State(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/State",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).State(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.network.v1.Network/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Inspect(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Network_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "stellar.services.network.v1.Network",
 	HandlerType: (*NetworkServer)(nil),
@@ -1338,6 +3499,58 @@ var _Network_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Routes",
 			Handler:    _Network_Routes_Handler,
 		},
+		{
+			MethodName: "IPs",
+			Handler:    _Network_IPs_Handler,
+		},
+		{
+			MethodName: "CreatePolicy",
+			Handler:    _Network_CreatePolicy_Handler,
+		},
+		{
+			MethodName: "Policies",
+			Handler:    _Network_Policies_Handler,
+		},
+		{
+			MethodName: "DeletePolicy",
+			Handler:    _Network_DeletePolicy_Handler,
+		},
+		{
+			MethodName: "CreateNetwork",
+			Handler:    _Network_CreateNetwork_Handler,
+		},
+		{
+			MethodName: "ListNetworks",
+			Handler:    _Network_ListNetworks_Handler,
+		},
+		{
+			MethodName: "DeleteNetwork",
+			Handler:    _Network_DeleteNetwork_Handler,
+		},
+		{
+			MethodName: "GC",
+			Handler:    _Network_GC_Handler,
+		},
+		{
+			MethodName: "CreatePool",
+			Handler:    _Network_CreatePool_Handler,
+		},
+		{
+			MethodName: "ListPools",
+			Handler:    _Network_ListPools_Handler,
+		},
+		{
+			MethodName: "DeletePool",
+			Handler:    _Network_DeletePool_Handler,
+		},
+		{
+			MethodName: "State",
+			Handler:    _Network_State_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Network_Inspect_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/ehazlett/stellar/api/services/network/v1/network.proto",
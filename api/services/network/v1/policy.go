@@ -0,0 +1,53 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarshalJSON returns the action name so policy files are readable
+func (a PolicyAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(a.String()))
+}
+
+// UnmarshalJSON accepts the action name or number
+func (a *PolicyAction) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, PolicyAction_value)
+	if err != nil {
+		return err
+	}
+	*a = PolicyAction(v)
+	return nil
+}
+
+// MarshalJSON returns the direction name so policy files are readable
+func (d PolicyDirection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(d.String()))
+}
+
+// UnmarshalJSON accepts the direction name or number
+func (d *PolicyDirection) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, PolicyDirection_value)
+	if err != nil {
+		return err
+	}
+	*d = PolicyDirection(v)
+	return nil
+}
+
+func unmarshalEnum(data []byte, values map[string]int32) (int32, error) {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var v int32
+		if err := json.Unmarshal(data, &v); err != nil {
+			return 0, err
+		}
+		return v, nil
+	}
+	v, ok := values[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown value %q", name)
+	}
+	return v, nil
+}
@@ -0,0 +1,14 @@
+package network
+
+// MemberRoutes returns the routes owned by the members.  Routes without an
+// owner are kept.
+func MemberRoutes(routes []*Route, members map[string]bool) []*Route {
+	var active []*Route
+	for _, r := range routes {
+		if r.Node != "" && !members[r.Node] {
+			continue
+		}
+		active = append(active, r)
+	}
+	return active
+}
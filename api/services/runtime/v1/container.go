@@ -1,5 +1,59 @@
 package runtime
 
+import "fmt"
+
 func (c *Container) Running() bool {
 	return c.Task.Pid > 0
 }
+
+// PublishedPort returns the published host port of the endpoint as
+// <protocol>/<port> or an empty string if the port is not published.  HTTP
+// endpoints are published as tcp.
+func (m *Endpoint) PublishedPort() string {
+	if m.HostPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", m.HostProtocol(), m.HostPort)
+}
+
+// HostProtocol returns the transport protocol of the endpoint
+func (m *Endpoint) HostProtocol() string {
+	if m.Protocol == Protocol_UDP {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// PublishedPorts returns the host ports published by the service endpoints
+func (m *Service) PublishedPorts() []string {
+	var ports []string
+	for _, ep := range m.Endpoints {
+		if p := ep.PublishedPort(); p != "" {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
+
+// ValidatePorts returns an error if a published host port is invalid or
+// published more than once by the service
+func (m *Service) ValidatePorts() error {
+	seen := map[string]bool{}
+	for _, ep := range m.Endpoints {
+		p := ep.PublishedPort()
+		if p == "" {
+			continue
+		}
+		if ep.HostPort > 65535 {
+			return fmt.Errorf("invalid host port %d for endpoint %s", ep.HostPort, ep.Service)
+		}
+		if ep.Port == 0 || ep.Port > 65535 {
+			return fmt.Errorf("endpoint %s must have a valid port to publish host port %d", ep.Service, ep.HostPort)
+		}
+		if seen[p] {
+			return fmt.Errorf("host port %s is published more than once by %s", p, m.Name)
+		}
+		seen[p] = true
+	}
+	return nil
+}
@@ -706,11 +706,13 @@ type Endpoint struct {
 	Host     string   `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
 	Port     uint32   `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
 	// config is a generic map that is parsed upon unmarshal that is used to generate the Any
-	Config               map[string]string `protobuf:"bytes,5,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	EndpointConfig       *types.Any        `protobuf:"bytes,6,opt,name=endpoint_config,json=endpointConfig,proto3" json:"endpoint_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Config         map[string]string `protobuf:"bytes,5,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EndpointConfig *types.Any        `protobuf:"bytes,6,opt,name=endpoint_config,json=endpointConfig,proto3" json:"endpoint_config,omitempty"`
+	// host_port publishes the endpoint port on the node; zero is not published
+	HostPort             uint32   `protobuf:"varint,7,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Endpoint) Reset()         { *m = Endpoint{} }
@@ -779,6 +781,13 @@ func (m *Endpoint) GetEndpointConfig() *types.Any {
 	return nil
 }
 
+func (m *Endpoint) GetHostPort() uint32 {
+	if m != nil {
+		return m.HostPort
+	}
+	return 0
+}
+
 type PlacementPreference struct {
 	NodeIDs              []string          `protobuf:"bytes,1,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
 	Labels               map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -834,22 +843,37 @@ func (m *PlacementPreference) GetReplicas() uint64 {
 }
 
 type Service struct {
-	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image                string               `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Runtime              string               `protobuf:"bytes,3,opt,name=runtime,proto3" json:"runtime,omitempty"`
-	Snapshotter          string               `protobuf:"bytes,4,opt,name=snapshotter,proto3" json:"snapshotter,omitempty"`
-	Node                 string               `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
-	Process              *Process             `protobuf:"bytes,6,opt,name=process,proto3" json:"process,omitempty"`
-	Labels               []string             `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
-	Network              bool                 `protobuf:"varint,8,opt,name=network,proto3" json:"network,omitempty"`
-	Mounts               []*Mount             `protobuf:"bytes,9,rep,name=mounts,proto3" json:"mounts,omitempty"`
-	Endpoints            []*Endpoint          `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
-	Replicas             uint64               `protobuf:"varint,11,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	PlacementPreference  *PlacementPreference `protobuf:"bytes,12,opt,name=placement_preference,json=placementPreference,proto3" json:"placement_preference,omitempty"`
-	Restart              bool                 `protobuf:"varint,13,opt,name=restart,proto3" json:"restart,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Name                string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image               string               `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Runtime             string               `protobuf:"bytes,3,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	Snapshotter         string               `protobuf:"bytes,4,opt,name=snapshotter,proto3" json:"snapshotter,omitempty"`
+	Node                string               `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	Process             *Process             `protobuf:"bytes,6,opt,name=process,proto3" json:"process,omitempty"`
+	Labels              []string             `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	Network             bool                 `protobuf:"varint,8,opt,name=network,proto3" json:"network,omitempty"`
+	Mounts              []*Mount             `protobuf:"bytes,9,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Endpoints           []*Endpoint          `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Replicas            uint64               `protobuf:"varint,11,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	PlacementPreference *PlacementPreference `protobuf:"bytes,12,opt,name=placement_preference,json=placementPreference,proto3" json:"placement_preference,omitempty"`
+	Restart             bool                 `protobuf:"varint,13,opt,name=restart,proto3" json:"restart,omitempty"`
+	// priority is the priority class of the service; higher priority services
+	// may preempt lower priority replicas when a node does not have capacity
+	Priority         int32             `protobuf:"varint,14,opt,name=priority,proto3" json:"priority,omitempty"`
+	Resources        *Resources        `protobuf:"bytes,15,opt,name=resources,proto3" json:"resources,omitempty"`
+	DisruptionBudget *DisruptionBudget `protobuf:"bytes,16,opt,name=disruption_budget,json=disruptionBudget,proto3" json:"disruption_budget,omitempty"`
+	// networks are the named networks the service is attached to in
+	// interface order; the default network if empty
+	Networks []string `protobuf:"bytes,17,rep,name=networks,proto3" json:"networks,omitempty"`
+	// ips are the static addresses of the replicas on the primary network
+	// in replica order
+	IPs []string `protobuf:"bytes,18,rep,name=ips,proto3" json:"ips,omitempty"`
+	// ip_pool is the reservation pool for the replica addresses on the
+	// primary network
+	IPPool               string     `protobuf:"bytes,19,opt,name=ip_pool,json=ipPool,proto3" json:"ip_pool,omitempty"`
+	Bandwidth            *Bandwidth `protobuf:"bytes,20,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Service) Reset()         { *m = Service{} }
@@ -967,6 +991,193 @@ func (m *Service) GetRestart() bool {
 	return false
 }
 
+func (m *Service) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Service) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Service) GetDisruptionBudget() *DisruptionBudget {
+	if m != nil {
+		return m.DisruptionBudget
+	}
+	return nil
+}
+
+func (m *Service) GetNetworks() []string {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
+}
+
+func (m *Service) GetIPs() []string {
+	if m != nil {
+		return m.IPs
+	}
+	return nil
+}
+
+func (m *Service) GetIPPool() string {
+	if m != nil {
+		return m.IPPool
+	}
+	return ""
+}
+
+func (m *Service) GetBandwidth() *Bandwidth {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return nil
+}
+
+// DisruptionBudget limits the replicas of a service that are moved during a rebalance
+type DisruptionBudget struct {
+	// max_moves is the maximum number of replicas moved per rebalance; defaults to 1
+	MaxMoves             uint64   `protobuf:"varint,1,opt,name=max_moves,json=maxMoves,proto3" json:"max_moves,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisruptionBudget) Reset()         { *m = DisruptionBudget{} }
+func (m *DisruptionBudget) String() string { return proto.CompactTextString(m) }
+func (*DisruptionBudget) ProtoMessage()    {}
+func (*DisruptionBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{16}
+}
+func (m *DisruptionBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisruptionBudget.Unmarshal(m, b)
+}
+func (m *DisruptionBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisruptionBudget.Marshal(b, m, deterministic)
+}
+func (m *DisruptionBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisruptionBudget.Merge(m, src)
+}
+func (m *DisruptionBudget) XXX_Size() int {
+	return xxx_messageInfo_DisruptionBudget.Size(m)
+}
+func (m *DisruptionBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisruptionBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisruptionBudget proto.InternalMessageInfo
+
+func (m *DisruptionBudget) GetMaxMoves() uint64 {
+	if m != nil {
+		return m.MaxMoves
+	}
+	return 0
+}
+
+// Resources are the resources reserved on a node for each service replica
+type Resources struct {
+	Cpus float64 `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// memory in bytes
+	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Resources) Reset()         { *m = Resources{} }
+func (m *Resources) String() string { return proto.CompactTextString(m) }
+func (*Resources) ProtoMessage()    {}
+func (*Resources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{17}
+}
+func (m *Resources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resources.Unmarshal(m, b)
+}
+func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+}
+func (m *Resources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resources.Merge(m, src)
+}
+func (m *Resources) XXX_Size() int {
+	return xxx_messageInfo_Resources.Size(m)
+}
+func (m *Resources) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resources proto.InternalMessageInfo
+
+func (m *Resources) GetCpus() float64 {
+	if m != nil {
+		return m.Cpus
+	}
+	return 0
+}
+
+func (m *Resources) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+// Bandwidth limits the traffic of each replica interface in bits per second;
+// zero is unlimited
+type Bandwidth struct {
+	// ingress limits the traffic to the replica
+	Ingress uint64 `protobuf:"varint,1,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	// egress limits the traffic from the replica
+	Egress               uint64   `protobuf:"varint,2,opt,name=egress,proto3" json:"egress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Bandwidth) Reset()         { *m = Bandwidth{} }
+func (m *Bandwidth) String() string { return proto.CompactTextString(m) }
+func (*Bandwidth) ProtoMessage()    {}
+func (*Bandwidth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{18}
+}
+func (m *Bandwidth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Bandwidth.Unmarshal(m, b)
+}
+func (m *Bandwidth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Bandwidth.Marshal(b, m, deterministic)
+}
+func (m *Bandwidth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bandwidth.Merge(m, src)
+}
+func (m *Bandwidth) XXX_Size() int {
+	return xxx_messageInfo_Bandwidth.Size(m)
+}
+func (m *Bandwidth) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bandwidth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Bandwidth proto.InternalMessageInfo
+
+func (m *Bandwidth) GetIngress() uint64 {
+	if m != nil {
+		return m.Ingress
+	}
+	return 0
+}
+
+func (m *Bandwidth) GetEgress() uint64 {
+	if m != nil {
+		return m.Egress
+	}
+	return 0
+}
+
 type CreateContainerRequest struct {
 	Application          string   `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
 	Service              *Service `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
@@ -980,7 +1191,7 @@ func (m *CreateContainerRequest) Reset()         { *m = CreateContainerRequest{}
 func (m *CreateContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateContainerRequest) ProtoMessage()    {}
 func (*CreateContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7c551ea4b986781f, []int{16}
+	return fileDescriptor_7c551ea4b986781f, []int{19}
 }
 func (m *CreateContainerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateContainerRequest.Unmarshal(m, b)
@@ -1032,7 +1243,7 @@ func (m *DeleteContainerRequest) Reset()         { *m = DeleteContainerRequest{}
 func (m *DeleteContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteContainerRequest) ProtoMessage()    {}
 func (*DeleteContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7c551ea4b986781f, []int{17}
+	return fileDescriptor_7c551ea4b986781f, []int{20}
 }
 func (m *DeleteContainerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteContainerRequest.Unmarshal(m, b)
@@ -1070,7 +1281,7 @@ func (m *RestartContainerRequest) Reset()         { *m = RestartContainerRequest
 func (m *RestartContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*RestartContainerRequest) ProtoMessage()    {}
 func (*RestartContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7c551ea4b986781f, []int{18}
+	return fileDescriptor_7c551ea4b986781f, []int{21}
 }
 func (m *RestartContainerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestartContainerRequest.Unmarshal(m, b)
@@ -1097,6 +1308,164 @@ func (m *RestartContainerRequest) GetID() string {
 	return ""
 }
 
+// PingRequest sends icmp echo requests to the target from the network
+// namespace of the container
+type PingRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Count                uint32   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{22}
+}
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
+}
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
+}
+func (m *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(m, src)
+}
+func (m *PingRequest) XXX_Size() int {
+	return xxx_messageInfo_PingRequest.Size(m)
+}
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
+
+func (m *PingRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *PingRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *PingRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type PingResponse struct {
+	Transmitted uint32 `protobuf:"varint,1,opt,name=transmitted,proto3" json:"transmitted,omitempty"`
+	Received    uint32 `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	// output is the output of ping
+	Output               string   `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingResponse) Reset()         { *m = PingResponse{} }
+func (m *PingResponse) String() string { return proto.CompactTextString(m) }
+func (*PingResponse) ProtoMessage()    {}
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{23}
+}
+func (m *PingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
+}
+func (m *PingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingResponse.Marshal(b, m, deterministic)
+}
+func (m *PingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponse.Merge(m, src)
+}
+func (m *PingResponse) XXX_Size() int {
+	return xxx_messageInfo_PingResponse.Size(m)
+}
+func (m *PingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingResponse proto.InternalMessageInfo
+
+func (m *PingResponse) GetTransmitted() uint32 {
+	if m != nil {
+		return m.Transmitted
+	}
+	return 0
+}
+
+func (m *PingResponse) GetReceived() uint32 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *PingResponse) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+// SetBandwidthRequest replaces the bandwidth limits of the running container
+type SetBandwidthRequest struct {
+	ID                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Bandwidth            *Bandwidth `protobuf:"bytes,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetBandwidthRequest) Reset()         { *m = SetBandwidthRequest{} }
+func (m *SetBandwidthRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBandwidthRequest) ProtoMessage()    {}
+func (*SetBandwidthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c551ea4b986781f, []int{24}
+}
+func (m *SetBandwidthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBandwidthRequest.Unmarshal(m, b)
+}
+func (m *SetBandwidthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBandwidthRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBandwidthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBandwidthRequest.Merge(m, src)
+}
+func (m *SetBandwidthRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBandwidthRequest.Size(m)
+}
+func (m *SetBandwidthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBandwidthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBandwidthRequest proto.InternalMessageInfo
+
+func (m *SetBandwidthRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *SetBandwidthRequest) GetBandwidth() *Bandwidth {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("stellar.services.runtime.v1.Protocol", Protocol_name, Protocol_value)
 	proto.RegisterType((*InfoRequest)(nil), "stellar.services.runtime.v1.InfoRequest")
@@ -1120,9 +1489,15 @@ func init() {
 	proto.RegisterType((*PlacementPreference)(nil), "stellar.services.runtime.v1.PlacementPreference")
 	proto.RegisterMapType((map[string]string)(nil), "stellar.services.runtime.v1.PlacementPreference.LabelsEntry")
 	proto.RegisterType((*Service)(nil), "stellar.services.runtime.v1.Service")
+	proto.RegisterType((*DisruptionBudget)(nil), "stellar.services.runtime.v1.DisruptionBudget")
+	proto.RegisterType((*Resources)(nil), "stellar.services.runtime.v1.Resources")
+	proto.RegisterType((*Bandwidth)(nil), "stellar.services.runtime.v1.Bandwidth")
 	proto.RegisterType((*CreateContainerRequest)(nil), "stellar.services.runtime.v1.CreateContainerRequest")
 	proto.RegisterType((*DeleteContainerRequest)(nil), "stellar.services.runtime.v1.DeleteContainerRequest")
 	proto.RegisterType((*RestartContainerRequest)(nil), "stellar.services.runtime.v1.RestartContainerRequest")
+	proto.RegisterType((*PingRequest)(nil), "stellar.services.runtime.v1.PingRequest")
+	proto.RegisterType((*PingResponse)(nil), "stellar.services.runtime.v1.PingResponse")
+	proto.RegisterType((*SetBandwidthRequest)(nil), "stellar.services.runtime.v1.SetBandwidthRequest")
 }
 
 func init() {
@@ -1130,87 +1505,109 @@ func init() {
 }
 
 var fileDescriptor_7c551ea4b986781f = []byte{
-	// 1278 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xdd, 0x72, 0xdb, 0xc4,
-	0x17, 0xff, 0xdb, 0x56, 0xfc, 0x71, 0x54, 0x37, 0xfe, 0x6f, 0x43, 0x2a, 0xdc, 0x8b, 0x78, 0x34,
-	0xb4, 0x63, 0xd2, 0xa9, 0xdc, 0xb8, 0x0c, 0x03, 0x2d, 0x85, 0x69, 0xe2, 0x30, 0x18, 0x4a, 0xf0,
-	0x6c, 0x52, 0x18, 0x3e, 0x86, 0xa0, 0x48, 0x6b, 0x47, 0x44, 0xd6, 0x0a, 0xed, 0x3a, 0xc5, 0xcc,
-	0xf0, 0x10, 0xbc, 0x06, 0x17, 0x7d, 0x1a, 0xae, 0x7b, 0x91, 0x4b, 0x9e, 0x82, 0xd9, 0xd5, 0x4a,
-	0x56, 0x1c, 0xdb, 0x51, 0xe1, 0xee, 0x9c, 0xdd, 0xf3, 0xa5, 0x73, 0x7e, 0xe7, 0x9c, 0x15, 0x3c,
-	0x1b, 0x79, 0xfc, 0x74, 0x72, 0x62, 0x39, 0x74, 0xdc, 0x21, 0xa7, 0xf6, 0x6f, 0x3e, 0xe1, 0xbc,
-	0xc3, 0x38, 0xf1, 0x7d, 0x3b, 0xea, 0xd8, 0xa1, 0xd7, 0x61, 0x24, 0x3a, 0xf7, 0x1c, 0xc2, 0x3a,
-	0xd1, 0x24, 0xe0, 0xde, 0x98, 0x74, 0xce, 0x77, 0x12, 0xd2, 0x0a, 0x23, 0xca, 0x29, 0xba, 0xa3,
-	0xc4, 0xad, 0x44, 0xd4, 0x4a, 0xee, 0xcf, 0x77, 0x9a, 0x1b, 0x23, 0x3a, 0xa2, 0x52, 0xae, 0x23,
-	0xa8, 0x58, 0xa5, 0xf9, 0xf6, 0x88, 0xd2, 0x91, 0x4f, 0x3a, 0x92, 0x3b, 0x99, 0x0c, 0x3b, 0x76,
-	0x30, 0x55, 0x57, 0x77, 0xe6, 0xaf, 0xc8, 0x38, 0xe4, 0xea, 0xd2, 0xac, 0x83, 0xde, 0x0f, 0x86,
-	0x14, 0x93, 0x5f, 0x26, 0x84, 0x71, 0xf3, 0x1e, 0xdc, 0x88, 0x59, 0x16, 0xd2, 0x80, 0x11, 0xb4,
-	0x09, 0x45, 0xcf, 0x35, 0x0a, 0xad, 0x42, 0xbb, 0xb6, 0x5b, 0xbe, 0x78, 0xbd, 0x55, 0xec, 0xf7,
-	0x70, 0xd1, 0x73, 0xcd, 0x07, 0xf0, 0xff, 0x3d, 0x1a, 0x70, 0xdb, 0x0b, 0x48, 0xc4, 0x94, 0x32,
-	0x32, 0xa0, 0x32, 0xf4, 0x7c, 0x4e, 0x22, 0x66, 0x14, 0x5a, 0xa5, 0x76, 0x0d, 0x27, 0xac, 0xf9,
-	0x4a, 0x83, 0x5a, 0x2a, 0xbf, 0xcc, 0x28, 0xda, 0x80, 0x35, 0x6f, 0x6c, 0x8f, 0x88, 0x51, 0x14,
-	0x57, 0x38, 0x66, 0xd0, 0xe7, 0x50, 0xf6, 0xed, 0x13, 0xe2, 0x33, 0xa3, 0xd4, 0x2a, 0xb5, 0xf5,
-	0x6e, 0xd7, 0x5a, 0x91, 0x1d, 0x2b, 0xf5, 0x62, 0x3d, 0x97, 0x4a, 0xfb, 0x01, 0x8f, 0xa6, 0x58,
-	0x59, 0x40, 0x6d, 0xd0, 0x58, 0x48, 0x1c, 0x43, 0x6b, 0x15, 0xda, 0x7a, 0x77, 0xc3, 0x8a, 0x33,
-	0x63, 0x25, 0x99, 0xb1, 0x9e, 0x05, 0x53, 0x2c, 0x25, 0x50, 0x0b, 0x74, 0x16, 0xd8, 0x21, 0x3b,
-	0xa5, 0x9c, 0x93, 0xc8, 0x58, 0x93, 0x11, 0x65, 0x8f, 0xd0, 0x27, 0xa0, 0x71, 0x9b, 0x9d, 0x19,
-	0x65, 0x69, 0xeb, 0x7e, 0xce, 0xa8, 0x8e, 0x6c, 0x76, 0x86, 0xa5, 0xa2, 0x48, 0x97, 0x12, 0x31,
-	0x2a, 0xd2, 0x7c, 0xc2, 0xa2, 0xaf, 0x01, 0xc8, 0xaf, 0x9c, 0x04, 0xcc, 0xa3, 0x01, 0x33, 0xaa,
-	0xf2, 0xb3, 0xdf, 0xcf, 0xe9, 0x60, 0x3f, 0x55, 0x8c, 0x3f, 0x3d, 0x63, 0xa9, 0xf9, 0x21, 0xe8,
-	0x99, 0xac, 0xa0, 0x06, 0x94, 0xce, 0xc8, 0x34, 0x2e, 0x04, 0x16, 0xa4, 0xa8, 0xc0, 0xb9, 0xed,
-	0x4f, 0xd2, 0x0a, 0x48, 0xe6, 0x71, 0xf1, 0x83, 0x42, 0xd3, 0x00, 0x4d, 0x84, 0x2e, 0x74, 0x42,
-	0x55, 0xbc, 0x3a, 0x16, 0x64, 0xf3, 0x10, 0xd6, 0xe7, 0x7c, 0x2e, 0x30, 0xbc, 0x9d, 0x35, 0xbc,
-	0x2c, 0xf3, 0x33, 0x77, 0xe6, 0x0f, 0x80, 0xb2, 0xf8, 0x52, 0x68, 0xfc, 0x14, 0xc0, 0x49, 0x4f,
-	0x25, 0xc6, 0xf4, 0xee, 0xbd, 0x7c, 0x79, 0xc1, 0x19, 0x4d, 0x73, 0x1b, 0x1a, 0xb3, 0x0b, 0x05,
-	0xde, 0x65, 0x48, 0xff, 0x36, 0x83, 0xf4, 0x34, 0x90, 0x1e, 0xd4, 0x52, 0x73, 0x52, 0x27, 0x7f,
-	0x1c, 0x33, 0x45, 0x73, 0x1d, 0xea, 0x7d, 0x01, 0xf1, 0xa4, 0x81, 0xcc, 0x2d, 0x58, 0x93, 0x07,
-	0x4b, 0x83, 0x79, 0x0e, 0x37, 0x13, 0x0d, 0x15, 0xc9, 0x63, 0x28, 0xcb, 0x36, 0x49, 0xd2, 0x61,
-	0xae, 0x0c, 0x43, 0x2a, 0x63, 0xa5, 0x61, 0xfe, 0x0e, 0xb7, 0xd3, 0xb8, 0x0e, 0x08, 0x7f, 0x49,
-	0xa3, 0xb3, 0x6b, 0xb2, 0x21, 0xcf, 0xc3, 0x18, 0x1d, 0xea, 0x7c, 0x80, 0x8b, 0x5e, 0x28, 0xb0,
-	0x1c, 0xc4, 0x16, 0x8c, 0x52, 0x8c, 0x65, 0xc5, 0x8a, 0x9b, 0x91, 0xcd, 0xc9, 0x4b, 0x7b, 0x2a,
-	0xbb, 0xae, 0x86, 0x13, 0xd6, 0x3c, 0x84, 0xca, 0x20, 0xa2, 0x0e, 0x61, 0x4c, 0x00, 0x66, 0x32,
-	0x43, 0xd5, 0xc4, 0x73, 0xc5, 0xc9, 0xc8, 0x73, 0xa5, 0xa7, 0x3a, 0x16, 0x24, 0x42, 0xa0, 0xd9,
-	0xd1, 0x28, 0x9e, 0x02, 0x35, 0x2c, 0x69, 0x21, 0x45, 0x82, 0x73, 0x43, 0x93, 0x47, 0x82, 0x34,
-	0x29, 0xac, 0x7d, 0x49, 0x27, 0x01, 0x17, 0xe2, 0x7c, 0x1a, 0x12, 0x05, 0x42, 0x49, 0xa3, 0x4d,
-	0x28, 0x33, 0x3a, 0x89, 0x9c, 0x04, 0xdf, 0x8a, 0x13, 0xcd, 0xee, 0x12, 0xc6, 0xbd, 0xc0, 0xe6,
-	0x1e, 0x0d, 0x54, 0x9c, 0xd9, 0x23, 0xf1, 0x15, 0x34, 0xe4, 0xb2, 0x1d, 0xd7, 0xe2, 0xd1, 0xa6,
-	0x58, 0xf3, 0xaf, 0x22, 0x54, 0xf7, 0x03, 0x37, 0xa4, 0x5e, 0x20, 0x27, 0xa0, 0x4a, 0xbb, 0xf2,
-	0x9b, 0xb0, 0xe8, 0x19, 0x54, 0x25, 0xd6, 0x1d, 0xea, 0x4b, 0xe7, 0x37, 0xbb, 0x77, 0x57, 0x56,
-	0x6a, 0xa0, 0x84, 0x71, 0xaa, 0x26, 0xbe, 0xe8, 0x94, 0x32, 0xae, 0x12, 0x2c, 0x69, 0x71, 0x16,
-	0xd2, 0x88, 0xcb, 0x90, 0xeb, 0x58, 0xd2, 0xa8, 0x0f, 0x65, 0x87, 0x06, 0x43, 0x6f, 0x24, 0x43,
-	0xd5, 0xbb, 0x3b, 0x2b, 0x1d, 0x25, 0xb1, 0x0b, 0x88, 0x0e, 0xbd, 0x91, 0x9a, 0x97, 0xb1, 0x01,
-	0xf4, 0x14, 0xd6, 0x89, 0xba, 0x3f, 0x56, 0x36, 0xcb, 0x2b, 0x1a, 0xf8, 0x66, 0x22, 0x1c, 0xdb,
-	0x12, 0xf3, 0x26, 0x63, 0xf5, 0x4d, 0xe6, 0x8d, 0xf9, 0x77, 0x01, 0x6e, 0x0d, 0x7c, 0xdb, 0x21,
-	0x63, 0x12, 0xf0, 0x41, 0x44, 0x86, 0x24, 0x22, 0x81, 0x43, 0xd0, 0x3d, 0xa8, 0x06, 0xd4, 0x25,
-	0xc7, 0x9e, 0xab, 0x96, 0xcc, 0xae, 0x7e, 0xf1, 0x7a, 0xab, 0x72, 0x40, 0x5d, 0xd2, 0xef, 0x31,
-	0x5c, 0x11, 0x97, 0x7d, 0x97, 0xa1, 0xa3, 0x74, 0x6b, 0x14, 0x65, 0x12, 0x3e, 0x5a, 0x9d, 0xed,
-	0xab, 0x9e, 0x16, 0xee, 0x8f, 0x26, 0x54, 0x23, 0x12, 0xfa, 0x9e, 0x63, 0x33, 0x59, 0x06, 0x0d,
-	0xa7, 0xfc, 0x7f, 0x18, 0xae, 0xe6, 0x1f, 0x1a, 0x54, 0x0e, 0x15, 0x50, 0x10, 0x68, 0x81, 0x3d,
-	0x4e, 0x71, 0x2b, 0xe8, 0x25, 0x8b, 0x31, 0xb3, 0x3f, 0x4a, 0x97, 0xf7, 0xc7, 0xdc, 0xf2, 0xd2,
-	0xae, 0x2e, 0x2f, 0xe1, 0x85, 0xba, 0x44, 0xed, 0x35, 0x49, 0xa3, 0x8f, 0xa1, 0x12, 0xc6, 0xfd,
-	0xa8, 0x8a, 0xfc, 0xce, 0x75, 0x08, 0x15, 0xb2, 0x38, 0x51, 0x12, 0xdd, 0xa5, 0x52, 0x5e, 0x91,
-	0x2d, 0x92, 0x24, 0x2d, 0x33, 0x1b, 0xaa, 0xad, 0x42, 0xbb, 0x3a, 0x9b, 0x0d, 0x8f, 0xa1, 0x3c,
-	0x16, 0xcd, 0xca, 0x8c, 0x5a, 0x8e, 0xe1, 0x25, 0xfb, 0x1a, 0x2b, 0x0d, 0xb4, 0x07, 0xb5, 0x04,
-	0x6d, 0xcc, 0x00, 0xa9, 0x7e, 0x37, 0x17, 0xd0, 0xf1, 0x4c, 0xef, 0x52, 0x3d, 0xf5, 0xcb, 0xf5,
-	0x44, 0x0e, 0x6c, 0x84, 0x09, 0x2c, 0x8e, 0xc3, 0x14, 0x17, 0xc6, 0x0d, 0x99, 0x9b, 0x87, 0x6f,
-	0x8a, 0x27, 0x7c, 0x2b, 0x5c, 0x00, 0x67, 0x51, 0x43, 0xc2, 0xb8, 0x1d, 0x71, 0xa3, 0x1e, 0xe7,
-	0x46, 0xb1, 0xe6, 0xab, 0x02, 0x6c, 0xee, 0x45, 0xc4, 0xe6, 0xe4, 0xca, 0xaa, 0x6a, 0x81, 0x6e,
-	0x87, 0x32, 0x4a, 0x39, 0xae, 0x62, 0xa4, 0x64, 0x8f, 0x44, 0x29, 0x93, 0x39, 0x54, 0xcc, 0x51,
-	0x4a, 0x85, 0xbd, 0xd9, 0xb4, 0xea, 0xc2, 0x8d, 0x74, 0x4d, 0x1d, 0x7b, 0x6e, 0x8c, 0xaf, 0xdd,
-	0xf5, 0x8b, 0xd7, 0x5b, 0x7a, 0x1a, 0x4d, 0xbf, 0x87, 0xf5, 0x54, 0xa8, 0xef, 0x9a, 0x0f, 0x61,
-	0xb3, 0x47, 0x7c, 0xb2, 0x20, 0xde, 0x65, 0xdb, 0x6c, 0x07, 0x6e, 0xe3, 0xf8, 0x6b, 0xf3, 0xaa,
-	0x6c, 0x3f, 0x82, 0x6a, 0x32, 0x19, 0x91, 0x0e, 0x95, 0x17, 0x07, 0x5f, 0x1c, 0x7c, 0xf5, 0xcd,
-	0x41, 0xe3, 0x7f, 0xa8, 0x02, 0xa5, 0xa3, 0xbd, 0x41, 0xa3, 0x20, 0x88, 0x17, 0xbd, 0x41, 0xa3,
-	0x88, 0xaa, 0xa0, 0x7d, 0x76, 0x74, 0x34, 0x68, 0x94, 0xba, 0x7f, 0x96, 0x41, 0x13, 0x03, 0x02,
-	0x7d, 0x0f, 0x9a, 0x78, 0xdd, 0xa2, 0xf6, 0xea, 0x25, 0x39, 0x7b, 0x0f, 0x37, 0xdf, 0xcd, 0x21,
-	0xa9, 0x36, 0xf1, 0x18, 0x60, 0xf6, 0x64, 0x41, 0x56, 0xbe, 0xe7, 0x40, 0xb2, 0xfa, 0x9b, 0x9d,
-	0xdc, 0xf2, 0xca, 0xdd, 0xcf, 0xd9, 0x17, 0xf5, 0x83, 0x9c, 0x8f, 0x0f, 0xe5, 0xcc, 0xca, 0x2b,
-	0xae, 0x7c, 0xd9, 0x50, 0x8e, 0x9f, 0x1d, 0x68, 0xfb, 0xfa, 0xe7, 0x45, 0xfa, 0x49, 0xf7, 0x73,
-	0xc9, 0x2a, 0x17, 0x04, 0xde, 0x3a, 0x24, 0x7c, 0x12, 0xce, 0x3f, 0x48, 0xd0, 0x7b, 0xf9, 0x62,
-	0xbd, 0xfc, 0x7e, 0x69, 0x6e, 0x5e, 0xd9, 0x4f, 0xfb, 0xe2, 0xa7, 0x07, 0xfd, 0x08, 0xeb, 0x73,
-	0x4d, 0x85, 0x1e, 0xad, 0x76, 0xb0, 0xb0, 0x05, 0x57, 0xd9, 0x9f, 0x6b, 0x82, 0x6b, 0xec, 0x2f,
-	0x6e, 0x99, 0xa5, 0xf6, 0x7f, 0x82, 0xc6, 0x7c, 0xcb, 0x5c, 0x93, 0xa1, 0x25, 0x1d, 0xb6, 0xcc,
-	0xc3, 0xee, 0xd3, 0xef, 0x9e, 0xfc, 0x8b, 0x1f, 0xd8, 0x27, 0x8a, 0x3c, 0x29, 0x4b, 0x73, 0x8f,
-	0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0xeb, 0x55, 0xe0, 0xd1, 0x06, 0x0f, 0x00, 0x00,
+	// 1618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x5b, 0x73, 0xdb, 0x36,
+	0x16, 0x5e, 0x5d, 0xac, 0xcb, 0x91, 0x2f, 0x0a, 0xec, 0x75, 0x18, 0xe5, 0xc1, 0x1a, 0xee, 0x26,
+	0xa3, 0x38, 0x13, 0x29, 0x56, 0x76, 0x76, 0xb7, 0x49, 0xd3, 0x4e, 0x7c, 0xe9, 0x54, 0x6d, 0xe2,
+	0x6a, 0x60, 0xa7, 0x97, 0xa4, 0x53, 0x95, 0x26, 0x61, 0x19, 0xb5, 0x44, 0xb0, 0x04, 0xe8, 0x44,
+	0x9d, 0xe9, 0x5f, 0xca, 0x4f, 0xf2, 0x4c, 0xfd, 0xd8, 0xb7, 0xfc, 0x83, 0x0e, 0x40, 0x90, 0xa2,
+	0x65, 0x4b, 0x62, 0xd2, 0x27, 0xe1, 0x00, 0xe7, 0x86, 0x83, 0xef, 0x5c, 0x28, 0x78, 0xd6, 0xa7,
+	0xe2, 0x24, 0x38, 0x6a, 0xda, 0x6c, 0xd8, 0x22, 0x27, 0xd6, 0x6f, 0x03, 0x22, 0x44, 0x8b, 0x0b,
+	0x32, 0x18, 0x58, 0x7e, 0xcb, 0xf2, 0x68, 0x8b, 0x13, 0xff, 0x8c, 0xda, 0x84, 0xb7, 0xfc, 0xc0,
+	0x15, 0x74, 0x48, 0x5a, 0x67, 0x5b, 0xd1, 0xb2, 0xe9, 0xf9, 0x4c, 0x30, 0x74, 0x5b, 0xb3, 0x37,
+	0x23, 0xd6, 0x66, 0x74, 0x7e, 0xb6, 0x55, 0x5b, 0xeb, 0xb3, 0x3e, 0x53, 0x7c, 0x2d, 0xb9, 0x0a,
+	0x45, 0x6a, 0xb7, 0xfa, 0x8c, 0xf5, 0x07, 0xa4, 0xa5, 0xa8, 0xa3, 0xe0, 0xb8, 0x65, 0xb9, 0x23,
+	0x7d, 0x74, 0x7b, 0xf2, 0x88, 0x0c, 0x3d, 0xa1, 0x0f, 0xcd, 0x25, 0xa8, 0x74, 0xdc, 0x63, 0x86,
+	0xc9, 0xaf, 0x01, 0xe1, 0xc2, 0xbc, 0x0b, 0x8b, 0x21, 0xc9, 0x3d, 0xe6, 0x72, 0x82, 0xd6, 0x21,
+	0x4b, 0x1d, 0x23, 0x53, 0xcf, 0x34, 0xca, 0xdb, 0x85, 0x8b, 0xf3, 0x8d, 0x6c, 0x67, 0x17, 0x67,
+	0xa9, 0x63, 0x3e, 0x80, 0x1b, 0x3b, 0xcc, 0x15, 0x16, 0x75, 0x89, 0xcf, 0xb5, 0x30, 0x32, 0xa0,
+	0x78, 0x4c, 0x07, 0x82, 0xf8, 0xdc, 0xc8, 0xd4, 0x73, 0x8d, 0x32, 0x8e, 0x48, 0xf3, 0x5d, 0x1e,
+	0xca, 0x31, 0xff, 0x34, 0xa5, 0x68, 0x0d, 0x16, 0xe8, 0xd0, 0xea, 0x13, 0x23, 0x2b, 0x8f, 0x70,
+	0x48, 0xa0, 0xaf, 0xa0, 0x30, 0xb0, 0x8e, 0xc8, 0x80, 0x1b, 0xb9, 0x7a, 0xae, 0x51, 0x69, 0xb7,
+	0x9b, 0x33, 0xa2, 0xd3, 0x8c, 0xad, 0x34, 0x9f, 0x2b, 0xa1, 0x3d, 0x57, 0xf8, 0x23, 0xac, 0x35,
+	0xa0, 0x06, 0xe4, 0xb9, 0x47, 0x6c, 0x23, 0x5f, 0xcf, 0x34, 0x2a, 0xed, 0xb5, 0x66, 0x18, 0x99,
+	0x66, 0x14, 0x99, 0xe6, 0x33, 0x77, 0x84, 0x15, 0x07, 0xaa, 0x43, 0x85, 0xbb, 0x96, 0xc7, 0x4f,
+	0x98, 0x10, 0xc4, 0x37, 0x16, 0x94, 0x47, 0xc9, 0x2d, 0xf4, 0x39, 0xe4, 0x85, 0xc5, 0x4f, 0x8d,
+	0x82, 0xd2, 0x75, 0x3f, 0xa5, 0x57, 0x87, 0x16, 0x3f, 0xc5, 0x4a, 0x50, 0x86, 0x4b, 0xb3, 0x18,
+	0x45, 0xa5, 0x3e, 0x22, 0xd1, 0xb7, 0x00, 0xe4, 0xad, 0x20, 0x2e, 0xa7, 0xcc, 0xe5, 0x46, 0x49,
+	0x5d, 0xfb, 0xbf, 0x29, 0x0d, 0xec, 0xc5, 0x82, 0xe1, 0xd5, 0x13, 0x9a, 0x6a, 0x9f, 0x40, 0x25,
+	0x11, 0x15, 0x54, 0x85, 0xdc, 0x29, 0x19, 0x85, 0x0f, 0x81, 0xe5, 0x52, 0xbe, 0xc0, 0x99, 0x35,
+	0x08, 0xe2, 0x17, 0x50, 0xc4, 0xe3, 0xec, 0xff, 0x33, 0x35, 0x03, 0xf2, 0xd2, 0x75, 0x29, 0xe3,
+	0xe9, 0xc7, 0x5b, 0xc2, 0x72, 0x59, 0x3b, 0x80, 0x95, 0x09, 0x9b, 0xd7, 0x28, 0xde, 0x4c, 0x2a,
+	0x9e, 0x16, 0xf9, 0xb1, 0x39, 0xf3, 0x47, 0x40, 0x49, 0x7c, 0x69, 0x34, 0x7e, 0x01, 0x60, 0xc7,
+	0xbb, 0x0a, 0x63, 0x95, 0xf6, 0xdd, 0x74, 0x71, 0xc1, 0x09, 0x49, 0x73, 0x13, 0xaa, 0xe3, 0x03,
+	0x0d, 0xde, 0x69, 0x48, 0xff, 0x21, 0x81, 0xf4, 0xd8, 0x91, 0x5d, 0x28, 0xc7, 0xea, 0x94, 0x4c,
+	0x7a, 0x3f, 0xc6, 0x82, 0xe6, 0x0a, 0x2c, 0x75, 0x24, 0xc4, 0xa3, 0x04, 0x32, 0x37, 0x60, 0x41,
+	0x6d, 0x4c, 0x75, 0xe6, 0x39, 0x2c, 0x47, 0x12, 0xda, 0x93, 0xc7, 0x50, 0x50, 0x69, 0x12, 0x85,
+	0xc3, 0x9c, 0xe9, 0x86, 0x12, 0xc6, 0x5a, 0xc2, 0xfc, 0x1d, 0x6e, 0xc6, 0x7e, 0xed, 0x13, 0xf1,
+	0x86, 0xf9, 0xa7, 0x73, 0xa2, 0xa1, 0xf6, 0x3d, 0x23, 0x9b, 0xd8, 0xef, 0xe2, 0x2c, 0xf5, 0x24,
+	0x96, 0xdd, 0x50, 0x83, 0x91, 0x0b, 0xb1, 0xac, 0x49, 0x79, 0xd2, 0xb7, 0x04, 0x79, 0x63, 0x8d,
+	0x54, 0xd6, 0x95, 0x71, 0x44, 0x9a, 0x07, 0x50, 0xec, 0xfa, 0xcc, 0x26, 0x9c, 0x4b, 0xc0, 0x04,
+	0x63, 0x54, 0x05, 0xd4, 0x91, 0x3b, 0x7d, 0xea, 0x28, 0x4b, 0x4b, 0x58, 0x2e, 0x11, 0x82, 0xbc,
+	0xe5, 0xf7, 0xc3, 0x2a, 0x50, 0xc6, 0x6a, 0x2d, 0xb9, 0x88, 0x7b, 0x66, 0xe4, 0xd5, 0x96, 0x5c,
+	0x9a, 0x0c, 0x16, 0x5e, 0xb0, 0xc0, 0x15, 0x92, 0x5d, 0x8c, 0x3c, 0xa2, 0x41, 0xa8, 0xd6, 0x68,
+	0x1d, 0x0a, 0x9c, 0x05, 0xbe, 0x1d, 0xe1, 0x5b, 0x53, 0x32, 0xd9, 0x1d, 0xc2, 0x05, 0x75, 0x2d,
+	0x41, 0x99, 0xab, 0xfd, 0x4c, 0x6e, 0xc9, 0x5b, 0x30, 0x4f, 0xa8, 0x74, 0x5c, 0x08, 0x4b, 0x9b,
+	0x26, 0xcd, 0xf7, 0x59, 0x28, 0xed, 0xb9, 0x8e, 0xc7, 0xa8, 0xab, 0x2a, 0xa0, 0x0e, 0xbb, 0xb6,
+	0x1b, 0x91, 0xe8, 0x19, 0x94, 0x14, 0xd6, 0x6d, 0x36, 0x50, 0xc6, 0x97, 0xdb, 0x77, 0x66, 0xbe,
+	0x54, 0x57, 0x33, 0xe3, 0x58, 0x4c, 0xde, 0xe8, 0x84, 0x71, 0xa1, 0x03, 0xac, 0xd6, 0x72, 0xcf,
+	0x63, 0xbe, 0x50, 0x2e, 0x2f, 0x61, 0xb5, 0x46, 0x1d, 0x28, 0xd8, 0xcc, 0x3d, 0xa6, 0x7d, 0xe5,
+	0x6a, 0xa5, 0xbd, 0x35, 0xd3, 0x50, 0xe4, 0xbb, 0x84, 0xe8, 0x31, 0xed, 0xeb, 0x7a, 0x19, 0x2a,
+	0x40, 0x4f, 0x61, 0x85, 0xe8, 0xf3, 0x9e, 0xd6, 0x59, 0x98, 0x91, 0xc0, 0xcb, 0x11, 0x73, 0xa8,
+	0x0b, 0xdd, 0x86, 0xb2, 0xf4, 0xb2, 0xa7, 0x5c, 0x2c, 0x2a, 0x17, 0x4b, 0x72, 0xa3, 0xcb, 0x7c,
+	0x21, 0x8b, 0x51, 0xc2, 0xe4, 0x87, 0x14, 0x23, 0xf3, 0xcf, 0x0c, 0xac, 0x76, 0x07, 0x96, 0x4d,
+	0x86, 0xc4, 0x15, 0x5d, 0x9f, 0x1c, 0x13, 0x9f, 0xb8, 0x36, 0x41, 0x77, 0xa1, 0xe4, 0x32, 0x87,
+	0xf4, 0xa8, 0xa3, 0x3b, 0xd0, 0x76, 0xe5, 0xe2, 0x7c, 0xa3, 0xb8, 0xcf, 0x1c, 0xd2, 0xd9, 0xe5,
+	0xb8, 0x28, 0x0f, 0x3b, 0x0e, 0x47, 0x87, 0x71, 0x4b, 0xc9, 0xaa, 0x08, 0x7d, 0x3a, 0xfb, 0x29,
+	0xae, 0x5a, 0xba, 0xb6, 0xb9, 0xd4, 0xa0, 0xe4, 0x13, 0x6f, 0x40, 0x6d, 0x8b, 0xab, 0x37, 0xca,
+	0xe3, 0x98, 0xfe, 0x1b, 0x95, 0xd7, 0x7c, 0x5f, 0x80, 0xe2, 0x81, 0x46, 0x11, 0x82, 0xbc, 0x6b,
+	0x0d, 0x63, 0x50, 0xcb, 0xf5, 0x94, 0xae, 0x99, 0x68, 0x2e, 0xb9, 0xcb, 0xcd, 0x65, 0xa2, 0xb3,
+	0xe5, 0xaf, 0x76, 0x36, 0x69, 0x85, 0x39, 0x44, 0x37, 0x3d, 0xb5, 0x46, 0x9f, 0x41, 0xd1, 0x0b,
+	0x93, 0x55, 0x23, 0xe0, 0xdf, 0xf3, 0xe0, 0x2b, 0x79, 0x71, 0x24, 0x24, 0x53, 0x4f, 0x87, 0xbc,
+	0xa8, 0xf2, 0x47, 0x53, 0xc9, 0xc2, 0x51, 0xaa, 0x67, 0x1a, 0xa5, 0x71, 0xe1, 0x78, 0x0c, 0x85,
+	0xa1, 0xcc, 0x64, 0x6e, 0x94, 0x53, 0x54, 0x36, 0x95, 0xf4, 0x58, 0x4b, 0xa0, 0x1d, 0x28, 0x47,
+	0x50, 0xe4, 0x06, 0x28, 0xf1, 0x3b, 0xa9, 0xb2, 0x00, 0x8f, 0xe5, 0x2e, 0xbd, 0x67, 0xe5, 0xf2,
+	0x7b, 0x22, 0x1b, 0xd6, 0xbc, 0x08, 0x16, 0x3d, 0x2f, 0xc6, 0x85, 0xb1, 0xa8, 0x62, 0xf3, 0xf0,
+	0x43, 0xf1, 0x84, 0x57, 0xbd, 0xab, 0x9b, 0xea, 0x0d, 0x09, 0x17, 0x96, 0x2f, 0x8c, 0xa5, 0x30,
+	0x36, 0x9a, 0x94, 0xae, 0x79, 0x3e, 0x65, 0x3e, 0x15, 0x23, 0x63, 0xb9, 0x9e, 0x69, 0x2c, 0xe0,
+	0x98, 0x96, 0xbd, 0xc9, 0x27, 0x61, 0x61, 0xe3, 0xc6, 0x4a, 0x8a, 0xde, 0x84, 0x23, 0x6e, 0x3c,
+	0x16, 0x44, 0xaf, 0xe0, 0x86, 0x43, 0xb9, 0x1f, 0xa8, 0x2a, 0xd7, 0x3b, 0x0a, 0x9c, 0x3e, 0x11,
+	0x46, 0x55, 0x69, 0x7b, 0x30, 0x53, 0xdb, 0x6e, 0x2c, 0xb5, 0xad, 0x84, 0x70, 0xd5, 0x99, 0xd8,
+	0x91, 0xde, 0xeb, 0x47, 0xe6, 0xc6, 0x0d, 0x85, 0x86, 0x98, 0x46, 0xb7, 0x20, 0x47, 0x3d, 0x6e,
+	0x20, 0x95, 0xbd, 0xc5, 0x8b, 0xf3, 0x8d, 0x5c, 0xa7, 0xcb, 0xb1, 0xdc, 0x43, 0xff, 0x82, 0x22,
+	0xf5, 0x7a, 0x1e, 0x63, 0x03, 0x63, 0x55, 0x35, 0x20, 0xb8, 0x38, 0xdf, 0x28, 0x74, 0xba, 0x5d,
+	0xc6, 0x06, 0xb8, 0x40, 0x3d, 0xf9, 0x2b, 0x6f, 0x7f, 0x64, 0xb9, 0xce, 0x1b, 0xea, 0x88, 0x13,
+	0x63, 0x2d, 0xc5, 0xed, 0xb7, 0x23, 0x6e, 0x3c, 0x16, 0x34, 0x5b, 0x50, 0x9d, 0xbc, 0x87, 0x2c,
+	0x66, 0x43, 0xeb, 0x6d, 0x6f, 0xc8, 0xce, 0x54, 0xb3, 0x55, 0x78, 0x18, 0x5a, 0x6f, 0x5f, 0x48,
+	0xda, 0xfc, 0x1f, 0x94, 0xe3, 0x30, 0xca, 0xfc, 0xb1, 0xbd, 0x20, 0x64, 0xca, 0x60, 0xb5, 0x96,
+	0xf8, 0x1f, 0x92, 0x21, 0xf3, 0x47, 0x2a, 0x4d, 0x73, 0x58, 0x53, 0xe6, 0x53, 0x28, 0xc7, 0x1e,
+	0xc8, 0x07, 0xa7, 0x6e, 0xdf, 0x27, 0x3c, 0x94, 0xcd, 0xe3, 0x88, 0x94, 0xe2, 0x24, 0x3c, 0xc8,
+	0xaa, 0x03, 0x4d, 0x99, 0xef, 0x32, 0xb0, 0xbe, 0xe3, 0x13, 0x4b, 0x90, 0x2b, 0x03, 0x4d, 0x1d,
+	0x2a, 0x96, 0xa7, 0xe0, 0xaa, 0x9a, 0x5a, 0x58, 0x32, 0x92, 0x5b, 0x32, 0xa7, 0xa3, 0x6e, 0x95,
+	0x4d, 0x91, 0xd3, 0xba, 0x08, 0x8d, 0x7b, 0x5a, 0x1b, 0x16, 0xe3, 0x61, 0xa6, 0x47, 0x9d, 0xb0,
+	0xd0, 0x6c, 0xaf, 0x5c, 0x9c, 0x6f, 0x54, 0x62, 0x6f, 0x3a, 0xbb, 0xb8, 0x12, 0x33, 0x75, 0x1c,
+	0xf3, 0x21, 0xac, 0xef, 0x92, 0x01, 0xb9, 0xc6, 0xdf, 0x69, 0x33, 0xcf, 0x16, 0xdc, 0xc4, 0x21,
+	0xec, 0x53, 0x8b, 0x1c, 0x40, 0xa5, 0x4b, 0xdd, 0xfe, 0xfc, 0x61, 0xa6, 0x20, 0x2c, 0x5f, 0x02,
+	0x5b, 0x8f, 0x03, 0x21, 0x25, 0x2b, 0xaa, 0x2d, 0xeb, 0x88, 0xba, 0xd0, 0x12, 0x0e, 0x09, 0xd3,
+	0x81, 0xc5, 0x50, 0xa9, 0x9e, 0xbc, 0xea, 0x50, 0x11, 0xbe, 0xe5, 0xf2, 0x21, 0x15, 0x82, 0x44,
+	0xb3, 0x4b, 0x72, 0x2b, 0x2c, 0x20, 0x36, 0xa1, 0x67, 0x24, 0x1a, 0x64, 0x62, 0x5a, 0xda, 0x66,
+	0x81, 0xf0, 0x82, 0xa8, 0x9d, 0x6b, 0xca, 0xe4, 0xb0, 0x7a, 0x40, 0xc4, 0x18, 0x94, 0x73, 0xae,
+	0x70, 0x09, 0xee, 0xd9, 0x8f, 0x84, 0xfb, 0xe6, 0x23, 0x28, 0x45, 0xf3, 0x06, 0xaa, 0x40, 0xf1,
+	0xe5, 0xfe, 0xd7, 0xfb, 0xdf, 0x7c, 0xb7, 0x5f, 0xfd, 0x07, 0x2a, 0x42, 0xee, 0x70, 0xa7, 0x5b,
+	0xcd, 0xc8, 0xc5, 0xcb, 0xdd, 0x6e, 0x35, 0x8b, 0x4a, 0x90, 0xff, 0xf2, 0xf0, 0xb0, 0x5b, 0xcd,
+	0xb5, 0xff, 0x28, 0x42, 0x5e, 0x76, 0x56, 0xf4, 0x1a, 0xf2, 0xf2, 0x9b, 0x11, 0x35, 0x66, 0x8f,
+	0x9e, 0xe3, 0xaf, 0xcc, 0xda, 0xbd, 0x14, 0x9c, 0x3a, 0xca, 0x43, 0x80, 0xf1, 0x87, 0x00, 0x6a,
+	0xa6, 0x1b, 0xb2, 0xa3, 0x81, 0xba, 0xd6, 0x4a, 0xcd, 0xaf, 0xcd, 0xfd, 0x92, 0xfc, 0x4e, 0x7d,
+	0x90, 0x4e, 0x3a, 0x32, 0xd6, 0x4c, 0xcb, 0xae, 0x6d, 0x59, 0x50, 0x08, 0x87, 0x79, 0xb4, 0x39,
+	0x7f, 0x68, 0x8f, 0xaf, 0x74, 0x3f, 0x15, 0xaf, 0x36, 0x41, 0xe0, 0x9f, 0x07, 0x44, 0x04, 0xde,
+	0xe4, 0x98, 0x8f, 0xfe, 0x93, 0xce, 0xd7, 0xcb, 0x5f, 0x05, 0xb5, 0xf5, 0x2b, 0x53, 0xdf, 0x9e,
+	0xfc, 0x2b, 0x01, 0xfd, 0x04, 0x2b, 0x13, 0x45, 0x08, 0x3d, 0x9a, 0x6d, 0xe0, 0xda, 0x92, 0x35,
+	0x4b, 0xff, 0x44, 0xd1, 0x98, 0xa3, 0xff, 0xfa, 0x12, 0x33, 0x55, 0xff, 0xcf, 0x50, 0x9d, 0x2c,
+	0x31, 0x73, 0x22, 0x34, 0xa5, 0x22, 0x4d, 0xb5, 0xf0, 0x1a, 0xf2, 0xb2, 0x78, 0xcc, 0xc9, 0x91,
+	0x44, 0xd1, 0xaa, 0xdd, 0x4b, 0xc1, 0xa9, 0x5f, 0xf9, 0x7b, 0x58, 0x4c, 0xd6, 0x0c, 0xf4, 0x70,
+	0x4e, 0x19, 0xbf, 0x52, 0x5e, 0xa6, 0xb9, 0xbd, 0xfd, 0xf4, 0xd5, 0x93, 0x8f, 0xf8, 0x37, 0xeb,
+	0x89, 0x5e, 0x1e, 0x15, 0x94, 0xba, 0x47, 0x7f, 0x0d, 0x00, 0xbd, 0xd7, 0x14, 0x66, 0x13, 0x13,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1233,6 +1630,8 @@ type NodeClient interface {
 	CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	DeleteContainer(ctx context.Context, in *DeleteContainerRequest, opts ...grpc.CallOption) (*types.Empty, error)
 	RestartContainer(ctx context.Context, in *RestartContainerRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	SetBandwidth(ctx context.Context, in *SetBandwidthRequest, opts ...grpc.CallOption) (*types.Empty, error)
 }
 
 type nodeClient struct {
@@ -1315,6 +1714,24 @@ func (c *nodeClient) RestartContainer(ctx context.Context, in *RestartContainerR
 	return out, nil
 }
 
+func (c *nodeClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/stellar.services.runtime.v1.Node/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SetBandwidth(ctx context.Context, in *SetBandwidthRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/stellar.services.runtime.v1.Node/SetBandwidth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServer is the server API for Node service.
 type NodeServer interface {
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
@@ -1325,6 +1742,8 @@ type NodeServer interface {
 	CreateContainer(context.Context, *CreateContainerRequest) (*types.Empty, error)
 	DeleteContainer(context.Context, *DeleteContainerRequest) (*types.Empty, error)
 	RestartContainer(context.Context, *RestartContainerRequest) (*types.Empty, error)
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	SetBandwidth(context.Context, *SetBandwidthRequest) (*types.Empty, error)
 }
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
@@ -1475,6 +1894,42 @@ func _Node_RestartContainer_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.runtime.v1.Node/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SetBandwidth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBandwidthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).SetBandwidth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stellar.services.runtime.v1.Node/SetBandwidth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).SetBandwidth(ctx, req.(*SetBandwidthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "stellar.services.runtime.v1.Node",
 	HandlerType: (*NodeServer)(nil),
@@ -1511,6 +1966,14 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RestartContainer",
 			Handler:    _Node_RestartContainer_Handler,
 		},
+		{
+			MethodName: "Ping",
+			Handler:    _Node_Ping_Handler,
+		},
+		{
+			MethodName: "SetBandwidth",
+			Handler:    _Node_SetBandwidth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/ehazlett/stellar/api/services/runtime/v1/runtime.proto",
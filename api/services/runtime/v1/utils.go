@@ -25,6 +25,8 @@ func (m *Endpoint) UnmarshalJSON(data []byte) error {
 			m.Host = v.(string)
 		case "port":
 			m.Port = uint32(v.(float64))
+		case "host_port":
+			m.HostPort = uint32(v.(float64))
 		case "protocol":
 			p, err := parseProtocol(v)
 			if err != nil {
@@ -26,11 +26,21 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type ScheduleRequest struct {
-	Service              *v1.Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	AvailableNodes       []*v11.Node `protobuf:"bytes,2,rep,name=available_nodes,json=availableNodes,proto3" json:"available_nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Service        *v1.Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	AvailableNodes []*v11.Node `protobuf:"bytes,2,rep,name=available_nodes,json=availableNodes,proto3" json:"available_nodes,omitempty"`
+	// dry_run returns the filter decisions along with the placements
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// node_health overrides the node capacity reported by the cluster
+	NodeHealth []*v11.NodeHealth `protobuf:"bytes,4,rep,name=node_health,json=nodeHealth,proto3" json:"node_health,omitempty"`
+	// exclude_container_ids are running replicas that do not reserve
+	// node capacity or host ports when placing the service
+	ExcludeContainerIDs []string `protobuf:"bytes,5,rep,name=exclude_container_ids,json=excludeContainerIds,proto3" json:"exclude_container_ids,omitempty"`
+	// reservations hold node capacity for replicas that are planned
+	// but not yet running
+	Reservations         []*Reservation `protobuf:"bytes,6,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ScheduleRequest) Reset()         { *m = ScheduleRequest{} }
@@ -71,18 +81,95 @@ func (m *ScheduleRequest) GetAvailableNodes() []*v11.Node {
 	return nil
 }
 
+func (m *ScheduleRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ScheduleRequest) GetNodeHealth() []*v11.NodeHealth {
+	if m != nil {
+		return m.NodeHealth
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetExcludeContainerIDs() []string {
+	if m != nil {
+		return m.ExcludeContainerIDs
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetReservations() []*Reservation {
+	if m != nil {
+		return m.Reservations
+	}
+	return nil
+}
+
+// Reservation is node capacity held for a replica that is not yet running
+type Reservation struct {
+	NodeID               string        `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Resources            *v1.Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Reservation) Reset()         { *m = Reservation{} }
+func (m *Reservation) String() string { return proto.CompactTextString(m) }
+func (*Reservation) ProtoMessage()    {}
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5bf2633cdf3b52d, []int{1}
+}
+func (m *Reservation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reservation.Unmarshal(m, b)
+}
+func (m *Reservation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reservation.Marshal(b, m, deterministic)
+}
+func (m *Reservation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reservation.Merge(m, src)
+}
+func (m *Reservation) XXX_Size() int {
+	return xxx_messageInfo_Reservation.Size(m)
+}
+func (m *Reservation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reservation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reservation proto.InternalMessageInfo
+
+func (m *Reservation) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *Reservation) GetResources() *v1.Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
 type ScheduleResponse struct {
-	Nodes                []*v11.Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Nodes                []*v11.Node     `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Eliminated           []*FilterResult `protobuf:"bytes,2,rep,name=eliminated,proto3" json:"eliminated,omitempty"`
+	Preemptions          []*Preemption   `protobuf:"bytes,3,rep,name=preemptions,proto3" json:"preemptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *ScheduleResponse) Reset()         { *m = ScheduleResponse{} }
 func (m *ScheduleResponse) String() string { return proto.CompactTextString(m) }
 func (*ScheduleResponse) ProtoMessage()    {}
 func (*ScheduleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5bf2633cdf3b52d, []int{1}
+	return fileDescriptor_b5bf2633cdf3b52d, []int{2}
 }
 func (m *ScheduleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ScheduleResponse.Unmarshal(m, b)
@@ -109,9 +196,153 @@ func (m *ScheduleResponse) GetNodes() []*v11.Node {
 	return nil
 }
 
+func (m *ScheduleResponse) GetEliminated() []*FilterResult {
+	if m != nil {
+		return m.Eliminated
+	}
+	return nil
+}
+
+func (m *ScheduleResponse) GetPreemptions() []*Preemption {
+	if m != nil {
+		return m.Preemptions
+	}
+	return nil
+}
+
+// Preemption is a lower priority replica that must be evicted to place the service
+type Preemption struct {
+	ContainerID string      `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Application string      `protobuf:"bytes,2,opt,name=application,proto3" json:"application,omitempty"`
+	Service     *v1.Service `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	NodeID      string      `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// target_node_id is the node the evicted replica is rescheduled to; empty if
+	// the replica could not be placed elsewhere
+	TargetNodeID         string   `protobuf:"bytes,5,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Preemption) Reset()         { *m = Preemption{} }
+func (m *Preemption) String() string { return proto.CompactTextString(m) }
+func (*Preemption) ProtoMessage()    {}
+func (*Preemption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5bf2633cdf3b52d, []int{3}
+}
+func (m *Preemption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Preemption.Unmarshal(m, b)
+}
+func (m *Preemption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Preemption.Marshal(b, m, deterministic)
+}
+func (m *Preemption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Preemption.Merge(m, src)
+}
+func (m *Preemption) XXX_Size() int {
+	return xxx_messageInfo_Preemption.Size(m)
+}
+func (m *Preemption) XXX_DiscardUnknown() {
+	xxx_messageInfo_Preemption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Preemption proto.InternalMessageInfo
+
+func (m *Preemption) GetContainerID() string {
+	if m != nil {
+		return m.ContainerID
+	}
+	return ""
+}
+
+func (m *Preemption) GetApplication() string {
+	if m != nil {
+		return m.Application
+	}
+	return ""
+}
+
+func (m *Preemption) GetService() *v1.Service {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *Preemption) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *Preemption) GetTargetNodeID() string {
+	if m != nil {
+		return m.TargetNodeID
+	}
+	return ""
+}
+
+type FilterResult struct {
+	Filter               string   `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	NodeID               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FilterResult) Reset()         { *m = FilterResult{} }
+func (m *FilterResult) String() string { return proto.CompactTextString(m) }
+func (*FilterResult) ProtoMessage()    {}
+func (*FilterResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5bf2633cdf3b52d, []int{4}
+}
+func (m *FilterResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterResult.Unmarshal(m, b)
+}
+func (m *FilterResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterResult.Marshal(b, m, deterministic)
+}
+func (m *FilterResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterResult.Merge(m, src)
+}
+func (m *FilterResult) XXX_Size() int {
+	return xxx_messageInfo_FilterResult.Size(m)
+}
+func (m *FilterResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterResult proto.InternalMessageInfo
+
+func (m *FilterResult) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *FilterResult) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *FilterResult) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ScheduleRequest)(nil), "stellar.services.scheduler.v1.ScheduleRequest")
+	proto.RegisterType((*Reservation)(nil), "stellar.services.scheduler.v1.Reservation")
 	proto.RegisterType((*ScheduleResponse)(nil), "stellar.services.scheduler.v1.ScheduleResponse")
+	proto.RegisterType((*Preemption)(nil), "stellar.services.scheduler.v1.Preemption")
+	proto.RegisterType((*FilterResult)(nil), "stellar.services.scheduler.v1.FilterResult")
 }
 
 func init() {
@@ -119,26 +350,46 @@ func init() {
 }
 
 var fileDescriptor_b5bf2633cdf3b52d = []byte{
-	// 291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0xa9, 0xe2, 0xbf, 0x0c, 0x9c, 0x04, 0x0f, 0x63, 0x20, 0xcc, 0xa1, 0xb0, 0x53, 0x42,
-	0xeb, 0xc1, 0x83, 0xa0, 0x28, 0x78, 0x51, 0xf0, 0xd0, 0xdd, 0xbc, 0x48, 0xda, 0xbe, 0xb4, 0xc5,
-	0xac, 0xa9, 0x79, 0xd3, 0x20, 0x7e, 0x17, 0xbf, 0xab, 0x74, 0x69, 0x56, 0x74, 0x22, 0xc3, 0xdb,
-	0xfb, 0x84, 0xdf, 0xf3, 0xe4, 0x21, 0x6f, 0xc8, 0x7d, 0x5e, 0x9a, 0xa2, 0x49, 0x58, 0xaa, 0x16,
-	0x1c, 0x0a, 0xf1, 0x21, 0xc1, 0x18, 0x8e, 0x06, 0xa4, 0x14, 0x9a, 0x8b, 0xba, 0xe4, 0x08, 0xda,
-	0x96, 0x29, 0x20, 0xc7, 0xb4, 0x80, 0xac, 0x91, 0xa0, 0xb9, 0x0d, 0x7b, 0xc1, 0x6a, 0xad, 0x8c,
-	0xa2, 0x27, 0x9d, 0x85, 0x79, 0x9c, 0xf5, 0x84, 0x0d, 0xc7, 0xc7, 0xb9, 0xca, 0xd5, 0x92, 0xe4,
-	0xed, 0xe4, 0x4c, 0xe3, 0xf3, 0x6f, 0xf9, 0xa9, 0x6c, 0xd0, 0xb8, 0xf4, 0x6e, 0xfc, 0x15, 0xd3,
-	0x4d, 0x65, 0xca, 0x05, 0xb4, 0x58, 0x37, 0x3a, 0x6c, 0xfa, 0x19, 0x90, 0xe1, 0xbc, 0xbb, 0x34,
-	0x86, 0xb7, 0x06, 0xd0, 0xd0, 0x6b, 0xb2, 0xd7, 0x19, 0x47, 0xc1, 0x24, 0x98, 0x0d, 0xa2, 0x33,
-	0xb6, 0x56, 0xd4, 0xa7, 0xd8, 0x90, 0xcd, 0xdd, 0x59, 0xec, 0x4d, 0xf4, 0x81, 0x0c, 0x85, 0x15,
-	0xa5, 0x14, 0x89, 0x84, 0x97, 0x4a, 0x65, 0x80, 0xa3, 0xad, 0xc9, 0xf6, 0x6c, 0x10, 0x9d, 0xae,
-	0xe7, 0xf8, 0xd2, 0x36, 0x64, 0x4f, 0x2a, 0x83, 0xf8, 0x70, 0xe5, 0x6c, 0x25, 0x4e, 0x1f, 0xc9,
-	0x51, 0x5f, 0x0f, 0x6b, 0x55, 0x21, 0xd0, 0x4b, 0xb2, 0xe3, 0x52, 0x83, 0x4d, 0x53, 0x1d, 0x1f,
-	0xbd, 0x93, 0x03, 0x1f, 0xa6, 0xe9, 0x2b, 0xd9, 0xf7, 0x82, 0x32, 0xf6, 0xe7, 0x26, 0xd8, 0x8f,
-	0x17, 0x1a, 0xf3, 0x8d, 0x79, 0x57, 0xf9, 0xee, 0xf6, 0xf9, 0xe6, 0x5f, 0x5f, 0xe6, 0x6a, 0x25,
-	0x92, 0xdd, 0xe5, 0xc2, 0x2e, 0xbe, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf4, 0xe1, 0x7b, 0xb5, 0x7c,
-	0x02, 0x00, 0x00,
+	// 621 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x55, 0xd7, 0x2d, 0x5b, 0x6f, 0xaa, 0x6d, 0xf2, 0x60, 0xab, 0x26, 0xa1, 0x96, 0xf2, 0x55,
+	0x40, 0x4a, 0xd4, 0x22, 0xc1, 0x03, 0x12, 0x88, 0xb1, 0xa1, 0x95, 0x49, 0x13, 0xf2, 0x78, 0xe2,
+	0xa5, 0xca, 0xe2, 0x4b, 0x6b, 0xe1, 0x26, 0xc1, 0x76, 0xaa, 0x8d, 0xff, 0xc2, 0x5f, 0xeb, 0x43,
+	0x25, 0x7e, 0x07, 0x28, 0x4e, 0xd2, 0x64, 0x1b, 0x5b, 0xa7, 0xbd, 0xf9, 0xf8, 0x9e, 0x73, 0x7d,
+	0x7d, 0xcf, 0xb5, 0xe1, 0x60, 0xc8, 0xf5, 0x28, 0x3e, 0x75, 0xfc, 0x70, 0xec, 0xe2, 0xc8, 0xfb,
+	0x25, 0x50, 0x6b, 0x57, 0x69, 0x14, 0xc2, 0x93, 0xae, 0x17, 0x71, 0x57, 0xa1, 0x9c, 0x70, 0x1f,
+	0x95, 0xab, 0xfc, 0x11, 0xb2, 0x58, 0xa0, 0x74, 0x27, 0xdd, 0x02, 0x38, 0x91, 0x0c, 0x75, 0x48,
+	0x1e, 0x64, 0x12, 0x27, 0xa7, 0x3b, 0x05, 0x63, 0xd2, 0xdd, 0xbd, 0x37, 0x0c, 0x87, 0xa1, 0x61,
+	0xba, 0xc9, 0x2a, 0x15, 0xed, 0x3e, 0xb9, 0x90, 0xdf, 0x17, 0xb1, 0xd2, 0x69, 0xf6, 0x6c, 0xf9,
+	0x5f, 0x9a, 0x8c, 0x03, 0xcd, 0xc7, 0x98, 0xd0, 0xb2, 0x65, 0x4a, 0x6b, 0xff, 0xae, 0xc2, 0xc6,
+	0x49, 0x76, 0x28, 0xc5, 0x9f, 0x31, 0x2a, 0x4d, 0xde, 0xc1, 0x6a, 0x26, 0x6c, 0x54, 0x5a, 0x95,
+	0x8e, 0xdd, 0x7b, 0xec, 0x5c, 0x29, 0x34, 0xcf, 0x32, 0xe9, 0x3a, 0x27, 0xe9, 0x1e, 0xcd, 0x45,
+	0xe4, 0x33, 0x6c, 0x78, 0x13, 0x8f, 0x0b, 0xef, 0x54, 0xe0, 0x20, 0x08, 0x19, 0xaa, 0xc6, 0x52,
+	0xab, 0xda, 0xb1, 0x7b, 0x0f, 0xaf, 0xe6, 0xc9, 0x8b, 0x9e, 0x74, 0x9d, 0xe3, 0x90, 0x21, 0x5d,
+	0x9f, 0x2b, 0x13, 0xa8, 0xc8, 0x0e, 0xac, 0x32, 0x79, 0x3e, 0x90, 0x71, 0xd0, 0xa8, 0xb6, 0x2a,
+	0x9d, 0x35, 0x6a, 0x31, 0x79, 0x4e, 0xe3, 0x80, 0x1c, 0x82, 0x9d, 0xa4, 0x1e, 0x8c, 0xd0, 0x13,
+	0x7a, 0xd4, 0x58, 0x36, 0x07, 0x3c, 0x5b, 0x78, 0xc0, 0xa1, 0xa1, 0x53, 0x08, 0xe6, 0x6b, 0x72,
+	0x04, 0xf7, 0xf1, 0xcc, 0x17, 0x31, 0xc3, 0x81, 0x1f, 0x06, 0xda, 0xe3, 0x01, 0xca, 0x01, 0x67,
+	0xaa, 0xb1, 0xd2, 0xaa, 0x76, 0x6a, 0x7b, 0x3b, 0xb3, 0x69, 0x73, 0xeb, 0x20, 0x25, 0x7c, 0xcc,
+	0xe3, 0xfd, 0x7d, 0x45, 0xb7, 0xf0, 0xf2, 0x26, 0x53, 0xe4, 0x18, 0xea, 0x12, 0x93, 0xc3, 0x3d,
+	0xcd, 0xc3, 0x40, 0x35, 0x2c, 0x53, 0xd7, 0x0b, 0xe7, 0x46, 0xa7, 0x1d, 0x5a, 0x48, 0xe8, 0x05,
+	0x7d, 0xfb, 0x0c, 0xec, 0x52, 0x90, 0x3c, 0x82, 0x55, 0x73, 0x6b, 0xce, 0x8c, 0x35, 0xb5, 0x3d,
+	0x98, 0x4d, 0x9b, 0x56, 0x72, 0xb1, 0xfe, 0x3e, 0xb5, 0x92, 0x50, 0x9f, 0x91, 0x7d, 0xa8, 0x49,
+	0x54, 0x61, 0x2c, 0x7d, 0xd3, 0xf9, 0xc4, 0xc1, 0xa7, 0x37, 0x3a, 0x48, 0x73, 0x36, 0x2d, 0x84,
+	0xed, 0x3f, 0x15, 0xd8, 0x2c, 0x26, 0x43, 0x45, 0x61, 0xa0, 0x90, 0xbc, 0x81, 0x95, 0xd4, 0xd0,
+	0xca, 0x6d, 0x0d, 0x4d, 0xf9, 0xe4, 0x08, 0x00, 0x05, 0x1f, 0xf3, 0xc0, 0xd3, 0xc8, 0xb2, 0x71,
+	0x78, 0xb9, 0xa0, 0x2b, 0x9f, 0xb8, 0xd0, 0x28, 0x29, 0xaa, 0x58, 0x68, 0x5a, 0x92, 0x93, 0x23,
+	0xb0, 0x23, 0x89, 0x38, 0x8e, 0xd2, 0x1e, 0x57, 0x4d, 0xb6, 0xe7, 0x0b, 0xb2, 0x7d, 0x99, 0x2b,
+	0x68, 0x59, 0xdd, 0xfe, 0x5b, 0x01, 0x28, 0x62, 0xa4, 0x07, 0xf5, 0xf2, 0x14, 0x64, 0x6d, 0xde,
+	0x98, 0x4d, 0x9b, 0x76, 0xc9, 0x7d, 0x6a, 0xcf, 0x49, 0x7d, 0x46, 0x5a, 0x60, 0x7b, 0x51, 0x24,
+	0xb8, 0x6f, 0x4c, 0x32, 0x2d, 0xaf, 0xd1, 0xf2, 0x56, 0xf9, 0x49, 0x55, 0xef, 0xf2, 0xa4, 0x4a,
+	0xbe, 0x2f, 0x5f, 0xeb, 0xfb, 0x6b, 0x58, 0xd7, 0x9e, 0x1c, 0xa2, 0x1e, 0xe4, 0xdc, 0x15, 0xc3,
+	0xdd, 0x9c, 0x4d, 0x9b, 0xf5, 0xaf, 0x26, 0x92, 0x29, 0xea, 0xba, 0x40, 0xac, 0xed, 0x43, 0xbd,
+	0xdc, 0x6a, 0xb2, 0x0d, 0xd6, 0x77, 0x83, 0xd3, 0xcb, 0xd3, 0x0c, 0x95, 0x8b, 0x58, 0xba, 0xb6,
+	0x88, 0x6d, 0xb0, 0x24, 0x7a, 0x2a, 0x4c, 0xdf, 0x6b, 0x8d, 0x66, 0xa8, 0x77, 0x06, 0xb5, 0x7c,
+	0x9a, 0x24, 0xf9, 0x01, 0x6b, 0x39, 0x20, 0xce, 0x02, 0xdf, 0x2e, 0xfd, 0x4e, 0xbb, 0xee, 0xad,
+	0xf9, 0xe9, 0xcc, 0xee, 0x7d, 0xf8, 0xf6, 0xfe, 0x4e, 0xdf, 0xf5, 0xdb, 0x39, 0x38, 0xb5, 0xcc,
+	0x67, 0xf9, 0xea, 0xdf, 0x00, 0xbf, 0x58, 0x2a, 0x6d, 0xf8, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
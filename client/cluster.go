@@ -51,3 +51,17 @@ func (c *cluster) Health() ([]*clusterapi.NodeHealth, error) {
 
 	return resp.Nodes, nil
 }
+
+// Rebalance moves replicas to match the current scheduler placement; if dryRun
+// is set the planned moves are returned without being executed
+func (c *cluster) Rebalance(dryRun bool) ([]*clusterapi.Move, error) {
+	ctx := context.Background()
+	resp, err := c.client.Rebalance(ctx, &clusterapi.RebalanceRequest{
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Moves, nil
+}
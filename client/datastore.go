@@ -2,9 +2,22 @@ package client
 
 import (
 	"context"
+	"io"
+	"time"
 
 	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
 	"github.com/ehazlett/stellar/api/types"
+	ptypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	watchRetryInterval = time.Second * 1
+	// searchPageSize is the number of keys requested per search page
+	searchPageSize = 1000
+	// restoreChunkSize is the size of each streamed restore chunk
+	restoreChunkSize = 64 * 1024
 )
 
 type datastore struct {
@@ -48,26 +61,64 @@ func (d *datastore) CreateBucket(bucket string) error {
 	return nil
 }
 
+// Search returns the keys matching the prefix.  The keys are fetched in
+// pages so large buckets do not exceed the message size limits.
 func (d *datastore) Search(bucket, prefix string) ([]types.KeyValue, error) {
-	ctx := context.Background()
-	resp, err := d.client.Search(ctx, &datastoreapi.SearchRequest{
-		Bucket: bucket,
-		Prefix: prefix,
-	})
-	if err != nil {
+	var data []types.KeyValue
+	if err := d.SearchPages(bucket, prefix, false, func(kvs []*datastoreapi.KeyValue) error {
+		for _, kv := range kvs {
+			data = append(data, types.KeyValue{
+				Bucket: bucket,
+				Key:    kv.Key,
+				Value:  kv.Value,
+			})
+		}
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
-	var data []types.KeyValue
-	for _, kv := range resp.Data {
-		data = append(data, types.KeyValue{
-			Bucket: resp.Bucket,
-			Key:    kv.Key,
-			Value:  kv.Value,
-		})
+	return data, nil
+}
+
+// SearchKeys returns the keys matching the prefix without the values
+func (d *datastore) SearchKeys(bucket, prefix string) ([]string, error) {
+	var keys []string
+	if err := d.SearchPages(bucket, prefix, true, func(kvs []*datastoreapi.KeyValue) error {
+		for _, kv := range kvs {
+			keys = append(keys, kv.Key)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
-	return data, nil
+	return keys, nil
+}
+
+// SearchPages calls fn with each page of keys matching the prefix
+func (d *datastore) SearchPages(bucket, prefix string, keysOnly bool, fn func([]*datastoreapi.KeyValue) error) error {
+	ctx := context.Background()
+	next := ""
+	for {
+		resp, err := d.client.Search(ctx, &datastoreapi.SearchRequest{
+			Bucket:     bucket,
+			Prefix:     prefix,
+			Limit:      searchPageSize,
+			StartAfter: next,
+			KeysOnly:   keysOnly,
+		})
+		if err != nil {
+			return err
+		}
+		if err := fn(resp.Data); err != nil {
+			return err
+		}
+		if resp.Next == "" {
+			return nil
+		}
+		next = resp.Next
+	}
 }
 
 func (d *datastore) Set(bucket, key string, value []byte, sync bool) error {
@@ -84,6 +135,22 @@ func (d *datastore) Set(bucket, key string, value []byte, sync bool) error {
 	return nil
 }
 
+// SetWithTTL sets the key to the value expiring it after the ttl
+func (d *datastore) SetWithTTL(bucket, key string, value []byte, ttl time.Duration, sync bool) error {
+	ctx := context.Background()
+	if _, err := d.client.Set(ctx, &datastoreapi.SetRequest{
+		Bucket: bucket,
+		Key:    key,
+		Value:  value,
+		Sync:   sync,
+		TTL:    ptypes.DurationProto(ttl),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (d *datastore) Delete(bucket, key string, sync bool) error {
 	ctx := context.Background()
 	if _, err := d.client.Delete(ctx, &datastoreapi.DeleteRequest{
@@ -96,3 +163,216 @@ func (d *datastore) Delete(bucket, key string, sync bool) error {
 
 	return nil
 }
+
+// Status returns the replication status of the node with each peer
+func (d *datastore) Status() (*datastoreapi.StatusResponse, error) {
+	ctx := context.Background()
+	return d.client.Status(ctx, &datastoreapi.StatusRequest{})
+}
+
+// Digest returns the digest of each bucket on the node
+func (d *datastore) Digest() (*datastoreapi.DigestResponse, error) {
+	ctx := context.Background()
+	return d.client.Digest(ctx, &datastoreapi.DigestRequest{})
+}
+
+// Versions returns the version of each key in the bucket including tombstones
+func (d *datastore) Versions(bucket string) ([]*datastoreapi.KeyVersion, error) {
+	ctx := context.Background()
+	resp, err := d.client.Versions(ctx, &datastoreapi.VersionsRequest{
+		Bucket: bucket,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Versions, nil
+}
+
+// Txn applies the success operations if all compares match and the failure
+// operations otherwise.  The response reports which branch was applied.
+func (d *datastore) Txn(compares []*datastoreapi.Compare, success, failure []*datastoreapi.TxnOp, sync bool) (*datastoreapi.TxnResponse, error) {
+	ctx := context.Background()
+	return d.client.Txn(ctx, &datastoreapi.TxnRequest{
+		Compare: compares,
+		Success: success,
+		Failure: failure,
+		Sync:    sync,
+	})
+}
+
+// AcquireLock acquires the named cluster lock for the owner waiting up to the
+// timeout.  The lease expires after the ttl unless renewed.
+func (d *datastore) AcquireLock(name, owner string, ttl, timeout time.Duration) (*datastoreapi.Lease, error) {
+	ctx := context.Background()
+	resp, err := d.client.AcquireLock(ctx, &datastoreapi.AcquireLockRequest{
+		Name:    name,
+		Owner:   owner,
+		TTL:     ptypes.DurationProto(ttl),
+		Timeout: ptypes.DurationProto(timeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Lease, nil
+}
+
+// RenewLock extends the lease by the ttl
+func (d *datastore) RenewLock(lease *datastoreapi.Lease, ttl time.Duration) (*datastoreapi.Lease, error) {
+	ctx := context.Background()
+	resp, err := d.client.RenewLock(ctx, &datastoreapi.RenewLockRequest{
+		Name:  lease.Name,
+		Owner: lease.Owner,
+		Token: lease.Token,
+		TTL:   ptypes.DurationProto(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Lease, nil
+}
+
+func (d *datastore) ReleaseLock(lease *datastoreapi.Lease) error {
+	ctx := context.Background()
+	if _, err := d.client.ReleaseLock(ctx, &datastoreapi.ReleaseLockRequest{
+		Name:  lease.Name,
+		Owner: lease.Owner,
+		Token: lease.Token,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BackupTo streams a gzip compressed backup of the datastore to the writer
+func (d *datastore) BackupTo(w io.Writer) error {
+	ctx := context.Background()
+	stream, err := d.client.BackupStream(ctx, &datastoreapi.BackupRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(resp.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// RestoreFrom streams the backup from the reader and replaces the datastore.
+// The backup may be gzip compressed.
+func (d *datastore) RestoreFrom(r io.Reader) error {
+	ctx := context.Background()
+	stream, err := d.client.RestoreStream(ctx)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, restoreChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&datastoreapi.RestoreRequest{
+				Data: append([]byte{}, buf[:n]...),
+			}); serr != nil {
+				return serr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// RestoreSnapshot replaces the datastore with the named snapshot on the node
+func (d *datastore) RestoreSnapshot(name string) error {
+	ctx := context.Background()
+	if _, err := d.client.Restore(ctx, &datastoreapi.RestoreRequest{
+		Snapshot: name,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Snapshots returns the snapshots on the node newest first
+func (d *datastore) Snapshots() ([]*datastoreapi.Snapshot, error) {
+	ctx := context.Background()
+	resp, err := d.client.Snapshots(ctx, &datastoreapi.SnapshotsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Snapshots, nil
+}
+
+// RotateKey reloads the data keys on the node and re-encrypts the datastore
+// with the primary key
+func (d *datastore) RotateKey() (*datastoreapi.RotateKeyResponse, error) {
+	ctx := context.Background()
+	return d.client.RotateKey(ctx, &datastoreapi.RotateKeyRequest{})
+}
+
+// Watch returns a channel of events for the keys in the bucket matching the
+// prefix.  If the stream is interrupted the watch is resumed from the last
+// received revision so no events are missed.  The error channel receives the
+// error if the watch cannot be resumed.  Both channels are closed when the
+// context is canceled.
+func (d *datastore) Watch(ctx context.Context, bucket, prefix string, revision uint64) (<-chan *datastoreapi.WatchEvent, <-chan error) {
+	ch := make(chan *datastoreapi.WatchEvent)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		for {
+			stream, err := d.client.Watch(ctx, &datastoreapi.WatchRequest{
+				Bucket:   bucket,
+				Prefix:   prefix,
+				Revision: revision,
+			})
+			if err == nil {
+				for {
+					evt, rerr := stream.Recv()
+					if rerr != nil {
+						err = rerr
+						break
+					}
+					revision = evt.Revision
+					select {
+					case ch <- evt:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if status.Code(err) == codes.OutOfRange {
+				errCh <- err
+				return
+			}
+			// resume from the last revision
+			select {
+			case <-time.After(watchRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, errCh
+}
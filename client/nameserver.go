@@ -104,6 +104,8 @@ func recordType(rtype string) (nameserverapi.RecordType, error) {
 	switch strings.ToUpper(rtype) {
 	case "A":
 		return nameserverapi.RecordType_A, nil
+	case "AAAA":
+		return nameserverapi.RecordType_AAAA, nil
 	case "CNAME":
 		return nameserverapi.RecordType_CNAME, nil
 	case "SRV":
@@ -58,11 +58,36 @@ func (n *network) GetSubnet(node string) (string, error) {
 	return resp.SubnetCIDR, nil
 }
 
-func (n *network) AddRoute(cidr, target string) error {
+// GetSubnet6 returns the ipv6 subnet for the node; the subnet is empty if
+// ipv6 is not enabled
+func (n *network) GetSubnet6(node string) (string, error) {
+	ctx := context.Background()
+	resp, err := n.client.GetSubnet(ctx, &networkapi.GetSubnetRequest{
+		Node: node,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Subnet6CIDR, nil
+}
+
+// GetNetworkSubnet returns the subnets of the named network for the node
+func (n *network) GetNetworkSubnet(network, node string) (*networkapi.GetSubnetResponse, error) {
+	ctx := context.Background()
+	return n.client.GetSubnet(ctx, &networkapi.GetSubnetRequest{
+		Node:    node,
+		Network: network,
+	})
+}
+
+// AddRoute publishes the route to the cidr via the target for the node
+func (n *network) AddRoute(cidr, target, node string) error {
 	ctx := context.Background()
 	if _, err := n.client.AddRoute(ctx, &networkapi.AddRouteRequest{
 		CIDR:   cidr,
 		Target: target,
+		Node:   node,
 	}); err != nil {
 		return err
 	}
@@ -70,11 +95,14 @@ func (n *network) AddRoute(cidr, target string) error {
 	return nil
 }
 
-func (n *network) DeleteRoute(cidr, target string) error {
+// DeleteRoute removes the route; if node is set the route is only removed if
+// it is owned by the node
+func (n *network) DeleteRoute(cidr, target, node string) error {
 	ctx := context.Background()
 	if _, err := n.client.DeleteRoute(ctx, &networkapi.DeleteRouteRequest{
 		CIDR:   cidr,
 		Target: target,
+		Node:   node,
 	}); err != nil {
 		return err
 	}
@@ -93,6 +121,18 @@ func (n *network) Routes() ([]*networkapi.Route, error) {
 }
 
 func (n *network) AllocateIP(id, node, subnetCIDR string) (net.IP, error) {
+	return n.AllocateNetworkIP("", id, node, subnetCIDR)
+}
+
+// AllocateNetworkIP allocates an ip from the subnet of the named network
+func (n *network) AllocateNetworkIP(network, id, node, subnetCIDR string) (net.IP, error) {
+	return n.ReserveNetworkIP(network, id, node, subnetCIDR, "", "")
+}
+
+// ReserveNetworkIP allocates and reserves the specific ip or an ip from the
+// pool for the id on the node.  The ip is allocated dynamically if both are
+// empty.
+func (n *network) ReserveNetworkIP(network, id, node, subnetCIDR, ip, pool string) (net.IP, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
@@ -100,22 +140,30 @@ func (n *network) AllocateIP(id, node, subnetCIDR string) (net.IP, error) {
 		ID:         id,
 		Node:       node,
 		SubnetCIDR: subnetCIDR,
+		Network:    network,
+		IP:         ip,
+		Pool:       pool,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	ip := net.ParseIP(resp.IP)
-	return ip, nil
+	return net.ParseIP(resp.IP), nil
 }
 
 func (n *network) GetIP(id, node string) (net.IP, error) {
+	return n.GetNetworkIP("", id, node)
+}
+
+// GetNetworkIP returns the ip allocated for the id in the named network
+func (n *network) GetNetworkIP(network, id, node string) (net.IP, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
 	resp, err := n.client.GetIP(ctx, &networkapi.GetIPRequest{
-		ID:   id,
-		Node: node,
+		ID:      id,
+		Node:    node,
+		Network: network,
 	})
 	if err != nil {
 		return nil, err
@@ -126,16 +174,164 @@ func (n *network) GetIP(id, node string) (net.IP, error) {
 }
 
 func (n *network) ReleaseIP(id, ip, node string) (*ptypes.Empty, error) {
+	return n.ReleaseNetworkIP("", id, ip, node)
+}
+
+// ReleaseNetworkIP releases the ips allocated for the id in the named network
+func (n *network) ReleaseNetworkIP(network, id, ip, node string) (*ptypes.Empty, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
 	if _, err := n.client.ReleaseIP(ctx, &networkapi.ReleaseIPRequest{
-		ID:   id,
-		IP:   ip,
-		Node: node,
+		ID:      id,
+		IP:      ip,
+		Node:    node,
+		Network: network,
 	}); err != nil {
 		return empty, err
 	}
 
 	return empty, nil
 }
+
+// IPs returns the ip allocations for the node; all nodes if node is empty
+func (n *network) IPs(node string) ([]*networkapi.IPAllocation, error) {
+	ctx := context.Background()
+	resp, err := n.client.IPs(ctx, &networkapi.IPsRequest{
+		Node: node,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.IPs, nil
+}
+
+func (n *network) CreatePolicy(policy *networkapi.NetworkPolicy) error {
+	ctx := context.Background()
+	if _, err := n.client.CreatePolicy(ctx, &networkapi.CreatePolicyRequest{
+		Policy: policy,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *network) Policies() ([]*networkapi.NetworkPolicy, error) {
+	ctx := context.Background()
+	resp, err := n.client.Policies(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Policies, nil
+}
+
+func (n *network) DeletePolicy(name string) error {
+	ctx := context.Background()
+	if _, err := n.client.DeletePolicy(ctx, &networkapi.DeletePolicyRequest{
+		Name: name,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *network) CreateNetwork(network *networkapi.ClusterNetwork) error {
+	ctx := context.Background()
+	if _, err := n.client.CreateNetwork(ctx, &networkapi.CreateNetworkRequest{
+		Network: network,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListNetworks returns the default cluster network and the named networks
+func (n *network) ListNetworks() ([]*networkapi.ClusterNetwork, error) {
+	ctx := context.Background()
+	resp, err := n.client.ListNetworks(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Networks, nil
+}
+
+func (n *network) DeleteNetwork(name string) error {
+	ctx := context.Background()
+	if _, err := n.client.DeleteNetwork(ctx, &networkapi.DeleteNetworkRequest{
+		Name: name,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *network) CreatePool(pool *networkapi.IPPool) error {
+	ctx := context.Background()
+	if _, err := n.client.CreatePool(ctx, &networkapi.CreatePoolRequest{
+		Pool: pool,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListPools returns the ip reservation pools
+func (n *network) ListPools() ([]*networkapi.IPPool, error) {
+	ctx := context.Background()
+	resp, err := n.client.ListPools(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Pools, nil
+}
+
+func (n *network) DeletePool(name string) error {
+	ctx := context.Background()
+	if _, err := n.client.DeletePool(ctx, &networkapi.DeletePoolRequest{
+		Name: name,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GC releases orphaned ip and subnet allocations that have been orphaned for
+// the grace period; a zero grace period uses the service default.  If dryRun
+// is set the orphans are returned without being released.
+func (n *network) GC(dryRun bool, gracePeriod time.Duration) ([]*networkapi.Orphan, error) {
+	ctx := context.Background()
+	req := &networkapi.GCRequest{
+		DryRun: dryRun,
+	}
+	if gracePeriod > 0 {
+		req.GracePeriod = ptypes.DurationProto(gracePeriod)
+	}
+	resp, err := n.client.GC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Orphans, nil
+}
+
+// State returns the network state of the node
+func (n *network) State() (*networkapi.NodeState, error) {
+	ctx := context.Background()
+	return n.client.State(ctx, &ptypes.Empty{})
+}
+
+// Inspect compares the network datastore with the state of every node
+func (n *network) Inspect() (*networkapi.InspectResponse, error) {
+	ctx := context.Background()
+	return n.client.Inspect(ctx, &ptypes.Empty{})
+}
@@ -107,3 +107,28 @@ func (n *node) Images() ([]*runtimeapi.Image, error) {
 
 	return resp.Images, nil
 }
+
+// Ping pings the target from the network namespace of the container
+func (n *node) Ping(id, target string, count uint32) (*runtimeapi.PingResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(count+10))
+	defer cancel()
+
+	return n.client.Ping(ctx, &runtimeapi.PingRequest{
+		ID:     id,
+		Target: target,
+		Count:  count,
+	})
+}
+
+// SetBandwidth replaces the bandwidth limits of the running container
+func (n *node) SetBandwidth(id string, bandwidth *runtimeapi.Bandwidth) error {
+	ctx := context.Background()
+	if _, err := n.client.SetBandwidth(ctx, &runtimeapi.SetBandwidthRequest{
+		ID:        id,
+		Bandwidth: bandwidth,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -13,13 +13,57 @@ type scheduler struct {
 }
 
 func (s *scheduler) Schedule(service *runtimeapi.Service, nodes []*clusterapi.Node) ([]*clusterapi.Node, error) {
+	scheduled, _, err := s.ScheduleWithPreemption(service, nodes)
+	return scheduled, err
+}
+
+// ScheduleWithPreemption returns the nodes for the service replicas along
+// with any lower priority replicas that must be preempted to place them
+func (s *scheduler) ScheduleWithPreemption(service *runtimeapi.Service, nodes []*clusterapi.Node) ([]*clusterapi.Node, []*schedulerapi.Preemption, error) {
 	ctx := context.Background()
 	resp, err := s.client.Schedule(ctx, &schedulerapi.ScheduleRequest{
 		Service:        service,
 		AvailableNodes: nodes,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	return resp.Nodes, resp.Preemptions, nil
+}
+
+// Simulate runs the scheduler in dry-run mode and returns the resolved
+// placements along with the nodes eliminated by the scheduler filters
+func (s *scheduler) Simulate(service *runtimeapi.Service, nodes []*clusterapi.Node) ([]*clusterapi.Node, []*schedulerapi.FilterResult, error) {
+	resp, err := s.SimulateWithHealth(service, nodes, nil)
+	if err != nil {
+		return nil, nil, err
 	}
-	return resp.Nodes, nil
+	return resp.Nodes, resp.Eliminated, nil
+}
+
+// SimulateWithHealth runs the scheduler in dry-run mode and returns the
+// scheduler response.  If health is specified it is used for the node
+// capacity instead of the live cluster.
+func (s *scheduler) SimulateWithHealth(service *runtimeapi.Service, nodes []*clusterapi.Node, health []*clusterapi.NodeHealth) (*schedulerapi.ScheduleResponse, error) {
+	ctx := context.Background()
+	return s.client.Schedule(ctx, &schedulerapi.ScheduleRequest{
+		Service:        service,
+		AvailableNodes: nodes,
+		DryRun:         true,
+		NodeHealth:     health,
+	})
+}
+
+// SimulateExcluding runs the scheduler in dry-run mode ignoring the capacity
+// and host ports held by the excluded containers.  The reservations hold
+// capacity for replicas that are planned but not yet running.
+func (s *scheduler) SimulateExcluding(service *runtimeapi.Service, nodes []*clusterapi.Node, exclude []string, reservations []*schedulerapi.Reservation) (*schedulerapi.ScheduleResponse, error) {
+	ctx := context.Background()
+	return s.client.Schedule(ctx, &schedulerapi.ScheduleRequest{
+		Service:             service,
+		AvailableNodes:      nodes,
+		DryRun:              true,
+		ExcludeContainerIDs: exclude,
+		Reservations:        reservations,
+	})
 }
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/codegangsta/cli"
+	humanize "github.com/dustin/go-humanize"
 	api "github.com/ehazlett/stellar/api/services/application/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
 	"github.com/pkg/errors"
 )
 
@@ -24,7 +27,11 @@ Services:{{ range .Services }}
       - Service: {{.Service}}
         Protocol: {{.Protocol}}
         Host: {{.Host}}
-        Port: {{.Port}}{{ end }}{{ end }}
+        Port: {{.Port}}{{ if .HostPort }}
+        HostPort: {{.HostPort}}{{ end }}{{ end }}{{ end }}{{ if .Bandwidth }}
+    Bandwidth:
+      Ingress: {{ bandwidth .Bandwidth.Ingress }}
+      Egress: {{ bandwidth .Bandwidth.Egress }}{{ end }}
 	{{ end }}
 `
 
@@ -38,6 +45,7 @@ var appCommand = cli.Command{
 		appDeleteCommand,
 		appInspectCommand,
 		appRestartCommand,
+		appBandwidthCommand,
 	},
 }
 
@@ -198,3 +206,95 @@ var appRestartCommand = cli.Command{
 		return nil
 	},
 }
+
+var appBandwidthCommand = cli.Command{
+	Name:      "bandwidth",
+	Usage:     "set the bandwidth limits of the running service replicas",
+	ArgsUsage: "<NAME> <SERVICE>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "ingress",
+			Usage: "limit for traffic to each replica in bits per second (i.e. 10M); unlimited if empty",
+			Value: "",
+		},
+		cli.StringFlag{
+			Name:  "egress",
+			Usage: "limit for traffic from each replica in bits per second (i.e. 10M); unlimited if empty",
+			Value: "",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().Get(0)
+		service := c.Args().Get(1)
+		if name == "" || service == "" {
+			return fmt.Errorf("you must specify an application and service name")
+		}
+		ingress, err := parseBandwidth(c.String("ingress"))
+		if err != nil {
+			return err
+		}
+		egress, err := parseBandwidth(c.String("egress"))
+		if err != nil {
+			return err
+		}
+		bandwidth := &runtimeapi.Bandwidth{
+			Ingress: ingress,
+			Egress:  egress,
+		}
+
+		containers, err := client.Cluster().Containers()
+		if err != nil {
+			return err
+		}
+		prefix := fmt.Sprintf("%s.%s.", name, service)
+		updated := 0
+		for _, ct := range containers {
+			if !strings.HasPrefix(ct.Container.ID, prefix) {
+				continue
+			}
+			nc, err := getClientForAddr(c, ct.Node.Address)
+			if err != nil {
+				return err
+			}
+			err = nc.Node().SetBandwidth(ct.Container.ID, bandwidth)
+			nc.Close()
+			if err != nil {
+				return errors.Wrapf(err, "error updating %s", ct.Container.ID)
+			}
+			fmt.Printf("%s updated\n", ct.Container.ID)
+			updated++
+		}
+		if updated == 0 {
+			return fmt.Errorf("no replicas found for service %s in %s", service, name)
+		}
+
+		return nil
+	},
+}
+
+// parseBandwidth parses a limit in bits per second with an optional SI
+// prefix; an empty limit is unlimited
+func parseBandwidth(v string) (uint64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	rate, unit, err := humanize.ParseSI(v)
+	if err != nil || (unit != "" && unit != "bit") || rate < 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q", v)
+	}
+	return uint64(rate), nil
+}
+
+// formatBandwidth returns the limit in bits per second with an SI prefix
+func formatBandwidth(rate uint64) string {
+	if rate == 0 {
+		return "unlimited"
+	}
+	return humanize.SI(float64(rate), "bit/s")
+}
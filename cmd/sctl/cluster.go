@@ -12,6 +12,8 @@ import (
 	"github.com/codegangsta/cli"
 	humanize "github.com/dustin/go-humanize"
 	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
+	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
 )
 
@@ -49,6 +51,8 @@ var clusterCommand = cli.Command{
 		clusterContainersCommand,
 		clusterNodesCommand,
 		clusterInfoCommand,
+		clusterRebalanceCommand,
+		clusterHealthCommand,
 	},
 }
 
@@ -166,6 +170,139 @@ var clusterInfoCommand = cli.Command{
 	},
 }
 
+var clusterRebalanceCommand = cli.Command{
+	Name:  "rebalance",
+	Usage: "move replicas to match the current scheduler placement",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "show the planned moves without executing them",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		cl, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		moves, err := cl.Cluster().Rebalance(c.Bool("dry-run"))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "CONTAINER\tNEW CONTAINER\tSERVICE\tFROM\tTO\tSTATUS\n")
+		for _, m := range moves {
+			status := "moved"
+			switch {
+			case c.Bool("dry-run"):
+				status = "planned"
+			case m.Error != "":
+				status = m.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", m.ContainerID, m.NewContainerID, m.Service, m.FromNodeID, m.ToNodeID, status)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var clusterHealthCommand = cli.Command{
+	Name:  "health",
+	Usage: "show datastore replication health between the nodes",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "sync-interval",
+			Usage: "datastore sync interval of the nodes",
+			Value: time.Minute * 5,
+		},
+		cli.IntFlag{
+			Name:  "stale-intervals, n",
+			Usage: "flag peers that have not synced within this many sync intervals",
+			Value: 3,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		cl, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		nodes, err := cl.Cluster().Nodes()
+		if err != nil {
+			return err
+		}
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+		staleAfter := c.Duration("sync-interval") * time.Duration(c.Int("stale-intervals"))
+		now := time.Now()
+		stale := 0
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NODE\tPEER\tLAST SYNC\tSENT\tRECEIVED\tERRORS\tTOMBSTONES\tSTATUS\n")
+		for _, node := range nodes {
+			nc, err := getClientForAddr(c, node.Address)
+			if err != nil {
+				fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t%s\n", node.ID, err)
+				continue
+			}
+			status, err := nc.Datastore().Status()
+			nc.Close()
+			if err != nil {
+				fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t%s\n", node.ID, err)
+				continue
+			}
+
+			peers := map[string]*datastoreapi.PeerStatus{}
+			for _, p := range status.Peers {
+				peers[p.ID] = p
+			}
+			for _, peer := range nodes {
+				if peer.ID == node.ID {
+					continue
+				}
+				p, ok := peers[peer.ID]
+				if !ok {
+					p = &datastoreapi.PeerStatus{ID: peer.ID}
+				}
+				lastSync := "never"
+				state := "ok"
+				last, err := ptypes.TimestampFromProto(p.LastSync)
+				if err == nil {
+					lastSync = humanize.RelTime(last, now, "ago", "")
+				}
+				if err != nil || now.Sub(last) > staleAfter {
+					state = "STALE"
+					stale++
+				}
+				if p.LastError != "" && (p.LastSync == nil || p.LastErrorAt.Compare(p.LastSync) > 0) {
+					state += ": " + p.LastError
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
+					node.ID,
+					peer.ID,
+					lastSync,
+					p.KeysSent,
+					p.KeysReceived,
+					p.Errors,
+					status.Tombstones,
+					state,
+				)
+			}
+		}
+		w.Flush()
+
+		if stale > 0 {
+			return fmt.Errorf("%d peers have not synced within %s", stale, staleAfter)
+		}
+
+		return nil
+	},
+}
+
 func memory(v int64) string {
 	return humanize.Bytes(uint64(v))
 }
@@ -0,0 +1,646 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/codegangsta/cli"
+	humanize "github.com/dustin/go-humanize"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
+	stellarclient "github.com/ehazlett/stellar/client"
+	ptypes "github.com/gogo/protobuf/types"
+)
+
+var datastoreCommand = cli.Command{
+	Name:  "datastore",
+	Usage: "manage the cluster datastore",
+	Subcommands: []cli.Command{
+		datastoreBucketsCommand,
+		datastoreGetCommand,
+		datastoreSetCommand,
+		datastoreSearchCommand,
+		datastoreDeleteCommand,
+		datastoreDumpCommand,
+		datastoreDiffCommand,
+		datastoreBackupCommand,
+		datastoreRestoreCommand,
+		datastoreSnapshotsCommand,
+		datastoreRotateKeyCommand,
+	},
+}
+
+var datastoreFormatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: "format to display values (text, json, hex)",
+	Value: "text",
+}
+
+var datastoreBucketsCommand = cli.Command{
+	Name:  "buckets",
+	Usage: "list the node datastore buckets",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "format to display output (text, json)",
+			Value: "text",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Datastore().Digest()
+		if err != nil {
+			return err
+		}
+		buckets := resp.Buckets
+		sort.Slice(buckets, func(i, j int) bool { return buckets[i].Bucket < buckets[j].Bucket })
+
+		switch format := c.String("format"); format {
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", " ")
+			return enc.Encode(buckets)
+		case "text":
+		default:
+			return fmt.Errorf("invalid output format: %s", format)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NAME\tKEYS\tDIGEST\n")
+		for _, b := range buckets {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", b.Bucket, b.Keys, hex.EncodeToString(b.Digest)[:12])
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var datastoreGetCommand = cli.Command{
+	Name:      "get",
+	Usage:     "get a datastore key",
+	Flags:     []cli.Flag{datastoreFormatFlag},
+	ArgsUsage: "<BUCKET> <KEY>",
+	Action: func(c *cli.Context) error {
+		bucket := c.Args().First()
+		key := c.Args().Get(1)
+		if bucket == "" || key == "" {
+			return fmt.Errorf("you must enter a bucket and key")
+		}
+		out, err := newKeyValueWriter(c.String("format"))
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Datastore().Client().Get(context.Background(), &datastoreapi.GetRequest{
+			Bucket: bucket,
+			Key:    key,
+		})
+		if err != nil {
+			return err
+		}
+
+		return out(bucket, resp.Data)
+	},
+}
+
+var datastoreSetCommand = cli.Command{
+	Name:  "set",
+	Usage: "set a datastore key",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "hex",
+			Usage: "value is hex encoded",
+		},
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "read the value from the file",
+		},
+		cli.DurationFlag{
+			Name:  "ttl",
+			Usage: "expire the key after the duration",
+		},
+		cli.BoolFlag{
+			Name:  "sync",
+			Usage: "replicate the write to the peers before returning",
+		},
+	},
+	ArgsUsage: "<BUCKET> <KEY> [VALUE]",
+	Action: func(c *cli.Context) error {
+		bucket := c.Args().First()
+		key := c.Args().Get(1)
+		if bucket == "" || key == "" {
+			return fmt.Errorf("you must enter a bucket and key")
+		}
+
+		value := []byte(c.Args().Get(2))
+		if p := c.String("file"); p != "" {
+			data, err := ioutil.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			value = data
+		}
+		if c.Bool("hex") {
+			data, err := hex.DecodeString(strings.TrimSpace(string(value)))
+			if err != nil {
+				return err
+			}
+			value = data
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		if ttl := c.Duration("ttl"); ttl > 0 {
+			return client.Datastore().SetWithTTL(bucket, key, value, ttl, c.Bool("sync"))
+		}
+		return client.Datastore().Set(bucket, key, value, c.Bool("sync"))
+	},
+}
+
+var datastoreSearchCommand = cli.Command{
+	Name:  "search",
+	Usage: "search datastore keys by prefix",
+	Flags: []cli.Flag{
+		datastoreFormatFlag,
+		cli.BoolFlag{
+			Name:  "keys-only, k",
+			Usage: "only display the keys",
+		},
+	},
+	ArgsUsage: "<BUCKET> [PREFIX]",
+	Action: func(c *cli.Context) error {
+		bucket := c.Args().First()
+		if bucket == "" {
+			return fmt.Errorf("you must enter a bucket")
+		}
+		prefix := c.Args().Get(1)
+		if prefix == "" {
+			prefix = "*"
+		}
+		keysOnly := c.Bool("keys-only")
+		out, err := newKeyValueWriter(c.String("format"))
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		return client.Datastore().SearchPages(bucket, prefix, keysOnly, func(kvs []*datastoreapi.KeyValue) error {
+			for _, kv := range kvs {
+				if keysOnly && c.String("format") == "text" {
+					fmt.Println(kv.Key)
+					continue
+				}
+				if err := out(bucket, kv); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var datastoreDeleteCommand = cli.Command{
+	Name:  "delete",
+	Usage: "delete a datastore key",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "sync",
+			Usage: "replicate the delete to the peers before returning",
+		},
+	},
+	ArgsUsage: "<BUCKET> <KEY>",
+	Action: func(c *cli.Context) error {
+		bucket := c.Args().First()
+		key := c.Args().Get(1)
+		if bucket == "" || key == "" {
+			return fmt.Errorf("you must enter a bucket and key")
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		if err := client.Datastore().Delete(bucket, key, c.Bool("sync")); err != nil {
+			return err
+		}
+		fmt.Printf("deleted %s/%s\n", bucket, key)
+
+		return nil
+	},
+}
+
+var datastoreDumpCommand = cli.Command{
+	Name:      "dump",
+	Usage:     "dump the keys in the node datastore buckets",
+	Flags:     []cli.Flag{datastoreFormatFlag},
+	ArgsUsage: "[BUCKET...]",
+	Action: func(c *cli.Context) error {
+		out, err := newKeyValueWriter(c.String("format"))
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		buckets := []string(c.Args())
+		if len(buckets) == 0 {
+			resp, err := client.Datastore().Digest()
+			if err != nil {
+				return err
+			}
+			for _, b := range resp.Buckets {
+				buckets = append(buckets, b.Bucket)
+			}
+			sort.Strings(buckets)
+		}
+
+		for _, bucket := range buckets {
+			if err := client.Datastore().SearchPages(bucket, "*", false, func(kvs []*datastoreapi.KeyValue) error {
+				for _, kv := range kvs {
+					if err := out(bucket, kv); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+var datastoreDiffCommand = cli.Command{
+	Name:  "diff",
+	Usage: "compare the datastore contents of two nodes",
+	Description: `Compares the key versions of each bucket on the nodes (including deletes).
+   Nodes are specified by node ID or GRPC address.`,
+	ArgsUsage: "<NODE_A> <NODE_B>",
+	Action: func(c *cli.Context) error {
+		nodeA := c.Args().First()
+		nodeB := c.Args().Get(1)
+		if nodeA == "" || nodeB == "" {
+			return fmt.Errorf("you must enter two nodes")
+		}
+
+		addrA, addrB, err := resolveNodeAddrs(c, nodeA, nodeB)
+		if err != nil {
+			return err
+		}
+		clientA, err := getClientForAddr(c, addrA)
+		if err != nil {
+			return err
+		}
+		defer clientA.Close()
+		clientB, err := getClientForAddr(c, addrB)
+		if err != nil {
+			return err
+		}
+		defer clientB.Close()
+
+		digestA, err := clientA.Datastore().Digest()
+		if err != nil {
+			return err
+		}
+		digestB, err := clientB.Datastore().Digest()
+		if err != nil {
+			return err
+		}
+
+		bucketsA := map[string]*datastoreapi.BucketDigest{}
+		bucketsB := map[string]*datastoreapi.BucketDigest{}
+		names := []string{}
+		for _, b := range digestA.Buckets {
+			bucketsA[b.Bucket] = b
+			names = append(names, b.Bucket)
+		}
+		for _, b := range digestB.Buckets {
+			bucketsB[b.Bucket] = b
+			if _, ok := bucketsA[b.Bucket]; !ok {
+				names = append(names, b.Bucket)
+			}
+		}
+		sort.Strings(names)
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "BUCKET\tKEY\t%s\t%s\n", strings.ToUpper(nodeA), strings.ToUpper(nodeB))
+		differences := 0
+		for _, name := range names {
+			a, b := bucketsA[name], bucketsB[name]
+			if a != nil && b != nil && bytes.Equal(a.Digest, b.Digest) {
+				continue
+			}
+			versionsA, err := bucketVersions(clientA, a)
+			if err != nil {
+				return err
+			}
+			versionsB, err := bucketVersions(clientB, b)
+			if err != nil {
+				return err
+			}
+
+			keys := []string{}
+			for k := range versionsA {
+				keys = append(keys, k)
+			}
+			for k := range versionsB {
+				if _, ok := versionsA[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				va, okA := versionsA[k]
+				vb, okB := versionsB[k]
+				if okA && okB && va.Compare(vb) == 0 {
+					continue
+				}
+				differences++
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, k, formatVersion(va, okA), formatVersion(vb, okB))
+			}
+		}
+		w.Flush()
+
+		if differences == 0 {
+			fmt.Printf("%s and %s are in sync\n", nodeA, nodeB)
+		}
+
+		return nil
+	},
+}
+
+// resolveNodeAddrs returns the GRPC addresses for the nodes; nodes that are
+// not cluster node IDs are used as addresses
+func resolveNodeAddrs(c *cli.Context, nodes ...string) (string, string, error) {
+	client, err := getClient(c)
+	if err != nil {
+		return "", "", err
+	}
+	defer client.Close()
+
+	clusterNodes, err := client.Cluster().Nodes()
+	if err != nil {
+		return "", "", err
+	}
+	addrs := make([]string, len(nodes))
+	for i, n := range nodes {
+		addrs[i] = n
+		for _, node := range clusterNodes {
+			if node.ID == n {
+				addrs[i] = node.Address
+			}
+		}
+	}
+	return addrs[0], addrs[1], nil
+}
+
+// bucketVersions returns the key versions in the bucket or none if the bucket
+// does not exist on the node
+func bucketVersions(client *stellarclient.Client, b *datastoreapi.BucketDigest) (map[string]*datastoreapi.Version, error) {
+	versions := map[string]*datastoreapi.Version{}
+	if b == nil {
+		return versions, nil
+	}
+	kvs, err := client.Datastore().Versions(b.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range kvs {
+		versions[kv.Key] = kv.Version
+	}
+	return versions, nil
+}
+
+func formatVersion(v *datastoreapi.Version, ok bool) string {
+	if !ok {
+		return "missing"
+	}
+	if v == nil {
+		return "unversioned"
+	}
+	return fmt.Sprintf("%s.%d (%s)", time.Unix(0, v.Wall).UTC().Format(time.RFC3339Nano), v.Logical, v.NodeID)
+}
+
+// datastoreKeyValue is the json output for a key
+type datastoreKeyValue struct {
+	Bucket    string                `json:"bucket"`
+	Key       string                `json:"key"`
+	Value     *string               `json:"value,omitempty"`
+	Hex       string                `json:"hex,omitempty"`
+	Version   *datastoreapi.Version `json:"version,omitempty"`
+	ExpiresAt *time.Time            `json:"expires_at,omitempty"`
+}
+
+// newKeyValueWriter returns a func writing the key in the format.  The json
+// format writes one object per line with binary values hex encoded.
+func newKeyValueWriter(format string) (func(string, *datastoreapi.KeyValue) error, error) {
+	switch format {
+	case "text":
+		return func(bucket string, kv *datastoreapi.KeyValue) error {
+			_, err := fmt.Printf("%s/%s\t%s\n", bucket, kv.Key, kv.Value)
+			return err
+		}, nil
+	case "hex":
+		return func(bucket string, kv *datastoreapi.KeyValue) error {
+			_, err := fmt.Printf("%s/%s\t%s\n", bucket, kv.Key, hex.EncodeToString(kv.Value))
+			return err
+		}, nil
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		return func(bucket string, kv *datastoreapi.KeyValue) error {
+			o := &datastoreKeyValue{
+				Bucket:  bucket,
+				Key:     kv.Key,
+				Version: kv.Version,
+			}
+			if kv.Value != nil {
+				if utf8.Valid(kv.Value) {
+					v := string(kv.Value)
+					o.Value = &v
+				} else {
+					o.Hex = hex.EncodeToString(kv.Value)
+				}
+			}
+			if kv.ExpiresAt > 0 {
+				t := time.Unix(0, kv.ExpiresAt).UTC()
+				o.ExpiresAt = &t
+			}
+			return enc.Encode(o)
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid output format: %s", format)
+	}
+}
+
+var datastoreBackupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "write a compressed backup of the node datastore",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "backup file path (default: stdout)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		var w io.Writer = os.Stdout
+		if p := c.String("file"); p != "" {
+			f, err := os.Create(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		return client.Datastore().BackupTo(w)
+	},
+}
+
+var datastoreRestoreCommand = cli.Command{
+	Name:  "restore",
+	Usage: "replace the datastore with a backup or snapshot; the restored data replicates to peers",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "backup file path (use - for stdin)",
+		},
+		cli.StringFlag{
+			Name:  "snapshot, s",
+			Usage: "name of a snapshot on the node",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		p := c.String("file")
+		snapshot := c.String("snapshot")
+		if (p == "") == (snapshot == "") {
+			return fmt.Errorf("you must specify either a file or a snapshot")
+		}
+
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		if snapshot != "" {
+			if err := client.Datastore().RestoreSnapshot(snapshot); err != nil {
+				return err
+			}
+			fmt.Printf("restored snapshot %s\n", snapshot)
+			return nil
+		}
+
+		var r io.Reader = os.Stdin
+		if p != "-" {
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		if err := client.Datastore().RestoreFrom(r); err != nil {
+			return err
+		}
+		fmt.Printf("restored %s\n", p)
+
+		return nil
+	},
+}
+
+var datastoreSnapshotsCommand = cli.Command{
+	Name:  "snapshots",
+	Usage: "list the node datastore snapshots",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		snapshots, err := client.Datastore().Snapshots()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NAME\tSIZE\tCREATED\n")
+		for _, s := range snapshots {
+			created := ""
+			if t, err := ptypes.TimestampFromProto(s.CreatedAt); err == nil {
+				created = humanize.RelTime(t, time.Now(), "ago", "")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, humanize.Bytes(uint64(s.Size_)), created)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var datastoreRotateKeyCommand = cli.Command{
+	Name:  "rotate-key",
+	Usage: "reload the node data keys and re-encrypt the datastore with the primary key",
+	Description: `Add the new key as the first line of the keyfile on every node and run
+   rotate-key against each node.  The previous keys can be removed from the keyfiles
+   once every node has been rotated.  An inline DatastoreEncryptionKey cannot be rotated.`,
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Datastore().RotateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rotated to key %s (re-encrypted %d values)\n", resp.KeyID, resp.Reencrypted)
+
+		return nil
+	},
+}
@@ -2,12 +2,14 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"os"
 	"sort"
 
 	api "github.com/ehazlett/stellar/api/services/application/v1"
 	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	yaml "gopkg.in/yaml.v2"
 )
 
 type ServiceSorter []*runtimeapi.Service
@@ -18,7 +20,9 @@ func (s ServiceSorter) Less(i, j int) bool { return s[i].Name < s[j].Name }
 
 func appInspectOutputText(app *api.App) error {
 	sort.Sort(ServiceSorter(app.Services))
-	t := template.New("app")
+	t := template.New("app").Funcs(template.FuncMap{
+		"bandwidth": formatBandwidth,
+	})
 	tmpl, err := t.Parse(appInspectTemplate)
 	if err != nil {
 		return err
@@ -35,3 +39,49 @@ func appInspectOutputJSON(app *api.App) error {
 	enc.SetIndent("", " ")
 	return enc.Encode(app)
 }
+
+// unmarshalYAML decodes YAML (or JSON) into v using the JSON field names
+// so the generated API types can be loaded from either format
+func unmarshalYAML(data []byte, v interface{}) error {
+	var o interface{}
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	o, err := yamlToJSON(o)
+	if err != nil {
+		return err
+	}
+	j, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(j, v)
+}
+
+func yamlToJSON(o interface{}) (interface{}, error) {
+	switch x := o.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range x {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid key %v", k)
+			}
+			val, err := yamlToJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = val
+		}
+		return m, nil
+	case []interface{}:
+		for i, v := range x {
+			val, err := yamlToJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = val
+		}
+	}
+	return o, nil
+}
@@ -55,8 +55,11 @@ func main() {
 		appCommand,
 		nodeCommand,
 		clusterCommand,
+		datastoreCommand,
 		nameserverCommand,
 		proxyCommand,
+		scheduleCommand,
+		networkCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -65,6 +68,12 @@ func main() {
 }
 
 func getClient(c *cli.Context) (*client.Client, error) {
+	return getClientForAddr(c, c.GlobalString("addr"))
+}
+
+// getClientForAddr returns a client for the node at the address using the
+// global connection options
+func getClientForAddr(c *cli.Context, addr string) (*client.Client, error) {
 	opts := []grpc.DialOption{}
 	cert := c.GlobalString("cert")
 	key := c.GlobalString("key")
@@ -80,5 +89,5 @@ func getClient(c *cli.Context) (*client.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(c.GlobalString("addr"), opts...)
+	return client.NewClient(addr, opts...)
 }
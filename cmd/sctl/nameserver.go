@@ -62,7 +62,7 @@ var nameserverCreateRecordCommand = cli.Command{
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "type, t",
-			Usage: "resource record type (A, CNAME, TXT, SRV, MX)",
+			Usage: "resource record type (A, AAAA, CNAME, TXT, SRV, MX)",
 			Value: "A",
 		},
 		// TODO: handle resource record options
@@ -99,7 +99,7 @@ var nameserverDeleteRecordCommand = cli.Command{
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "type, t",
-			Usage: "resource record type (A, CNAME, TXT, SRV, MX)",
+			Usage: "resource record type (A, AAAA, CNAME, TXT, SRV, MX)",
 			Value: "A",
 		},
 	},
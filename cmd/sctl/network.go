@@ -0,0 +1,632 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	humanize "github.com/dustin/go-humanize"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+)
+
+var networkCommand = cli.Command{
+	Name:  "network",
+	Usage: "manage cluster networking",
+	Subcommands: []cli.Command{
+		networkListCommand,
+		networkCreateCommand,
+		networkDeleteCommand,
+		networkGCCommand,
+		networkPolicyCommand,
+		networkPoolCommand,
+		networkInspectCommand,
+		networkPingCommand,
+	},
+}
+
+var networkGCCommand = cli.Command{
+	Name:  "gc",
+	Usage: "release ip and subnet allocations without a container or node",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "show the orphaned allocations without releasing them",
+		},
+		cli.DurationFlag{
+			Name:  "grace-period",
+			Usage: "release allocations orphaned for at least this long (default: service default)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		orphans, err := client.Network().GC(c.Bool("dry-run"), c.Duration("grace-period"))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "KIND\tNETWORK\tNODE\tID\tADDRESS\tORPHANED\tSTATUS\n")
+		for _, o := range orphans {
+			orphaned := ""
+			if t, err := ptypes.TimestampFromProto(o.OrphanedAt); err == nil {
+				orphaned = humanize.Time(t)
+			}
+			status := "pending"
+			switch {
+			case o.Released && c.Bool("dry-run"):
+				status = "releasable"
+			case o.Released:
+				status = "released"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				strings.ToLower(o.Kind.String()),
+				o.Network,
+				o.Node,
+				o.ID,
+				o.Address,
+				orphaned,
+				status,
+			)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var networkCreateCommand = cli.Command{
+	Name:      "create",
+	Usage:     "create a named network",
+	ArgsUsage: "<NAME>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "subnet",
+			Usage: "cluster subnet for the network (i.e. 10.1.0.0/16)",
+			Value: "",
+		},
+		cli.StringFlag{
+			Name:  "subnet6",
+			Usage: "optional ipv6 cluster subnet for the network",
+			Value: "",
+		},
+		cli.StringFlag{
+			Name:  "bridge",
+			Usage: "node bridge for the network (default: stellar-<NAME>)",
+			Value: "",
+		},
+		cli.BoolFlag{
+			Name:  "isolated",
+			Usage: "drop traffic between the network and other networks",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a network name")
+		}
+
+		network := &api.ClusterNetwork{
+			Name:        name,
+			SubnetCIDR:  c.String("subnet"),
+			Subnet6CIDR: c.String("subnet6"),
+			Bridge:      c.String("bridge"),
+		}
+		if c.Bool("isolated") {
+			network.Isolation = api.NetworkIsolation_ISOLATED
+		}
+		if err := client.Network().CreateNetwork(network); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s created\n", name)
+
+		return nil
+	},
+}
+
+var networkListCommand = cli.Command{
+	Name:  "list",
+	Usage: "list cluster networks",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		networks, err := client.Network().ListNetworks()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NAME\tSUBNET\tSUBNET6\tBRIDGE\tISOLATION\tDEFAULT\n")
+		for _, n := range networks {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%v\n",
+				n.Name,
+				n.SubnetCIDR,
+				n.Subnet6CIDR,
+				n.Bridge,
+				strings.ToLower(n.Isolation.String()),
+				n.Default,
+			)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var networkDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete a named network",
+	ArgsUsage: "<NAME>",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a network name")
+		}
+
+		if err := client.Network().DeleteNetwork(name); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s deleted\n", name)
+
+		return nil
+	},
+}
+
+var networkPolicyCommand = cli.Command{
+	Name:    "policies",
+	Aliases: []string{"policy"},
+	Usage:   "manage network policies",
+	Subcommands: []cli.Command{
+		networkPolicyListCommand,
+		networkPolicyCreateCommand,
+		networkPolicyInspectCommand,
+		networkPolicyDeleteCommand,
+	},
+}
+
+var networkPolicyCreateCommand = cli.Command{
+	Name:  "create",
+	Usage: "create or update a network policy",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path to policy config",
+			Value: "",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		configPath := c.String("file")
+		if configPath == "" {
+			return cli.ShowSubcommandHelp(c)
+		}
+		data, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			return errors.Wrapf(err, "error accessing config %s", configPath)
+		}
+		var policy *api.NetworkPolicy
+		if err := json.Unmarshal(data, &policy); err != nil {
+			return errors.Wrap(err, "error loading config")
+		}
+
+		if err := client.Network().CreatePolicy(policy); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s created\n", policy.Name)
+
+		return nil
+	},
+}
+
+var networkPolicyListCommand = cli.Command{
+	Name:  "list",
+	Usage: "list network policies",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		policies, err := client.Network().Policies()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NAME\tTARGET\tDIRECTION\tRULES\tDEFAULT\n")
+		for _, p := range policies {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+				p.Name,
+				formatPolicyPeer(p.Target),
+				strings.ToLower(p.Direction.String()),
+				len(p.Rules),
+				strings.ToLower(p.DefaultAction.String()),
+			)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var networkPolicyInspectCommand = cli.Command{
+	Name:      "inspect",
+	Usage:     "view network policy details",
+	ArgsUsage: "<NAME>",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a policy name")
+		}
+		policies, err := client.Network().Policies()
+		if err != nil {
+			return err
+		}
+		for _, p := range policies {
+			if p.Name != name {
+				continue
+			}
+			data, err := json.MarshalIndent(p, "", "    ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		return fmt.Errorf("network policy %s not found", name)
+	},
+}
+
+var networkPolicyDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete a network policy",
+	ArgsUsage: "<NAME>",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a policy name")
+		}
+
+		if err := client.Network().DeletePolicy(name); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s deleted\n", name)
+
+		return nil
+	},
+}
+
+var networkPoolCommand = cli.Command{
+	Name:    "pools",
+	Aliases: []string{"pool"},
+	Usage:   "manage ip reservation pools",
+	Subcommands: []cli.Command{
+		networkPoolListCommand,
+		networkPoolCreateCommand,
+		networkPoolDeleteCommand,
+	},
+}
+
+var networkPoolCreateCommand = cli.Command{
+	Name:      "create",
+	Usage:     "create or update an ip reservation pool",
+	ArgsUsage: "<NAME>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "network",
+			Usage: "network of the pool addresses (default: stellar)",
+			Value: "",
+		},
+		cli.StringSliceFlag{
+			Name:  "address, a",
+			Usage: "pool address or range (i.e. 10.0.0.16/28)",
+			Value: &cli.StringSlice{},
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a pool name")
+		}
+
+		pool := &api.IPPool{
+			Name:      name,
+			Network:   c.String("network"),
+			Addresses: c.StringSlice("address"),
+		}
+		if err := client.Network().CreatePool(pool); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s created\n", name)
+
+		return nil
+	},
+}
+
+var networkPoolListCommand = cli.Command{
+	Name:  "list",
+	Usage: "list ip reservation pools",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		pools, err := client.Network().ListPools()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NAME\tNETWORK\tADDRESSES\n")
+		for _, p := range pools {
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				p.Name,
+				p.Network,
+				strings.Join(p.Addresses, ","),
+			)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var networkPoolDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete an ip reservation pool",
+	ArgsUsage: "<NAME>",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		name := c.Args().First()
+		if name == "" {
+			return fmt.Errorf("you must specify a pool name")
+		}
+
+		if err := client.Network().DeletePool(name); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s deleted\n", name)
+
+		return nil
+	},
+}
+
+func formatPolicyPeer(peer *api.PolicyPeer) string {
+	switch {
+	case peer == nil || (peer.Application == "" && peer.Service == "" && peer.CIDR == ""):
+		return "*"
+	case peer.CIDR != "":
+		return peer.CIDR
+	case peer.Service == "":
+		return peer.Application
+	case peer.Application == "":
+		return "*/" + peer.Service
+	}
+	return peer.Application + "/" + peer.Service
+}
+
+var networkInspectCommand = cli.Command{
+	Name:  "inspect",
+	Usage: "compare the network datastore with the state of each node",
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Network().Inspect()
+		if err != nil {
+			return err
+		}
+
+		var bandwidths []string
+		for _, n := range resp.Nodes {
+			for _, b := range n.Bandwidths {
+				bandwidths = append(bandwidths, fmt.Sprintf("%s\t%s\t%s\t%s\n",
+					b.ID,
+					n.NodeID,
+					formatBandwidth(b.Ingress),
+					formatBandwidth(b.Egress),
+				))
+			}
+		}
+		if len(bandwidths) > 0 {
+			w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+			fmt.Fprintf(w, "CONTAINER\tNODE\tINGRESS\tEGRESS\n")
+			for _, b := range bandwidths {
+				fmt.Fprint(w, b)
+			}
+			w.Flush()
+			fmt.Println()
+		}
+
+		if len(resp.Issues) == 0 {
+			fmt.Printf("no issues found on %d nodes\n", len(resp.Nodes))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "KIND\tNODE\tNETWORK\tMESSAGE\n")
+		for _, i := range resp.Issues {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				strings.ToLower(i.Kind.String()),
+				i.Node,
+				i.Network,
+				i.Message,
+			)
+		}
+		w.Flush()
+
+		return fmt.Errorf("%d issues found", len(resp.Issues))
+	},
+}
+
+var networkPingCommand = cli.Command{
+	Name:      "ping",
+	Usage:     "ping a container or address from inside a container",
+	ArgsUsage: "<CONTAINER> <CONTAINER|IP>",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "count, c",
+			Usage: "number of packets to send",
+			Value: 3,
+		},
+		cli.BoolFlag{
+			Name:  "ipv6",
+			Usage: "ping the ipv6 address of the target container",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		source := c.Args().Get(0)
+		target := c.Args().Get(1)
+		if source == "" || target == "" {
+			return fmt.Errorf("you must specify a source container and a target")
+		}
+
+		containers, err := client.Cluster().Containers()
+		if err != nil {
+			return err
+		}
+		var node string
+		for _, ct := range containers {
+			if ct.Container.ID == source {
+				node = ct.Node.Address
+				break
+			}
+		}
+		if node == "" {
+			return fmt.Errorf("container %s not found", source)
+		}
+
+		address := target
+		if net.ParseIP(target) == nil {
+			ips, err := client.Network().IPs("")
+			if err != nil {
+				return err
+			}
+			address, err = pingAddress(ips, source, target, c.Bool("ipv6"))
+			if err != nil {
+				return err
+			}
+		}
+
+		nc, err := getClientForAddr(c, node)
+		if err != nil {
+			return err
+		}
+		defer nc.Close()
+
+		resp, err := nc.Node().Ping(source, address, uint32(c.Int("count")))
+		if err != nil {
+			return err
+		}
+		fmt.Print(resp.Output)
+		if resp.Received == 0 {
+			return fmt.Errorf("%s is unreachable from %s", address, source)
+		}
+
+		return nil
+	},
+}
+
+// pingAddress returns the address of the target container in the first
+// network shared with the source
+func pingAddress(ips []*api.IPAllocation, source, target string, ipv6 bool) (string, error) {
+	addresses := map[string]string{}
+	for _, a := range ips {
+		if a.ID != target {
+			continue
+		}
+		ip := a.IP
+		if ipv6 {
+			ip = a.IP6
+		}
+		if ip != "" {
+			addresses[a.Network] = ip
+		}
+	}
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("no address allocated for %s", target)
+	}
+	for _, a := range ips {
+		if a.ID != source {
+			continue
+		}
+		if ip, ok := addresses[a.Network]; ok {
+			return ip, nil
+		}
+	}
+	return "", fmt.Errorf("%s and %s do not share a network", source, target)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	"github.com/containerd/typeurl"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/application/v1"
+	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	"github.com/pkg/errors"
+)
+
+var scheduleCommand = cli.Command{
+	Name:  "schedule",
+	Usage: "interact with the scheduler",
+	Subcommands: []cli.Command{
+		scheduleSimulateCommand,
+	},
+}
+
+var scheduleSimulateCommand = cli.Command{
+	Name:  "simulate",
+	Usage: "simulate scheduling an application without creating anything",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path to application config",
+			Value: "",
+		},
+		cli.StringFlag{
+			Name:  "nodes",
+			Usage: "path to node list (defaults to the live cluster)",
+			Value: "",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := getClient(c)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		configPath := c.String("file")
+		if configPath == "" {
+			return cli.ShowSubcommandHelp(c)
+		}
+		var req *api.CreateRequest
+		if err := loadConfig(configPath, &req); err != nil {
+			return err
+		}
+
+		var (
+			health     []*clusterapi.NodeHealth
+			override   []*clusterapi.NodeHealth
+			containers []*clusterapi.Container
+		)
+		if nodesPath := c.String("nodes"); nodesPath != "" {
+			if err := loadConfig(nodesPath, &override); err != nil {
+				return err
+			}
+			health = override
+		} else {
+			h, err := client.Cluster().Health()
+			if err != nil {
+				return err
+			}
+			health = h
+
+			cc, err := client.Cluster().Containers()
+			if err != nil {
+				return err
+			}
+			containers = cc
+		}
+
+		sort.Sort(ByNodeID(health))
+		nodes := []*clusterapi.Node{}
+		headroom := map[string]*nodeHeadroom{}
+		for _, h := range health {
+			nodes = append(nodes, h.Node)
+			headroom[h.Node.ID] = newNodeHeadroom(h)
+		}
+		for _, c := range containers {
+			r, ok := headroom[c.Node.ID]
+			if !ok {
+				continue
+			}
+			r.containers++
+			if svc := containerService(c); svc != nil {
+				r.reserve(svc.Resources)
+			}
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		for _, service := range req.Services {
+			resp, err := client.Scheduler().SimulateWithHealth(service, nodes, override)
+			if err != nil {
+				return errors.Wrapf(err, "error simulating service %s", service.Name)
+			}
+
+			ids := []string{}
+			for _, node := range resp.Nodes {
+				ids = append(ids, node.ID)
+				if r, ok := headroom[node.ID]; ok {
+					r.scheduled++
+					r.reserve(service.Resources)
+				}
+			}
+			if len(ids) == 0 {
+				ids = append(ids, "<unschedulable>")
+			}
+
+			fmt.Fprintf(w, "SERVICE\tPRIORITY\tREPLICAS\tNODES\n")
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", service.Name, service.Priority, len(resp.Nodes), strings.Join(ids, ","))
+			if len(resp.Eliminated) > 0 {
+				fmt.Fprintf(w, "  ELIMINATED\tFILTER\tREASON\n")
+				for _, e := range resp.Eliminated {
+					nodeID := e.NodeID
+					if nodeID == "" {
+						nodeID = "-"
+					}
+					fmt.Fprintf(w, "  %s\t%s\t%s\n", nodeID, e.Filter, e.Reason)
+				}
+			}
+			if len(resp.Preemptions) > 0 {
+				fmt.Fprintf(w, "  PREEMPTED\tNODE\tRESCHEDULED\n")
+				for _, p := range resp.Preemptions {
+					target := p.TargetNodeID
+					if target == "" {
+						target = "-"
+					}
+					fmt.Fprintf(w, "  %s\t%s\t%s\n", p.ContainerID, p.NodeID, target)
+					if r, ok := headroom[p.NodeID]; ok {
+						r.release(p.Service.Resources)
+					}
+					if r, ok := headroom[p.TargetNodeID]; ok {
+						r.reserve(p.Service.Resources)
+					}
+				}
+			}
+			fmt.Fprintf(w, "\n")
+		}
+
+		fmt.Fprintf(w, "NODE\tCPUS\tMEMORY\tMEMORY FREE\tCONTAINERS\tSCHEDULED\n")
+		for _, h := range health {
+			r := headroom[h.Node.ID]
+			cpus := "-"
+			memory := "-"
+			memoryFree := "-"
+			if h.Health != nil {
+				cpus = fmt.Sprintf("%.2f", r.cpus)
+				if r.memory > 0 {
+					memory = humanize.Bytes(uint64(r.memory))
+				} else {
+					memory = "0 B"
+				}
+				memoryFree = humanize.Bytes(uint64(h.Health.MemoryFree))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", h.Node.ID, cpus, memory, memoryFree, r.containers, r.scheduled)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+// nodeHeadroom is the unreserved capacity left on a node
+type nodeHeadroom struct {
+	cpus       float64
+	memory     int64
+	containers int
+	scheduled  int
+}
+
+func newNodeHeadroom(h *clusterapi.NodeHealth) *nodeHeadroom {
+	r := &nodeHeadroom{}
+	if h.Health != nil {
+		r.cpus = float64(h.Health.Cpus)
+		r.memory = h.Health.MemoryTotal
+	}
+	return r
+}
+
+func (r *nodeHeadroom) reserve(res *runtimeapi.Resources) {
+	if res == nil {
+		return
+	}
+	r.cpus -= res.Cpus
+	r.memory -= res.Memory
+}
+
+func (r *nodeHeadroom) release(res *runtimeapi.Resources) {
+	if res == nil {
+		return
+	}
+	r.cpus += res.Cpus
+	r.memory += res.Memory
+}
+
+// containerService returns the stellar service for the container if present
+func containerService(c *clusterapi.Container) *runtimeapi.Service {
+	ext, ok := c.Container.Extensions[stellar.StellarServiceExtension]
+	if !ok {
+		return nil
+	}
+	v, err := typeurl.UnmarshalAny(ext)
+	if err != nil {
+		return nil
+	}
+	svc, _ := v.(*runtimeapi.Service)
+	return svc
+}
+
+// loadConfig reads a YAML or JSON config into v
+func loadConfig(configPath string, v interface{}) error {
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return errors.Wrapf(err, "error accessing config %s", configPath)
+	}
+	if err := unmarshalYAML(data, v); err != nil {
+		return errors.Wrapf(err, "error loading config %s", configPath)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/containernetworking/cni/pkg/types"
 	"github.com/containernetworking/cni/pkg/types/current"
 	cniversion "github.com/containernetworking/cni/pkg/version"
+	"github.com/ehazlett/stellar"
 	"github.com/ehazlett/stellar/client"
 	"github.com/ehazlett/stellar/version"
 )
@@ -24,6 +25,16 @@ type IPAMConfig struct {
 	Type     string `json:"type"`
 	NodeName string `json:"node_name"`
 	PeerAddr string `json:"peer_addr"`
+	// Network is the named network; empty for the default network
+	Network string `json:"network"`
+	// Routes are the destinations routed via the gateway; the default route
+	// is used if empty
+	Routes []string `json:"routes"`
+	// IP is the address to reserve for the container in the subnet of the
+	// same family
+	IP string `json:"ip"`
+	// Pool is the reservation pool to allocate the addresses from
+	Pool string `json:"pool"`
 }
 
 func main() {
@@ -62,27 +73,33 @@ func cmdAdd(args *skel.CmdArgs) error {
 	}
 
 	id := args.ContainerID
-	ip, subnet, err := allocateIP(id, cfg.NodeName, cfg.PeerAddr)
+	allocations, err := allocateIPs(id, cfg)
 	if err != nil {
 		return err
 	}
 
-	gw := ip.Mask(subnet.Mask)
-	gw[3]++
-
 	result := &current.Result{}
-	result.IPs = []*current.IPConfig{
-		{
-			Version: "4",
-			Address: net.IPNet{IP: ip, Mask: subnet.Mask},
+	for _, a := range allocations {
+		gw := stellar.Gateway(a.subnet)
+		version := "4"
+		if a.ip.To4() == nil {
+			version = "6"
+		}
+		result.IPs = append(result.IPs, &current.IPConfig{
+			Version: version,
+			Address: net.IPNet{IP: a.ip, Mask: a.subnet.Mask},
 			Gateway: gw,
-		},
-	}
-	result.Routes = []*types.Route{
-		{
-			Dst: net.IPNet{IP: net.IP{0, 0, 0, 0}, Mask: net.IPv4Mask(0, 0, 0, 0)},
-			GW:  gw,
-		},
+		})
+		dsts, err := routeDestinations(cfg.Routes, a.ip.To4() == nil)
+		if err != nil {
+			return err
+		}
+		for _, dst := range dsts {
+			result.Routes = append(result.Routes, &types.Route{
+				Dst: dst,
+				GW:  gw,
+			})
+		}
 	}
 
 	return types.PrintResult(result, confVersion)
@@ -94,48 +111,99 @@ func cmdDel(args *skel.CmdArgs) error {
 		return err
 	}
 	id := args.ContainerID
-	if err := releaseIP(id, cfg.NodeName, cfg.PeerAddr); err != nil {
+	if err := releaseIP(id, cfg.Network, cfg.NodeName, cfg.PeerAddr); err != nil {
 		return err
 	}
 	return nil
 }
 
-func allocateIP(id, nodeName, peerAddr string) (net.IP, *net.IPNet, error) {
-	c, err := client.NewClient(peerAddr)
+// routeDestinations returns the route destinations in the address family.
+// The default route is returned if no routes are configured.
+func routeDestinations(routes []string, ipv6 bool) ([]net.IPNet, error) {
+	if len(routes) == 0 {
+		if ipv6 {
+			return []net.IPNet{{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}}, nil
+		}
+		return []net.IPNet{{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 32)}}, nil
+	}
+	var dsts []net.IPNet
+	for _, r := range routes {
+		_, dst, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, err
+		}
+		if (dst.IP.To4() == nil) == ipv6 {
+			dsts = append(dsts, *dst)
+		}
+	}
+	return dsts, nil
+}
+
+type allocation struct {
+	ip     net.IP
+	subnet *net.IPNet
+}
+
+// allocateIPs allocates an ip from the node subnet of the network and from
+// the node ipv6 subnet if ipv6 is enabled for the network.  The configured ip
+// is only requested from the subnet in its family.
+func allocateIPs(id string, cfg *IPAMConfig) ([]*allocation, error) {
+	c, err := client.NewClient(cfg.PeerAddr)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer c.Close()
 
-	subnetCIDR, err := c.Network().GetSubnet(nodeName)
-	if err != nil {
-		return nil, nil, err
-	}
-	_, subnet, err := net.ParseCIDR(subnetCIDR)
-	if err != nil {
-		return nil, nil, err
+	var static net.IP
+	if cfg.IP != "" {
+		if static = net.ParseIP(cfg.IP); static == nil {
+			return nil, fmt.Errorf("invalid ip %q", cfg.IP)
+		}
 	}
 
-	ip, err := c.Network().AllocateIP(id, nodeName, subnetCIDR)
+	subnets, err := c.Network().GetNetworkSubnet(cfg.Network, cfg.NodeName)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	return ip, subnet, nil
+		return nil, err
+	}
+
+	var allocations []*allocation
+	for _, cidr := range []string{subnets.SubnetCIDR, subnets.Subnet6CIDR} {
+		if cidr == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		reserved := ""
+		if static != nil && (static.To4() == nil) == (subnet.IP.To4() == nil) {
+			reserved = static.String()
+		}
+		ip, err := c.Network().ReserveNetworkIP(cfg.Network, id, cfg.NodeName, cidr, reserved, cfg.Pool)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, &allocation{
+			ip:     ip,
+			subnet: subnet,
+		})
+	}
+
+	return allocations, nil
 }
 
-func releaseIP(id, nodeName, peerAddr string) error {
+func releaseIP(id, network, nodeName, peerAddr string) error {
 	c, err := client.NewClient(peerAddr)
 	if err != nil {
 		return err
 	}
 	defer c.Close()
 
-	ip, err := c.Network().GetIP(id, nodeName)
+	ip, err := c.Network().GetNetworkIP(network, id, nodeName)
 	if err != nil {
 		return err
 	}
-	if _, err := c.Network().ReleaseIP(id, ip.String(), nodeName); err != nil {
+	if _, err := c.Network().ReleaseNetworkIP(network, id, ip.String(), nodeName); err != nil {
 		return err
 	}
 
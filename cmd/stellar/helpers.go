@@ -68,25 +68,32 @@ func defaultConfig(ctx *cli.Context) (*stellar.Config, error) {
 		return nil, err
 	}
 	return &stellar.Config{
-		NodeID:                   nodeID,
-		GRPCAddress:              fmt.Sprintf("%s:%d", ip, 9000),
-		AgentConfig:              agentConfig,
-		ContainerdAddr:           "/run/containerd/containerd.sock",
-		Namespace:                ctx.String("namespace"),
-		Subnet:                   subnet,
-		DataDir:                  "/var/lib/stellar",
-		StateDir:                 "/run/stellar",
-		Bridge:                   "stellar0",
-		UpstreamDNSAddr:          "8.8.8.8:53",
-		ProxyHTTPPort:            80,
-		ProxyHTTPSPort:           443,
-		ProxyTLSEmail:            "",
-		ProxyHealthcheckInterval: time.Second * 5,
-		GatewayAddress:           fmt.Sprintf("%s:%d", ip, 9001),
-		EventsAddress:            fmt.Sprintf("%s:%d", ip, 4222),
-		EventsHTTPAddress:        fmt.Sprintf("%s:%d", ip, 4322),
-		EventsClusterAddress:     fmt.Sprintf("%s:%d", ip, 5222),
-		CNIBinPaths:              []string{"/opt/containerd/bin", "/opt/cni/bin"},
+		NodeID:                     nodeID,
+		GRPCAddress:                fmt.Sprintf("%s:%d", ip, 9000),
+		AgentConfig:                agentConfig,
+		ContainerdAddr:             "/run/containerd/containerd.sock",
+		Namespace:                  ctx.String("namespace"),
+		Subnet:                     subnet,
+		DataDir:                    "/var/lib/stellar",
+		DatastoreEngine:            "bolt",
+		DatastoreSnapshotDir:       "/var/lib/stellar/snapshots",
+		DatastoreSnapshotInterval:  time.Hour * 1,
+		DatastoreSnapshotRetention: 24,
+		StateDir:                   "/run/stellar",
+		Bridge:                     "stellar0",
+		NetworkMode:                stellar.NetworkModeRouted,
+		VXLANID:                    1,
+		VXLANPort:                  4789,
+		UpstreamDNSAddr:            "8.8.8.8:53",
+		ProxyHTTPPort:              80,
+		ProxyHTTPSPort:             443,
+		ProxyTLSEmail:              "",
+		ProxyHealthcheckInterval:   time.Second * 5,
+		GatewayAddress:             fmt.Sprintf("%s:%d", ip, 9001),
+		EventsAddress:              fmt.Sprintf("%s:%d", ip, 4222),
+		EventsHTTPAddress:          fmt.Sprintf("%s:%d", ip, 4322),
+		EventsClusterAddress:       fmt.Sprintf("%s:%d", ip, 5222),
+		CNIBinPaths:                []string{"/opt/containerd/bin", "/opt/cni/bin"},
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package stellar
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"time"
 
@@ -37,12 +38,33 @@ type Config struct {
 	Namespace string
 	// Subnet is the subnet to use for stellar networking
 	Subnet *net.IPNet
+	// Subnet6 is the optional IPv6 subnet to use for dual-stack stellar networking
+	Subnet6 *net.IPNet
 	// DataDir is the directory used to store stellar data
 	DataDir string
+	// DatastoreEngine is the storage engine for the datastore (bolt or memory)
+	DatastoreEngine string
+	// DatastoreSnapshotDir is the directory for datastore snapshots
+	DatastoreSnapshotDir string
+	// DatastoreSnapshotInterval is the interval between datastore snapshots; zero disables snapshots
+	DatastoreSnapshotInterval time.Duration
+	// DatastoreSnapshotRetention is the number of datastore snapshots kept
+	DatastoreSnapshotRetention int
+	// DatastoreEncryptionKey is the base64 encoded 256 bit cluster data key used to encrypt the datastore at rest
+	DatastoreEncryptionKey string
+	// DatastoreEncryptionKeyFile is a file of base64 encoded data keys, one per line; the first key
+	// encrypts and the others decrypt values written before a rotation
+	DatastoreEncryptionKeyFile string
 	// State is the directory to store run state
 	StateDir string
 	// Bridge is the name of the bridge for networking
 	Bridge string
+	// NetworkMode is the multi-host networking mode (routed or vxlan)
+	NetworkMode string
+	// VXLANID is the VXLAN network identifier used in vxlan mode
+	VXLANID int
+	// VXLANPort is the UDP port used for VXLAN traffic in vxlan mode
+	VXLANPort int
 	// UpstreamDNSAddr is the address to use for external queries
 	UpstreamDNSAddr string
 	// ProxyHTTPPort is the http port to use for the proxy service
@@ -69,18 +91,26 @@ type Config struct {
 func (c *Config) MarshalJSON() ([]byte, error) {
 	type Alias Config
 	type Agent element.Config
+	subnet6 := ""
+	if c.Subnet6 != nil {
+		subnet6 = c.Subnet6.String()
+	}
 	return json.Marshal(&struct {
 		*Alias
 		*Agent
-		Peers                    []string
-		Subnet                   string
-		ProxyHealthcheckInterval string
+		Peers                     []string
+		Subnet                    string
+		Subnet6                   string
+		ProxyHealthcheckInterval  string
+		DatastoreSnapshotInterval string
 	}{
-		Alias:                    (*Alias)(c),
-		Agent:                    (*Agent)(c.AgentConfig),
-		Peers:                    c.AgentConfig.Peers,
-		Subnet:                   c.Subnet.String(),
-		ProxyHealthcheckInterval: c.ProxyHealthcheckInterval.String(),
+		Alias:                     (*Alias)(c),
+		Agent:                     (*Agent)(c.AgentConfig),
+		Peers:                     c.AgentConfig.Peers,
+		Subnet:                    c.Subnet.String(),
+		Subnet6:                   subnet6,
+		ProxyHealthcheckInterval:  c.ProxyHealthcheckInterval.String(),
+		DatastoreSnapshotInterval: c.DatastoreSnapshotInterval.String(),
 	})
 }
 
@@ -91,8 +121,10 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	tmp := &struct {
 		*Alias
 		*Agent
-		Subnet                   string
-		ProxyHealthcheckInterval string
+		Subnet                    string
+		Subnet6                   string
+		ProxyHealthcheckInterval  string
+		DatastoreSnapshotInterval string
 	}{
 		Alias: (*Alias)(c),
 		Agent: (*Agent)(c.AgentConfig),
@@ -115,11 +147,32 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	}
 	c.Subnet = subnet
 
+	// ipv6 is only enabled if a subnet is configured
+	if tmp.Subnet6 != "" {
+		_, subnet6, err := net.ParseCIDR(tmp.Subnet6)
+		if err != nil {
+			return err
+		}
+		if subnet6.IP.To4() != nil {
+			return fmt.Errorf("invalid Subnet6 %s: not an IPv6 subnet", tmp.Subnet6)
+		}
+		c.Subnet6 = subnet6
+	}
+
 	d, err := time.ParseDuration(tmp.ProxyHealthcheckInterval)
 	if err != nil {
 		return err
 	}
 	c.ProxyHealthcheckInterval = d
 
+	// snapshots are disabled if no interval is configured
+	if tmp.DatastoreSnapshotInterval != "" {
+		d, err := time.ParseDuration(tmp.DatastoreSnapshotInterval)
+		if err != nil {
+			return err
+		}
+		c.DatastoreSnapshotInterval = d
+	}
+
 	return nil
 }
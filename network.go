@@ -0,0 +1,20 @@
+package stellar
+
+import (
+	"net"
+)
+
+// Gateway returns the gateway address for the subnet which is the first
+// address after the network address for both IPv4 and IPv6 subnets
+func Gateway(ipnet *net.IPNet) net.IP {
+	ip := ipnet.IP.Mask(ipnet.Mask)
+	gw := make(net.IP, len(ip))
+	copy(gw, ip)
+	for i := len(gw) - 1; i >= 0; i-- {
+		gw[i]++
+		if gw[i] > 0 {
+			break
+		}
+	}
+	return gw
+}
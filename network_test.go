@@ -0,0 +1,23 @@
+package stellar
+
+import (
+	"net"
+	"testing"
+)
+
+func TestGateway(t *testing.T) {
+	for cidr, expected := range map[string]string{
+		"172.16.4.0/22":    "172.16.4.1",
+		"10.0.0.42/8":      "10.0.0.1",
+		"fd00:0:0:40::/58": "fd00:0:0:40::1",
+		"fd00::1234/64":    "fd00::1",
+	} {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gw := Gateway(ipnet); gw.String() != expected {
+			t.Fatalf("expected gateway %s for %s; received %s", expected, cidr, gw)
+		}
+	}
+}
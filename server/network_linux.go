@@ -7,9 +7,11 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/ehazlett/stellar"
 	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	"github.com/ehazlett/stellar/client"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
@@ -23,6 +25,11 @@ ndots 0
 
 func (s *Server) initNetworking() error {
 	logrus.Debug("network init")
+	switch s.config.NetworkMode {
+	case "", stellar.NetworkModeRouted, stellar.NetworkModeVXLAN:
+	default:
+		return fmt.Errorf("unknown network mode %q", s.config.NetworkMode)
+	}
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
 		return err
@@ -40,13 +47,32 @@ func (s *Server) initNetworking() error {
 	}
 	logrus.Debugf("setting up subnet %s", subnetCIDR)
 
-	gw := ip.Mask(ipnet.Mask)
-	gw[3]++
+	gw := stellar.Gateway(ipnet)
+	gateways := []*net.IPNet{
+		{IP: gw, Mask: ipnet.Mask},
+	}
+	fields := logrus.Fields{
+		"subnet":  subnetCIDR,
+		"gateway": gw.String(),
+	}
 
-	mask, _ := ipnet.Mask.Size()
+	subnet6CIDR, err := c.Network().GetSubnet6(s.NodeID())
+	if err != nil {
+		return err
+	}
+	if subnet6CIDR != "" {
+		_, ipnet6, err := net.ParseCIDR(subnet6CIDR)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing subnet %q", subnet6CIDR)
+		}
+		gw6 := stellar.Gateway(ipnet6)
+		gateways = append(gateways, &net.IPNet{IP: gw6, Mask: ipnet6.Mask})
+		fields["subnet6"] = subnet6CIDR
+		fields["gateway6"] = gw6.String()
+	}
 
 	logrus.Debugf("setting up local gateway %s", gw.String())
-	if err := s.setupGateway(gw, mask); err != nil {
+	if err := s.setupGateway(gateways...); err != nil {
 		return err
 	}
 
@@ -54,10 +80,7 @@ func (s *Server) initNetworking() error {
 		return err
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"subnet":  subnetCIDR,
-		"gateway": gw.String(),
-	}).Info("network initialized")
+	logrus.WithFields(fields).Info("network initialized")
 
 	return nil
 }
@@ -156,7 +179,10 @@ func (s *Server) getBindDeviceName() (string, error) {
 	return "", fmt.Errorf("unable to find interface for bind addr %s", bindHost)
 }
 
-func (s *Server) setupGateway(ip net.IP, mask int) error {
+// setupGateway assigns the gateway addresses to the bridge and publishes the
+// node subnet routes.  The first gateway is the ipv4 gateway; the optional
+// second gateway is the ipv6 gateway.
+func (s *Server) setupGateway(gateways ...*net.IPNet) error {
 	logrus.Debug("setting up gateway")
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
@@ -171,12 +197,6 @@ func (s *Server) setupGateway(ip net.IP, mask int) error {
 
 	logrus.Debugf("bind interface: %s", bindInterface)
 
-	target := fmt.Sprintf("%s/%d", ip.String(), mask)
-	brAddr, err := netlink.ParseAddr(target)
-	if err != nil {
-		return err
-	}
-
 	brLink, err := netlink.LinkByName(s.config.Bridge)
 	if err != nil {
 		if _, ok := err.(netlink.LinkNotFoundError); !ok {
@@ -204,6 +224,10 @@ func (s *Server) setupGateway(ip net.IP, mask int) error {
 	}
 
 	for _, addr := range addrs {
+		// keep the kernel assigned link local address
+		if addr.IP.IsLinkLocalUnicast() {
+			continue
+		}
 		if err := netlink.AddrDel(brLink, &addr); err != nil {
 			logrus.WithFields(logrus.Fields{
 				"addr":   addr,
@@ -212,23 +236,50 @@ func (s *Server) setupGateway(ip net.IP, mask int) error {
 		}
 	}
 
-	if err := netlink.AddrAdd(brLink, brAddr); err != nil {
-		return err
-	}
-
 	bindIP, err := s.getBindIP()
 	if err != nil {
 		return err
 	}
 
-	// add route
-	_, ipnet, err := net.ParseCIDR(target)
-	if err != nil {
-		return err
+	for _, gw := range gateways {
+		brAddr := &netlink.Addr{
+			IPNet: &net.IPNet{IP: gw.IP, Mask: gw.Mask},
+		}
+		target := bindIP
+		if gw.IP.To4() == nil {
+			// the bridge has no carrier until a container is attached so
+			// duplicate address detection would leave the address tentative
+			brAddr.Flags = syscall.IFA_F_NODAD
+			// ipv6 routes need an ipv6 next hop unless encapsulated
+			if s.config.NetworkMode != stellar.NetworkModeVXLAN {
+				target, err = s.getBindIP6()
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := netlink.AddrAdd(brLink, brAddr); err != nil {
+			return err
+		}
+
+		if target == nil {
+			logrus.Warnf("no ipv6 address on the bind interface; subnet %s will not be routed to this node", gw)
+			continue
+		}
+
+		// add route
+		networkCIDR := (&net.IPNet{IP: gw.IP.Mask(gw.Mask), Mask: gw.Mask}).String()
+		if err := c.Network().AddRoute(networkCIDR, target.String(), s.NodeID()); err != nil {
+			return err
+		}
 	}
-	networkCIDR := fmt.Sprintf("%s/%d", ipnet.IP.String(), mask)
-	if err := c.Network().AddRoute(networkCIDR, bindIP.String()); err != nil {
-		return err
+
+	// peers resolve the gateway from its address in vxlan mode
+	if s.config.NetworkMode == stellar.NetworkModeVXLAN && len(gateways) > 0 {
+		if err := netlink.LinkSetHardwareAddr(brLink, gatewayMAC(gateways[0].IP)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -244,7 +295,37 @@ func (s *Server) getBindIP() (net.IP, error) {
 	return bindIP, nil
 }
 
+// getBindIP6 returns the first global ipv6 address on the bind interface.  A
+// nil address is returned if the interface has no global ipv6 address.
+func (s *Server) getBindIP6() (net.IP, error) {
+	deviceName, err := s.getBindDeviceName()
+	if err != nil {
+		return nil, err
+	}
+	dev, err := netlink.LinkByName(deviceName)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := netlink.AddrList(dev, netlink.FAMILY_V6)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.IP.IsGlobalUnicast() {
+			return addr.IP, nil
+		}
+	}
+	return nil, nil
+}
+
 func (s *Server) setupRoutes() error {
+	if s.config.NetworkMode == stellar.NetworkModeVXLAN {
+		return s.setupOverlay()
+	}
+	if err := s.removeOverlay(); err != nil {
+		return err
+	}
+
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
 		return err
@@ -265,8 +346,20 @@ func (s *Server) setupRoutes() error {
 	if err != nil {
 		return err
 	}
+	bindIP6, err := s.getBindIP6()
+	if err != nil {
+		return err
+	}
 
-	routes, err := c.Network().Routes()
+	networks, err := s.clusterNetworks(c)
+	if err != nil {
+		return err
+	}
+	routes, err := s.peerRoutes(c)
+	if err != nil {
+		return err
+	}
+	expected := map[string]string{}
 	for _, r := range routes {
 		_, ipnet, err := net.ParseCIDR(r.CIDR)
 		if err != nil {
@@ -275,11 +368,23 @@ func (s *Server) setupRoutes() error {
 		}
 
 		gw := net.ParseIP(r.Target)
-		if err != nil {
-			logrus.Errorf("error parsing target CIDR: %s", err)
+		if gw == nil {
+			logrus.Errorf("error parsing route target %s", r.Target)
+			continue
+		}
+		// ipv6 subnets of nodes in vxlan mode are routed via the ipv4 bind
+		// address and cannot be routed without the overlay
+		if (ipnet.IP.To4() == nil) != (gw.To4() == nil) {
+			logrus.Debugf("skipping route %s via %s; address family mismatch", r.CIDR, r.Target)
 			continue
 		}
 
+		if bindIP.Equal(gw) || (bindIP6 != nil && bindIP6.Equal(gw)) {
+			logrus.Debugf("skipping local route %s", r.CIDR)
+			continue
+		}
+		expected[ipnet.String()] = gw.String()
+
 		// check for route
 		exists, err := routeExists(dev, ipnet, gw)
 		if err != nil {
@@ -291,11 +396,6 @@ func (s *Server) setupRoutes() error {
 			continue
 		}
 
-		if bindIP.Equal(gw) {
-			logrus.Debugf("skipping local route %s", r.CIDR)
-			continue
-		}
-
 		logrus.Debugf("configuring peer route %s via %s", r.CIDR, r.Target)
 		route := &netlink.Route{
 			LinkIndex: dev.Attrs().Index,
@@ -307,11 +407,76 @@ func (s *Server) setupRoutes() error {
 		}
 	}
 
+	if err := pruneRoutes(dev, networks, expected); err != nil {
+		return err
+	}
+	// remove the overlay routes if the node was in vxlan mode
+	if br, err := netlink.LinkByName(s.config.Bridge); err == nil {
+		return pruneRoutes(br, networks, nil)
+	}
+
+	return nil
+}
+
+// clusterNetworks returns the subnets of the default and named networks
+func (s *Server) clusterNetworks(c *client.Client) ([]*net.IPNet, error) {
+	networks, err := c.Network().ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+	var subnets []*net.IPNet
+	for _, n := range networks {
+		for _, cidr := range []string{n.SubnetCIDR, n.Subnet6CIDR} {
+			if cidr == "" {
+				continue
+			}
+			_, ipnet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			subnets = append(subnets, ipnet)
+		}
+	}
+	return subnets, nil
+}
+
+// pruneRoutes removes the routes on the link to the cluster networks that are
+// not via the expected gateway.  Routes without a gateway are the routes of
+// the local bridges and are kept.
+func pruneRoutes(link netlink.Link, networks []*net.IPNet, expected map[string]string) error {
+	routes, err := netlink.RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if r.Dst == nil || r.Gw == nil || !inNetworks(networks, r.Dst) {
+			continue
+		}
+		if expected[r.Dst.String()] == r.Gw.String() {
+			continue
+		}
+		logrus.Infof("removing stale route %s via %s", r.Dst, r.Gw)
+		if err := netlink.RouteDel(&r); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// inNetworks returns true if the subnet is within one of the networks
+func inNetworks(networks []*net.IPNet, subnet *net.IPNet) bool {
+	ones, bits := subnet.Mask.Size()
+	for _, n := range networks {
+		nOnes, nBits := n.Mask.Size()
+		if bits == nBits && ones >= nOnes && n.Contains(subnet.IP) {
+			return true
+		}
+	}
+	return false
+}
+
 func routeExists(link netlink.Link, network *net.IPNet, gateway net.IP) (bool, error) {
-	routes, err := netlink.RouteList(link, netlink.FAMILY_V4)
+	routes, err := netlink.RouteList(link, routeFamily(network))
 	if err != nil {
 		return false, err
 	}
@@ -328,6 +493,14 @@ func routeExists(link netlink.Link, network *net.IPNet, gateway net.IP) (bool, e
 	return false, nil
 }
 
+// routeFamily returns the netlink address family for the network
+func routeFamily(network *net.IPNet) int {
+	if network.IP.To4() == nil {
+		return netlink.FAMILY_V6
+	}
+	return netlink.FAMILY_V4
+}
+
 func networkEnabled(container *runtimeapi.Container) bool {
 	_, exists := container.Labels[stellar.StellarNetworkLabel]
 	return exists
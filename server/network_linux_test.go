@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net"
+	"testing"
+)
+
+func TestInNetworks(t *testing.T) {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"172.16.0.0/12", "fd00:5354::/48"} {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		networks = append(networks, ipnet)
+	}
+	for cidr, expected := range map[string]bool{
+		"172.16.4.0/22":       true,
+		"172.16.0.0/12":       true,
+		"172.0.0.0/8":         false,
+		"10.0.0.0/22":         false,
+		"fd00:5354:0:40::/58": true,
+		"fd00::/16":           false,
+	} {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := inNetworks(networks, ipnet); v != expected {
+			t.Fatalf("expected %v for %s; received %v", expected, cidr, v)
+		}
+	}
+}
@@ -8,6 +8,14 @@ func (s *Server) initNetworking() error {
 	return fmt.Errorf("networking not supported")
 }
 
+func (s *Server) setupNetworks() error {
+	return fmt.Errorf("networking not supported")
+}
+
 func (s *Server) setupRoutes() error {
 	return fmt.Errorf("networking not supported")
 }
+
+func (s *Server) setupPolicies() error {
+	return fmt.Errorf("networking not supported")
+}
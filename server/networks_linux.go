@@ -0,0 +1,159 @@
+package server
+
+import (
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/ehazlett/stellar"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// networkBridgeAlias is the alias prefix of the named network bridges
+	networkBridgeAlias = "stellar:"
+)
+
+// setupNetworks creates the bridges for the named networks, assigns the node
+// gateways and publishes the node subnet routes.  Bridges of networks that
+// have been removed are deleted.
+func (s *Server) setupNetworks() error {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	networks, err := c.Network().ListNetworks()
+	if err != nil {
+		return err
+	}
+	routes, err := c.Network().Routes()
+	if err != nil {
+		return err
+	}
+	published := map[string]string{}
+	for _, r := range routes {
+		if r.Node == s.NodeID() {
+			published[r.CIDR] = r.Target
+		}
+	}
+
+	bindIP, err := s.getBindIP()
+	if err != nil {
+		return err
+	}
+	bindIP6, err := s.getBindIP6()
+	if err != nil {
+		return err
+	}
+
+	bridges := map[string]bool{}
+	for _, n := range networks {
+		if n.Default {
+			continue
+		}
+		bridges[n.Bridge] = true
+
+		subnets, err := c.Network().GetNetworkSubnet(n.Name, s.NodeID())
+		if err != nil {
+			return err
+		}
+		if subnets.SubnetCIDR == "" {
+			logrus.Warnf("no subnet for network %s on node %s", n.Name, s.NodeID())
+			continue
+		}
+
+		br, err := ensureNetworkBridge(n.Bridge, networkBridgeAlias+n.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error setting up bridge for network %s", n.Name)
+		}
+
+		for _, cidr := range []string{subnets.SubnetCIDR, subnets.Subnet6CIDR} {
+			if cidr == "" {
+				continue
+			}
+			_, ipnet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return err
+			}
+			addr := &netlink.Addr{
+				IPNet: &net.IPNet{IP: stellar.Gateway(ipnet), Mask: ipnet.Mask},
+			}
+			target := bindIP
+			if ipnet.IP.To4() == nil {
+				addr.Flags = syscall.IFA_F_NODAD
+				if s.config.NetworkMode != stellar.NetworkModeVXLAN {
+					target = bindIP6
+				}
+			}
+			if err := netlink.AddrReplace(br, addr); err != nil {
+				return errors.Wrapf(err, "error assigning gateway %s for network %s", addr.IPNet, n.Name)
+			}
+			if target == nil {
+				logrus.Debugf("no ipv6 address on the bind interface; subnet %s will not be routed to this node", cidr)
+				continue
+			}
+			if published[ipnet.String()] == target.String() {
+				continue
+			}
+			logrus.Debugf("publishing route %s via %s for network %s", ipnet, target, n.Name)
+			if err := c.Network().AddRoute(ipnet.String(), target.String(), s.NodeID()); err != nil {
+				return err
+			}
+		}
+	}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		attrs := link.Attrs()
+		if !strings.HasPrefix(attrs.Alias, networkBridgeAlias) || bridges[attrs.Name] {
+			continue
+		}
+		logrus.Infof("removing bridge %s of deleted network %s", attrs.Name, strings.TrimPrefix(attrs.Alias, networkBridgeAlias))
+		if err := netlink.LinkDel(link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureNetworkBridge returns the bridge for the named network creating it if
+// needed
+func ensureNetworkBridge(name, alias string) (netlink.Link, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return nil, err
+		}
+		logrus.Infof("creating network bridge %s", name)
+		if err := netlink.LinkAdd(&netlink.Bridge{
+			LinkAttrs: netlink.LinkAttrs{
+				Name: name,
+			},
+		}); err != nil {
+			return nil, err
+		}
+		if link, err = netlink.LinkByName(name); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := link.(*netlink.Bridge); !ok {
+		return nil, errors.Errorf("network device %s is not a bridge", name)
+	}
+	if link.Attrs().Alias != alias {
+		if err := netlink.LinkSetAlias(link, alias); err != nil {
+			return nil, err
+		}
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"bytes"
+	"net"
+	"syscall"
+
+	"github.com/ehazlett/stellar"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	defaultVXLANID   = 1
+	defaultVXLANPort = 4789
+	// vxlanOverhead is the size of the VXLAN encapsulation headers
+	vxlanOverhead = 50
+)
+
+// gatewayMACPrefix is the locally administered prefix of the gateway MACs
+var gatewayMACPrefix = net.HardwareAddr{0x02, 0x53}
+
+// setupOverlay configures the VXLAN overlay.  The VTEP is attached to the
+// bridge so the node bridges form a single segment.  Each peer subnet is
+// routed via the peer gateway whose MAC is derived from the gateway IP so the
+// FDB and ARP entries can be populated from the datastore routes.
+func (s *Server) setupOverlay() error {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	bindDeviceName, err := s.getBindDeviceName()
+	if err != nil {
+		return err
+	}
+	bindDev, err := netlink.LinkByName(bindDeviceName)
+	if err != nil {
+		return err
+	}
+	bindIP, err := s.getBindIP()
+	if err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(s.config.Bridge)
+	if err != nil {
+		return err
+	}
+	br, ok := link.(*netlink.Bridge)
+	if !ok {
+		return errors.Errorf("network device %s is not a bridge", s.config.Bridge)
+	}
+
+	vtep, err := s.ensureVTEP(bindDev, bindIP, br)
+	if err != nil {
+		return err
+	}
+
+	networks, err := s.clusterNetworks(c)
+	if err != nil {
+		return err
+	}
+	routes, err := s.peerRoutes(c)
+	if err != nil {
+		return err
+	}
+
+	// the bridge MAC of each peer is derived from the ipv4 gateway of the
+	// cluster subnet so the ipv6 and named network gateways of the peer
+	// resolve to the same MAC and are routed by the peer
+	macs := map[string]net.HardwareAddr{}
+	for _, r := range routes {
+		if _, ipnet, err := net.ParseCIDR(r.CIDR); err == nil && s.config.Subnet.Contains(ipnet.IP) {
+			macs[r.Target] = gatewayMAC(stellar.Gateway(ipnet))
+		}
+	}
+
+	peers := map[string]bool{}
+	gateways := map[string]bool{}
+	expected := map[string]string{}
+	for _, r := range routes {
+		target := net.ParseIP(r.Target)
+		if target == nil || target.Equal(bindIP) {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(r.CIDR)
+		if err != nil {
+			logrus.Warnf("error setting up overlay route %s", r.CIDR)
+			continue
+		}
+		gw := stellar.Gateway(ipnet)
+		mac, ok := macs[r.Target]
+		if !ok {
+			logrus.Warnf("no ipv4 subnet for overlay peer %s; skipping route %s", r.Target, r.CIDR)
+			continue
+		}
+
+		// forward frames for the peer gateway to the peer VTEP
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    vtep.Attrs().Index,
+			Family:       syscall.AF_BRIDGE,
+			State:        netlink.NUD_PERMANENT,
+			Flags:        netlink.NTF_SELF,
+			IP:           target,
+			HardwareAddr: mac,
+		}); err != nil {
+			return errors.Wrapf(err, "error adding fdb entry for %s", target)
+		}
+		// flood broadcast frames to the peer
+		if err := netlink.NeighAppend(&netlink.Neigh{
+			LinkIndex:    vtep.Attrs().Index,
+			Family:       syscall.AF_BRIDGE,
+			State:        netlink.NUD_PERMANENT,
+			Flags:        netlink.NTF_SELF,
+			IP:           target,
+			HardwareAddr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+		}); err != nil && err != syscall.EEXIST {
+			return errors.Wrapf(err, "error adding flood entry for %s", target)
+		}
+		// resolve the peer gateway without arp or neighbor discovery
+		if err := netlink.NeighSet(&netlink.Neigh{
+			LinkIndex:    br.Attrs().Index,
+			Family:       routeFamily(ipnet),
+			State:        netlink.NUD_PERMANENT,
+			IP:           gw,
+			HardwareAddr: mac,
+		}); err != nil {
+			return errors.Wrapf(err, "error adding arp entry for %s", gw)
+		}
+
+		logrus.Debugf("configuring overlay route %s via %s (%s)", r.CIDR, gw, r.Target)
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: br.Attrs().Index,
+			Dst:       ipnet,
+			Gw:        gw,
+			Flags:     int(netlink.FLAG_ONLINK),
+		}); err != nil {
+			return err
+		}
+
+		peers[target.String()] = true
+		gateways[gw.String()] = true
+		expected[ipnet.String()] = gw.String()
+	}
+
+	if err := pruneRoutes(br, networks, expected); err != nil {
+		return err
+	}
+	// remove the peer routes if the node was in routed mode
+	if err := pruneRoutes(bindDev, networks, nil); err != nil {
+		return err
+	}
+
+	return pruneOverlayNeighbors(vtep, br, peers, gateways)
+}
+
+// ensureVTEP returns the VTEP attached to the bridge creating it if needed.
+// The VTEP is recreated if the VXLAN config has changed.
+func (s *Server) ensureVTEP(bindDev netlink.Link, bindIP net.IP, br *netlink.Bridge) (netlink.Link, error) {
+	id := s.config.VXLANID
+	if id == 0 {
+		id = defaultVXLANID
+	}
+	port := s.config.VXLANPort
+	if port == 0 {
+		port = defaultVXLANPort
+	}
+
+	link, err := netlink.LinkByName(stellar.VXLANDeviceName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return nil, err
+		}
+		link = nil
+	}
+	if v, ok := link.(*netlink.Vxlan); ok && (v.VxlanId != id || v.Port != port || !v.SrcAddr.Equal(bindIP)) {
+		logrus.Infof("vxlan config changed; recreating %s", stellar.VXLANDeviceName)
+		if err := netlink.LinkDel(link); err != nil {
+			return nil, err
+		}
+		link = nil
+	}
+
+	if link == nil {
+		logrus.WithFields(logrus.Fields{
+			"id":   id,
+			"port": port,
+			"addr": bindIP.String(),
+		}).Info("creating vxlan overlay device")
+		if err := netlink.LinkAdd(&netlink.Vxlan{
+			LinkAttrs: netlink.LinkAttrs{
+				Name: stellar.VXLANDeviceName,
+				MTU:  bindDev.Attrs().MTU - vxlanOverhead,
+			},
+			VxlanId:      id,
+			VtepDevIndex: bindDev.Attrs().Index,
+			SrcAddr:      bindIP,
+			Port:         port,
+			Learning:     false,
+		}); err != nil {
+			return nil, errors.Wrap(err, "error creating vxlan device")
+		}
+		if link, err = netlink.LinkByName(stellar.VXLANDeviceName); err != nil {
+			return nil, err
+		}
+	}
+
+	if link.Attrs().MasterIndex != br.Attrs().Index {
+		if err := netlink.LinkSetMaster(link, br); err != nil {
+			return nil, err
+		}
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// removeOverlay removes the VTEP and overlay ARP entries when the node is not
+// in vxlan mode
+func (s *Server) removeOverlay() error {
+	link, err := netlink.LinkByName(stellar.VXLANDeviceName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+	logrus.Infof("removing vxlan overlay device %s", stellar.VXLANDeviceName)
+	if err := netlink.LinkDel(link); err != nil {
+		return err
+	}
+
+	br, err := netlink.LinkByName(s.config.Bridge)
+	if err != nil {
+		return nil
+	}
+	return pruneOverlayNeighbors(nil, br, nil, nil)
+}
+
+// pruneOverlayNeighbors removes the FDB entries for peers and the ARP entries
+// for gateways that are no longer in the datastore routes
+func pruneOverlayNeighbors(vtep, br netlink.Link, peers, gateways map[string]bool) error {
+	if vtep != nil {
+		entries, err := netlink.NeighList(vtep.Attrs().Index, syscall.AF_BRIDGE)
+		if err != nil {
+			return err
+		}
+		for _, n := range entries {
+			if n.IP == nil || peers[n.IP.String()] {
+				continue
+			}
+			logrus.Debugf("removing overlay fdb entry %s %s", n.HardwareAddr, n.IP)
+			if err := netlink.NeighDel(&n); err != nil {
+				return err
+			}
+		}
+	}
+
+	entries, err := netlink.NeighList(br.Attrs().Index, netlink.FAMILY_ALL)
+	if err != nil {
+		return err
+	}
+	for _, n := range entries {
+		// only the entries added for the overlay are removed
+		if n.State != netlink.NUD_PERMANENT || n.IP == nil || gateways[n.IP.String()] {
+			continue
+		}
+		// ipv6 and named network gateways use the MAC of the peer ipv4
+		// gateway
+		if !bytes.HasPrefix(n.HardwareAddr, gatewayMACPrefix) {
+			continue
+		}
+		logrus.Debugf("removing overlay neighbor entry %s %s", n.IP, n.HardwareAddr)
+		if err := netlink.NeighDel(&n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatewayMAC returns the MAC for the bridge gateway in vxlan mode.  The MAC is
+// derived from the gateway IP so peers can resolve it from the routes.
+func gatewayMAC(ip net.IP) net.HardwareAddr {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return nil
+	}
+	return append(append(net.HardwareAddr{}, gatewayMACPrefix...), ip4...)
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ehazlett/stellar"
+)
+
+func TestOverlayGatewayMAC(t *testing.T) {
+	_, ipnet, err := net.ParseCIDR("172.16.4.0/22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := stellar.Gateway(ipnet)
+	if gw.String() != "172.16.4.1" {
+		t.Fatalf("expected gateway 172.16.4.1; received %s", gw)
+	}
+	if ipnet.IP.String() != "172.16.4.0" {
+		t.Fatalf("expected subnet to be unchanged; received %s", ipnet.IP)
+	}
+
+	if mac := gatewayMAC(gw).String(); mac != "02:53:ac:10:04:01" {
+		t.Fatalf("expected mac 02:53:ac:10:04:01; received %s", mac)
+	}
+}
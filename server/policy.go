@@ -0,0 +1,318 @@
+package server
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
+	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+)
+
+const (
+	// policyChain is the filter chain for the network policy rules
+	policyChain = "STELLAR-POLICY"
+	// policyIngressChainPrefix is the prefix of the endpoint ingress chains
+	policyIngressChainPrefix = "STELLAR-IN-"
+	// policyEgressChainPrefix is the prefix of the endpoint egress chains
+	policyEgressChainPrefix = "STELLAR-OUT-"
+)
+
+// policyEndpoint is a container with stellar networking and its addresses
+type policyEndpoint struct {
+	id          string
+	node        string
+	application string
+	service     string
+	ips         []net.IP
+}
+
+// matches returns true if the peer selects the endpoint; an empty peer
+// selects all endpoints
+func (e *policyEndpoint) matches(peer *networkapi.PolicyPeer) bool {
+	if peer == nil {
+		return true
+	}
+	if peer.CIDR != "" {
+		_, ipnet, err := net.ParseCIDR(peer.CIDR)
+		if err != nil {
+			return false
+		}
+		for _, ip := range e.ips {
+			if ipnet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	if peer.Application != "" && peer.Application != e.application {
+		return false
+	}
+	if peer.Service != "" && peer.Service != e.service {
+		return false
+	}
+	return true
+}
+
+// policyEndpoints returns the endpoints for the cluster containers with an
+// allocated address sorted by id
+func policyEndpoints(containers []*clusterapi.Container, allocations []*networkapi.IPAllocation) ([]*policyEndpoint, error) {
+	ips := map[string][]net.IP{}
+	for _, a := range allocations {
+		key := a.Node + "/" + a.ID
+		for _, v := range []string{a.IP, a.IP6} {
+			if ip := net.ParseIP(v); ip != nil {
+				ips[key] = append(ips[key], ip)
+			}
+		}
+	}
+
+	var endpoints []*policyEndpoint
+	for _, c := range containers {
+		if c.Node == nil {
+			continue
+		}
+		if _, ok := c.Container.Labels[stellar.StellarNetworkLabel]; !ok {
+			continue
+		}
+		addrs, ok := ips[c.Node.ID+"/"+c.Container.ID]
+		if !ok {
+			continue
+		}
+		e := &policyEndpoint{
+			id:          c.Container.ID,
+			node:        c.Node.ID,
+			application: c.Container.Labels[stellar.StellarApplicationLabel],
+			ips:         addrs,
+		}
+		if ext, ok := c.Container.Extensions[stellar.StellarServiceExtension]; ok {
+			v, err := typeurl.UnmarshalAny(ext)
+			if err != nil {
+				return nil, err
+			}
+			if svc, ok := v.(*runtimeapi.Service); ok {
+				e.service = svc.Name
+			}
+		}
+		endpoints = append(endpoints, e)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].id < endpoints[j].id
+	})
+	return endpoints, nil
+}
+
+// policyRules returns the chains and rules for the endpoints on the node.
+// Each node only filters the traffic of its own containers.  The policy chain
+// jumps to the egress chain of the source endpoint and then to the ingress
+// chain of the destination endpoint so traffic is only accepted if both allow
+// it.  Allowed traffic returns from the endpoint chain to the next stage and
+// traffic that passes all stages returns to the calling chain so other
+// forward rules still apply.  Rules of all policies selecting an endpoint are
+// matched in policy order and the endpoint traffic is dropped if any of the
+// policies denies by default.
+func policyRules(policies []*networkapi.NetworkPolicy, endpoints []*policyEndpoint, node string, ipv6 bool) ([]string, []string) {
+	var (
+		chains     []string
+		egress     []string
+		ingress    []string
+		chainRules []string
+		directions = []networkapi.PolicyDirection{networkapi.PolicyDirection_EGRESS, networkapi.PolicyDirection_INGRESS}
+	)
+	for _, e := range endpoints {
+		if e.node != node {
+			continue
+		}
+		ips := familyIPs(e.ips, ipv6)
+		if len(ips) == 0 {
+			continue
+		}
+		for _, d := range directions {
+			chain := endpointChain(e.id, d)
+			rules, defaultDeny := endpointRules(chain, policies, e, endpoints, d, ipv6)
+			if len(rules) == 0 && defaultDeny == "" {
+				continue
+			}
+			if defaultDeny != "" {
+				rules = append(rules, fmt.Sprintf("-A %s -m comment --comment \"stellar policy %s\" -j DROP", chain, defaultDeny))
+			}
+			chains = append(chains, chain)
+			chainRules = append(chainRules, rules...)
+			for _, ip := range ips {
+				if d == networkapi.PolicyDirection_EGRESS {
+					egress = append(egress, fmt.Sprintf("-A %s -s %s -j %s", policyChain, hostCIDR(ip), chain))
+				} else {
+					ingress = append(ingress, fmt.Sprintf("-A %s -d %s -j %s", policyChain, hostCIDR(ip), chain))
+				}
+			}
+		}
+	}
+	rules := []string{
+		fmt.Sprintf("-A %s -m conntrack --ctstate RELATED,ESTABLISHED -j RETURN", policyChain),
+	}
+	rules = append(rules, egress...)
+	rules = append(rules, ingress...)
+	return chains, append(rules, chainRules...)
+}
+
+// endpointRules returns the rules of the endpoint chain for the direction and
+// the name of the first policy denying the direction by default
+func endpointRules(chain string, policies []*networkapi.NetworkPolicy, e *policyEndpoint, endpoints []*policyEndpoint, direction networkapi.PolicyDirection, ipv6 bool) ([]string, string) {
+	var (
+		rules       []string
+		defaultDeny string
+	)
+	remote := "-s"
+	if direction == networkapi.PolicyDirection_EGRESS {
+		remote = "-d"
+	}
+	for _, p := range policies {
+		if p.Direction != direction || !e.matches(p.Target) {
+			continue
+		}
+		comment := fmt.Sprintf("-m comment --comment \"stellar policy %s\"", p.Name)
+		for _, r := range p.Rules {
+			target := "RETURN"
+			if r.Action == networkapi.PolicyAction_DENY {
+				target = "DROP"
+			}
+			for _, peer := range peerCIDRs(r.Peer, endpoints, ipv6) {
+				match := ""
+				if peer != "" {
+					match = " " + remote + " " + peer
+				}
+				if len(r.Ports) == 0 {
+					rules = append(rules, fmt.Sprintf("-A %s%s %s -j %s", chain, match, comment, target))
+					continue
+				}
+				for _, port := range r.Ports {
+					proto := strings.ToLower(port.Protocol)
+					rules = append(rules, fmt.Sprintf("-A %s%s -p %s -m %s --dport %d %s -j %s", chain, match, proto, proto, port.Port, comment, target))
+				}
+			}
+		}
+		if p.DefaultAction == networkapi.PolicyAction_DENY && defaultDeny == "" {
+			defaultDeny = p.Name
+		}
+	}
+	return rules, defaultDeny
+}
+
+// endpointChain returns the policy chain for the endpoint and direction.  The
+// id is hashed as chain names are limited to 28 characters.
+func endpointChain(id string, direction networkapi.PolicyDirection) string {
+	prefix := policyIngressChainPrefix
+	if direction == networkapi.PolicyDirection_EGRESS {
+		prefix = policyEgressChainPrefix
+	}
+	sum := sha256.Sum256([]byte(id))
+	return fmt.Sprintf("%s%x", prefix, sum[:5])
+}
+
+// isPolicyChain returns true if the chain is an endpoint policy chain
+func isPolicyChain(chain string) bool {
+	return strings.HasPrefix(chain, policyIngressChainPrefix) || strings.HasPrefix(chain, policyEgressChainPrefix)
+}
+
+// stalePolicyChains returns the endpoint chains in the iptables -S output that
+// are not in the chains
+func stalePolicyChains(output string, chains []string) []string {
+	current := map[string]bool{}
+	for _, c := range chains {
+		current[c] = true
+	}
+	var stale []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "-N" {
+			continue
+		}
+		if isPolicyChain(fields[1]) && !current[fields[1]] {
+			stale = append(stale, fields[1])
+		}
+	}
+	return stale
+}
+
+// isolationRules returns the rules dropping traffic between isolated networks
+// and the other cluster networks in the family
+func isolationRules(networks []*networkapi.ClusterNetwork, ipv6 bool) []string {
+	var rules []string
+	for i, n := range networks {
+		for _, o := range networks[i+1:] {
+			if n.Isolation != networkapi.NetworkIsolation_ISOLATED && o.Isolation != networkapi.NetworkIsolation_ISOLATED {
+				continue
+			}
+			src, dst := networkCIDR(n, ipv6), networkCIDR(o, ipv6)
+			if src == "" || dst == "" {
+				continue
+			}
+			name := n.Name
+			if o.Isolation == networkapi.NetworkIsolation_ISOLATED {
+				name = o.Name
+			}
+			comment := fmt.Sprintf("-m comment --comment \"stellar network %s\"", name)
+			rules = append(rules,
+				fmt.Sprintf("-A %s -s %s -d %s %s -j DROP", policyChain, src, dst, comment),
+				fmt.Sprintf("-A %s -s %s -d %s %s -j DROP", policyChain, dst, src, comment),
+			)
+		}
+	}
+	return rules
+}
+
+// networkCIDR returns the network subnet in the family
+func networkCIDR(n *networkapi.ClusterNetwork, ipv6 bool) string {
+	if ipv6 {
+		return n.Subnet6CIDR
+	}
+	return n.SubnetCIDR
+}
+
+// peerCIDRs returns the addresses of the peer in the family.  An empty string
+// matches any address and no addresses are returned if the peer selects
+// nothing in the family.
+func peerCIDRs(peer *networkapi.PolicyPeer, endpoints []*policyEndpoint, ipv6 bool) []string {
+	if peer == nil || (peer.Application == "" && peer.Service == "" && peer.CIDR == "") {
+		return []string{""}
+	}
+	if peer.CIDR != "" {
+		_, ipnet, err := net.ParseCIDR(peer.CIDR)
+		if err != nil || (ipnet.IP.To4() == nil) != ipv6 {
+			return nil
+		}
+		return []string{ipnet.String()}
+	}
+	var cidrs []string
+	for _, e := range endpoints {
+		if !e.matches(peer) {
+			continue
+		}
+		for _, ip := range familyIPs(e.ips, ipv6) {
+			cidrs = append(cidrs, hostCIDR(ip))
+		}
+	}
+	return cidrs
+}
+
+func familyIPs(ips []net.IP, ipv6 bool) []net.IP {
+	var res []net.IP
+	for _, ip := range ips {
+		if (ip.To4() == nil) == ipv6 {
+			res = append(res, ip)
+		}
+	}
+	return res
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}).String()
+}
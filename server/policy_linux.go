@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var bridgeNetfilterPaths = []string{
+	"/proc/sys/net/bridge/bridge-nf-call-iptables",
+	"/proc/sys/net/bridge/bridge-nf-call-ip6tables",
+}
+
+// setupPolicies updates the policy chain with the rules for the network
+// policies and the containers currently on the node
+func (s *Server) setupPolicies() error {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	policies, err := c.Network().Policies()
+	if err != nil {
+		return err
+	}
+
+	networks, err := c.Network().ListNetworks()
+	if err != nil {
+		return err
+	}
+	isolated := false
+	for _, n := range networks {
+		if n.Isolation == networkapi.NetworkIsolation_ISOLATED {
+			isolated = true
+		}
+	}
+
+	var endpoints []*policyEndpoint
+	if len(policies) > 0 {
+		containers, err := c.Cluster().Containers()
+		if err != nil {
+			return err
+		}
+		allocations, err := c.Network().IPs("")
+		if err != nil {
+			return err
+		}
+		if endpoints, err = policyEndpoints(containers, allocations); err != nil {
+			return err
+		}
+		if err := enableBridgeNetfilter(); err != nil {
+			logrus.Warnf("network policies are not enforced between containers on the same node: %s", err)
+		}
+	}
+
+	families := []bool{false}
+	if s.config.Subnet6 != nil {
+		families = append(families, true)
+	}
+	for _, ipv6 := range families {
+		chains, rules := policyRules(policies, endpoints, s.NodeID(), ipv6)
+		// network isolation applies before the policies so policies cannot
+		// allow traffic between isolated networks
+		rules = append(rules[:1], append(isolationRules(networks, ipv6), rules[1:]...)...)
+		if err := applyPolicyRules(ipv6, chains, rules, len(policies) > 0 || isolated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPolicyRules replaces the rules of the policy and endpoint chains and
+// ensures the policy chain is called from the forward chain.  Endpoint chains
+// that are no longer used are removed.  Missing iptables tools are only an
+// error if there are policies to enforce.
+func applyPolicyRules(ipv6 bool, chains, rules []string, required bool) error {
+	iptables, restore := "iptables", "iptables-restore"
+	if ipv6 {
+		iptables, restore = "ip6tables", "ip6tables-restore"
+	}
+	if _, err := exec.LookPath(restore); err != nil {
+		if required {
+			return errors.Wrapf(err, "%s is required to enforce network policies", restore)
+		}
+		return nil
+	}
+
+	out, err := exec.Command(iptables, "-S").CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "error listing chains: %s", strings.TrimSpace(string(out)))
+	}
+	stale := stalePolicyChains(string(out), chains)
+
+	// declaring the chains flushes them without touching the other chains
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "*filter")
+	for _, chain := range append(append([]string{policyChain}, chains...), stale...) {
+		fmt.Fprintf(&b, ":%s - [0:0]\n", chain)
+	}
+	for _, r := range rules {
+		fmt.Fprintln(&b, r)
+	}
+	for _, chain := range stale {
+		fmt.Fprintf(&b, "-X %s\n", chain)
+	}
+	fmt.Fprintln(&b, "COMMIT")
+
+	cmd := exec.Command(restore, "--noflush")
+	cmd.Stdin = &b
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "error applying network policies: %s", strings.TrimSpace(string(out)))
+	}
+
+	jump := []string{"FORWARD", "-j", policyChain}
+	if err := exec.Command(iptables, append([]string{"-C"}, jump...)...).Run(); err != nil {
+		logrus.Debugf("adding %s to the forward chain", policyChain)
+		if out, err := exec.Command(iptables, append([]string{"-I"}, jump...)...).CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "error adding %s: %s", policyChain, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// enableBridgeNetfilter passes bridged traffic through iptables so traffic
+// between containers on the same bridge is filtered
+func enableBridgeNetfilter() error {
+	if _, err := os.Stat(bridgeNetfilterPaths[0]); os.IsNotExist(err) {
+		if out, err := exec.Command("modprobe", "br_netfilter").CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "error loading br_netfilter: %s", strings.TrimSpace(string(out)))
+		}
+	}
+	for _, p := range bridgeNetfilterPaths {
+		if err := ioutil.WriteFile(p, []byte("1"), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+)
+
+func TestPolicyRules(t *testing.T) {
+	endpoints := []*policyEndpoint{
+		{id: "db.0", node: "node-00", application: "db", service: "postgres", ips: []net.IP{net.ParseIP("172.16.0.2"), net.ParseIP("fd00::2")}},
+		{id: "web.0", node: "node-01", application: "web", service: "app", ips: []net.IP{net.ParseIP("172.16.4.2")}},
+		{id: "web.1", node: "node-00", application: "web", service: "app", ips: []net.IP{net.ParseIP("172.16.0.3")}},
+	}
+	policies := []*networkapi.NetworkPolicy{
+		{
+			Name:   "db",
+			Target: &networkapi.PolicyPeer{Application: "db"},
+			Rules: []*networkapi.PolicyRule{
+				{
+					Peer:  &networkapi.PolicyPeer{Application: "web"},
+					Ports: []*networkapi.PolicyPort{{Protocol: "TCP", Port: 5432}},
+				},
+				{
+					Action: networkapi.PolicyAction_DENY,
+					Peer:   &networkapi.PolicyPeer{CIDR: "fd00::/64"},
+				},
+			},
+			DefaultAction: networkapi.PolicyAction_DENY,
+		},
+		{
+			Name:      "web-egress",
+			Target:    &networkapi.PolicyPeer{Application: "web"},
+			Direction: networkapi.PolicyDirection_EGRESS,
+			Rules: []*networkapi.PolicyRule{
+				{
+					Action: networkapi.PolicyAction_DENY,
+					Peer:   &networkapi.PolicyPeer{CIDR: "10.0.0.0/8"},
+				},
+			},
+		},
+	}
+
+	dbIn := endpointChain("db.0", networkapi.PolicyDirection_INGRESS)
+	webOut := endpointChain("web.1", networkapi.PolicyDirection_EGRESS)
+	expected := []string{
+		"-A STELLAR-POLICY -m conntrack --ctstate RELATED,ESTABLISHED -j RETURN",
+		"-A STELLAR-POLICY -s 172.16.0.3/32 -j " + webOut,
+		"-A STELLAR-POLICY -d 172.16.0.2/32 -j " + dbIn,
+		"-A " + dbIn + " -s 172.16.4.2/32 -p tcp -m tcp --dport 5432 -m comment --comment \"stellar policy db\" -j RETURN",
+		"-A " + dbIn + " -s 172.16.0.3/32 -p tcp -m tcp --dport 5432 -m comment --comment \"stellar policy db\" -j RETURN",
+		"-A " + dbIn + " -m comment --comment \"stellar policy db\" -j DROP",
+		"-A " + webOut + " -d 10.0.0.0/8 -m comment --comment \"stellar policy web-egress\" -j DROP",
+	}
+	chains, rules := policyRules(policies, endpoints, "node-00", false)
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("unexpected ipv4 rules:\n%q\nexpected:\n%q", rules, expected)
+	}
+	if !reflect.DeepEqual(chains, []string{dbIn, webOut}) {
+		t.Fatalf("unexpected ipv4 chains %q", chains)
+	}
+
+	expected = []string{
+		"-A STELLAR-POLICY -m conntrack --ctstate RELATED,ESTABLISHED -j RETURN",
+		"-A STELLAR-POLICY -d fd00::2/128 -j " + dbIn,
+		"-A " + dbIn + " -s fd00::/64 -m comment --comment \"stellar policy db\" -j DROP",
+		"-A " + dbIn + " -m comment --comment \"stellar policy db\" -j DROP",
+	}
+	if _, rules := policyRules(policies, endpoints, "node-00", true); !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("unexpected ipv6 rules:\n%q\nexpected:\n%q", rules, expected)
+	}
+
+	// containers on other nodes are filtered by their node
+	if chains, rules := policyRules(policies, endpoints, "node-02", false); len(rules) != 1 || len(chains) != 0 {
+		t.Fatalf("expected only the conntrack rule; received %q", rules)
+	}
+}
+
+func TestPolicyRulesSameNode(t *testing.T) {
+	endpoints := []*policyEndpoint{
+		{id: "a.0", node: "node-00", application: "a", ips: []net.IP{net.ParseIP("172.16.0.2")}},
+		{id: "b.0", node: "node-00", application: "b", ips: []net.IP{net.ParseIP("172.16.0.3")}},
+		{id: "c.0", node: "node-00", application: "c", ips: []net.IP{net.ParseIP("172.16.0.4")}},
+	}
+	policies := []*networkapi.NetworkPolicy{
+		{
+			Name:      "a-egress",
+			Target:    &networkapi.PolicyPeer{Application: "a"},
+			Direction: networkapi.PolicyDirection_EGRESS,
+			Rules: []*networkapi.PolicyRule{
+				{Peer: &networkapi.PolicyPeer{Application: "b"}},
+			},
+			DefaultAction: networkapi.PolicyAction_DENY,
+		},
+		{
+			Name:          "b-ingress",
+			Target:        &networkapi.PolicyPeer{Application: "b"},
+			DefaultAction: networkapi.PolicyAction_DENY,
+		},
+	}
+	_, rules := policyRules(policies, endpoints, "node-00", false)
+
+	for _, tc := range []struct {
+		src, dst string
+		accept   bool
+	}{
+		// the egress allow of a does not skip the ingress deny of b
+		{"172.16.0.2", "172.16.0.3", false},
+		// the egress of a denies other destinations
+		{"172.16.0.2", "172.16.0.4", false},
+		{"172.16.0.4", "172.16.0.3", false},
+		{"172.16.0.4", "172.16.0.2", true},
+	} {
+		if accept := evalPolicyRules(t, rules, tc.src, tc.dst); accept != tc.accept {
+			t.Fatalf("expected accept %v for %s -> %s; received %v\n%q", tc.accept, tc.src, tc.dst, accept, rules)
+		}
+	}
+}
+
+// evalPolicyRules returns true if a new packet from src to dst passes the
+// policy chain.  Only the address matches of the rules are evaluated.
+func evalPolicyRules(t *testing.T, rules []string, src, dst string) bool {
+	chains := map[string][][]string{}
+	for _, r := range rules {
+		fields := strings.Fields(r)
+		chains[fields[1]] = append(chains[fields[1]], fields[2:])
+	}
+	matches := func(cidr, ip string) bool {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ipnet.Contains(net.ParseIP(ip))
+	}
+	// eval returns the verdict of the chain; an empty verdict returns to
+	// the calling chain
+	var eval func(chain string) string
+	eval = func(chain string) string {
+		for _, fields := range chains[chain] {
+			match, target := true, ""
+			for i := 0; i < len(fields); i++ {
+				switch fields[i] {
+				case "--ctstate":
+					match = false
+				case "-s":
+					i++
+					match = match && matches(fields[i], src)
+				case "-d":
+					i++
+					match = match && matches(fields[i], dst)
+				case "-j":
+					i++
+					target = fields[i]
+				}
+			}
+			if !match {
+				continue
+			}
+			switch target {
+			case "RETURN":
+				return ""
+			case "DROP":
+				return "DROP"
+			default:
+				if v := eval(target); v != "" {
+					return v
+				}
+			}
+		}
+		return ""
+	}
+	return eval(policyChain) == ""
+}
+
+func TestStalePolicyChains(t *testing.T) {
+	current := endpointChain("a.0", networkapi.PolicyDirection_INGRESS)
+	old := endpointChain("b.0", networkapi.PolicyDirection_EGRESS)
+	output := strings.Join([]string{
+		"-P FORWARD ACCEPT",
+		"-N DOCKER",
+		"-N STELLAR-POLICY",
+		"-N " + current,
+		"-N " + old,
+		"-A FORWARD -j STELLAR-POLICY",
+	}, "\n")
+	if stale := stalePolicyChains(output, []string{current}); !reflect.DeepEqual(stale, []string{old}) {
+		t.Fatalf("expected stale chain %s; received %q", old, stale)
+	}
+	if len(current) > 28 {
+		t.Fatalf("chain name %s is too long", current)
+	}
+}
+
+func TestIsolationRules(t *testing.T) {
+	networks := []*networkapi.ClusterNetwork{
+		{Name: "stellar", SubnetCIDR: "172.16.0.0/12", Subnet6CIDR: "fd00::/48", Default: true},
+		{Name: "backend", SubnetCIDR: "10.1.0.0/16", Isolation: networkapi.NetworkIsolation_ISOLATED},
+		{Name: "frontend", SubnetCIDR: "10.2.0.0/16", Subnet6CIDR: "fd01::/48"},
+	}
+
+	expected := []string{
+		"-A STELLAR-POLICY -s 172.16.0.0/12 -d 10.1.0.0/16 -m comment --comment \"stellar network backend\" -j DROP",
+		"-A STELLAR-POLICY -s 10.1.0.0/16 -d 172.16.0.0/12 -m comment --comment \"stellar network backend\" -j DROP",
+		"-A STELLAR-POLICY -s 10.1.0.0/16 -d 10.2.0.0/16 -m comment --comment \"stellar network backend\" -j DROP",
+		"-A STELLAR-POLICY -s 10.2.0.0/16 -d 10.1.0.0/16 -m comment --comment \"stellar network backend\" -j DROP",
+	}
+	if rules := isolationRules(networks, false); !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("unexpected ipv4 rules:\n%q\nexpected:\n%q", rules, expected)
+	}
+	if rules := isolationRules(networks, true); len(rules) != 0 {
+		t.Fatalf("expected no ipv6 rules; received %q", rules)
+	}
+}
@@ -11,10 +11,20 @@ func (s *Server) reconcile() error {
 	}
 	defer c.Close()
 
+	// setup named network bridges
+	if err := s.setupNetworks(); err != nil {
+		return err
+	}
+
 	// setup cluster routes
 	if err := s.setupRoutes(); err != nil {
 		return err
 	}
 
+	// enforce network policies
+	if err := s.setupPolicies(); err != nil {
+		return err
+	}
+
 	return nil
 }
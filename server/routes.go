@@ -0,0 +1,53 @@
+package server
+
+import (
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
+	"github.com/sirupsen/logrus"
+)
+
+// peerRoutes returns the datastore routes of the cluster members.  Routes of
+// nodes that have left the cluster are excluded so they are removed from the
+// kernel before the node subnet is released.
+func (s *Server) peerRoutes(c *client.Client) ([]*networkapi.Route, error) {
+	routes, err := c.Network().Routes()
+	if err != nil {
+		return nil, err
+	}
+	peers, err := s.agent.Peers()
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]bool{
+		s.NodeID(): true,
+	}
+	for _, p := range peers {
+		members[p.ID] = true
+	}
+	return networkapi.MemberRoutes(routes, members), nil
+}
+
+// deregisterRoutes removes the routes owned by the node from the datastore so
+// peers remove them on their next reconcile
+func (s *Server) deregisterRoutes() error {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	routes, err := c.Network().Routes()
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if r.Node != s.NodeID() {
+			continue
+		}
+		logrus.Debugf("deregistering route %s via %s", r.CIDR, r.Target)
+		if err := c.Network().DeleteRoute(r.CIDR, r.Target, s.NodeID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
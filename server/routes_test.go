@@ -0,0 +1,19 @@
+package server
+
+import (
+	"testing"
+
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+)
+
+func TestMemberRoutes(t *testing.T) {
+	routes := []*networkapi.Route{
+		{CIDR: "172.16.0.0/22", Target: "10.0.1.70", Node: "node-00"},
+		{CIDR: "172.16.4.0/22", Target: "10.0.1.71", Node: "node-01"},
+		{CIDR: "172.16.8.0/22", Target: "10.0.1.72"},
+	}
+	active := networkapi.MemberRoutes(routes, map[string]bool{"node-00": true})
+	if len(active) != 2 || active[0].CIDR != "172.16.0.0/22" || active[1].CIDR != "172.16.8.0/22" {
+		t.Fatalf("unexpected routes %+v", active)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net"
 	"path/filepath"
@@ -234,34 +233,27 @@ func (s *Server) waitForPeers() error {
 }
 
 func (s *Server) syncDatastore() error {
-	// sync entire datastore with peer
+	// catch up with a peer; only the keys that differ are transferred
 	peers, err := s.agent.Peers()
 	if err != nil {
 		return err
 	}
 	peer := peers[0]
-	c, err := s.client(peer.Address)
-	if err != nil {
-		return err
-	}
-
-	ctx := context.Background()
-	logrus.Debugf("getting backup from peer %s", peer)
-	bResp, err := c.DatastoreService().Backup(ctx, &datastoreapi.BackupRequest{})
-	if err != nil {
-		return err
-	}
 
 	lc, err := s.client(s.agent.Self().Address)
 	if err != nil {
 		return err
 	}
-	if _, err := lc.DatastoreService().Restore(ctx, &datastoreapi.RestoreRequest{Data: bResp.Data}); err != nil {
+	defer lc.Close()
+
+	logrus.Debugf("syncing datastore from peer %s", peer)
+	if _, err := lc.DatastoreService().PeerSync(context.Background(), &datastoreapi.PeerSyncRequest{
+		ID:      peer.ID,
+		Address: peer.Address,
+	}); err != nil {
 		return err
 	}
 
-	logrus.Debugf("restored %d bytes", len(bResp.Data))
-
 	return nil
 }
 
@@ -301,8 +293,8 @@ func (s *Server) Run() error {
 	go s.grpcServer.Serve(l)
 
 	if isPeer {
-		// check if joining; if so, clear current datastore and sync from peer
-		logrus.Debug("joining cluster; clearing current datastore")
+		// check if joining; if so, sync the datastore from a peer
+		logrus.Debug("joining cluster; syncing datastore")
 		if err := s.syncDatastore(); err != nil {
 			return err
 		}
@@ -401,44 +393,16 @@ func (s *Server) syncPeerDatastores() error {
 			"peer": peer.ID,
 			"addr": peer.Address,
 		}).Debug("syncing peer datastore")
-		c, err := s.client(peer.Address)
-		if err != nil {
-			return err
-		}
-		defer c.Close()
 
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-		defer cancel()
-
-		stream, err := c.DatastoreService().Sync(ctx, &datastoreapi.SyncRequest{})
+		_, err := lc.DatastoreService().PeerSync(ctx, &datastoreapi.PeerSyncRequest{
+			ID:      peer.ID,
+			Address: peer.Address,
+		})
+		cancel()
 		if err != nil {
-			return err
-		}
-
-		count := 0
-		for {
-			op, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-
-			if err != nil {
-				return errors.Wrap(err, "error syncing datastore")
-			}
-
-			// TODO: handle operation
-			if _, err := lc.DatastoreService().Set(ctx, &datastoreapi.SetRequest{
-				Bucket: op.Bucket,
-				Key:    op.Key,
-				Value:  op.Value,
-			}); err != nil {
-				return errors.Wrapf(err, "error syncing key %s", op.Key)
-			}
-			count++
+			return errors.Wrapf(err, "error syncing datastore with peer %s", peer.ID)
 		}
-
-		logrus.Debugf("synchronized %d operations from peer %s", count, peer.ID)
-
 	}
 
 	return nil
@@ -462,6 +426,11 @@ func (s *Server) Stop() error {
 	s.tickerReconcile.Stop()
 	s.tickerDatastoreSync.Stop()
 
+	// remove the node routes so peers stop routing to the node
+	if err := s.deregisterRoutes(); err != nil {
+		logrus.WithError(err).Warn("error deregistering node routes")
+	}
+
 	// shutdown server
 	if err := s.shutdown(); err != nil {
 		return err
@@ -3,10 +3,14 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	api "github.com/ehazlett/stellar/api/services/application/v1"
+	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
 	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	schedulerapi "github.com/ehazlett/stellar/api/services/scheduler/v1"
 	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -48,7 +52,7 @@ func (s *service) Create(ctx context.Context, req *api.CreateRequest) (*ptypes.E
 
 	for _, service := range services {
 		// get list of target nodes for the service
-		scheduledNodes, err := c.Scheduler().Schedule(service, nodes)
+		scheduledNodes, preemptions, err := c.Scheduler().ScheduleWithPreemption(service, nodes)
 		if err != nil {
 			return empty, err
 		}
@@ -56,6 +60,9 @@ func (s *service) Create(ctx context.Context, req *api.CreateRequest) (*ptypes.E
 			"service": service.Name,
 			"nodes":   scheduledNodes,
 		}).Debug("scheduled nodes for service")
+		if err := s.preempt(service, preemptions, nodes); err != nil {
+			return empty, err
+		}
 		for i, node := range scheduledNodes {
 			nc, err := s.client(node.Address)
 			if err != nil {
@@ -86,3 +93,79 @@ func (s *service) Create(ctx context.Context, req *api.CreateRequest) (*ptypes.E
 	}
 	return empty, nil
 }
+
+// preempt evicts the lower priority replicas selected by the scheduler for
+// the service and recreates them under a new id on their new node if one was
+// found
+func (s *service) preempt(svc *runtimeapi.Service, preemptions []*schedulerapi.Preemption, nodes []*clusterapi.Node) error {
+	addresses := map[string]string{}
+	for _, node := range nodes {
+		addresses[node.ID] = node.Address
+	}
+	// the ids of the evicted replicas are not reused
+	evicted := map[string]struct{}{}
+	for _, p := range preemptions {
+		evicted[p.ContainerID] = struct{}{}
+	}
+
+	for _, p := range preemptions {
+		nc, err := s.client(addresses[p.NodeID])
+		if err != nil {
+			return err
+		}
+		err = nc.Node().DeleteContainer(p.ContainerID)
+		if err == nil {
+			err = nc.Proxy().Reload()
+		}
+		nc.Close()
+		if err != nil {
+			return errors.Wrapf(err, "error preempting %s on %s", p.ContainerID, p.NodeID)
+		}
+
+		id := ""
+		if p.TargetNodeID != "" {
+			if id, err = s.replicaID(p.Application, p.Service.Name, evicted); err != nil {
+				return err
+			}
+		}
+		logrus.WithFields(logrus.Fields{
+			"service":   svc.Name,
+			"container": p.ContainerID,
+			"node":      p.NodeID,
+			"target":    p.TargetNodeID,
+		}).Info("preempted replica")
+		if err := s.publish(&PreemptEvent{
+			Service:         svc.Name,
+			Priority:        svc.Priority,
+			Container:       p.ContainerID,
+			Node:            p.NodeID,
+			Target:          p.TargetNodeID,
+			TargetContainer: id,
+		}); err != nil {
+			return err
+		}
+
+		if p.TargetNodeID == "" {
+			logrus.WithFields(logrus.Fields{
+				"container": p.ContainerID,
+				"node":      p.NodeID,
+			}).Warn("preempted replica could not be rescheduled")
+			continue
+		}
+
+		tc, err := s.client(addresses[p.TargetNodeID])
+		if err != nil {
+			return err
+		}
+		err = tc.Node().CreateContainer(p.Application, p.Service, strings.TrimPrefix(id, p.Application+"."))
+		if err == nil {
+			err = tc.Proxy().Reload()
+		}
+		tc.Close()
+		if err != nil {
+			return errors.Wrapf(err, "error rescheduling %s as %s on %s", p.ContainerID, id, p.TargetNodeID)
+		}
+	}
+
+	return nil
+}
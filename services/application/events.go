@@ -7,6 +7,7 @@ import (
 
 func init() {
 	typeurl.Register(&UpdateEvent{}, serviceID+"/UpdateEvent")
+	typeurl.Register(&PreemptEvent{}, serviceID+"/PreemptEvent")
 }
 
 // UpdateEvent is the event published when an application is updated
@@ -15,6 +16,17 @@ type UpdateEvent struct {
 	Action      string
 }
 
+// PreemptEvent is the event published when a replica is evicted for a higher
+// priority service
+type PreemptEvent struct {
+	Service         string
+	Priority        int32
+	Container       string
+	Node            string
+	Target          string
+	TargetContainer string
+}
+
 func (s *service) publish(v interface{}) error {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
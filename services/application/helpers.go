@@ -35,6 +35,7 @@ func (s *service) containerToService(ctx context.Context, c *clusterapi.Containe
 		s, ok := v.(*runtimeapi.Service)
 		if ok {
 			svc.Endpoints = s.Endpoints
+			svc.Bandwidth = s.Bandwidth
 		}
 	}
 
@@ -56,6 +57,28 @@ func (s *service) getApplicationContainers(name string) ([]*clusterapi.Container
 	return containers, nil
 }
 
+// replicaID returns the lowest replica id of the application service that is
+// not used by a container in the cluster or skipped
+func (s *service) replicaID(app, service string, skip map[string]struct{}) (string, error) {
+	containers, err := s.getApplicationContainers(app)
+	if err != nil {
+		return "", err
+	}
+	used := map[string]struct{}{}
+	for id := range skip {
+		used[id] = struct{}{}
+	}
+	for _, c := range containers {
+		used[c.Container.ID] = struct{}{}
+	}
+	for i := 0; ; i++ {
+		id := fmt.Sprintf("%s.%s.%d", app, service, i)
+		if _, ok := used[id]; !ok {
+			return id, nil
+		}
+	}
+}
+
 func getAppName(name string) string {
 	return strings.Split(name, ".")[0]
 }
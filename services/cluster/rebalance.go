@@ -0,0 +1,280 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/cluster/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	schedulerapi "github.com/ehazlett/stellar/api/services/scheduler/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// replicaGroup is the set of running replicas for an application service
+type replicaGroup struct {
+	application string
+	service     *runtimeapi.Service
+	containers  []*api.Container
+}
+
+func (s *service) Rebalance(ctx context.Context, req *api.RebalanceRequest) (*api.RebalanceResponse, error) {
+	nodesResp, err := s.Nodes(ctx, &api.NodesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	nodes := map[string]*api.Node{}
+	for _, node := range nodesResp.Nodes {
+		nodes[node.ID] = node
+	}
+
+	containersResp, err := s.Containers(ctx, &api.ContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	groups, err := replicaGroups(containersResp.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	moves, err := planRebalance(groups, func(svc *runtimeapi.Service, exclude []string, reservations []*schedulerapi.Reservation) (*schedulerapi.ScheduleResponse, error) {
+		return c.Scheduler().SimulateExcluding(svc, nodesResp.Nodes, exclude, reservations)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		return &api.RebalanceResponse{
+			Moves: moves,
+		}, nil
+	}
+
+	for _, move := range moves {
+		if err := s.move(move, nodes, groups); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"container": move.ContainerID,
+				"from":      move.FromNodeID,
+				"to":        move.ToNodeID,
+			}).Error(err)
+			move.Error = err.Error()
+		}
+	}
+
+	return &api.RebalanceResponse{
+		Moves: moves,
+	}, nil
+}
+
+// simulateFunc schedules the service without the excluded replicas and with
+// the node capacity held by the reservations
+type simulateFunc func(svc *runtimeapi.Service, exclude []string, reservations []*schedulerapi.Reservation) (*schedulerapi.ScheduleResponse, error)
+
+// planRebalance returns the moves for the replica groups.  Each group is
+// scheduled with the moves planned for the previous groups so the groups do
+// not place replicas on the same spare capacity.
+func planRebalance(groups map[string]*replicaGroup, simulate simulateFunc) ([]*api.Move, error) {
+	keys := []string{}
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// the moved replicas release the capacity on their current node and
+	// hold it on the new node
+	moved := []string{}
+	reservations := []*schedulerapi.Reservation{}
+	moves := []*api.Move{}
+	for _, k := range keys {
+		group := groups[k]
+		// moving a replica changes the node its host ports are reachable on
+		if len(group.service.PublishedPorts()) > 0 {
+			logrus.WithFields(logrus.Fields{
+				"application": group.application,
+				"service":     group.service.Name,
+			}).Debug("skipping rebalance of service with published host ports")
+			continue
+		}
+		// static addresses are bound to the replica id and cannot be
+		// held by the new replica while the old one is running
+		if len(group.service.IPs) > 0 {
+			logrus.WithFields(logrus.Fields{
+				"application": group.application,
+				"service":     group.service.Name,
+			}).Debug("skipping rebalance of service with static addresses")
+			continue
+		}
+		// schedule the service as if it were created with the current
+		// replicas without the capacity they currently hold
+		svc := *group.service
+		svc.Replicas = uint64(len(group.containers))
+		exclude := append([]string{}, moved...)
+		for _, c := range group.containers {
+			exclude = append(exclude, c.Container.ID)
+		}
+		resp, err := simulate(&svc, exclude, reservations)
+		if err != nil {
+			return nil, err
+		}
+		// do not evict other services to rebalance
+		if len(resp.Preemptions) > 0 {
+			logrus.WithFields(logrus.Fields{
+				"application": group.application,
+				"service":     svc.Name,
+			}).Debug("skipping rebalance that requires preemption")
+			continue
+		}
+		for _, m := range planMoves(group, resp.Nodes) {
+			moved = append(moved, m.ContainerID)
+			reservations = append(reservations, &schedulerapi.Reservation{
+				NodeID:    m.ToNodeID,
+				Resources: group.service.Resources,
+			})
+			moves = append(moves, m)
+		}
+	}
+
+	return moves, nil
+}
+
+// move creates the replica under a new id on the new node and then deletes
+// the old replica so the records of the two replicas do not collide
+func (s *service) move(move *api.Move, nodes map[string]*api.Node, groups map[string]*replicaGroup) error {
+	group := groups[move.Application+"/"+move.Service]
+
+	to, err := s.client(nodes[move.ToNodeID].Address)
+	if err != nil {
+		return err
+	}
+	defer to.Close()
+
+	id := strings.TrimPrefix(move.NewContainerID, move.Application+".")
+	if err := to.Node().CreateContainer(move.Application, group.service, id); err != nil {
+		return err
+	}
+	if err := to.Proxy().Reload(); err != nil {
+		return err
+	}
+
+	from, err := s.client(nodes[move.FromNodeID].Address)
+	if err != nil {
+		return err
+	}
+	defer from.Close()
+
+	if err := from.Node().DeleteContainer(move.ContainerID); err != nil {
+		return err
+	}
+
+	return from.Proxy().Reload()
+}
+
+// replicaGroups groups the stellar containers by application and service
+func replicaGroups(containers []*api.Container) (map[string]*replicaGroup, error) {
+	groups := map[string]*replicaGroup{}
+	for _, c := range containers {
+		ext, ok := c.Container.Extensions[stellar.StellarServiceExtension]
+		if !ok {
+			continue
+		}
+		v, err := typeurl.UnmarshalAny(ext)
+		if err != nil {
+			return nil, err
+		}
+		svc, ok := v.(*runtimeapi.Service)
+		if !ok {
+			continue
+		}
+		app := c.Container.Labels[stellar.StellarApplicationLabel]
+		key := app + "/" + svc.Name
+		group, ok := groups[key]
+		if !ok {
+			group = &replicaGroup{
+				application: app,
+				service:     svc,
+			}
+			groups[key] = group
+		}
+		group.containers = append(group.containers, c)
+	}
+
+	return groups, nil
+}
+
+// planMoves returns the moves needed to reach the scheduled placement
+// limited by the service disruption budget
+func planMoves(group *replicaGroup, scheduled []*api.Node) []*api.Move {
+	budget := uint64(1)
+	if b := group.service.DisruptionBudget; b != nil && b.MaxMoves > 0 {
+		budget = b.MaxMoves
+	}
+
+	// difference between the current and scheduled replicas per node
+	delta := map[string]int{}
+	for _, c := range group.containers {
+		delta[c.Node.ID]++
+	}
+	for _, node := range scheduled {
+		delta[node.ID]--
+	}
+
+	targets := []string{}
+	for id, d := range delta {
+		for i := 0; i < -d; i++ {
+			targets = append(targets, id)
+		}
+	}
+	sort.Strings(targets)
+
+	// replicas are recreated under the lowest unused replica index
+	used := map[string]struct{}{}
+	for _, c := range group.containers {
+		used[c.Container.ID] = struct{}{}
+	}
+	index := 0
+	nextID := func() string {
+		for {
+			id := fmt.Sprintf("%s.%s.%d", group.application, group.service.Name, index)
+			index++
+			if _, ok := used[id]; !ok {
+				return id
+			}
+		}
+	}
+
+	containers := append([]*api.Container{}, group.containers...)
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Container.ID > containers[j].Container.ID
+	})
+
+	moves := []*api.Move{}
+	for _, c := range containers {
+		if len(targets) == 0 || uint64(len(moves)) == budget {
+			break
+		}
+		if delta[c.Node.ID] <= 0 {
+			continue
+		}
+		delta[c.Node.ID]--
+		moves = append(moves, &api.Move{
+			Application:    group.application,
+			Service:        group.service.Name,
+			ContainerID:    c.Container.ID,
+			NewContainerID: nextID(),
+			FromNodeID:     c.Node.ID,
+			ToNodeID:       targets[0],
+		})
+		targets = targets[1:]
+	}
+
+	return moves
+}
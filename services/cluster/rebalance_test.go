@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/cluster/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	schedulerapi "github.com/ehazlett/stellar/api/services/scheduler/v1"
+)
+
+func testReplicaGroup(budget uint64, nodes ...string) *replicaGroup {
+	group := &replicaGroup{
+		application: "app",
+		service: &runtimeapi.Service{
+			Name: "web",
+			DisruptionBudget: &runtimeapi.DisruptionBudget{
+				MaxMoves: budget,
+			},
+		},
+	}
+	for i, node := range nodes {
+		group.containers = append(group.containers, &api.Container{
+			Container: &runtimeapi.Container{
+				ID: fmt.Sprintf("app.web.%d", i),
+			},
+			Node: &api.Node{
+				ID: node,
+			},
+		})
+	}
+	return group
+}
+
+func TestPlanMovesBalanced(t *testing.T) {
+	group := testReplicaGroup(1, "node-00", "node-01")
+	scheduled := []*api.Node{{ID: "node-00"}, {ID: "node-01"}}
+
+	if moves := planMoves(group, scheduled); len(moves) != 0 {
+		t.Fatalf("expected no moves; received %d", len(moves))
+	}
+}
+
+func TestPlanMovesNewNodes(t *testing.T) {
+	group := testReplicaGroup(3, "node-00", "node-00", "node-00")
+	scheduled := []*api.Node{{ID: "node-00"}, {ID: "node-01"}, {ID: "node-02"}}
+
+	moves := planMoves(group, scheduled)
+	if len(moves) != 2 {
+		t.Fatalf("expected 2 moves; received %d", len(moves))
+	}
+
+	expected := map[string][2]string{
+		"app.web.2": {"node-01", "app.web.3"},
+		"app.web.1": {"node-02", "app.web.4"},
+	}
+	for _, m := range moves {
+		// the moved replicas are recreated under unused ids
+		if m.FromNodeID != "node-00" || expected[m.ContainerID] != [2]string{m.ToNodeID, m.NewContainerID} {
+			t.Fatalf("unexpected move %s: %s -> %s (%s)", m.ContainerID, m.FromNodeID, m.ToNodeID, m.NewContainerID)
+		}
+	}
+}
+
+func TestPlanMovesDisruptionBudget(t *testing.T) {
+	group := testReplicaGroup(0, "node-00", "node-00", "node-00")
+	scheduled := []*api.Node{{ID: "node-00"}, {ID: "node-01"}, {ID: "node-02"}}
+
+	if moves := planMoves(group, scheduled); len(moves) != 1 {
+		t.Fatalf("expected 1 move; received %d", len(moves))
+	}
+}
+
+func TestPlanRebalanceReservesMoves(t *testing.T) {
+	// node-00 is full with two replicas of each service and node-01 has
+	// room for a single replica
+	capacity := map[string]float64{"node-00": 4, "node-01": 1.5}
+	groups := map[string]*replicaGroup{}
+	for _, name := range []string{"api", "web"} {
+		group := &replicaGroup{
+			application: "app",
+			service: &runtimeapi.Service{
+				Name:      name,
+				Resources: &runtimeapi.Resources{Cpus: 1},
+			},
+		}
+		for i := 0; i < 2; i++ {
+			group.containers = append(group.containers, &api.Container{
+				Container: &runtimeapi.Container{ID: fmt.Sprintf("app.%s.%d", name, i)},
+				Node:      &api.Node{ID: "node-00"},
+			})
+		}
+		groups["app/"+name] = group
+	}
+
+	// simulate places each replica on the node with the most free cpus
+	simulate := func(svc *runtimeapi.Service, exclude []string, reservations []*schedulerapi.Reservation) (*schedulerapi.ScheduleResponse, error) {
+		free := map[string]float64{}
+		for id, cpus := range capacity {
+			free[id] = cpus
+		}
+		excluded := map[string]bool{}
+		for _, id := range exclude {
+			excluded[id] = true
+		}
+		for _, group := range groups {
+			for _, c := range group.containers {
+				if !excluded[c.Container.ID] {
+					free[c.Node.ID] -= group.service.Resources.Cpus
+				}
+			}
+		}
+		for _, r := range reservations {
+			free[r.NodeID] -= r.Resources.Cpus
+		}
+		resp := &schedulerapi.ScheduleResponse{}
+		for i := uint64(0); i < svc.Replicas; i++ {
+			ids := []string{"node-00", "node-01"}
+			sort.SliceStable(ids, func(a, b int) bool { return free[ids[a]] > free[ids[b]] })
+			if free[ids[0]] < svc.Resources.Cpus {
+				break
+			}
+			free[ids[0]] -= svc.Resources.Cpus
+			resp.Nodes = append(resp.Nodes, &api.Node{ID: ids[0]})
+		}
+		return resp, nil
+	}
+
+	moves, err := planRebalance(groups, simulate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only one replica fits on node-01
+	if len(moves) != 1 {
+		t.Fatalf("expected 1 move; received %+v", moves)
+	}
+	if m := moves[0]; m.Service != "api" || m.ToNodeID != "node-01" {
+		t.Fatalf("unexpected move %+v", m)
+	}
+}
@@ -49,6 +49,7 @@ func (s *service) Requires() []services.Type {
 	return []services.Type{
 		services.RuntimeService,
 		services.HealthService,
+		services.SchedulerService,
 	}
 }
 
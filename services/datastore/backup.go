@@ -1,24 +1,56 @@
 package datastore
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
 )
 
+const (
+	// backupChunkSize is the size of each streamed backup chunk
+	backupChunkSize = 64 * 1024
+)
+
 func (s *service) Backup(ctx context.Context, _ *api.BackupRequest) (*api.BackupResponse, error) {
 	buf := bytes.NewBuffer(nil)
-	err := s.db.View(func(tx *bolt.Tx) error {
-		if _, err := tx.WriteTo(buf); err != nil {
-			return err
-		}
-
-		return nil
-	})
+	if _, err := s.db.Snapshot(buf); err != nil {
+		return nil, err
+	}
 
 	return &api.BackupResponse{
 		Data: buf.Bytes(),
-	}, err
+	}, nil
+}
+
+// BackupStream streams a gzip compressed backup in chunks so the backup does
+// not need to fit in memory
+func (s *service) BackupStream(_ *api.BackupRequest, srv api.Datastore_BackupStreamServer) error {
+	w := bufio.NewWriterSize(&backupWriter{srv: srv}, backupChunkSize)
+	gz := gzip.NewWriter(w)
+	if _, err := s.db.Snapshot(gz); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// backupWriter sends each write as a backup chunk
+type backupWriter struct {
+	srv api.Datastore_BackupStreamServer
+}
+
+func (w *backupWriter) Write(p []byte) (int, error) {
+	// the buffer is reused by the caller
+	data := append([]byte{}, p...)
+	if err := w.srv.Send(&api.BackupResponse{
+		Data: data,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
@@ -0,0 +1,197 @@
+package datastore
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	// changelogRetention is the number of change log records kept for peers
+	// catching up with a delta
+	changelogRetention = 10000
+
+	metaLogIDKey      = "log_id"
+	metaPeerKeyPrefix = "peer."
+)
+
+// peerState is the position in the change log of a peer that has been synced
+type peerState struct {
+	LogID    string
+	Sequence uint64
+}
+
+func sequenceKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+// isInternalBucket returns true for the buckets used by the datastore itself
+// that are not replicated
+func (s *service) isInternalBucket(name string) bool {
+	return name == s.changelogBucketName || name == s.metaBucketName || name == s.lockBucketName
+}
+
+// appendChange records the applied entry in the change log
+func (s *service) appendChange(tx engine.Tx, bucket, key string, e *api.Entry) (uint64, error) {
+	b, err := tx.CreateBucketIfNotExists([]byte(s.changelogBucketName))
+	if err != nil {
+		return 0, err
+	}
+	seq, err := b.NextSequence()
+	if err != nil {
+		return 0, err
+	}
+	data, err := proto.Marshal(&api.Change{
+		Sequence: seq,
+		Bucket:   bucket,
+		Key:      key,
+		Entry:    e,
+	})
+	if err != nil {
+		return 0, err
+	}
+	k := sequenceKey(seq)
+	if data, err = s.keys.seal(data, sealContext(s.changelogBucketName, k)); err != nil {
+		return 0, err
+	}
+	if err := b.Put(k, data); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// changelogRange returns the oldest retained and latest change log sequence
+func (s *service) changelogRange(tx engine.Tx) (uint64, uint64) {
+	b := tx.Bucket([]byte(s.changelogBucketName))
+	if b == nil {
+		return 1, 0
+	}
+	c := b.Cursor()
+	first, _ := c.First()
+	if first == nil {
+		return 1, 0
+	}
+	last, _ := c.Last()
+	return binary.BigEndian.Uint64(first), binary.BigEndian.Uint64(last)
+}
+
+// changesSince calls fn for each change log record after the sequence
+func (s *service) changesSince(tx engine.Tx, since uint64, fn func(*api.Change) error) error {
+	b := tx.Bucket([]byte(s.changelogBucketName))
+	if b == nil {
+		return nil
+	}
+	c := b.Cursor()
+	for k, v := c.Seek(sequenceKey(since + 1)); k != nil; k, v = c.Next() {
+		data, err := s.keys.open(v, sealContext(s.changelogBucketName, k))
+		if err != nil {
+			return err
+		}
+		var change api.Change
+		if err := proto.Unmarshal(data, &change); err != nil {
+			return err
+		}
+		if err := fn(&change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trimChangelog removes the change log records beyond the retention
+func (s *service) trimChangelog(tx engine.Tx) error {
+	b := tx.Bucket([]byte(s.changelogBucketName))
+	if b == nil {
+		return nil
+	}
+	c := b.Cursor()
+	last, _ := c.Last()
+	if last == nil {
+		return nil
+	}
+	head := binary.BigEndian.Uint64(last)
+	if head <= changelogRetention {
+		return nil
+	}
+	limit := sequenceKey(head - changelogRetention)
+	for k, _ := c.First(); k != nil && string(k) <= string(limit); k, _ = c.First() {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logID returns the id of the local change log creating it if needed
+func (s *service) logID(tx engine.Tx) (string, error) {
+	b, err := tx.CreateBucketIfNotExists([]byte(s.metaBucketName))
+	if err != nil {
+		return "", err
+	}
+	if v := b.Get([]byte(metaLogIDKey)); v != nil {
+		return string(v), nil
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	logID := hex.EncodeToString(id)
+	if err := b.Put([]byte(metaLogIDKey), []byte(logID)); err != nil {
+		return "", err
+	}
+	return logID, nil
+}
+
+// resetChangelog removes the change log and peer state; this is used when the
+// database is replaced so the log is not confused with that of another node
+func (s *service) resetChangelog(tx engine.Tx) error {
+	for _, name := range []string{s.changelogBucketName, s.metaBucketName} {
+		if tx.Bucket([]byte(name)) == nil {
+			continue
+		}
+		if err := tx.DeleteBucket([]byte(name)); err != nil {
+			return err
+		}
+	}
+	_, err := s.logID(tx)
+	return err
+}
+
+func (s *service) getPeerState(id string) (*peerState, error) {
+	var state *peerState
+	err := s.db.View(func(tx engine.Tx) error {
+		b := tx.Bucket([]byte(s.metaBucketName))
+		if b == nil {
+			return nil
+		}
+		v := b.Get([]byte(metaPeerKeyPrefix + id))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &state)
+	})
+	return state, err
+}
+
+func (s *service) setPeerState(id string, state *peerState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.db.Update(func(tx engine.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(s.metaBucketName))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(metaPeerKeyPrefix+id), data)
+	})
+}
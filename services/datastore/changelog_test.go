@@ -0,0 +1,99 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+)
+
+func TestChangelogSince(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: key, Value: []byte(key)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Bucket: "test", Key: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := []*api.Change{}
+	if err := s.db.View(func(tx engine.Tx) error {
+		first, head := s.changelogRange(tx)
+		if first != 1 || head != 4 {
+			t.Fatalf("unexpected change log range %d-%d", first, head)
+		}
+		return s.changesSince(tx, 2, func(c *api.Change) error {
+			changes = append(changes, c)
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes; received %d", len(changes))
+	}
+	if changes[0].Key != "c" || changes[0].Sequence != 3 {
+		t.Fatalf("unexpected change %+v", changes[0])
+	}
+	if changes[1].Key != "a" || !changes[1].Entry.Deleted {
+		t.Fatalf("expected delete change; received %+v", changes[1])
+	}
+}
+
+func TestDigestMatchesReplicatedWrites(t *testing.T) {
+	a, cleanupA := testService(t)
+	defer cleanupA()
+	b, cleanupB := testService(t)
+	defer cleanupB()
+
+	ctx := context.Background()
+	if _, err := a.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+
+	digest := func(s *service) []byte {
+		resp, err := s.Digest(ctx, &api.DigestRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Buckets {
+			if d.Bucket == "test" {
+				return d.Digest
+			}
+		}
+		return nil
+	}
+
+	if bytes.Equal(digest(a), digest(b)) {
+		t.Fatal("expected digests to differ")
+	}
+
+	// replicate the change as a peer would
+	resp, err := a.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: resp.Data.Value, Version: resp.Data.Version}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(digest(a), digest(b)) {
+		t.Fatal("expected digests to match")
+	}
+
+	keys, err := b.newerKeys("test", []*api.KeyVersion{{Key: "foo", Version: resp.Data.Version}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("expected no newer keys; received %v", keys)
+	}
+}
@@ -2,55 +2,52 @@ package datastore
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"time"
 
 	"github.com/containerd/containerd/errdefs"
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 func (s *service) Delete(ctx context.Context, req *api.DeleteRequest) (*ptypes.Empty, error) {
+	// deletes replicated from a peer may arrive before the bucket exists
+	replicated := req.Version != nil
+	entry := &api.Entry{
+		Version: s.version(req.Version),
+		Deleted: true,
+	}
+	var change *api.Change
 	s.lock.Lock()
-	err := s.db.Update(func(tx *bolt.Tx) error {
+	err := s.db.Update(func(tx engine.Tx) error {
 		b := tx.Bucket([]byte(req.Bucket))
-		if b == nil {
+		if b == nil && !replicated {
 			return errdefs.ToGRPC(errors.Wrapf(errdefs.ErrNotFound, "bucket %s", req.Bucket))
 		}
-		val := b.Get([]byte(req.Key))
-		if err := b.Delete([]byte(req.Key)); err != nil {
-			return err
-		}
-		if !req.NoTombstone {
-			// add tombstone
-			tb := tx.Bucket([]byte(s.dsTombstoneBucketName))
-			if tb == nil {
-				return errdefs.ToGRPC(errors.Wrapf(errdefs.ErrNotFound, "bucket %s", s.dsTombstoneBucketName))
-			}
-
-			t := &tombstone{
-				Timestamp: time.Now(),
-				Bucket:    req.Bucket,
-				Key:       req.Key,
-				Value:     val,
-			}
-			data, err := json.Marshal(t)
-			if err != nil {
-				return err
-			}
-			key := s.tombstoneKeyName(req.Bucket, req.Key)
-			if err := tb.Put([]byte(key), data); err != nil {
-				return err
+		if req.NoTombstone {
+			if b == nil {
+				return nil
 			}
-			logrus.Debugf("datastore: added tombstone record for %s:%s", req.Bucket, req.Key)
+			return b.Delete([]byte(req.Key))
 		}
+		// record a versioned tombstone so older writes from peers cannot
+		// resurrect the key
+		c, err := s.apply(tx, req.Bucket, req.Key, entry)
+		if err != nil {
+			return err
+		}
+		change = c
+		logrus.Debugf("datastore: added tombstone record for %s:%s", req.Bucket, req.Key)
 		return nil
 	})
+	if err == nil {
+		s.notify(change)
+	}
 	s.lock.Unlock()
+	if err != nil {
+		return empty, err
+	}
 
 	logrus.WithFields(logrus.Fields{
 		"bucket": req.Bucket,
@@ -63,9 +60,5 @@ func (s *service) Delete(ctx context.Context, req *api.DeleteRequest) (*ptypes.E
 		}
 	}
 
-	return empty, err
-}
-
-func (s *service) tombstoneKeyName(bucket, key string) string {
-	return fmt.Sprintf("%s:%s:%s", s.agent.Self().ID, bucket, key)
+	return empty, nil
 }
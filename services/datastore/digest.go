@@ -0,0 +1,116 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/pkg/errors"
+)
+
+// Digest returns a digest of the versions in each bucket along with the
+// position of the change log.  The digest is computed in a read transaction
+// so it does not block writes.
+func (s *service) Digest(ctx context.Context, _ *api.DigestRequest) (*api.DigestResponse, error) {
+	resp := &api.DigestResponse{}
+	err := s.db.View(func(tx engine.Tx) error {
+		// the log id is created on start
+		if b := tx.Bucket([]byte(s.metaBucketName)); b != nil {
+			resp.LogID = string(b.Get([]byte(metaLogIDKey)))
+		}
+		resp.FirstSequence, resp.Sequence = s.changelogRange(tx)
+
+		digests, err := s.digests(tx)
+		if err != nil {
+			return err
+		}
+		resp.Buckets = digests
+		return nil
+	})
+	return resp, err
+}
+
+// Versions returns the version of each key in the bucket
+func (s *service) Versions(ctx context.Context, req *api.VersionsRequest) (*api.VersionsResponse, error) {
+	resp := &api.VersionsResponse{}
+	err := s.db.View(func(tx engine.Tx) error {
+		b := tx.Bucket([]byte(req.Bucket))
+		if b == nil || s.isInternalBucket(req.Bucket) {
+			return errdefs.ToGRPC(errors.Wrapf(errdefs.ErrNotFound, "bucket %s", req.Bucket))
+		}
+		return b.ForEach(func(k, v []byte) error {
+			e, err := s.decodeEntry(req.Bucket, k, v)
+			if err != nil {
+				return err
+			}
+			resp.Versions = append(resp.Versions, &api.KeyVersion{
+				Key:     string(k),
+				Version: e.Version,
+			})
+			return nil
+		})
+	})
+	return resp, err
+}
+
+// digests returns the digest for each replicated bucket.  The digest covers
+// the key and version of each entry (including tombstones) so nodes holding
+// the same writes produce the same digest.
+func (s *service) digests(tx engine.Tx) ([]*api.BucketDigest, error) {
+	var digests []*api.BucketDigest
+	now := time.Now().UnixNano()
+	err := tx.ForEach(func(name []byte, b engine.Bucket) error {
+		if s.isInternalBucket(string(name)) {
+			return nil
+		}
+		h := sha256.New()
+		keys := uint64(0)
+		if err := b.ForEach(func(k, v []byte) error {
+			e, err := s.decodeEntry(string(name), k, v)
+			if err != nil {
+				return err
+			}
+			// expired entries digest the same as the tombstone replacing them
+			if expired(e, now) {
+				e = expiredTombstone(e)
+			}
+			writeVersion(h, k, e)
+			keys++
+			return nil
+		}); err != nil {
+			return err
+		}
+		digests = append(digests, &api.BucketDigest{
+			Bucket: string(name),
+			Digest: h.Sum(nil),
+			Keys:   keys,
+		})
+		return nil
+	})
+	return digests, err
+}
+
+func writeVersion(w io.Writer, key []byte, e *api.Entry) {
+	buf := make([]byte, 8)
+	w.Write(key)
+	w.Write([]byte{0})
+	if v := e.Version; v != nil {
+		binary.BigEndian.PutUint64(buf, uint64(v.Wall))
+		w.Write(buf)
+		binary.BigEndian.PutUint64(buf, uint64(v.Logical))
+		w.Write(buf)
+		w.Write([]byte(v.NodeID))
+	} else {
+		// unversioned entries are identified by their value
+		w.Write(e.Value)
+	}
+	if e.Deleted {
+		w.Write([]byte{1})
+	}
+	w.Write([]byte{0})
+}
@@ -0,0 +1,444 @@
+package datastore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// dataKeySize is the size of the cluster data keys and the per value keys
+	dataKeySize = 32
+	// reencryptBatchSize is the number of values re-encrypted per transaction
+	// so writes are not blocked while rotating
+	reencryptBatchSize = 1000
+)
+
+var (
+	// sealedHeader prefixes values encrypted with a data key.  The bucket
+	// and key the value is stored under are authenticated with the value so
+	// it cannot be moved to another key.
+	sealedHeader = []byte{0x00, 's', 'x', 2}
+	// sealedHeaderV1 prefixes values encrypted before the values were
+	// bound to their key; they are read until they are re-encrypted
+	sealedHeaderV1 = []byte{0x00, 's', 'x', 1}
+)
+
+// dataKey is a cluster data key used to wrap the per value keys
+type dataKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+func newDataKey(key []byte) (*dataKey, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes; received %d", dataKeySize, len(key))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &dataKey{
+		id:   hex.EncodeToString(sum[:8]),
+		aead: aead,
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keyring holds the cluster data keys.  Values are encrypted with a random
+// key that is wrapped by the primary data key and stored with the value
+// (envelope encryption).  The other keys decrypt values written before the
+// primary key was rotated.  A nil or empty keyring stores values in plain text.
+type keyring struct {
+	mu      sync.RWMutex
+	primary *dataKey
+	keys    map[string]*dataKey
+}
+
+// newKeyring returns a keyring for the keys; the first key is the primary
+func newKeyring(keys [][]byte) (*keyring, error) {
+	k := &keyring{}
+	if err := k.set(keys); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// set replaces the keys in the keyring; the first key is the primary
+func (k *keyring) set(keys [][]byte) error {
+	dataKeys := make(map[string]*dataKey, len(keys))
+	var primary *dataKey
+	for i, key := range keys {
+		dk, err := newDataKey(key)
+		if err != nil {
+			return errors.Wrapf(err, "data key %d", i+1)
+		}
+		if primary == nil {
+			primary = dk
+		}
+		dataKeys[dk.id] = dk
+	}
+
+	k.mu.Lock()
+	k.primary = primary
+	k.keys = dataKeys
+	k.mu.Unlock()
+	return nil
+}
+
+// primaryID returns the id of the primary key or empty if encryption is
+// disabled
+func (k *keyring) primaryID() string {
+	if k == nil {
+		return ""
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.primary == nil {
+		return ""
+	}
+	return k.primary.id
+}
+
+// sealContext returns the bucket and key a value is stored under
+func sealContext(bucket string, key []byte) []byte {
+	c := make([]byte, 0, len(bucket)+1+len(key))
+	c = append(c, bucket...)
+	c = append(c, 0)
+	return append(c, key...)
+}
+
+// sealedAAD returns the additional data authenticated with the sealed value
+func sealedAAD(context []byte) []byte {
+	return append(append([]byte{}, sealedHeader...), context...)
+}
+
+// seal encrypts the data with a new value key wrapped by the primary key.
+// The sealed data is the header, the key id, the wrapped value key and the
+// encrypted data.  The context returned by sealContext must be passed to
+// open the data.
+func (k *keyring) seal(data, context []byte) ([]byte, error) {
+	if k == nil {
+		return data, nil
+	}
+	k.mu.RLock()
+	primary := k.primary
+	k.mu.RUnlock()
+	if primary == nil {
+		return data, nil
+	}
+
+	valueKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(valueKey); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(valueKey)
+	if err != nil {
+		return nil, err
+	}
+	aad := sealedAAD(context)
+	wrapped, err := sealWithNonce(primary.aead, valueKey, aad)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := sealWithNonce(aead, data, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(sealedHeader)+1+len(primary.id)+2+len(wrapped)+len(encrypted)))
+	buf.Write(sealedHeader)
+	buf.WriteByte(byte(len(primary.id)))
+	buf.WriteString(primary.id)
+	binary.Write(buf, binary.BigEndian, uint16(len(wrapped)))
+	buf.Write(wrapped)
+	buf.Write(encrypted)
+	return buf.Bytes(), nil
+}
+
+// open decrypts the sealed data stored under the context; data that is not
+// sealed is returned as is so values written before encryption was enabled
+// can be read
+func (k *keyring) open(data, context []byte) ([]byte, error) {
+	var aad []byte
+	switch {
+	case bytes.HasPrefix(data, sealedHeader):
+		aad = sealedAAD(context)
+	case bytes.HasPrefix(data, sealedHeaderV1):
+		aad = sealedHeaderV1
+	default:
+		return data, nil
+	}
+	id, wrapped, encrypted, err := parseSealed(data)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, fmt.Errorf("value is encrypted with data key %s but datastore encryption is not configured", id)
+	}
+	k.mu.RLock()
+	dk, ok := k.keys[id]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("value is encrypted with unknown data key %s", id)
+	}
+
+	valueKey, err := openWithNonce(dk.aead, wrapped, aad)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to unwrap value key with data key %s", id)
+	}
+	aead, err := newAEAD(valueKey)
+	if err != nil {
+		return nil, err
+	}
+	return openWithNonce(aead, encrypted, aad)
+}
+
+// sealedWith returns the id of the key the data is sealed with or empty if
+// the data is in plain text
+func sealedWith(data []byte) string {
+	if !bytes.HasPrefix(data, sealedHeader) && !bytes.HasPrefix(data, sealedHeaderV1) {
+		return ""
+	}
+	id, _, _, err := parseSealed(data)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+func parseSealed(data []byte) (string, []byte, []byte, error) {
+	d := data[len(sealedHeader):]
+	if len(d) < 1 || len(d) < 1+int(d[0])+2 {
+		return "", nil, nil, fmt.Errorf("invalid encrypted value")
+	}
+	id := string(d[1 : 1+int(d[0])])
+	d = d[1+int(d[0]):]
+	n := int(binary.BigEndian.Uint16(d))
+	d = d[2:]
+	if len(d) < n {
+		return "", nil, nil, fmt.Errorf("invalid encrypted value")
+	}
+	return id, d[:n], d[n:], nil
+}
+
+// sealedCurrent returns true if the data is sealed with the key in the
+// current format
+func sealedCurrent(data []byte, id string) bool {
+	return bytes.HasPrefix(data, sealedHeader) && sealedWith(data) == id
+}
+
+// sealWithNonce encrypts the data with a random nonce prepended
+func sealWithNonce(aead cipher.AEAD, data, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, aad), nil
+}
+
+func openWithNonce(aead cipher.AEAD, data, aad []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted value")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], aad)
+}
+
+// loadDataKeys returns the cluster data keys from the config.  The keys in
+// the keyfile are read on each call so a rotated keyfile is picked up.
+func loadDataKeys(cfg *stellar.Config) ([][]byte, error) {
+	if cfg.DatastoreEncryptionKey != "" && cfg.DatastoreEncryptionKeyFile != "" {
+		return nil, fmt.Errorf("only one of DatastoreEncryptionKey and DatastoreEncryptionKeyFile may be set")
+	}
+	if cfg.DatastoreEncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.DatastoreEncryptionKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid datastore encryption key")
+		}
+		return [][]byte{key}, nil
+	}
+	if cfg.DatastoreEncryptionKeyFile == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(cfg.DatastoreEncryptionKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys [][]byte
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key in %s", cfg.DatastoreEncryptionKeyFile)
+		}
+		keys = append(keys, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys in %s", cfg.DatastoreEncryptionKeyFile)
+	}
+	return keys, nil
+}
+
+// RotateKey reloads the cluster data keys from the config and re-encrypts the
+// values that are not encrypted with the primary key.  The previous keys must
+// remain in the keyfile until the rotation completes on every node.  Keys set
+// inline in the config cannot be rotated.
+func (s *service) RotateKey(ctx context.Context, _ *api.RotateKeyRequest) (*api.RotateKeyResponse, error) {
+	if s.keys == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "datastore encryption is not configured")
+	}
+	if s.config.DatastoreEncryptionKey != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "key rotation requires DatastoreEncryptionKeyFile; move DatastoreEncryptionKey to a keyfile to rotate it")
+	}
+	keys, err := loadDataKeys(s.config)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to load data keys: %s", err)
+	}
+	if err := s.keys.set(keys); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"key": s.keys.primaryID(),
+	}).Info("datastore data key rotated")
+
+	n, err := s.reencrypt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RotateKeyResponse{
+		KeyID:       s.keys.primaryID(),
+		Reencrypted: n,
+	}, nil
+}
+
+// reencrypt encrypts the values that are in plain text, encrypted with a
+// previous key or sealed in the previous format with the primary key.  The values are rewritten in batches so
+// writes continue while re-encrypting.
+func (s *service) reencrypt() (uint64, error) {
+	primary := s.keys.primaryID()
+	if primary == "" {
+		return 0, nil
+	}
+
+	var buckets [][]byte
+	if err := s.db.View(func(tx engine.Tx) error {
+		return tx.ForEach(func(name []byte, _ engine.Bucket) error {
+			// the meta and lock buckets hold no values
+			if string(name) == s.metaBucketName || string(name) == s.lockBucketName {
+				return nil
+			}
+			buckets = append(buckets, append([]byte{}, name...))
+			return nil
+		})
+	}); err != nil {
+		return 0, err
+	}
+
+	total := uint64(0)
+	for _, bucket := range buckets {
+		var after []byte
+		for {
+			var keys [][]byte
+			if err := s.db.View(func(tx engine.Tx) error {
+				b := tx.Bucket(bucket)
+				if b == nil {
+					return nil
+				}
+				c := b.Cursor()
+				k, v := c.First()
+				if after != nil {
+					k, v = c.Seek(after)
+					if k != nil && bytes.Equal(k, after) {
+						k, v = c.Next()
+					}
+				}
+				for ; k != nil && len(keys) < reencryptBatchSize; k, v = c.Next() {
+					after = append([]byte{}, k...)
+					if !sealedCurrent(v, primary) {
+						keys = append(keys, after)
+					}
+				}
+				if k == nil {
+					after = nil
+				}
+				return nil
+			}); err != nil {
+				return total, err
+			}
+
+			if len(keys) > 0 {
+				if err := s.db.Update(func(tx engine.Tx) error {
+					b := tx.Bucket(bucket)
+					if b == nil {
+						return nil
+					}
+					for _, k := range keys {
+						// the value may have been rewritten since the scan
+						v := b.Get(k)
+						if v == nil || sealedCurrent(v, primary) {
+							continue
+						}
+						context := sealContext(string(bucket), k)
+						data, err := s.keys.open(v, context)
+						if err != nil {
+							return errors.Wrapf(err, "%s/%s", bucket, k)
+						}
+						sealed, err := s.keys.seal(data, context)
+						if err != nil {
+							return err
+						}
+						if err := b.Put(k, sealed); err != nil {
+							return err
+						}
+						total++
+					}
+					return nil
+				}); err != nil {
+					return total, err
+				}
+			}
+			if after == nil {
+				break
+			}
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,291 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testDataKey(t *testing.T) []byte {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writeKeyFile(t *testing.T, keys ...[]byte) string {
+	f, err := ioutil.TempFile("", "stellar-keys-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := []string{"# stellar data keys"}
+	for _, k := range keys {
+		lines = append(lines, base64.StdEncoding.EncodeToString(k))
+	}
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+// rawValues returns the stored data for the keys in the bucket
+func rawValues(t *testing.T, s *service, bucket string) map[string][]byte {
+	values := map[string][]byte{}
+	if err := s.db.View(func(tx engine.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			values[string(k)] = append([]byte{}, v...)
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func TestEncryptedValues(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	keys, err := newKeyring([][]byte{testDataKey(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = keys
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("secret-value")}); err != nil {
+		t.Fatal(err)
+	}
+	if v := string(get(t, s, "test", "foo").Value); v != "secret-value" {
+		t.Fatalf("expected secret-value; received %q", v)
+	}
+
+	for _, bucket := range []string{"test", s.changelogBucketName} {
+		for k, v := range rawValues(t, s, bucket) {
+			if bytes.Contains(v, []byte("secret-value")) {
+				t.Fatalf("expected %s/%s to be encrypted", bucket, k)
+			}
+			if id := sealedWith(v); id != keys.primaryID() {
+				t.Fatalf("expected %s/%s to be sealed with %s; received %q", bucket, k, keys.primaryID(), id)
+			}
+		}
+	}
+
+	// values cannot be read without the key
+	other, err := newKeyring([][]byte{testDataKey(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = other
+	if _, err := s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"}); err == nil {
+		t.Fatal("expected error reading value encrypted with unknown key")
+	}
+}
+
+func TestRotateKey(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	// written before encryption was enabled
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "plain", Value: []byte("1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	oldKey := testDataKey(t)
+	oldKeyFile := writeKeyFile(t, oldKey)
+	defer os.Remove(oldKeyFile)
+	s.config = &stellar.Config{DatastoreEncryptionKeyFile: oldKeyFile}
+	keys, err := newKeyring([][]byte{oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = keys
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "old", Value: []byte("2")}); err != nil {
+		t.Fatal(err)
+	}
+
+	newKey := testDataKey(t)
+	newKeyFile := writeKeyFile(t, newKey, oldKey)
+	defer os.Remove(newKeyFile)
+	s.config.DatastoreEncryptionKeyFile = newKeyFile
+
+	resp, err := s.RotateKey(ctx, &api.RotateKeyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newID := sealedWith(mustSeal(t, newKey))
+	if resp.KeyID != newID {
+		t.Fatalf("expected primary key %s; received %s", newID, resp.KeyID)
+	}
+	// both values and their change log records
+	if resp.Reencrypted != 4 {
+		t.Fatalf("expected 4 values re-encrypted; received %d", resp.Reencrypted)
+	}
+	for k, v := range rawValues(t, s, "test") {
+		if id := sealedWith(v); id != newID {
+			t.Fatalf("expected %s to be sealed with %s; received %q", k, newID, id)
+		}
+	}
+
+	// the previous key is no longer needed
+	if err := s.keys.set([][]byte{newKey}); err != nil {
+		t.Fatal(err)
+	}
+	if v := string(get(t, s, "test", "plain").Value); v != "1" {
+		t.Fatalf("expected 1; received %q", v)
+	}
+	if v := string(get(t, s, "test", "old").Value); v != "2" {
+		t.Fatalf("expected 2; received %q", v)
+	}
+}
+
+func TestRotateInlineKey(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	key := testDataKey(t)
+	s.config = &stellar.Config{DatastoreEncryptionKey: base64.StdEncoding.EncodeToString(key)}
+	keys, err := newKeyring([][]byte{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = keys
+
+	if _, err := s.RotateKey(context.Background(), &api.RotateKeyRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected failed precondition rotating an inline key; received %v", err)
+	}
+}
+
+func mustSeal(t *testing.T, key []byte) []byte {
+	k, err := newKeyring([][]byte{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := k.seal([]byte("test"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSealedValueBoundToKey(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	keys, err := newKeyring([][]byte{testDataKey(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = keys
+
+	ctx := context.Background()
+	for _, k := range []string{"foo", "bar"} {
+		if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: k, Value: []byte(k)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a sealed value copied to another key cannot be opened
+	if err := s.db.Update(func(tx engine.Tx) error {
+		b := tx.Bucket([]byte("test"))
+		return b.Put([]byte("bar"), b.Get([]byte("foo")))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "bar"}); err == nil {
+		t.Fatal("expected error reading value sealed for another key")
+	}
+}
+
+func TestSealedV1Migration(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	key := testDataKey(t)
+	keys, err := newKeyring([][]byte{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.keys = keys
+
+	data, err := encodeEntry(&api.Entry{Value: []byte("v1"), Version: s.clock.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed := sealV1(t, key, data)
+	if err := s.db.Update(func(tx engine.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("test"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("foo"), sealed)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// values sealed in the previous format are read and re-encrypted
+	if v := string(get(t, s, "test", "foo").Value); v != "v1" {
+		t.Fatalf("expected v1; received %q", v)
+	}
+	n, err := s.reencrypt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 value re-encrypted; received %d", n)
+	}
+	if v := rawValues(t, s, "test")["foo"]; !sealedCurrent(v, keys.primaryID()) {
+		t.Fatalf("expected value sealed in the current format; received %x", v[:len(sealedHeader)])
+	}
+	if v := string(get(t, s, "test", "foo").Value); v != "v1" {
+		t.Fatalf("expected v1; received %q", v)
+	}
+}
+
+// sealV1 seals the data in the format used before values were bound to
+// their key
+func sealV1(t *testing.T, key, data []byte) []byte {
+	dk, err := newDataKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valueKey := testDataKey(t)
+	aead, err := newAEAD(valueKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped, err := sealWithNonce(dk.aead, valueKey, sealedHeaderV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := sealWithNonce(aead, data, sealedHeaderV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := bytes.NewBuffer(append([]byte{}, sealedHeaderV1...))
+	buf.WriteByte(byte(len(dk.id)))
+	buf.WriteString(dk.id)
+	binary.Write(buf, binary.BigEndian, uint16(len(wrapped)))
+	buf.Write(wrapped)
+	buf.Write(encrypted)
+	return buf.Bytes()
+}
@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+)
+
+type boltEngine struct {
+	path string
+	// mu guards the database while it is replaced by a restore
+	mu sync.RWMutex
+	db *bolt.DB
+}
+
+// NewBolt returns an engine backed by the bolt database at the path
+func NewBolt(path string) (Engine, error) {
+	db, err := openBolt(path)
+	if err != nil {
+		return nil, err
+	}
+	return &boltEngine{
+		path: path,
+		db:   db,
+	}, nil
+}
+
+func openBolt(path string) (*bolt.DB, error) {
+	return bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second * 1})
+}
+
+func (e *boltEngine) View(fn func(Tx) error) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx})
+	})
+}
+
+func (e *boltEngine) Update(fn func(Tx) error) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx})
+	})
+}
+
+// Snapshot writes a copy of the bolt database file
+func (e *boltEngine) Snapshot(w io.Writer) (int64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var n int64
+	err := e.db.View(func(tx *bolt.Tx) error {
+		v, err := tx.WriteTo(w)
+		n = v
+		return err
+	})
+	return n, err
+}
+
+// Restore replaces the database file with the snapshot
+func (e *boltEngine) Restore(r io.Reader) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	tmp := e.path + ".restore"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	// ensure the snapshot is a valid database before replacing the current
+	db, err := openBolt(tmp)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	db.Close()
+
+	if err := e.db.Close(); err != nil {
+		os.Remove(tmp)
+		return e.reopen(err)
+	}
+	if err := os.Rename(tmp, e.path); err != nil {
+		os.Remove(tmp)
+		return e.reopen(err)
+	}
+	db, err = openBolt(e.path)
+	if err != nil {
+		return e.reopen(err)
+	}
+	e.db = db
+	return nil
+}
+
+// reopen opens the database at the path after a failed restore so the
+// engine is not left with a closed database.  The restore error is returned.
+func (e *boltEngine) reopen(restoreErr error) error {
+	db, err := openBolt(e.path)
+	if err != nil {
+		return fmt.Errorf("%v; error reopening database: %v", restoreErr, err)
+	}
+	e.db = db
+	return restoreErr
+}
+
+func (e *boltEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) Bucket(name []byte) Bucket {
+	b := t.tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return &boltBucket{b}
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{b}, nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	return t.tx.DeleteBucket(name)
+}
+
+func (t *boltTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	return t.tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		return fn(name, &boltBucket{b})
+	})
+}
+
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+func (b *boltBucket) Cursor() Cursor {
+	return b.Bucket.Cursor()
+}
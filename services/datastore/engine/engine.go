@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// Bolt stores the datastore in a bolt database on disk
+	Bolt = "bolt"
+	// Memory stores the datastore in memory; data is lost on restart
+	Memory = "memory"
+)
+
+var (
+	// ErrBucketNotFound is returned when deleting a bucket that does not exist
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrBucketNameRequired is returned when creating a bucket without a name
+	ErrBucketNameRequired = errors.New("bucket name required")
+	// ErrTxNotWritable is returned when modifying data in a read only transaction
+	ErrTxNotWritable = errors.New("transaction not writable")
+)
+
+// Engine is a transactional key value store with keys organized in buckets
+type Engine interface {
+	// View executes fn in a read only transaction
+	View(fn func(Tx) error) error
+	// Update executes fn in a read write transaction.  The changes are
+	// committed if fn returns nil and discarded otherwise.
+	Update(fn func(Tx) error) error
+	// Snapshot writes a consistent copy of the store to w
+	Snapshot(w io.Writer) (int64, error)
+	// Restore replaces the contents of the store with a snapshot taken by
+	// the same engine type
+	Restore(r io.Reader) error
+	// Close releases the resources of the engine
+	Close() error
+}
+
+// Tx is a transaction
+type Tx interface {
+	// Bucket returns the bucket or nil if it does not exist
+	Bucket(name []byte) Bucket
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	DeleteBucket(name []byte) error
+	// ForEach calls fn for each bucket in name order
+	ForEach(fn func(name []byte, b Bucket) error) error
+}
+
+// Bucket is a collection of keys ordered by key
+type Bucket interface {
+	// Get returns the value for the key or nil if the key does not exist.
+	// The value is only valid for the life of the transaction.
+	Get(key []byte) []byte
+	Put(key []byte, value []byte) error
+	Delete(key []byte) error
+	// NextSequence returns an auto incrementing integer for the bucket
+	NextSequence() (uint64, error)
+	// ForEach calls fn for each key in order; the bucket must not be
+	// modified by fn
+	ForEach(fn func(k, v []byte) error) error
+	Cursor() Cursor
+}
+
+// Cursor iterates the keys of a bucket in order.  A nil key is returned when
+// the cursor is exhausted.
+type Cursor interface {
+	First() (key []byte, value []byte)
+	Last() (key []byte, value []byte)
+	// Seek moves to the first key greater than or equal to seek
+	Seek(seek []byte) (key []byte, value []byte)
+	Next() (key []byte, value []byte)
+	// Delete removes the key at the cursor position
+	Delete() error
+}
+
+// Open returns the engine of the specified type.  The path is the database
+// file used by engines storing data on disk.
+func Open(engineType, path string) (Engine, error) {
+	switch engineType {
+	case "", Bolt:
+		return NewBolt(path)
+	case Memory:
+		return NewMemory(), nil
+	}
+	return nil, fmt.Errorf("unknown datastore engine %q", engineType)
+}
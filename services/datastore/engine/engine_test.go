@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testEngines runs the test against each engine
+func testEngines(t *testing.T, fn func(t *testing.T, e Engine)) {
+	t.Run(Memory, func(t *testing.T) {
+		fn(t, NewMemory())
+	})
+	t.Run(Bolt, func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "stellar-engine-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		e, err := NewBolt(filepath.Join(dir, "test.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer e.Close()
+		fn(t, e)
+	})
+}
+
+func put(t *testing.T, e Engine, bucket string, kv ...string) {
+	if err := e.Update(func(tx Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(kv); i += 2 {
+			if err := b.Put([]byte(kv[i]), []byte(kv[i+1])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func keys(t *testing.T, e Engine, bucket string) []string {
+	var keys []string
+	if err := e.View(func(tx Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestEngineBuckets(t *testing.T) {
+	testEngines(t, func(t *testing.T, e Engine) {
+		put(t, e, "b", "k", "v")
+		put(t, e, "a", "k", "v")
+
+		if err := e.View(func(tx Tx) error {
+			if tx.Bucket([]byte("missing")) != nil {
+				t.Fatal("expected nil for missing bucket")
+			}
+			names := []string{}
+			if err := tx.ForEach(func(name []byte, b Bucket) error {
+				names = append(names, string(name))
+				return nil
+			}); err != nil {
+				return err
+			}
+			if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+				t.Fatalf("unexpected buckets %v", names)
+			}
+			if v := tx.Bucket([]byte("a")).Get([]byte("k")); string(v) != "v" {
+				t.Fatalf("unexpected value %q", v)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := e.Update(func(tx Tx) error {
+			return tx.DeleteBucket([]byte("a"))
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.View(func(tx Tx) error {
+			if tx.Bucket([]byte("a")) != nil {
+				t.Fatal("expected bucket to be deleted")
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestEngineRollback(t *testing.T) {
+	testEngines(t, func(t *testing.T, e Engine) {
+		put(t, e, "test", "a", "1")
+
+		errRollback := errors.New("rollback")
+		if err := e.Update(func(tx Tx) error {
+			b := tx.Bucket([]byte("test"))
+			if err := b.Put([]byte("b"), []byte("2")); err != nil {
+				return err
+			}
+			if err := b.Delete([]byte("a")); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucketIfNotExists([]byte("other")); err != nil {
+				return err
+			}
+			return errRollback
+		}); err != errRollback {
+			t.Fatalf("expected rollback error; received %v", err)
+		}
+
+		if k := keys(t, e, "test"); len(k) != 1 || k[0] != "a" {
+			t.Fatalf("expected changes to be discarded; received %v", k)
+		}
+		if err := e.View(func(tx Tx) error {
+			if tx.Bucket([]byte("other")) != nil {
+				t.Fatal("expected bucket creation to be discarded")
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestEngineCursor(t *testing.T) {
+	testEngines(t, func(t *testing.T, e Engine) {
+		put(t, e, "test", "c", "3", "a", "1", "d", "4", "b", "2")
+
+		if err := e.Update(func(tx Tx) error {
+			c := tx.Bucket([]byte("test")).Cursor()
+			if k, _ := c.First(); string(k) != "a" {
+				t.Fatalf("unexpected first key %q", k)
+			}
+			if k, _ := c.Last(); string(k) != "d" {
+				t.Fatalf("unexpected last key %q", k)
+			}
+			k, v := c.Seek([]byte("bb"))
+			if string(k) != "c" || string(v) != "3" {
+				t.Fatalf("unexpected seek result %q=%q", k, v)
+			}
+			if k, _ := c.Seek([]byte("e")); k != nil {
+				t.Fatalf("expected seek past the end to return nil; received %q", k)
+			}
+
+			// delete while iterating
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				if string(k) == "b" {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if k := keys(t, e, "test"); len(k) != 3 || k[0] != "a" || k[1] != "c" || k[2] != "d" {
+			t.Fatalf("unexpected keys after delete %v", k)
+		}
+	})
+}
+
+func TestEngineSequence(t *testing.T) {
+	testEngines(t, func(t *testing.T, e Engine) {
+		for i := uint64(1); i <= 3; i++ {
+			if err := e.Update(func(tx Tx) error {
+				b, err := tx.CreateBucketIfNotExists([]byte("test"))
+				if err != nil {
+					return err
+				}
+				seq, err := b.NextSequence()
+				if err != nil {
+					return err
+				}
+				if seq != i {
+					t.Fatalf("expected sequence %d; received %d", i, seq)
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestEngineSnapshotRestore(t *testing.T) {
+	testEngines(t, func(t *testing.T, e Engine) {
+		put(t, e, "test", "a", "1", "b", "2")
+
+		buf := bytes.NewBuffer(nil)
+		if _, err := e.Snapshot(buf); err != nil {
+			t.Fatal(err)
+		}
+
+		put(t, e, "test", "c", "3")
+		put(t, e, "other", "a", "1")
+
+		if err := e.Restore(buf); err != nil {
+			t.Fatal(err)
+		}
+		if k := keys(t, e, "test"); len(k) != 2 || k[0] != "a" || k[1] != "b" {
+			t.Fatalf("unexpected keys after restore %v", k)
+		}
+		if k := keys(t, e, "other"); len(k) != 0 {
+			t.Fatalf("expected bucket created after the snapshot to be removed; received %v", k)
+		}
+	})
+}
+
+func TestMemoryReadOnly(t *testing.T) {
+	e := NewMemory()
+	put(t, e, "test", "a", "1")
+	if err := e.View(func(tx Tx) error {
+		return tx.Bucket([]byte("test")).Put([]byte("b"), []byte("2"))
+	}); err != ErrTxNotWritable {
+		t.Fatalf("expected %v; received %v", ErrTxNotWritable, err)
+	}
+}
+
+func TestMemoryWriteIsolation(t *testing.T) {
+	e := NewMemory()
+	put(t, e, "test", "a", "1", "b", "2")
+	if err := e.View(func(tx Tx) error {
+		b := tx.Bucket([]byte("test"))
+		// a write committed while reading is not visible to the reader
+		put(t, e, "test", "a", "changed", "c", "3")
+		if v := string(b.Get([]byte("a"))); v != "1" {
+			t.Fatalf("expected 1; received %s", v)
+		}
+		if v := b.Get([]byte("c")); v != nil {
+			t.Fatalf("expected no value for c; received %s", v)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if k := keys(t, e, "test"); len(k) != 3 {
+		t.Fatalf("expected 3 keys; received %v", k)
+	}
+}
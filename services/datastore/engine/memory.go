@@ -0,0 +1,339 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+)
+
+// memoryEngine keeps the buckets in memory.  The keys of each bucket are held
+// in a persistent tree so write transactions only copy the nodes on the path
+// to the keys they modify and replace the committed buckets on success;
+// readers always see a consistent view.
+type memoryEngine struct {
+	mu sync.RWMutex
+	// writer serializes write transactions
+	writer  sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+// NewMemory returns an engine that stores data in memory
+func NewMemory() Engine {
+	return &memoryEngine{
+		buckets: map[string]*memoryBucket{},
+	}
+}
+
+func (e *memoryEngine) View(fn func(Tx) error) error {
+	e.mu.RLock()
+	buckets := e.buckets
+	e.mu.RUnlock()
+	return fn(&memoryTx{
+		buckets: buckets,
+	})
+}
+
+func (e *memoryEngine) Update(fn func(Tx) error) error {
+	e.writer.Lock()
+	defer e.writer.Unlock()
+
+	e.mu.RLock()
+	buckets := make(map[string]*memoryBucket, len(e.buckets))
+	for name, b := range e.buckets {
+		buckets[name] = b
+	}
+	e.mu.RUnlock()
+
+	tx := &memoryTx{
+		buckets:  buckets,
+		writable: true,
+		copied:   map[string]bool{},
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.buckets = tx.buckets
+	e.mu.Unlock()
+	return nil
+}
+
+// memorySnapshot is the encoded form of the engine contents
+type memorySnapshot struct {
+	Buckets []memorySnapshotBucket
+}
+
+type memorySnapshotBucket struct {
+	Name     string
+	Sequence uint64
+	Keys     [][]byte
+	Values   [][]byte
+}
+
+// Snapshot writes the contents of the engine in gob encoding
+func (e *memoryEngine) Snapshot(w io.Writer) (int64, error) {
+	e.mu.RLock()
+	buckets := e.buckets
+	e.mu.RUnlock()
+
+	snapshot := memorySnapshot{}
+	for name, b := range buckets {
+		s := memorySnapshotBucket{
+			Name:     name,
+			Sequence: b.sequence,
+		}
+		walk(b.root, func(n *node) error {
+			s.Keys = append(s.Keys, []byte(n.key))
+			s.Values = append(s.Values, n.value)
+			return nil
+		})
+		snapshot.Buckets = append(snapshot.Buckets, s)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := gob.NewEncoder(buf).Encode(snapshot); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+func (e *memoryEngine) Restore(r io.Reader) error {
+	var snapshot memorySnapshot
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return err
+	}
+	buckets := map[string]*memoryBucket{}
+	for _, s := range snapshot.Buckets {
+		b := newMemoryBucket()
+		b.sequence = s.Sequence
+		for i, k := range s.Keys {
+			b.put(string(k), s.Values[i])
+		}
+		buckets[s.Name] = b
+	}
+
+	e.writer.Lock()
+	defer e.writer.Unlock()
+	e.mu.Lock()
+	e.buckets = buckets
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *memoryEngine) Close() error {
+	return nil
+}
+
+type memoryTx struct {
+	buckets  map[string]*memoryBucket
+	writable bool
+	// copied are the buckets copied for modification by the transaction
+	copied map[string]bool
+}
+
+func (t *memoryTx) bucket(name string) *memoryBucket {
+	b, ok := t.buckets[name]
+	if !ok {
+		return nil
+	}
+	if t.writable && !t.copied[name] {
+		b = b.clone()
+		t.buckets[name] = b
+		t.copied[name] = true
+	}
+	return b
+}
+
+func (t *memoryTx) Bucket(name []byte) Bucket {
+	b := t.bucket(string(name))
+	if b == nil {
+		return nil
+	}
+	return t.wrap(b)
+}
+
+// wrap prevents modifying the committed buckets in read only transactions
+func (t *memoryTx) wrap(b *memoryBucket) Bucket {
+	if !t.writable {
+		return &readOnlyBucket{b}
+	}
+	return b
+}
+
+func (t *memoryTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	if !t.writable {
+		return nil, ErrTxNotWritable
+	}
+	if len(name) == 0 {
+		return nil, ErrBucketNameRequired
+	}
+	if b := t.bucket(string(name)); b != nil {
+		return b, nil
+	}
+	b := newMemoryBucket()
+	t.buckets[string(name)] = b
+	t.copied[string(name)] = true
+	return b, nil
+}
+
+func (t *memoryTx) DeleteBucket(name []byte) error {
+	if !t.writable {
+		return ErrTxNotWritable
+	}
+	if _, ok := t.buckets[string(name)]; !ok {
+		return ErrBucketNotFound
+	}
+	delete(t.buckets, string(name))
+	delete(t.copied, string(name))
+	return nil
+}
+
+func (t *memoryTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	names := make([]string, 0, len(t.buckets))
+	for name := range t.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn([]byte(name), t.wrap(t.bucket(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type memoryBucket struct {
+	sequence uint64
+	root     *node
+}
+
+func newMemoryBucket() *memoryBucket {
+	return &memoryBucket{}
+}
+
+// clone returns a copy of the bucket sharing the tree; the tree is not
+// modified in place so the copy is independent of the original
+func (b *memoryBucket) clone() *memoryBucket {
+	return &memoryBucket{
+		sequence: b.sequence,
+		root:     b.root,
+	}
+}
+
+func (b *memoryBucket) put(key string, value []byte) {
+	b.root = insert(b.root, key, append([]byte{}, value...))
+}
+
+func (b *memoryBucket) Get(key []byte) []byte {
+	if n := lookup(b.root, string(key)); n != nil {
+		return n.value
+	}
+	return nil
+}
+
+func (b *memoryBucket) Put(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return errors.New("key required")
+	}
+	b.put(string(key), value)
+	return nil
+}
+
+func (b *memoryBucket) Delete(key []byte) error {
+	b.root = remove(b.root, string(key))
+	return nil
+}
+
+func (b *memoryBucket) NextSequence() (uint64, error) {
+	b.sequence++
+	return b.sequence, nil
+}
+
+// ForEach iterates the keys in the bucket when it was called; keys modified
+// by fn are not visited
+func (b *memoryBucket) ForEach(fn func(k, v []byte) error) error {
+	return walk(b.root, func(n *node) error {
+		return fn([]byte(n.key), n.value)
+	})
+}
+
+func (b *memoryBucket) Cursor() Cursor {
+	return &memoryCursor{
+		bucket: b,
+	}
+}
+
+type readOnlyBucket struct {
+	*memoryBucket
+}
+
+func (b *readOnlyBucket) Put(key []byte, value []byte) error {
+	return ErrTxNotWritable
+}
+
+func (b *readOnlyBucket) Delete(key []byte) error {
+	return ErrTxNotWritable
+}
+
+func (b *readOnlyBucket) NextSequence() (uint64, error) {
+	return 0, ErrTxNotWritable
+}
+
+func (b *readOnlyBucket) Cursor() Cursor {
+	return &memoryCursor{
+		bucket:   b.memoryBucket,
+		readOnly: true,
+	}
+}
+
+// memoryCursor tracks the current key so the bucket can be modified while
+// iterating
+type memoryCursor struct {
+	bucket   *memoryBucket
+	key      *string
+	readOnly bool
+}
+
+func (c *memoryCursor) at(n *node) ([]byte, []byte) {
+	if n == nil {
+		c.key = nil
+		return nil, nil
+	}
+	k := n.key
+	c.key = &k
+	return []byte(k), n.value
+}
+
+func (c *memoryCursor) First() ([]byte, []byte) {
+	return c.at(first(c.bucket.root))
+}
+
+func (c *memoryCursor) Last() ([]byte, []byte) {
+	return c.at(last(c.bucket.root))
+}
+
+func (c *memoryCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.at(ceiling(c.bucket.root, string(seek), true))
+}
+
+func (c *memoryCursor) Next() ([]byte, []byte) {
+	if c.key == nil {
+		return nil, nil
+	}
+	// the current key may have been deleted
+	return c.at(ceiling(c.bucket.root, *c.key, false))
+}
+
+func (c *memoryCursor) Delete() error {
+	if c.readOnly {
+		return ErrTxNotWritable
+	}
+	if c.key == nil {
+		return nil
+	}
+	return c.bucket.Delete([]byte(*c.key))
+}
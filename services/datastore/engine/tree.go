@@ -0,0 +1,158 @@
+package engine
+
+// node is a node of the persistent AVL tree holding the keys of a memory
+// bucket.  Nodes are never modified once created so a committed tree can be
+// read while a write transaction builds a new tree sharing the nodes it did
+// not modify.
+type node struct {
+	key    string
+	value  []byte
+	height int
+	left   *node
+	right  *node
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func newNode(key string, value []byte, left, right *node) *node {
+	h := height(left)
+	if r := height(right); r > h {
+		h = r
+	}
+	return &node{
+		key:    key,
+		value:  value,
+		height: h + 1,
+		left:   left,
+		right:  right,
+	}
+}
+
+// balance returns the tree rooted at a new node for the key with the
+// subtrees rotated so their heights differ by at most one
+func balance(key string, value []byte, left, right *node) *node {
+	switch d := height(left) - height(right); {
+	case d > 1:
+		if height(left.left) < height(left.right) {
+			lr := left.right
+			left = newNode(lr.key, lr.value, newNode(left.key, left.value, left.left, lr.left), lr.right)
+		}
+		return newNode(left.key, left.value, left.left, newNode(key, value, left.right, right))
+	case d < -1:
+		if height(right.right) < height(right.left) {
+			rl := right.left
+			right = newNode(rl.key, rl.value, rl.left, newNode(right.key, right.value, rl.right, right.right))
+		}
+		return newNode(right.key, right.value, newNode(key, value, left, right.left), right.right)
+	}
+	return newNode(key, value, left, right)
+}
+
+// insert returns the tree with the key set to the value
+func insert(n *node, key string, value []byte) *node {
+	switch {
+	case n == nil:
+		return newNode(key, value, nil, nil)
+	case key < n.key:
+		return balance(n.key, n.value, insert(n.left, key, value), n.right)
+	case key > n.key:
+		return balance(n.key, n.value, n.left, insert(n.right, key, value))
+	}
+	return newNode(key, value, n.left, n.right)
+}
+
+// remove returns the tree without the key; the tree is returned unchanged
+// if it does not hold the key
+func remove(n *node, key string) *node {
+	switch {
+	case n == nil:
+		return nil
+	case key < n.key:
+		left := remove(n.left, key)
+		if left == n.left {
+			return n
+		}
+		return balance(n.key, n.value, left, n.right)
+	case key > n.key:
+		right := remove(n.right, key)
+		if right == n.right {
+			return n
+		}
+		return balance(n.key, n.value, n.left, right)
+	}
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+	min := first(n.right)
+	return balance(min.key, min.value, n.left, remove(n.right, min.key))
+}
+
+func lookup(n *node, key string) *node {
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// ceiling returns the node with the lowest key greater than the key or equal
+// to it if inclusive is set
+func ceiling(n *node, key string, inclusive bool) *node {
+	var found *node
+	for n != nil {
+		switch {
+		case key < n.key:
+			found = n
+			n = n.left
+		case key > n.key:
+			n = n.right
+		case inclusive:
+			return n
+		default:
+			n = n.right
+		}
+	}
+	return found
+}
+
+func first(n *node) *node {
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func last(n *node) *node {
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// walk calls fn for each node in key order
+func walk(n *node, fn func(*node) error) error {
+	if n == nil {
+		return nil
+	}
+	if err := walk(n.left, fn); err != nil {
+		return err
+	}
+	if err := fn(n); err != nil {
+		return err
+	}
+	return walk(n.right, fn)
+}
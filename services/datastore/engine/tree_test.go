@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkTree verifies the tree holds the expected keys in order and is balanced
+func checkTree(t *testing.T, root *node, expected map[string]string) {
+	keys := []string{}
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	i := 0
+	walk(root, func(n *node) error {
+		if i >= len(keys) || n.key != keys[i] || string(n.value) != expected[n.key] {
+			t.Fatalf("unexpected node %s=%s at %d", n.key, n.value, i)
+		}
+		if d := height(n.left) - height(n.right); d > 1 || d < -1 {
+			t.Fatalf("unbalanced node %s", n.key)
+		}
+		i++
+		return nil
+	})
+	if i != len(keys) {
+		t.Fatalf("expected %d keys; received %d", len(keys), i)
+	}
+}
+
+func TestTreePersistent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	expected := map[string]string{}
+	var root *node
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("%04d", r.Intn(500))
+		if r.Intn(3) == 0 {
+			root = remove(root, k)
+			delete(expected, k)
+			continue
+		}
+		v := fmt.Sprintf("%d", i)
+		root = insert(root, k, []byte(v))
+		expected[k] = v
+	}
+	checkTree(t, root, expected)
+
+	// modifying a new version leaves the original tree unchanged
+	next := root
+	for k := range expected {
+		next = remove(next, k)
+	}
+	next = insert(next, "new", []byte("1"))
+	checkTree(t, root, expected)
+	checkTree(t, next, map[string]string{"new": "1"})
+
+	if n := ceiling(root, "0250", false); n == nil || n.key <= "0250" {
+		t.Fatalf("unexpected ceiling %+v", n)
+	}
+}
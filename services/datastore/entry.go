@@ -0,0 +1,155 @@
+package datastore
+
+import (
+	"bytes"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/gogo/protobuf/proto"
+)
+
+// entryHeader prefixes encoded entries to distinguish them from values
+// written before entries were versioned
+var entryHeader = []byte{0x00, 's', 'e', 1}
+
+func encodeEntry(e *api.Entry) ([]byte, error) {
+	data, err := proto.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, entryHeader...), data...), nil
+}
+
+// decodeEntry returns the entry for the stored data; unversioned values are
+// returned as an entry without a version so any versioned write replaces them
+func decodeEntry(data []byte) (*api.Entry, error) {
+	if data == nil {
+		return nil, nil
+	}
+	if !bytes.HasPrefix(data, entryHeader) {
+		return &api.Entry{
+			Value: data,
+		}, nil
+	}
+	var e api.Entry
+	if err := proto.Unmarshal(data[len(entryHeader):], &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// encodeEntry encodes the entry for the key encrypting it if encryption is
+// configured
+func (s *service) encodeEntry(bucket string, key []byte, e *api.Entry) ([]byte, error) {
+	data, err := encodeEntry(e)
+	if err != nil {
+		return nil, err
+	}
+	return s.keys.seal(data, sealContext(bucket, key))
+}
+
+// decodeEntry decrypts the data stored for the key if encrypted and decodes
+// the entry
+func (s *service) decodeEntry(bucket string, key, data []byte) (*api.Entry, error) {
+	if data == nil {
+		return nil, nil
+	}
+	data, err := s.keys.open(data, sealContext(bucket, key))
+	if err != nil {
+		return nil, err
+	}
+	return decodeEntry(data)
+}
+
+func (s *service) getEntry(b engine.Bucket, bucket, key string) (*api.Entry, error) {
+	return s.decodeEntry(bucket, []byte(key), b.Get([]byte(key)))
+}
+
+// expired returns true if the entry has a ttl that elapsed before now
+func expired(e *api.Entry, now int64) bool {
+	return e.ExpiresAt > 0 && e.ExpiresAt <= now
+}
+
+// live returns true if the entry exists and has not expired
+func live(e *api.Entry, now int64) bool {
+	return e != nil && !e.Deleted && !expired(e, now)
+}
+
+// expiredTombstone returns the tombstone replacing an expired entry.  The
+// tombstone is versioned at the expiry time so every node expiring the entry
+// holds the same tombstone and it is not pruned until the prune timeout has
+// elapsed after the expiry.
+func expiredTombstone(e *api.Entry) *api.Entry {
+	v := &api.Version{
+		Wall:   e.ExpiresAt,
+		NodeID: e.Version.GetNodeID(),
+	}
+	// the tombstone must replace the entry on nodes that have not expired it
+	if !v.Newer(e.Version) {
+		v = &api.Version{
+			Wall:    e.Version.Wall,
+			Logical: e.Version.Logical + 1,
+			NodeID:  e.Version.NodeID,
+		}
+	}
+	return &api.Entry{
+		Version: v,
+		Deleted: true,
+	}
+}
+
+// apply stores the entry if it is newer than the current entry for the key
+// and records it in the change log.  The change is returned if the entry was
+// stored so it can be sent to watchers once the transaction is committed.
+func (s *service) apply(tx engine.Tx, bucket, key string, e *api.Entry) (*api.Change, error) {
+	b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.getEntry(b, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && !e.Version.Newer(current.Version) {
+		return nil, nil
+	}
+	// entries replicated after they expired are stored as tombstones
+	if expired(e, time.Now().UnixNano()) {
+		e = expiredTombstone(e)
+	}
+	return s.put(tx, b, bucket, key, e)
+}
+
+// put records the entry in the change log and stores it with the change log
+// position
+func (s *service) put(tx engine.Tx, b engine.Bucket, bucket, key string, e *api.Entry) (*api.Change, error) {
+	seq, err := s.appendChange(tx, bucket, key, e)
+	if err != nil {
+		return nil, err
+	}
+	e.Sequence = seq
+	data, err := s.encodeEntry(bucket, []byte(key), e)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Put([]byte(key), data); err != nil {
+		return nil, err
+	}
+	return &api.Change{
+		Sequence: seq,
+		Bucket:   bucket,
+		Key:      key,
+		Entry:    e,
+	}, nil
+}
+
+// version returns the version for the write; writes replicated from a peer
+// carry their original version while local writes are versioned by the clock
+func (s *service) version(v *api.Version) *api.Version {
+	if v == nil {
+		return s.clock.Now()
+	}
+	s.clock.Update(v)
+	return v
+}
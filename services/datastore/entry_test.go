@@ -0,0 +1,139 @@
+package datastore
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+)
+
+func testService(t *testing.T) (*service, func()) {
+	s := &service{
+		engineType:          engine.Memory,
+		lock:                &sync.Mutex{},
+		clock:               newClock("node-00"),
+		watchers:            &watchers{},
+		locks:               &locks{},
+		syncStats:           &syncStats{},
+		changelogBucketName: "test.changelog",
+		metaBucketName:      "test.meta",
+		lockBucketName:      "test.locks",
+	}
+	db, err := s.openDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.db = db
+	return s, func() {
+		db.Close()
+	}
+}
+
+func TestSetLastWriterWins(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	newer := &api.Version{Wall: 200, NodeID: "node-01"}
+	older := &api.Version{Wall: 100, NodeID: "node-02"}
+
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("newer"), Version: newer}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("older"), Version: older}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Data.Value) != "newer" {
+		t.Fatalf("expected newer value; received %s", resp.Data.Value)
+	}
+}
+
+func TestDeleteTombstoneNotResurrected(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := resp.Data.Version
+
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Bucket: "test", Key: "foo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// replay the original write as a peer would
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("bar"), Version: stale}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Value != nil {
+		t.Fatalf("expected deleted key; received %s", resp.Data.Value)
+	}
+
+	search, err := s.Search(ctx, &api.SearchRequest{Bucket: "test", Prefix: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(search.Data) != 0 {
+		t.Fatalf("expected no search results; received %d", len(search.Data))
+	}
+}
+
+func TestDecodeLegacyValue(t *testing.T) {
+	e, err := decodeEntry([]byte("10.0.0.2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(e.Value) != "10.0.0.2" || e.Version != nil {
+		t.Fatalf("unexpected legacy entry %+v", e)
+	}
+}
+
+func TestReplicatedUnversionedWrite(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("newer")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// writes from peers without versions do not replace versioned writes
+	for _, key := range []string{"foo", "bar"} {
+		if err := s.applyOperation(ctx, &api.SyncOperation{Bucket: "test", Key: key, Value: []byte("stale"), Action: api.SyncAction_SET}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Data.Value) != "newer" {
+		t.Fatalf("expected newer value; received %s", resp.Data.Value)
+	}
+
+	resp, err = s.Get(ctx, &api.GetRequest{Bucket: "test", Key: "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Data.Value) != "stale" || resp.Data.Version != nil {
+		t.Fatalf("expected unversioned value; received %+v", resp.Data)
+	}
+}
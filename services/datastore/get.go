@@ -2,28 +2,37 @@ package datastore
 
 import (
 	"context"
+	"time"
 
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (s *service) Get(ctx context.Context, req *api.GetRequest) (*api.GetResponse, error) {
-	var val []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	kv := &api.KeyValue{
+		Key: req.Key,
+	}
+	err := s.db.View(func(tx engine.Tx) error {
 		b := tx.Bucket([]byte(req.Bucket))
 		if b == nil {
 			return status.Errorf(codes.NotFound, "bucket %s not found", req.Bucket)
 		}
-		val = b.Get([]byte(req.Key))
+		e, err := s.getEntry(b, req.Bucket, req.Key)
+		if err != nil {
+			return err
+		}
+		// tombstones and expired keys are hidden
+		if live(e, time.Now().UnixNano()) {
+			kv.Value = e.Value
+			kv.Version = e.Version
+			kv.ExpiresAt = e.ExpiresAt
+		}
 		return nil
 	})
 	return &api.GetResponse{
 		Bucket: req.Bucket,
-		Data: &api.KeyValue{
-			Key:   req.Key,
-			Value: val,
-		},
+		Data:   kv,
 	}, err
 }
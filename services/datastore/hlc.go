@@ -0,0 +1,71 @@
+package datastore
+
+import (
+	"sync"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+)
+
+// clock is a hybrid logical clock used to version datastore writes
+type clock struct {
+	mu      sync.Mutex
+	nodeID  string
+	wall    int64
+	logical uint32
+	now     func() int64
+}
+
+func newClock(nodeID string) *clock {
+	return &clock{
+		nodeID: nodeID,
+		now: func() int64 {
+			return time.Now().UnixNano()
+		},
+	}
+}
+
+// Now returns a new version for a local write
+func (c *clock) Now() *api.Version {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pt := c.now(); pt > c.wall {
+		c.wall = pt
+		c.logical = 0
+	} else {
+		c.logical++
+	}
+
+	return &api.Version{
+		Wall:    c.wall,
+		Logical: c.logical,
+		NodeID:  c.nodeID,
+	}
+}
+
+// Update advances the clock past the version received from a peer
+func (c *clock) Update(v *api.Version) {
+	if v == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pt := c.now()
+	switch {
+	case pt > c.wall && pt > v.Wall:
+		c.wall = pt
+		c.logical = 0
+	case v.Wall > c.wall:
+		c.wall = v.Wall
+		c.logical = v.Logical + 1
+	case c.wall > v.Wall:
+		c.logical++
+	default:
+		if v.Logical > c.logical {
+			c.logical = v.Logical
+		}
+		c.logical++
+	}
+}
@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+)
+
+func testClock(nodeID string, pt *int64) *clock {
+	c := newClock(nodeID)
+	c.now = func() int64 {
+		return *pt
+	}
+	return c
+}
+
+func TestClockNowMonotonic(t *testing.T) {
+	pt := int64(100)
+	c := testClock("node-00", &pt)
+
+	a := c.Now()
+	b := c.Now()
+	if !b.Newer(a) {
+		t.Fatalf("expected %+v to be newer than %+v", b, a)
+	}
+
+	// physical clock moving backwards must not move the version backwards
+	pt = 50
+	d := c.Now()
+	if !d.Newer(b) {
+		t.Fatalf("expected %+v to be newer than %+v", d, b)
+	}
+}
+
+func TestClockUpdateRemote(t *testing.T) {
+	pt := int64(100)
+	c := testClock("node-00", &pt)
+
+	remote := &api.Version{
+		Wall:    200,
+		Logical: 3,
+		NodeID:  "node-01",
+	}
+	c.Update(remote)
+
+	v := c.Now()
+	if !v.Newer(remote) {
+		t.Fatalf("expected %+v to be newer than remote %+v", v, remote)
+	}
+}
+
+func TestVersionCompareNodeID(t *testing.T) {
+	a := &api.Version{Wall: 100, NodeID: "node-00"}
+	b := &api.Version{Wall: 100, NodeID: "node-01"}
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 {
+		t.Fatal("expected tie to be broken by node id")
+	}
+
+	var legacy *api.Version
+	if !a.Newer(legacy) {
+		t.Fatal("expected version to be newer than nil version")
+	}
+}
@@ -2,35 +2,435 @@ package datastore
 
 import (
 	"context"
+	"encoding/binary"
+	"sync"
 	"time"
 
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func (s *service) AcquireLock(ctx context.Context, req *api.AcquireLockRequest) (*ptypes.Empty, error) {
-	s.lock.Lock()
+const (
+	// defaultLockTTL is the lease duration used when none is requested
+	defaultLockTTL     = time.Second * 30
+	lockRetryInterval  = time.Millisecond * 100
+	lockRequestTimeout = time.Second * 5
+)
+
+// lease is the local record of a lock granted to an owner.  Leases expire
+// using the local clock of each node so no clock agreement is required.
+type lease struct {
+	owner   string
+	token   uint64
+	ttl     time.Duration
+	expires time.Time
+}
+
+func (l *lease) proto(name string) *api.Lease {
+	if l == nil {
+		return nil
+	}
+	return &api.Lease{
+		Name:  name,
+		Owner: l.owner,
+		Token: l.token,
+		TTL:   ptypes.DurationProto(l.ttl),
+	}
+}
+
+// locks is the table of leases granted by the local node
+type locks struct {
+	mu     sync.Mutex
+	leases map[string]*lease
+}
+
+// current returns the unexpired lease for the lock
+func (ls *locks) current(name string) *lease {
+	l, ok := ls.leases[name]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(l.expires) {
+		delete(ls.leases, name)
+		return nil
+	}
+	return l
+}
+
+func (ls *locks) set(name string, l *lease) {
+	if ls.leases == nil {
+		ls.leases = map[string]*lease{}
+	}
+	l.expires = time.Now().Add(l.ttl)
+	ls.leases[name] = l
+}
+
+// expire removes the expired leases
+func (ls *locks) expire() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	for name := range ls.leases {
+		ls.current(name)
+	}
+}
+
+// lockNode is a node participating in the lock quorum
+type lockNode struct {
+	id      string
+	address string
+}
 
-	timeout, err := ptypes.DurationFromProto(req.Timeout)
+// AcquireLock acquires the named lock for the owner.  The lock is granted
+// once a majority of the cluster nodes grant the lease; the returned fencing
+// token is greater than any token previously issued for the lock.
+func (s *service) AcquireLock(ctx context.Context, req *api.AcquireLockRequest) (*api.AcquireLockResponse, error) {
+	if req.Name == "" || req.Owner == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "lock name and owner are required")
+	}
+	timeout, err := durationFromProto(req.Timeout, 0)
 	if err != nil {
-		return empty, err
+		return nil, err
+	}
+	ttl, err := durationFromProto(req.TTL, defaultLockTTL)
+	if err != nil {
+		return nil, err
 	}
 
-	go func() {
+	nodes, err := s.lockNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		lease, holder := s.acquire(ctx, nodes, req.Name, req.Owner, ttl)
+		if lease != nil {
+			logrus.WithFields(logrus.Fields{
+				"name":  lease.Name,
+				"owner": lease.Owner,
+				"token": lease.Token,
+			}).Debug("lock acquired")
+			return &api.AcquireLockResponse{
+				Lease: lease,
+			}, nil
+		}
+		if !time.Now().Before(deadline) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "timeout acquiring lock %s (held by %s)", req.Name, holder)
+		}
 		select {
-		case <-time.After(timeout):
-			logrus.Warnf("lock timeout occurred (%s)", timeout)
-			s.lock.Unlock()
-		case <-s.lockChan:
-			s.lock.Unlock()
+		case <-time.After(lockRetryInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
-	}()
+	}
+}
 
-	return empty, nil
+// RenewLock extends the lease; it fails if the lease was lost to another owner
+func (s *service) RenewLock(ctx context.Context, req *api.RenewLockRequest) (*api.RenewLockResponse, error) {
+	ttl, err := durationFromProto(req.TTL, defaultLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.lockNodes()
+	if err != nil {
+		return nil, err
+	}
+	lease := &api.Lease{
+		Name:  req.Name,
+		Owner: req.Owner,
+		Token: req.Token,
+		TTL:   ptypes.DurationProto(ttl),
+	}
+	responses := s.peerLocks(ctx, nodes, api.PeerLockRequest_RENEW, lease)
+	if granted(responses) < quorum(nodes) {
+		return nil, status.Errorf(codes.FailedPrecondition, "lease for lock %s has been lost", req.Name)
+	}
+	return &api.RenewLockResponse{
+		Lease: lease,
+	}, nil
 }
 
-func (s *service) ReleaseLock(ctx context.Context, _ *api.ReleaseLockRequest) (*ptypes.Empty, error) {
-	s.lockChan <- true
+// ReleaseLock releases the lease held by the owner; releasing a lock that is
+// not held is not an error
+func (s *service) ReleaseLock(ctx context.Context, req *api.ReleaseLockRequest) (*ptypes.Empty, error) {
+	nodes, err := s.lockNodes()
+	if err != nil {
+		return empty, err
+	}
+	s.peerLocks(ctx, nodes, api.PeerLockRequest_RELEASE, &api.Lease{
+		Name:  req.Name,
+		Owner: req.Owner,
+		Token: req.Token,
+	})
 	return empty, nil
 }
+
+// PeerLock updates the lease in the local lock table on behalf of the node
+// coordinating the lock
+func (s *service) PeerLock(ctx context.Context, req *api.PeerLockRequest) (*api.PeerLockResponse, error) {
+	l := req.Lease
+	if l == nil || l.Name == "" || l.Owner == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "lock name and owner are required")
+	}
+	ttl, err := durationFromProto(l.TTL, defaultLockTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.locks.mu.Lock()
+	defer s.locks.mu.Unlock()
+
+	token, err := s.lockToken(l.Name)
+	if err != nil {
+		return nil, err
+	}
+	current := s.locks.current(l.Name)
+	ok := false
+	switch req.Action {
+	case api.PeerLockRequest_GRANT:
+		if current == nil || current.owner == l.Owner {
+			current = &lease{
+				owner: l.Owner,
+				ttl:   ttl,
+			}
+			s.locks.set(l.Name, current)
+			ok = true
+		}
+	case api.PeerLockRequest_CONFIRM:
+		if current != nil && current.owner == l.Owner && l.Token > token {
+			if err := s.setLockToken(l.Name, l.Token); err != nil {
+				return nil, err
+			}
+			token = l.Token
+			current.token = l.Token
+			ok = true
+		}
+	case api.PeerLockRequest_RENEW:
+		if current != nil {
+			ok = current.owner == l.Owner && current.token == l.Token
+		} else {
+			// the lease expired locally; it can be renewed unless a newer
+			// lease was issued
+			ok = l.Token >= token
+		}
+		if ok {
+			current = &lease{
+				owner: l.Owner,
+				token: l.Token,
+				ttl:   ttl,
+			}
+			s.locks.set(l.Name, current)
+		}
+	case api.PeerLockRequest_RELEASE:
+		if current != nil && current.owner == l.Owner && (l.Token == 0 || current.token == l.Token) {
+			delete(s.locks.leases, l.Name)
+			current = nil
+			ok = true
+		}
+	}
+
+	return &api.PeerLockResponse{
+		Granted: ok,
+		Holder:  current.proto(l.Name),
+		Token:   token,
+	}, nil
+}
+
+// acquire attempts to acquire the lock once.  If the lock is not acquired
+// the owner of the lock is returned.
+func (s *service) acquire(ctx context.Context, nodes []*lockNode, name, owner string, ttl time.Duration) (*api.Lease, string) {
+	l := &api.Lease{
+		Name:  name,
+		Owner: owner,
+		TTL:   ptypes.DurationProto(ttl),
+	}
+	// pending releases the grants of the owner regardless of token
+	pending := &api.Lease{
+		Name:  name,
+		Owner: owner,
+	}
+	responses := s.peerLocks(ctx, nodes, api.PeerLockRequest_GRANT, l)
+
+	holder := ""
+	token := uint64(0)
+	grantedNodes := []*lockNode{}
+	for i, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		if resp.Token > token {
+			token = resp.Token
+		}
+		if resp.Granted {
+			grantedNodes = append(grantedNodes, nodes[i])
+		} else if resp.Holder != nil {
+			holder = resp.Holder.Owner
+		}
+	}
+
+	if len(grantedNodes) < quorum(nodes) {
+		s.peerLocks(ctx, grantedNodes, api.PeerLockRequest_RELEASE, pending)
+		return nil, holder
+	}
+
+	// the majority has seen every token issued for the lock so the next
+	// token is greater than any previous token
+	l.Token = token + 1
+	if granted(s.peerLocks(ctx, grantedNodes, api.PeerLockRequest_CONFIRM, l)) < quorum(nodes) {
+		s.peerLocks(ctx, grantedNodes, api.PeerLockRequest_RELEASE, pending)
+		return nil, holder
+	}
+
+	return l, ""
+}
+
+// peerLocks sends the lock request to the nodes returning the response from
+// each node or nil if the node could not be reached
+func (s *service) peerLocks(ctx context.Context, nodes []*lockNode, action api.PeerLockRequest_Action, l *api.Lease) []*api.PeerLockResponse {
+	req := &api.PeerLockRequest{
+		Action: action,
+		Lease:  l,
+	}
+	self := s.agent.Self().ID
+	responses := make([]*api.PeerLockResponse, len(nodes))
+	wg := &sync.WaitGroup{}
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *lockNode) {
+			defer wg.Done()
+			resp, err := s.peerLock(ctx, self, node, req)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"peer":   node.id,
+					"lock":   l.Name,
+					"action": action,
+				}).Warnf("peer lock error: %s", err)
+				return
+			}
+			responses[i] = resp
+		}(i, node)
+	}
+	wg.Wait()
+	return responses
+}
+
+func (s *service) peerLock(ctx context.Context, self string, node *lockNode, req *api.PeerLockRequest) (*api.PeerLockResponse, error) {
+	if node.id == self {
+		return s.PeerLock(ctx, req)
+	}
+	c, err := s.client(node.address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, lockRequestTimeout)
+	defer cancel()
+	return c.DatastoreService().PeerLock(ctx, req)
+}
+
+// lockNodes returns the local node and its peers
+func (s *service) lockNodes() ([]*lockNode, error) {
+	self := s.agent.Self()
+	nodes := []*lockNode{
+		{
+			id:      self.ID,
+			address: self.Address,
+		},
+	}
+	peers, err := s.agent.Peers()
+	if err != nil {
+		return nil, err
+	}
+	for _, peer := range peers {
+		nodes = append(nodes, &lockNode{
+			id:      peer.ID,
+			address: peer.Address,
+		})
+	}
+	return nodes, nil
+}
+
+// lockToken returns the highest fencing token issued for the lock
+func (s *service) lockToken(name string) (uint64, error) {
+	token := uint64(0)
+	err := s.db.View(func(tx engine.Tx) error {
+		token = lockTokens(tx, s.lockBucketName)[name]
+		return nil
+	})
+	return token, err
+}
+
+func (s *service) setLockToken(name string, token uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.db.Update(func(tx engine.Tx) error {
+		return putLockTokens(tx, s.lockBucketName, map[string]uint64{name: token})
+	})
+}
+
+// lockTokens returns the fencing tokens issued for each lock
+func lockTokens(tx engine.Tx, bucket string) map[string]uint64 {
+	tokens := map[string]uint64{}
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return tokens
+	}
+	b.ForEach(func(k, v []byte) error {
+		if len(v) == 8 {
+			tokens[string(k)] = binary.BigEndian.Uint64(v)
+		}
+		return nil
+	})
+	return tokens
+}
+
+// putLockTokens stores the fencing tokens keeping any higher token already
+// issued for the lock
+func putLockTokens(tx engine.Tx, bucket string, tokens map[string]uint64) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return err
+	}
+	for name, token := range tokens {
+		if v := b.Get([]byte(name)); len(v) == 8 && binary.BigEndian.Uint64(v) > token {
+			continue
+		}
+		if err := b.Put([]byte(name), sequenceKey(token)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func quorum(nodes []*lockNode) int {
+	return len(nodes)/2 + 1
+}
+
+func granted(responses []*api.PeerLockResponse) int {
+	n := 0
+	for _, resp := range responses {
+		if resp != nil && resp.Granted {
+			n++
+		}
+	}
+	return n
+}
+
+func durationFromProto(d *ptypes.Duration, def time.Duration) (time.Duration, error) {
+	if d == nil {
+		return def, nil
+	}
+	v, err := ptypes.DurationFromProto(d)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid duration: %s", err)
+	}
+	if v <= 0 {
+		return def, nil
+	}
+	return v, nil
+}
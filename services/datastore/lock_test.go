@@ -0,0 +1,126 @@
+package datastore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	ptypes "github.com/gogo/protobuf/types"
+)
+
+func peerLock(t *testing.T, s *service, action api.PeerLockRequest_Action, owner string, token uint64) *api.PeerLockResponse {
+	resp, err := s.PeerLock(context.Background(), &api.PeerLockRequest{
+		Action: action,
+		Lease: &api.Lease{
+			Name:  "test",
+			Owner: owner,
+			Token: token,
+			TTL:   ptypes.DurationProto(time.Minute),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestPeerLockGrant(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	if resp := peerLock(t, s, api.PeerLockRequest_GRANT, "a", 0); !resp.Granted {
+		t.Fatal("expected lock to be granted")
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_CONFIRM, "a", 1); !resp.Granted || resp.Token != 1 {
+		t.Fatalf("expected lease to be confirmed with token 1; received %+v", resp)
+	}
+
+	// the lock is held by a
+	resp := peerLock(t, s, api.PeerLockRequest_GRANT, "b", 0)
+	if resp.Granted {
+		t.Fatal("expected lock held by another owner to be refused")
+	}
+	if resp.Holder.Owner != "a" || resp.Token != 1 {
+		t.Fatalf("unexpected holder %+v", resp)
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_RELEASE, "b", 0); resp.Granted {
+		t.Fatal("expected release by another owner to be refused")
+	}
+
+	if resp := peerLock(t, s, api.PeerLockRequest_RENEW, "a", 1); !resp.Granted {
+		t.Fatal("expected lease to be renewed")
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_RELEASE, "a", 1); !resp.Granted {
+		t.Fatal("expected lease to be released")
+	}
+
+	// the next lease must use a newer fencing token
+	if resp := peerLock(t, s, api.PeerLockRequest_GRANT, "b", 0); !resp.Granted || resp.Token != 1 {
+		t.Fatalf("expected lock to be granted with token 1; received %+v", resp)
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_CONFIRM, "b", 1); resp.Granted {
+		t.Fatal("expected stale token to be refused")
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_CONFIRM, "b", 2); !resp.Granted {
+		t.Fatal("expected lease to be confirmed")
+	}
+	// the lease of a was lost
+	if resp := peerLock(t, s, api.PeerLockRequest_RENEW, "a", 1); resp.Granted {
+		t.Fatal("expected renewal of lost lease to be refused")
+	}
+
+	token, err := s.lockToken("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != 2 {
+		t.Fatalf("expected persisted token 2; received %d", token)
+	}
+}
+
+func TestPeerLockExpire(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	if resp := peerLock(t, s, api.PeerLockRequest_GRANT, "a", 0); !resp.Granted {
+		t.Fatal("expected lock to be granted")
+	}
+	s.locks.leases["test"].expires = time.Now().Add(-time.Second)
+	s.locks.expire()
+	if len(s.locks.leases) != 0 {
+		t.Fatal("expected expired lease to be removed")
+	}
+	if resp := peerLock(t, s, api.PeerLockRequest_GRANT, "b", 0); !resp.Granted {
+		t.Fatal("expected expired lock to be granted")
+	}
+}
+
+func TestLockTokenRestore(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	if err := s.setLockToken("test", 1); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := s.snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.setLockToken("test", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Restore(context.Background(), &api.RestoreRequest{Snapshot: snapshot.Name}); err != nil {
+		t.Fatal(err)
+	}
+
+	// tokens issued after the snapshot are not reissued
+	token, err := s.lockToken("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != 5 {
+		t.Fatalf("expected token 5 after restore; received %d", token)
+	}
+}
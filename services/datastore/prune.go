@@ -1,12 +1,10 @@
 package datastore
 
 import (
-	"encoding/json"
 	"time"
 
-	"github.com/containerd/containerd/errdefs"
-	bolt "github.com/coreos/bbolt"
-	"github.com/pkg/errors"
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,37 +12,77 @@ var (
 	pruneTimeout = time.Second * 90
 )
 
-func (s *service) prune() error {
+// prunePeers prunes the datastore with the current cluster peers
+func (s *service) prunePeers() error {
+	peers, err := s.agent.Peers()
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, peer := range peers {
+		ids = append(ids, peer.ID)
+	}
+	return s.prune(ids)
+}
+
+// prune replaces expired keys with tombstones, removes tombstones older than
+// the prune timeout that every peer has applied and trims the change log
+func (s *service) prune(peers []string) error {
+	changes := []*api.Change{}
+	ack := s.syncStats.acknowledged(peers)
 	s.lock.Lock()
-	if err := s.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(s.dsTombstoneBucketName))
-		if b == nil {
-			return errdefs.ToGRPC(errors.Wrapf(errdefs.ErrNotFound, "bucket %s", s.dsTombstoneBucketName))
+	now := time.Now()
+	cutoff := now.Add(-pruneTimeout).UnixNano()
+	err := s.db.Update(func(tx engine.Tx) error {
+		if err := s.trimChangelog(tx); err != nil {
+			return err
 		}
-		if err := b.ForEach(func(k, v []byte) error {
-			var t *tombstone
-			if err := json.Unmarshal(v, &t); err != nil {
+		return tx.ForEach(func(name []byte, b engine.Bucket) error {
+			if s.isInternalBucket(string(name)) {
+				return nil
+			}
+			keys := [][]byte{}
+			expiredKeys := map[string]*api.Entry{}
+			if err := b.ForEach(func(k, v []byte) error {
+				e, err := s.decodeEntry(string(name), k, v)
+				if err != nil {
+					return err
+				}
+				if e.Deleted && e.Version.GetWall() < cutoff && ack > 0 && e.Sequence <= ack {
+					keys = append(keys, k)
+				} else if !e.Deleted && expired(e, now.UnixNano()) {
+					expiredKeys[string(k)] = e
+				}
+				return nil
+			}); err != nil {
 				return err
 			}
-			if time.Now().After(t.Timestamp.Add(pruneTimeout)) {
-				logrus.Debugf("prune: removing expired tombstone record %s", k)
+			for _, k := range keys {
+				logrus.Debugf("prune: removing expired tombstone record %s:%s", name, k)
 				if err := pruneRemove(b, k); err != nil {
 					return err
 				}
 			}
+			for k, e := range expiredKeys {
+				logrus.Debugf("prune: expiring key %s:%s", name, k)
+				c, err := s.put(tx, b, string(name), k, expiredTombstone(e))
+				if err != nil {
+					return err
+				}
+				changes = append(changes, c)
+			}
 			return nil
-		}); err != nil {
-			return err
+		})
+	})
+	if err == nil {
+		for _, c := range changes {
+			s.notify(c)
 		}
-		return nil
-	}); err != nil {
-		return err
 	}
 	s.lock.Unlock()
-
-	return nil
+	return err
 }
 
-func pruneRemove(b *bolt.Bucket, key []byte) error {
+func pruneRemove(b engine.Bucket, key []byte) error {
 	return b.Delete(key)
 }
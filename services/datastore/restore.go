@@ -1,38 +1,255 @@
 package datastore
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
+	"io/ioutil"
 	"os"
-	"path/filepath"
+	"time"
 
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
+// Restore replaces the datastore with the backup data or the named snapshot
 func (s *service) Restore(ctx context.Context, req *api.RestoreRequest) (*ptypes.Empty, error) {
+	if req.Snapshot != "" {
+		p, err := s.snapshotPath(req.Snapshot)
+		if err != nil {
+			return empty, err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return empty, err
+		}
+		defer f.Close()
+
+		logrus.Infof("restoring datastore from snapshot %s", req.Snapshot)
+		return empty, s.restore(f)
+	}
+
+	return empty, s.restore(bytes.NewReader(req.Data))
+}
+
+// RestoreStream receives the backup in chunks and replaces the datastore
+// once the backup is complete
+func (s *service) RestoreStream(srv api.Datastore_RestoreStreamServer) error {
+	if err := os.MkdirAll(s.snapshotDir, 0700); err != nil {
+		return err
+	}
+	// spool to disk so the backup does not need to fit in memory
+	f, err := ioutil.TempFile(s.snapshotDir, ".restore-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	for {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(req.Data); err != nil {
+			return err
+		}
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := s.restore(f); err != nil {
+		return err
+	}
+	return srv.SendAndClose(empty)
+}
+
+// restore replaces the datastore with the backup which may be gzip compressed
+func (s *service) restore(r io.Reader) error {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	changes := []*api.Change{}
 	s.lock.Lock()
-	s.db.Close()
 	defer s.lock.Unlock()
+	if err := s.replace(r, func(c *api.Change) {
+		changes = append(changes, c)
+	}); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		s.notify(c)
+	}
+	return nil
+}
 
-	dbPath := filepath.Join(s.dir, dbFilename)
-
-	if err := os.Remove(dbPath); err != nil {
-		return empty, err
+// replace restores the database from the backup.  The restored entries are
+// given new versions so they replace the entries held by peers and the keys
+// that are not in the backup are deleted; each change is passed to fn.  The
+// previous database is put back if the entries cannot be versioned.  The lock
+// must be held.
+func (s *service) replace(r io.Reader, fn func(*api.Change)) error {
+	// fencing tokens must never be reissued so the tokens issued before the
+	// restore are kept
+	var tokens map[string]uint64
+	var previous map[string][]string
+	if err := s.db.View(func(tx engine.Tx) error {
+		tokens = lockTokens(tx, s.lockBucketName)
+		p, err := s.liveKeys(tx)
+		previous = p
+		return err
+	}); err != nil {
+		return err
 	}
 
-	f, err := os.OpenFile(dbPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	// keep a copy of the current database so it can be put back if the
+	// restored entries cannot be versioned
+	if s.snapshotDir != "" {
+		if err := os.MkdirAll(s.snapshotDir, 0700); err != nil {
+			return err
+		}
+	}
+	current, err := ioutil.TempFile(s.snapshotDir, ".rollback-")
 	if err != nil {
-		return empty, err
+		return err
+	}
+	defer func() {
+		current.Close()
+		os.Remove(current.Name())
+	}()
+	if _, err := s.db.Snapshot(current); err != nil {
+		return err
 	}
-	f.Write(req.Data)
-	f.Close()
 
-	db, err := s.openDB()
-	if err != nil {
-		return empty, err
+	if err := s.db.Restore(r); err != nil {
+		return err
 	}
 
-	s.db = db
+	changes := []*api.Change{}
+	if err := s.db.Update(func(tx engine.Tx) error {
+		if err := putLockTokens(tx, s.lockBucketName, tokens); err != nil {
+			return err
+		}
+		if err := s.resetChangelog(tx); err != nil {
+			return err
+		}
+
+		// the buckets are listed first as the change log bucket is created
+		// when the entries are stored
+		names := []string{}
+		if err := tx.ForEach(func(name []byte, _ engine.Bucket) error {
+			if !s.isInternalBucket(string(name)) {
+				names = append(names, string(name))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		restored := map[string]map[string]bool{}
+		for _, bucket := range names {
+			b := tx.Bucket([]byte(bucket))
+			restored[bucket] = map[string]bool{}
+			keys := []string{}
+			entries := []*api.Entry{}
+			if err := b.ForEach(func(k, v []byte) error {
+				e, err := s.decodeEntry(bucket, k, v)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, string(k))
+				entries = append(entries, e)
+				return nil
+			}); err != nil {
+				return err
+			}
+			for i, e := range entries {
+				restored[bucket][keys[i]] = true
+				e.Version = s.clock.Now()
+				c, err := s.put(tx, b, bucket, keys[i], e)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, c)
+			}
+		}
+
+		for bucket, keys := range previous {
+			for _, k := range keys {
+				if restored[bucket][k] {
+					continue
+				}
+				b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+				if err != nil {
+					return err
+				}
+				c, err := s.put(tx, b, bucket, k, &api.Entry{
+					Version: s.clock.Now(),
+					Deleted: true,
+				})
+				if err != nil {
+					return err
+				}
+				changes = append(changes, c)
+			}
+		}
+		return nil
+	}); err != nil {
+		if _, serr := current.Seek(0, io.SeekStart); serr != nil {
+			return errors.Errorf("%s; error rolling back restore: %s", err, serr)
+		}
+		if rerr := s.db.Restore(current); rerr != nil {
+			return errors.Errorf("%s; error rolling back restore: %s", err, rerr)
+		}
+		return err
+	}
+
+	// the restored change log belongs to the node the backup was taken from
+	s.syncStats.resetAcknowledged()
+	for _, c := range changes {
+		fn(c)
+	}
+	return nil
+}
 
-	return empty, err
+// liveKeys returns the keys that are not deleted in each replicated bucket
+func (s *service) liveKeys(tx engine.Tx) (map[string][]string, error) {
+	keys := map[string][]string{}
+	now := time.Now().UnixNano()
+	err := tx.ForEach(func(name []byte, b engine.Bucket) error {
+		if s.isInternalBucket(string(name)) {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			e, err := s.decodeEntry(string(name), k, v)
+			if err != nil {
+				return err
+			}
+			if live(e, now) {
+				keys[string(name)] = append(keys[string(name)], string(k))
+			}
+			return nil
+		})
+	})
+	return keys, err
 }
@@ -3,35 +3,97 @@ package datastore
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/containerd/containerd/errdefs"
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	"github.com/pkg/errors"
 )
 
+// errSearchDone stops a search once the limit is reached
+var errSearchDone = errors.New("search done")
+
 func (s *service) Search(ctx context.Context, req *api.SearchRequest) (*api.SearchResponse, error) {
 	var data []*api.KeyValue
 
+	next := ""
+	err := s.search(req, func(kv *api.KeyValue) error {
+		if req.Limit > 0 && uint64(len(data)) == req.Limit {
+			// there are more keys after the page
+			next = data[len(data)-1].Key
+			return errSearchDone
+		}
+		data = append(data, kv)
+		return nil
+	})
+
+	return &api.SearchResponse{
+		Bucket: req.Bucket,
+		Data:   data,
+		Next:   next,
+	}, err
+}
+
+// SearchStream streams the matching keys
+func (s *service) SearchStream(req *api.SearchRequest, srv api.Datastore_SearchStreamServer) error {
+	sent := uint64(0)
+	return s.search(req, func(kv *api.KeyValue) error {
+		if req.Limit > 0 && sent == req.Limit {
+			return errSearchDone
+		}
+		sent++
+		return srv.Send(kv)
+	})
+}
+
+// search calls fn for each key matching the request in key order until fn
+// returns errSearchDone
+func (s *service) search(req *api.SearchRequest, fn func(*api.KeyValue) error) error {
 	prefix := []byte(req.Prefix)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	if req.Prefix == "*" {
+		prefix = nil
+	}
+	seek := prefix
+	if req.StartAfter > string(prefix) {
+		seek = []byte(req.StartAfter)
+	}
+	now := time.Now().UnixNano()
+	err := s.db.View(func(tx engine.Tx) error {
 		b := tx.Bucket([]byte(req.Bucket))
 		if b == nil {
 			return errdefs.ToGRPC(errors.Wrapf(errdefs.ErrNotFound, "bucket %s", req.Bucket))
 		}
 
 		c := b.Cursor()
-		for k, v := c.Seek(prefix); k != nil && req.Prefix == "*" || bytes.HasPrefix(k, prefix); k, v = c.Next() {
-			data = append(data, &api.KeyValue{
-				Key:   string(k),
-				Value: v,
-			})
+		for k, v := c.Seek(seek); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if req.StartAfter != "" && string(k) <= req.StartAfter {
+				continue
+			}
+			e, err := s.decodeEntry(req.Bucket, k, v)
+			if err != nil {
+				return err
+			}
+			// tombstones and expired keys are hidden
+			if !live(e, now) {
+				continue
+			}
+			kv := &api.KeyValue{
+				Key:       string(k),
+				Version:   e.Version,
+				ExpiresAt: e.ExpiresAt,
+			}
+			if !req.KeysOnly {
+				kv.Value = e.Value
+			}
+			if err := fn(kv); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
-
-	return &api.SearchResponse{
-		Bucket: req.Bucket,
-		Data:   data,
-	}, err
+	if err == errSearchDone {
+		return nil
+	}
+	return err
 }
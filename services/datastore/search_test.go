@@ -0,0 +1,99 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"google.golang.org/grpc"
+)
+
+type testSearchServer struct {
+	grpc.ServerStream
+	data []*api.KeyValue
+}
+
+func (t *testSearchServer) Send(kv *api.KeyValue) error {
+	t.data = append(t.data, kv)
+	return nil
+}
+
+func TestSearchPages(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: fmt.Sprintf("foo.%d", i), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "bar", Value: []byte("v")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Bucket: "test", Key: "foo.2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []string{}
+	next := ""
+	pages := 0
+	for {
+		resp, err := s.Search(ctx, &api.SearchRequest{
+			Bucket:     "test",
+			Prefix:     "foo.",
+			Limit:      2,
+			StartAfter: next,
+			KeysOnly:   true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		for _, kv := range resp.Data {
+			if kv.Value != nil {
+				t.Fatalf("expected keys only; received value for %s", kv.Key)
+			}
+			keys = append(keys, kv.Key)
+		}
+		if resp.Next == "" {
+			break
+		}
+		next = resp.Next
+	}
+	if pages != 2 {
+		t.Fatalf("expected 2 pages; received %d", pages)
+	}
+	if fmt.Sprint(keys) != "[foo.0 foo.1 foo.3 foo.4]" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	resp, err := s.Search(ctx, &api.SearchRequest{Bucket: "test", Prefix: "*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 5 || resp.Data[0].Key != "bar" || string(resp.Data[0].Value) != "v" {
+		t.Fatalf("unexpected search of all keys %+v", resp.Data)
+	}
+}
+
+func TestSearchStream(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: fmt.Sprintf("foo.%d", i), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	srv := &testSearchServer{}
+	if err := s.SearchStream(&api.SearchRequest{Bucket: "test", Prefix: "foo.", StartAfter: "foo.1", Limit: 2}, srv); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.data) != 2 || srv.data[0].Key != "foo.2" || srv.data[1].Key != "foo.3" {
+		t.Fatalf("unexpected streamed keys %+v", srv.data)
+	}
+}
@@ -7,14 +7,14 @@ import (
 	"sync"
 	"time"
 
-	bolt "github.com/coreos/bbolt"
-	"github.com/stellarproject/element"
 	"github.com/ehazlett/stellar"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
 	"github.com/ehazlett/stellar/client"
 	"github.com/ehazlett/stellar/services"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
+	"github.com/stellarproject/element"
 	"google.golang.org/grpc"
 )
 
@@ -28,30 +28,68 @@ var (
 )
 
 type service struct {
-	agent                 *element.Agent
-	config                *stellar.Config
-	dir                   string
-	lock                  *sync.Mutex
-	lockChan              chan bool
-	db                    *bolt.DB
-	dsTombstoneBucketName string
-}
-
-type tombstone struct {
-	Timestamp time.Time
-	Bucket    string
-	Key       string
-	Value     []byte
+	agent      *element.Agent
+	config     *stellar.Config
+	dir        string
+	engineType string
+	lock       *sync.Mutex
+	db         engine.Engine
+	clock      *clock
+	watchers   *watchers
+	locks      *locks
+	syncStats  *syncStats
+	// changelogBucketName is the local change log used for delta sync
+	changelogBucketName string
+	// metaBucketName stores the change log id and the synced peer positions
+	metaBucketName string
+	// lockBucketName stores the fencing tokens issued for each lock; the
+	// tokens are kept when the datastore is restored
+	lockBucketName string
+	// legacyTombstoneBucketName is the per node tombstone bucket used before
+	// tombstones were versioned with the entries
+	legacyTombstoneBucketName string
+	// snapshotDir stores the periodic compressed snapshots
+	snapshotDir       string
+	snapshotInterval  time.Duration
+	snapshotRetention int
+	// keys encrypts the values at rest; nil stores values in plain text
+	keys *keyring
 }
 
 func New(cfg *stellar.Config, agent *element.Agent) (services.Service, error) {
 	svc := &service{
-		agent:                 agent,
-		config:                cfg,
-		dir:                   cfg.DataDir,
-		lock:                  &sync.Mutex{},
-		lockChan:              make(chan bool),
-		dsTombstoneBucketName: "stellar." + stellar.APIVersion + "." + agent.Self().ID + ".services.datastore.tombstone",
+		agent:                     agent,
+		config:                    cfg,
+		dir:                       cfg.DataDir,
+		engineType:                cfg.DatastoreEngine,
+		lock:                      &sync.Mutex{},
+		clock:                     newClock(agent.Self().ID),
+		watchers:                  &watchers{},
+		locks:                     &locks{},
+		syncStats:                 &syncStats{},
+		changelogBucketName:       "stellar." + stellar.APIVersion + ".services.datastore.changelog",
+		metaBucketName:            "stellar." + stellar.APIVersion + ".services.datastore.meta",
+		lockBucketName:            "stellar." + stellar.APIVersion + ".services.datastore.locks",
+		legacyTombstoneBucketName: "stellar." + stellar.APIVersion + "." + agent.Self().ID + ".services.datastore.tombstone",
+		snapshotDir:               cfg.DatastoreSnapshotDir,
+		snapshotInterval:          cfg.DatastoreSnapshotInterval,
+		snapshotRetention:         cfg.DatastoreSnapshotRetention,
+	}
+
+	if svc.snapshotDir == "" {
+		svc.snapshotDir = filepath.Join(cfg.DataDir, "snapshots")
+	}
+
+	keys, err := loadDataKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		kr, err := newKeyring(keys)
+		if err != nil {
+			return nil, err
+		}
+		svc.keys = kr
 	}
 
 	db, err := svc.openDB()
@@ -89,18 +127,21 @@ func (s *service) Info(ctx context.Context, req *api.InfoRequest) (*api.InfoResp
 
 func (s *service) Start() error {
 	s.lock.Lock()
-	if err := s.db.Update(func(tx *bolt.Tx) error {
-		if _, err := tx.CreateBucketIfNotExists([]byte(s.dsTombstoneBucketName)); err != nil {
+	if err := s.db.Update(func(tx engine.Tx) error {
+		if _, err := s.logID(tx); err != nil {
 			return err
 		}
-		return nil
+		if tx.Bucket([]byte(s.legacyTombstoneBucketName)) == nil {
+			return nil
+		}
+		return tx.DeleteBucket([]byte(s.legacyTombstoneBucketName))
 	}); err != nil {
 		return err
 	}
 	s.lock.Unlock()
 
-	if err := s.db.View(func(tx *bolt.Tx) error {
-		if err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+	if err := s.db.View(func(tx engine.Tx) error {
+		if err := tx.ForEach(func(name []byte, b engine.Bucket) error {
 			bucket := string(name)
 			logrus.Debugf("datastore: bucket %s", bucket)
 			return nil
@@ -112,13 +153,33 @@ func (s *service) Start() error {
 		return err
 	}
 
+	s.startSnapshots()
+
+	if id := s.keys.primaryID(); id != "" {
+		logrus.WithFields(logrus.Fields{
+			"key": id,
+		}).Info("datastore encryption enabled")
+		// encrypt values written before encryption was enabled or a rotation
+		go func() {
+			n, err := s.reencrypt()
+			if err != nil {
+				logrus.Errorf("error encrypting datastore: %s", err)
+				return
+			}
+			if n > 0 {
+				logrus.Infof("datastore: encrypted %d values with key %s", n, id)
+			}
+		}()
+	}
+
 	t := time.NewTicker(time.Second * 60)
 	go func() {
 		for range t.C {
 			logrus.Debug("pruning datastore")
-			if err := s.prune(); err != nil {
+			if err := s.prunePeers(); err != nil {
 				logrus.Errorf("error pruning datastore: %s", err)
 			}
+			s.locks.expire()
 		}
 	}()
 
@@ -143,32 +204,33 @@ func (s *service) Reset() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.db.Close()
-
-	if err := os.Remove(dbFilename); err != nil {
-		return err
-	}
-
-	db, err := s.openDB()
-	if err != nil {
-		return err
-	}
-
-	s.db = db
-	return nil
+	return s.db.Update(func(tx engine.Tx) error {
+		names := [][]byte{}
+		if err := tx.ForEach(func(name []byte, _ engine.Bucket) error {
+			names = append(names, append([]byte{}, name...))
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (s *service) openDB() (*bolt.DB, error) {
-	if err := os.MkdirAll(s.dir, 0700); err != nil {
-		return nil, err
+func (s *service) openDB() (engine.Engine, error) {
+	if s.engineType == engine.Memory {
+		return engine.NewMemory(), nil
 	}
 
-	db, err := bolt.Open(filepath.Join(s.dir, dbFilename), 0600, &bolt.Options{Timeout: time.Second * 1})
-	if err != nil {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
 		return nil, err
 	}
 
-	return db, nil
+	return engine.Open(s.engineType, filepath.Join(s.dir, dbFilename))
 }
 
 func (s *service) client(address string) (*client.Client, error) {
@@ -2,49 +2,57 @@ package datastore
 
 import (
 	"context"
+	"time"
 
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (s *service) Set(ctx context.Context, req *api.SetRequest) (*ptypes.Empty, error) {
-	var err error
-	s.lock.Lock()
-	err = s.db.Update(func(tx *bolt.Tx) error {
-		b, err := tx.CreateBucketIfNotExists([]byte(req.Bucket))
+	entry := &api.Entry{
+		Version:   s.version(req.Version),
+		Value:     req.Value,
+		ExpiresAt: req.ExpiresAt,
+	}
+	// writes replicated from a peer carry the original expiry
+	if req.ExpiresAt == 0 && req.TTL != nil {
+		ttl, err := ptypes.DurationFromProto(req.TTL)
 		if err != nil {
-			return err
+			return empty, status.Errorf(codes.InvalidArgument, "invalid ttl: %s", err)
 		}
-		if err := b.Put([]byte(req.Key), req.Value); err != nil {
-			return err
-		}
-		return nil
-	})
-	// check for any tombstone records and remove if exists as this is an updated record
-	err = s.db.Update(func(tx *bolt.Tx) error {
-		tb := tx.Bucket([]byte(s.dsTombstoneBucketName))
-		if tb == nil {
-			// no tombstone bucket; ignore
-			return nil
+		if ttl > 0 {
+			entry.ExpiresAt = time.Now().Add(ttl).UnixNano()
 		}
-		tsKey := s.tombstoneKeyName(req.Bucket, req.Key)
-		if v := tb.Get([]byte(tsKey)); v != nil {
-			// ignore delete errors
-			if err := tb.Delete([]byte(tsKey)); err != nil {
-				return err
-			}
-			logrus.WithField("key", tsKey).Debug("removed tombstone record as key was updated")
+	}
+	var change *api.Change
+	s.lock.Lock()
+	err := s.db.Update(func(tx engine.Tx) error {
+		c, err := s.apply(tx, req.Bucket, req.Key, entry)
+		if err != nil {
+			return err
 		}
+		change = c
 		return nil
 	})
+	if err == nil {
+		// notify while locked so watchers receive changes in commit order
+		s.notify(change)
+	}
 	s.lock.Unlock()
+	if err != nil {
+		return empty, err
+	}
 
 	logrus.WithFields(logrus.Fields{
-		"bucket": req.Bucket,
-		"key":    req.Key,
-		"sync":   req.Sync,
+		"bucket":  req.Bucket,
+		"key":     req.Key,
+		"sync":    req.Sync,
+		"ttl":     req.TTL,
+		"applied": change != nil,
 	}).Debug("updated datastore")
 
 	if req.Sync {
@@ -53,5 +61,5 @@ func (s *service) Set(ctx context.Context, req *api.SetRequest) (*ptypes.Empty,
 		}
 	}
 
-	return empty, err
+	return empty, nil
 }
@@ -0,0 +1,185 @@
+package datastore
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	snapshotPrefix = "datastore-"
+	snapshotSuffix = ".snap.gz"
+	// snapshotTimeFormat sorts snapshot names by creation time
+	snapshotTimeFormat = "20060102150405.000000000"
+)
+
+// Snapshots returns the snapshots on the node newest first
+func (s *service) Snapshots(ctx context.Context, _ *api.SnapshotsRequest) (*api.SnapshotsResponse, error) {
+	snapshots, err := s.snapshots()
+	if err != nil {
+		return nil, err
+	}
+	return &api.SnapshotsResponse{
+		Snapshots: snapshots,
+	}, nil
+}
+
+// startSnapshots takes a snapshot on each interval
+func (s *service) startSnapshots() {
+	if s.snapshotInterval <= 0 {
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"dir":       s.snapshotDir,
+		"interval":  s.snapshotInterval,
+		"retention": s.snapshotRetention,
+	}).Debug("starting datastore snapshots")
+	t := time.NewTicker(s.snapshotInterval)
+	go func() {
+		for range t.C {
+			snapshot, err := s.snapshot()
+			if err != nil {
+				logrus.Errorf("error taking datastore snapshot: %s", err)
+				continue
+			}
+			logrus.WithFields(logrus.Fields{
+				"name": snapshot.Name,
+				"size": snapshot.Size_,
+			}).Debug("datastore snapshot taken")
+		}
+	}()
+}
+
+// snapshot writes a compressed snapshot of the datastore to the snapshot
+// directory and removes the snapshots beyond the retention
+func (s *service) snapshot() (*api.Snapshot, error) {
+	if err := os.MkdirAll(s.snapshotDir, 0700); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	name := snapshotPrefix + now.Format(snapshotTimeFormat) + snapshotSuffix
+
+	// write to a temporary file so partial snapshots are never listed
+	f, err := ioutil.TempFile(s.snapshotDir, ".snapshot-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	gz := gzip.NewWriter(f)
+	if _, err := s.db.Snapshot(gz); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(s.snapshotDir, name)); err != nil {
+		return nil, err
+	}
+
+	if err := s.pruneSnapshots(); err != nil {
+		return nil, err
+	}
+
+	return &api.Snapshot{
+		Name:      name,
+		Size_:     info.Size(),
+		CreatedAt: timestampProto(now),
+	}, nil
+}
+
+// snapshots returns the snapshots in the snapshot directory newest first
+func (s *service) snapshots() ([]*api.Snapshot, error) {
+	files, err := ioutil.ReadDir(s.snapshotDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	snapshots := []*api.Snapshot{}
+	for _, f := range files {
+		if f.IsDir() || !isSnapshotName(f.Name()) {
+			continue
+		}
+		snapshots = append(snapshots, &api.Snapshot{
+			Name:      f.Name(),
+			Size_:     f.Size(),
+			CreatedAt: timestampProto(f.ModTime()),
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name > snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+// pruneSnapshots removes the oldest snapshots beyond the retention
+func (s *service) pruneSnapshots() error {
+	if s.snapshotRetention <= 0 {
+		return nil
+	}
+	snapshots, err := s.snapshots()
+	if err != nil {
+		return err
+	}
+	for i := s.snapshotRetention; i < len(snapshots); i++ {
+		logrus.Debugf("removing datastore snapshot %s", snapshots[i].Name)
+		if err := os.Remove(filepath.Join(s.snapshotDir, snapshots[i].Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotPath returns the path for the named snapshot
+func (s *service) snapshotPath(name string) (string, error) {
+	if filepath.Base(name) != name || !isSnapshotName(name) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid snapshot name %q", name)
+	}
+	p := filepath.Join(s.snapshotDir, name)
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return "", status.Errorf(codes.NotFound, "snapshot %s not found", name)
+		}
+		return "", err
+	}
+	return p, nil
+}
+
+func isSnapshotName(name string) bool {
+	return strings.HasPrefix(name, snapshotPrefix) && strings.HasSuffix(name, snapshotSuffix)
+}
+
+func timestampProto(t time.Time) *ptypes.Timestamp {
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
@@ -0,0 +1,233 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	ptypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testBackupServer struct {
+	grpc.ServerStream
+	buf bytes.Buffer
+}
+
+func (t *testBackupServer) Send(resp *api.BackupResponse) error {
+	_, err := t.buf.Write(resp.Data)
+	return err
+}
+
+type testRestoreServer struct {
+	grpc.ServerStream
+	chunks [][]byte
+}
+
+func (t *testRestoreServer) Recv() (*api.RestoreRequest, error) {
+	if len(t.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := t.chunks[0]
+	t.chunks = t.chunks[1:]
+	return &api.RestoreRequest{Data: chunk}, nil
+}
+
+func (t *testRestoreServer) SendAndClose(*ptypes.Empty) error {
+	return nil
+}
+
+func testSnapshotService(t *testing.T) (*service, func()) {
+	s, cleanup := testService(t)
+	dir, err := ioutil.TempDir("", "stellar-snapshots-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.snapshotDir = dir
+	return s, func() {
+		cleanup()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSnapshotRetention(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	s.snapshotRetention = 2
+	names := []string{}
+	for i := 0; i < 3; i++ {
+		snapshot, err := s.snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, snapshot.Name)
+	}
+
+	resp, err := s.Snapshots(context.Background(), &api.SnapshotsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots; received %d", len(resp.Snapshots))
+	}
+	if resp.Snapshots[0].Name != names[2] || resp.Snapshots[1].Name != names[1] {
+		t.Fatalf("expected newest snapshots %v; received %+v", names[1:], resp.Snapshots)
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("snapshot")}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := s.snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("changed")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Restore(ctx, &api.RestoreRequest{Snapshot: snapshot.Name}); err != nil {
+		t.Fatal(err)
+	}
+	if v := string(get(t, s, "test", "foo").Value); v != "snapshot" {
+		t.Fatalf("expected restored value snapshot; received %q", v)
+	}
+
+	if _, err := s.Restore(ctx, &api.RestoreRequest{Snapshot: "../" + snapshot.Name}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for snapshot outside the snapshot dir; received %v", err)
+	}
+}
+
+func TestBackupRestoreStream(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("backup")}); err != nil {
+		t.Fatal(err)
+	}
+	backup := &testBackupServer{}
+	if err := s.BackupStream(&api.BackupRequest{}, backup); err != nil {
+		t.Fatal(err)
+	}
+	data := backup.buf.Bytes()
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatal("expected gzip compressed backup")
+	}
+
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("changed")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// split the backup across chunks
+	restore := &testRestoreServer{
+		chunks: [][]byte{data[:len(data)/2], data[len(data)/2:]},
+	}
+	if err := s.RestoreStream(restore); err != nil {
+		t.Fatal(err)
+	}
+	if v := string(get(t, s, "test", "foo").Value); v != "backup" {
+		t.Fatalf("expected restored value backup; received %q", v)
+	}
+}
+
+func TestRestoreReplacesPeerWrites(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("snapshot")}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := s.snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("changed")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "bar", Value: []byte("new")}); err != nil {
+		t.Fatal(err)
+	}
+	changed := get(t, s, "test", "foo").Version
+
+	if _, err := s.Restore(ctx, &api.RestoreRequest{Snapshot: snapshot.Name}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restored entries replace the writes held by peers
+	if kv := get(t, s, "test", "foo"); string(kv.Value) != "snapshot" || !kv.Version.Newer(changed) {
+		t.Fatalf("expected restored value with a newer version; received %+v", kv)
+	}
+	if kv := get(t, s, "test", "bar"); kv.Value != nil {
+		t.Fatalf("expected key missing from the backup to be deleted; received %+v", kv)
+	}
+
+	changes := map[string]bool{}
+	if err := s.db.View(func(tx engine.Tx) error {
+		return s.changesSince(tx, 0, func(c *api.Change) error {
+			changes[c.Key] = c.Entry.Deleted
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, ok := changes["foo"]; !ok || deleted {
+		t.Fatalf("expected restored key in the change log; received %+v", changes)
+	}
+	if deleted := changes["bar"]; !deleted {
+		t.Fatalf("expected deleted key in the change log; received %+v", changes)
+	}
+}
+
+func TestRestoreRollback(t *testing.T) {
+	s, cleanup := testSnapshotService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("current")}); err != nil {
+		t.Fatal(err)
+	}
+	current := get(t, s, "test", "foo").Version
+
+	// a backup with an entry that cannot be opened fails to be versioned
+	backup, err := engine.Open(engine.Memory, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backup.Update(func(tx engine.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("test"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("foo"), sealedHeader)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := backup.Snapshot(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Restore(ctx, &api.RestoreRequest{Data: buf.Bytes()}); err == nil {
+		t.Fatal("expected error restoring invalid entry")
+	}
+
+	// the previous database is kept
+	if kv := get(t, s, "test", "foo"); string(kv.Value) != "current" || kv.Version.Compare(current) != 0 {
+		t.Fatalf("expected previous entry; received %+v", kv)
+	}
+}
@@ -0,0 +1,177 @@
+package datastore
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+)
+
+// syncStats tracks the replication with each peer since the node started
+type syncStats struct {
+	mu    sync.Mutex
+	peers map[string]*api.PeerStatus
+}
+
+// peer returns the status for the peer; the lock must be held
+func (t *syncStats) peer(id string) *api.PeerStatus {
+	if t.peers == nil {
+		t.peers = map[string]*api.PeerStatus{}
+	}
+	p, ok := t.peers[id]
+	if !ok {
+		p = &api.PeerStatus{
+			ID: id,
+		}
+		t.peers[id] = p
+	}
+	return p
+}
+
+// attempt records the start of a sync from the peer
+func (t *syncStats) attempt(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peer(id).LastAttempt = timestampProto(time.Now())
+}
+
+// synced records a successful sync from the peer along with the synced and
+// latest position in the peer change log
+func (t *syncStats) synced(id string, seq, peerSeq uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := t.peer(id)
+	p.LastSync = timestampProto(time.Now())
+	p.Sequence = seq
+	if peerSeq < seq {
+		peerSeq = seq
+	}
+	p.PeerSequence = peerSeq
+}
+
+// failed records a sync error with the peer
+func (t *syncStats) failed(id string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := t.peer(id)
+	p.Errors++
+	p.LastError = err.Error()
+	p.LastErrorAt = timestampProto(time.Now())
+}
+
+// received records the keys applied from the peer
+func (t *syncStats) received(id string, n uint64) {
+	if n == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peer(id).KeysReceived += n
+}
+
+// sent records the keys streamed to the peer
+func (t *syncStats) sent(id string, n uint64) {
+	if n == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peer(id).KeysSent += n
+}
+
+// acknowledge records the position in the local change log applied by the peer
+func (t *syncStats) acknowledge(id string, seq uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peer(id).Acknowledged = seq
+}
+
+// acknowledged returns the lowest position in the local change log applied by
+// all of the peers; zero is returned if a peer has not acknowledged any changes
+func (t *syncStats) acknowledged(ids []string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ack := uint64(math.MaxUint64)
+	for _, id := range ids {
+		p, ok := t.peers[id]
+		if !ok {
+			return 0
+		}
+		if p.Acknowledged < ack {
+			ack = p.Acknowledged
+		}
+	}
+	return ack
+}
+
+// resetAcknowledged clears the acknowledged positions when the local change
+// log is replaced
+func (t *syncStats) resetAcknowledged() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.peers {
+		p.Acknowledged = 0
+	}
+}
+
+// list returns a copy of the peer statuses sorted by id
+func (t *syncStats) list() []*api.PeerStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	peers := make([]*api.PeerStatus, 0, len(t.peers))
+	for _, p := range t.peers {
+		c := *p
+		peers = append(peers, &c)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+	return peers
+}
+
+// Status returns the replication status with each peer along with the size
+// and tombstone backlog of each bucket
+func (s *service) Status(ctx context.Context, _ *api.StatusRequest) (*api.StatusResponse, error) {
+	resp := &api.StatusResponse{
+		NodeID: s.clock.nodeID,
+		Peers:  s.syncStats.list(),
+	}
+	err := s.db.View(func(tx engine.Tx) error {
+		if b := tx.Bucket([]byte(s.metaBucketName)); b != nil {
+			resp.LogID = string(b.Get([]byte(metaLogIDKey)))
+		}
+		_, resp.Sequence = s.changelogRange(tx)
+
+		return tx.ForEach(func(name []byte, b engine.Bucket) error {
+			if s.isInternalBucket(string(name)) {
+				return nil
+			}
+			bs := &api.BucketStatus{
+				Bucket: string(name),
+			}
+			if err := b.ForEach(func(k, v []byte) error {
+				bs.Size_ += uint64(len(k) + len(v))
+				e, err := s.decodeEntry(string(name), k, v)
+				if err != nil {
+					return err
+				}
+				if e.Deleted {
+					bs.Tombstones++
+					return nil
+				}
+				bs.Keys++
+				return nil
+			}); err != nil {
+				return err
+			}
+			resp.Buckets = append(resp.Buckets, bs)
+			resp.Tombstones += bs.Tombstones
+			return nil
+		})
+	})
+	return resp, err
+}
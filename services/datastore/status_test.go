@@ -0,0 +1,69 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"google.golang.org/grpc"
+)
+
+type testSyncServer struct {
+	grpc.ServerStream
+	ops []*api.SyncOperation
+}
+
+func (t *testSyncServer) Send(op *api.SyncOperation) error {
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+func TestStatus(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, k := range []string{"foo", "bar", "baz"} {
+		if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: k, Value: []byte("value")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Bucket: "test", Key: "baz"}); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &testSyncServer{}
+	if err := s.Sync(&api.SyncRequest{Delta: true, NodeID: "node-01"}, srv); err != nil {
+		t.Fatal(err)
+	}
+	s.syncStats.attempt("node-01")
+	s.syncStats.received("node-01", 2)
+	s.syncStats.synced("node-01", 5, 7)
+	s.syncStats.failed("node-02", errors.New("unavailable"))
+
+	resp, err := s.Status(ctx, &api.StatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NodeID != "node-00" || resp.Sequence != 4 {
+		t.Fatalf("unexpected node status %+v", resp)
+	}
+	if resp.Tombstones != 1 || len(resp.Buckets) != 1 {
+		t.Fatalf("expected 1 tombstone in 1 bucket; received %+v", resp)
+	}
+	if b := resp.Buckets[0]; b.Bucket != "test" || b.Keys != 2 || b.Tombstones != 1 || b.Size_ == 0 {
+		t.Fatalf("unexpected bucket status %+v", b)
+	}
+
+	if len(resp.Peers) != 2 {
+		t.Fatalf("expected 2 peers; received %d", len(resp.Peers))
+	}
+	p := resp.Peers[0]
+	if p.ID != "node-01" || p.KeysSent != 4 || p.KeysReceived != 2 || p.Sequence != 5 || p.PeerSequence != 7 || p.LastSync == nil || p.Errors != 0 {
+		t.Fatalf("unexpected peer status %+v", p)
+	}
+	if p := resp.Peers[1]; p.ID != "node-02" || p.Errors != 1 || p.LastError != "unavailable" || p.LastSync != nil {
+		t.Fatalf("unexpected peer status %+v", p)
+	}
+}
@@ -1,124 +1,361 @@
 package datastore
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"io"
 
-	bolt "github.com/coreos/bbolt"
 	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/client"
+	"github.com/ehazlett/stellar/services/datastore/engine"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Sync returns a stream of all datastore buckets and keys
-func (s *service) Sync(_ *api.SyncRequest, srv api.Datastore_SyncServer) error {
-	logrus.Debug("syncing datastore")
-	// set operations
-	if err := s.db.View(func(tx *bolt.Tx) error {
-		if err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+const (
+	// syncBatchSize is the number of keys requested per sync stream when
+	// transferring differing keys
+	syncBatchSize = 500
+)
+
+// Sync returns a stream of datastore operations.  If delta is set the change
+// log after the requested sequence is streamed; if keys are specified only
+// those keys are streamed.  Otherwise all buckets and keys are streamed.
+// Tombstones are sent as delete operations.
+func (s *service) Sync(req *api.SyncRequest, srv api.Datastore_SyncServer) error {
+	logrus.WithFields(logrus.Fields{
+		"delta":  req.Delta,
+		"since":  req.Since,
+		"bucket": req.Bucket,
+		"keys":   len(req.Keys),
+	}).Debug("syncing datastore")
+	sent := uint64(0)
+	send := func(op *api.SyncOperation) error {
+		if err := srv.Send(op); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	}
+	defer func() {
+		if req.NodeID != "" {
+			s.syncStats.sent(req.NodeID, sent)
+		}
+	}()
+	return s.db.View(func(tx engine.Tx) error {
+		switch {
+		case req.Delta:
+			first, _ := s.changelogRange(tx)
+			if req.Since+1 < first {
+				return status.Errorf(codes.OutOfRange, "change log sequence %d has been pruned", req.Since)
+			}
+			// the peer requests the changes after those it has applied
+			if req.NodeID != "" {
+				s.syncStats.acknowledge(req.NodeID, req.Since)
+			}
+			return s.changesSince(tx, req.Since, func(c *api.Change) error {
+				op := syncOperation(c.Bucket, c.Key, c.Entry)
+				op.Sequence = c.Sequence
+				return send(op)
+			})
+		case len(req.Keys) > 0:
+			b := tx.Bucket([]byte(req.Bucket))
+			if b == nil || s.isInternalBucket(req.Bucket) {
+				return nil
+			}
+			for _, key := range req.Keys {
+				e, err := s.getEntry(b, req.Bucket, key)
+				if err != nil {
+					return err
+				}
+				if e == nil {
+					continue
+				}
+				if err := send(syncOperation(req.Bucket, key, e)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return tx.ForEach(func(name []byte, b engine.Bucket) error {
 			bucket := string(name)
-			// skip tombstone buckets
-			if bucket == s.dsTombstoneBucketName {
+			if s.isInternalBucket(bucket) {
 				return nil
 			}
 			c := b.Cursor()
 			for k, v := c.First(); k != nil; k, v = c.Next() {
-				if err := srv.Send(&api.SyncOperation{
-					Bucket: bucket,
-					Key:    string(k),
-					Value:  v,
-					Action: api.SyncAction_SET,
-				}); err != nil {
+				e, err := s.decodeEntry(bucket, k, v)
+				if err != nil {
+					return err
+				}
+				if err := send(syncOperation(bucket, string(k), e)); err != nil {
 					return err
 				}
 			}
 			return nil
-		}); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
+		})
+	})
+}
+
+func syncOperation(bucket, key string, e *api.Entry) *api.SyncOperation {
+	action := api.SyncAction_SET
+	if e.Deleted {
+		action = api.SyncAction_DELETE
+	}
+	return &api.SyncOperation{
+		Bucket:    bucket,
+		Key:       key,
+		Value:     e.Value,
+		Version:   e.Version,
+		Action:    action,
+		ExpiresAt: e.ExpiresAt,
+	}
+}
+
+// PeerSync issues the local node to sync with the requested peer.  If the
+// peer change log still covers the last synced sequence only the delta is
+// transferred; otherwise the bucket digests are compared and only the keys
+// that are newer on the peer are transferred.
+func (s *service) PeerSync(ctx context.Context, req *api.PeerSyncRequest) (*ptypes.Empty, error) {
+	logrus.Debugf("performing datastore sync with peer %s", req.ID)
+	s.syncStats.attempt(req.ID)
+	if err := s.peerSync(ctx, req); err != nil {
+		s.syncStats.failed(req.ID, err)
+		return empty, err
+	}
+	return empty, nil
+}
+
+func (s *service) peerSync(ctx context.Context, req *api.PeerSyncRequest) error {
+	c, err := s.client(req.Address)
+	if err != nil {
 		return err
 	}
-	// delete operations
-	s.lock.Lock()
-	defer s.lock.Unlock()
-	if err := s.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(s.dsTombstoneBucketName))
-		if b == nil {
-			// if bucket doesn't exist there is no need to sync
-			return nil
-		}
+	defer c.Close()
 
-		if err := b.ForEach(func(k, v []byte) error {
-			var t *tombstone
-			if err := json.Unmarshal(v, &t); err != nil {
-				return err
-			}
-			if err := srv.Send(&api.SyncOperation{
-				Bucket: t.Bucket,
-				Key:    t.Key,
-				Value:  t.Value,
-				Action: api.SyncAction_DELETE,
-			}); err != nil {
+	digest, err := c.DatastoreService().Digest(ctx, &api.DigestRequest{})
+	if err != nil {
+		return errors.Wrap(err, "error getting peer digest")
+	}
+
+	state, err := s.getPeerState(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if state != nil && state.LogID == digest.LogID && state.Sequence <= digest.Sequence {
+		seq, n, err := s.syncDelta(ctx, c, state.Sequence)
+		s.syncStats.received(req.ID, n)
+		if err == nil {
+			state.Sequence = seq
+			if err := s.setPeerState(req.ID, state); err != nil {
 				return err
 			}
+			s.syncStats.synced(req.ID, seq, digest.Sequence)
 			return nil
-		}); err != nil {
+		}
+		if status.Code(errors.Cause(err)) != codes.OutOfRange {
 			return err
 		}
-		return nil
+		logrus.WithField("peer", req.ID).Debug("peer change log pruned; comparing digests")
+	}
+
+	n, err := s.syncDigest(ctx, c, digest)
+	s.syncStats.received(req.ID, n)
+	if err != nil {
+		return err
+	}
+
+	// changes after the digest was taken are transferred with the next delta
+	if err := s.setPeerState(req.ID, &peerState{
+		LogID:    digest.LogID,
+		Sequence: digest.Sequence,
 	}); err != nil {
 		return err
 	}
+	s.syncStats.synced(req.ID, digest.Sequence, digest.Sequence)
 	return nil
 }
 
-// PeerSync issues the local node to sync with the requested peer
-func (s *service) PeerSync(ctx context.Context, req *api.PeerSyncRequest) (*ptypes.Empty, error) {
-	logrus.Debugf("performing datastore sync with peer %s", req.ID)
-	c, err := s.client(req.Address)
-	if err != nil {
-		return empty, err
-	}
-	defer c.Close()
-
-	stream, err := c.DatastoreService().Sync(ctx, &api.SyncRequest{})
+// syncDelta applies the peer change log after the sequence and returns the
+// last sequence applied along with the number of changes applied
+func (s *service) syncDelta(ctx context.Context, c *client.Client, since uint64) (uint64, uint64, error) {
+	stream, err := c.DatastoreService().Sync(ctx, &api.SyncRequest{
+		Delta:  true,
+		Since:  since,
+		NodeID: s.clock.nodeID,
+	})
 	if err != nil {
-		return empty, err
+		return since, 0, err
 	}
 
+	seq := since
+	count := uint64(0)
 	for {
 		op, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return empty, errors.Wrap(err, "error syncing datastore")
+			return seq, count, err
+		}
+		if err := s.applyOperation(ctx, op); err != nil {
+			return seq, count, err
 		}
+		seq = op.Sequence
+		count++
+	}
+
+	logrus.Debugf("sync: applied %d changes", count)
+	return seq, count, nil
+}
 
-		switch op.Action {
-		case api.SyncAction_SET:
-			if _, err := s.Set(ctx, &api.SetRequest{
-				Bucket: op.Bucket,
-				Key:    op.Key,
-				Value:  op.Value,
-			}); err != nil {
-				return empty, errors.Wrapf(err, "sync: error setting key %s", op.Key)
+// syncDigest transfers the keys that are newer on the peer for each bucket
+// with a differing digest and returns the number of keys applied
+func (s *service) syncDigest(ctx context.Context, c *client.Client, digest *api.DigestResponse) (uint64, error) {
+	local := map[string][]byte{}
+	if err := s.db.View(func(tx engine.Tx) error {
+		digests, err := s.digests(tx)
+		if err != nil {
+			return err
+		}
+		for _, d := range digests {
+			local[d.Bucket] = d.Digest
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	count := uint64(0)
+	for _, d := range digest.Buckets {
+		if bytes.Equal(local[d.Bucket], d.Digest) {
+			continue
+		}
+		resp, err := c.DatastoreService().Versions(ctx, &api.VersionsRequest{
+			Bucket: d.Bucket,
+		})
+		if err != nil {
+			return count, errors.Wrapf(err, "error getting versions for bucket %s", d.Bucket)
+		}
+		keys, err := s.newerKeys(d.Bucket, resp.Versions)
+		if err != nil {
+			return count, err
+		}
+		logrus.Debugf("sync: bucket %s differs; transferring %d keys", d.Bucket, len(keys))
+		for len(keys) > 0 {
+			n := syncBatchSize
+			if len(keys) < n {
+				n = len(keys)
 			}
-		case api.SyncAction_DELETE:
-			if _, err := s.Delete(ctx, &api.DeleteRequest{
-				Bucket:      op.Bucket,
-				Key:         op.Key,
-				NoTombstone: true,
-			}); err != nil {
-				return empty, errors.Wrapf(err, "sync: error removing key %s", op.Key)
+			applied, err := s.syncKeys(ctx, c, d.Bucket, keys[:n])
+			count += applied
+			if err != nil {
+				return count, err
 			}
+			keys = keys[n:]
 		}
 	}
-	return empty, nil
+
+	return count, nil
+}
+
+// newerKeys returns the keys where the peer version is newer than the local version
+func (s *service) newerKeys(bucket string, versions []*api.KeyVersion) ([]string, error) {
+	keys := []string{}
+	err := s.db.View(func(tx engine.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		for _, kv := range versions {
+			if b == nil {
+				keys = append(keys, kv.Key)
+				continue
+			}
+			e, err := s.getEntry(b, bucket, kv.Key)
+			if err != nil {
+				return err
+			}
+			if e == nil || kv.Version.Newer(e.Version) {
+				keys = append(keys, kv.Key)
+			}
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// syncKeys applies the keys from the peer and returns the number applied
+func (s *service) syncKeys(ctx context.Context, c *client.Client, bucket string, keys []string) (uint64, error) {
+	stream, err := c.DatastoreService().Sync(ctx, &api.SyncRequest{
+		Bucket: bucket,
+		Keys:   keys,
+		NodeID: s.clock.nodeID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	count := uint64(0)
+	for {
+		op, err := stream.Recv()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, errors.Wrap(err, "error syncing datastore")
+		}
+		if err := s.applyOperation(ctx, op); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// applyOperation applies a replicated operation using the original version.
+// Operations from peers that do not version their writes keep the zero
+// version so any versioned write takes precedence.
+func (s *service) applyOperation(ctx context.Context, op *api.SyncOperation) error {
+	var entry *api.Entry
+	switch op.Action {
+	case api.SyncAction_SET:
+		entry = &api.Entry{
+			Version:   op.Version,
+			Value:     op.Value,
+			ExpiresAt: op.ExpiresAt,
+		}
+	case api.SyncAction_DELETE:
+		entry = &api.Entry{
+			Version: op.Version,
+			Deleted: true,
+		}
+	default:
+		return nil
+	}
+	s.clock.Update(op.Version)
+
+	var change *api.Change
+	s.lock.Lock()
+	err := s.db.Update(func(tx engine.Tx) error {
+		c, err := s.apply(tx, op.Bucket, op.Key, entry)
+		if err != nil {
+			return err
+		}
+		change = c
+		return nil
+	})
+	if err == nil {
+		s.notify(change)
+	}
+	s.lock.Unlock()
+	if err != nil {
+		if entry.Deleted {
+			return errors.Wrapf(err, "sync: error removing key %s", op.Key)
+		}
+		return errors.Wrapf(err, "sync: error setting key %s", op.Key)
+	}
+	return nil
 }
 
 func (s *service) replicateToPeers(ctx context.Context) error {
@@ -139,6 +376,7 @@ func (s *service) replicateToPeers(ctx context.Context) error {
 			logrus.WithFields(logrus.Fields{
 				"peer": peer.ID,
 			}).Errorf("error performing sync: %s", err)
+			s.syncStats.failed(peer.ID, errors.Wrap(err, "error replicating to peer"))
 			continue
 		}
 		defer c.Close()
@@ -150,6 +388,7 @@ func (s *service) replicateToPeers(ctx context.Context) error {
 			logrus.WithFields(logrus.Fields{
 				"peer": peer.ID,
 			}).Errorf("peer sync error: %s", err)
+			s.syncStats.failed(peer.ID, errors.Wrap(err, "error replicating to peer"))
 			continue
 		}
 	}
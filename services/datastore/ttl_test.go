@@ -0,0 +1,184 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	ptypes "github.com/gogo/protobuf/types"
+)
+
+func TestSetTTL(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "live", Value: []byte("1"), TTL: ptypes.DurationProto(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "short", Value: []byte("1"), TTL: ptypes.DurationProto(time.Millisecond)}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 5)
+
+	if kv := get(t, s, "test", "live"); kv.ExpiresAt == 0 || string(kv.Value) != "1" {
+		t.Fatalf("expected live key with expiry; received %+v", kv)
+	}
+	if kv := get(t, s, "test", "short"); kv.Value != nil {
+		t.Fatalf("expected expired key to be hidden; received %+v", kv)
+	}
+	resp, err := s.Search(ctx, &api.SearchRequest{Bucket: "test", Prefix: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Key != "live" {
+		t.Fatalf("expected only live key in search; received %+v", resp.Data)
+	}
+
+	if err := s.prune(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.View(func(tx engine.Tx) error {
+		e, err := s.getEntry(tx.Bucket([]byte("test")), "test", "short")
+		if err != nil {
+			return err
+		}
+		if !e.Deleted {
+			t.Fatalf("expected expired key to be replaced with a tombstone; received %+v", e)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplicatedExpiry(t *testing.T) {
+	a, cleanupA := testService(t)
+	defer cleanupA()
+	b, cleanupB := testService(t)
+	defer cleanupB()
+
+	ctx := context.Background()
+	if _, err := a.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("1"), TTL: ptypes.DurationProto(time.Millisecond)}); err != nil {
+		t.Fatal(err)
+	}
+	var op *api.SyncOperation
+	if err := a.db.View(func(tx engine.Tx) error {
+		e, err := a.getEntry(tx.Bucket([]byte("test")), "test", "foo")
+		if err != nil {
+			return err
+		}
+		op = syncOperation("test", "foo", e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if op.ExpiresAt == 0 {
+		t.Fatal("expected expiry to be replicated")
+	}
+	time.Sleep(time.Millisecond * 5)
+
+	// the entry arrives after it expired on the origin
+	if err := b.applyOperation(ctx, op); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.prune(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	digest := func(s *service) []byte {
+		var d []byte
+		if err := s.db.View(func(tx engine.Tx) error {
+			digests, err := s.digests(tx)
+			if err != nil {
+				return err
+			}
+			d = digests[0].Digest
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	if !bytes.Equal(digest(a), digest(b)) {
+		t.Fatal("expected expired key to converge on both nodes")
+	}
+}
+
+func TestPruneUnacknowledgedTombstone(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	// a delete replicated from a peer long after it was issued
+	if err := s.applyOperation(ctx, &api.SyncOperation{Bucket: "test", Key: "foo", Version: &api.Version{Wall: 1, NodeID: "node-01"}, Action: api.SyncAction_DELETE}); err != nil {
+		t.Fatal(err)
+	}
+
+	tombstone := func() *api.Entry {
+		var e *api.Entry
+		if err := s.db.View(func(tx engine.Tx) error {
+			v, err := s.getEntry(tx.Bucket([]byte("test")), "test", "foo")
+			e = v
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+
+	peers := []string{"node-01", "node-02"}
+	seq := tombstone().Sequence
+	s.syncStats.acknowledge("node-01", seq)
+	s.syncStats.acknowledge("node-02", seq-1)
+	if err := s.prune(peers); err != nil {
+		t.Fatal(err)
+	}
+	if e := tombstone(); e == nil || !e.Deleted {
+		t.Fatalf("expected tombstone to be kept until applied by every peer; received %+v", e)
+	}
+
+	s.syncStats.acknowledge("node-02", seq)
+	if err := s.prune(peers); err != nil {
+		t.Fatal(err)
+	}
+	if e := tombstone(); e != nil {
+		t.Fatalf("expected tombstone to be pruned; received %+v", e)
+	}
+}
+
+func TestExpiredTombstoneVersion(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	// a key written with a long ttl that has just expired
+	ctx := context.Background()
+	now := time.Now()
+	version := &api.Version{Wall: now.Add(-time.Hour * 2).UnixNano(), NodeID: "node-01"}
+	expiresAt := now.Add(-time.Second).UnixNano()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo", Value: []byte("1"), Version: version, ExpiresAt: expiresAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.prune(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.prune(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.View(func(tx engine.Tx) error {
+		e, err := s.getEntry(tx.Bucket([]byte("test")), "test", "foo")
+		if err != nil {
+			return err
+		}
+		if e == nil || !e.Deleted || e.Version.Wall != expiresAt || !e.Version.Newer(version) {
+			t.Fatalf("expected tombstone versioned at the expiry; received %+v", e)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
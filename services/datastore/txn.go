@@ -0,0 +1,113 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Txn evaluates the compares and applies the success or failure operations
+// in a single transaction
+func (s *service) Txn(ctx context.Context, req *api.TxnRequest) (*api.TxnResponse, error) {
+	for _, op := range append(req.Success, req.Failure...) {
+		if op.Bucket == "" || op.Key == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "bucket and key are required for transaction operations")
+		}
+		if s.isInternalBucket(op.Bucket) {
+			return nil, status.Errorf(codes.InvalidArgument, "bucket %s is reserved", op.Bucket)
+		}
+	}
+
+	resp := &api.TxnResponse{}
+	changes := []*api.Change{}
+	s.lock.Lock()
+	err := s.db.Update(func(tx engine.Tx) error {
+		succeeded, err := s.compare(tx, req.Compare)
+		if err != nil {
+			return err
+		}
+		resp.Succeeded = succeeded
+
+		ops := req.Failure
+		if succeeded {
+			ops = req.Success
+		}
+		for _, op := range ops {
+			entry := &api.Entry{
+				Version: s.clock.Now(),
+			}
+			switch op.Type {
+			case api.TxnOp_PUT:
+				entry.Value = op.Value
+			case api.TxnOp_DELETE:
+				entry.Deleted = true
+			}
+			c, err := s.apply(tx, op.Bucket, op.Key, entry)
+			if err != nil {
+				return err
+			}
+			if c != nil {
+				changes = append(changes, c)
+			}
+			resp.Data = append(resp.Data, &api.KeyValue{
+				Key:     op.Key,
+				Value:   entry.Value,
+				Version: entry.Version,
+			})
+		}
+
+		_, resp.Revision = s.changelogRange(tx)
+		return nil
+	})
+	if err == nil {
+		for _, c := range changes {
+			s.notify(c)
+		}
+	}
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"succeeded": resp.Succeeded,
+		"applied":   len(changes),
+		"sync":      req.Sync,
+	}).Debug("datastore transaction")
+
+	if req.Sync && len(changes) > 0 {
+		if err := s.replicateToPeers(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// compare returns true if all compares match the current entries
+func (s *service) compare(tx engine.Tx, compares []*api.Compare) (bool, error) {
+	now := time.Now().UnixNano()
+	for _, c := range compares {
+		var e *api.Entry
+		if b := tx.Bucket([]byte(c.Bucket)); b != nil {
+			v, err := s.getEntry(b, c.Bucket, c.Key)
+			if err != nil {
+				return false, err
+			}
+			e = v
+		}
+		// expired keys do not exist
+		if e != nil && expired(e, now) {
+			e = nil
+		}
+		if !c.Matches(e) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
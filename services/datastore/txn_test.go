@@ -0,0 +1,130 @@
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+)
+
+func TestTxnCompareAndSwap(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	put := func(value string) *api.TxnResponse {
+		resp, err := s.Txn(ctx, &api.TxnRequest{
+			Compare: []*api.Compare{
+				api.CompareMissing("a", "key"),
+			},
+			Success: []*api.TxnOp{
+				api.OpPut("a", "key", []byte(value)),
+				api.OpPut("b", "key", []byte(value)),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := put("0")
+	if !resp.Succeeded || len(resp.Data) != 2 || resp.Revision != 2 {
+		t.Fatalf("expected transaction to succeed; received %+v", resp)
+	}
+	if resp := put("1"); resp.Succeeded || len(resp.Data) != 0 {
+		t.Fatalf("expected transaction to fail for existing key; received %+v", resp)
+	}
+	for _, bucket := range []string{"a", "b"} {
+		if v := get(t, s, bucket, "key"); string(v.Value) != "0" {
+			t.Fatalf("expected value 0 in bucket %s; received %q", bucket, v.Value)
+		}
+	}
+
+	// swap the value using the version
+	version := resp.Data[0].Version
+	resp, err := s.Txn(ctx, &api.TxnRequest{
+		Compare: []*api.Compare{
+			api.CompareVersion("a", "key", version),
+			api.CompareValue("b", "key", []byte("0")),
+		},
+		Success: []*api.TxnOp{
+			api.OpPut("a", "key", []byte("2")),
+			api.OpDelete("b", "key"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Succeeded {
+		t.Fatal("expected swap to succeed")
+	}
+	if v := get(t, s, "a", "key"); string(v.Value) != "2" {
+		t.Fatalf("expected swapped value; received %q", v.Value)
+	}
+	if v := get(t, s, "b", "key"); v.Version != nil {
+		t.Fatalf("expected key to be deleted; received %+v", v)
+	}
+
+	// the old version no longer matches
+	resp, err = s.Txn(ctx, &api.TxnRequest{
+		Compare: []*api.Compare{
+			api.CompareVersion("a", "key", version),
+		},
+		Success: []*api.TxnOp{
+			api.OpPut("a", "key", []byte("3")),
+		},
+		Failure: []*api.TxnOp{
+			api.OpPut("a", "conflict", []byte("1")),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Succeeded {
+		t.Fatal("expected stale version compare to fail")
+	}
+	if v := get(t, s, "a", "conflict"); string(v.Value) != "1" {
+		t.Fatal("expected failure operations to be applied")
+	}
+}
+
+func TestCompareMatches(t *testing.T) {
+	e := &api.Entry{
+		Version: &api.Version{Wall: 1},
+		Value:   []byte("v"),
+	}
+	tombstone := &api.Entry{
+		Version: &api.Version{Wall: 2},
+		Deleted: true,
+	}
+	notEqual := api.CompareValue("b", "k", []byte("x"))
+	notEqual.Result = api.Compare_NOT_EQUAL
+
+	for i, tc := range []struct {
+		compare *api.Compare
+		entry   *api.Entry
+		match   bool
+	}{
+		{api.CompareValue("b", "k", []byte("v")), e, true},
+		{api.CompareValue("b", "k", []byte("v")), nil, false},
+		{api.CompareVersion("b", "k", &api.Version{Wall: 1}), e, true},
+		{api.CompareVersion("b", "k", &api.Version{Wall: 2}), tombstone, false},
+		{api.CompareExists("b", "k"), e, true},
+		{api.CompareMissing("b", "k"), tombstone, true},
+		{api.CompareMissing("b", "k"), nil, true},
+		{notEqual, e, true},
+	} {
+		if m := tc.compare.Matches(tc.entry); m != tc.match {
+			t.Errorf("case %d: expected match %v; received %v", i, tc.match, m)
+		}
+	}
+}
+
+func get(t *testing.T, s *service, bucket, key string) *api.KeyValue {
+	resp, err := s.Get(context.Background(), &api.GetRequest{Bucket: bucket, Key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Data
+}
@@ -0,0 +1,164 @@
+package datastore
+
+import (
+	"strings"
+	"sync"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"github.com/ehazlett/stellar/services/datastore/engine"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchBufferSize is the number of events buffered per watcher; watchers
+	// that fall further behind are closed and must resume from their revision
+	watchBufferSize = 1024
+)
+
+type watcher struct {
+	bucket string
+	prefix string
+	ch     chan *api.WatchEvent
+	// behind is set when the watcher buffer overflowed
+	behind bool
+}
+
+func (w *watcher) matches(bucket, key string) bool {
+	return w.bucket == bucket && strings.HasPrefix(key, w.prefix)
+}
+
+type watchers struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+func (ws *watchers) add(w *watcher) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.watchers == nil {
+		ws.watchers = map[*watcher]struct{}{}
+	}
+	ws.watchers[w] = struct{}{}
+}
+
+func (ws *watchers) remove(w *watcher) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if _, ok := ws.watchers[w]; ok {
+		delete(ws.watchers, w)
+		if !w.behind {
+			close(w.ch)
+		}
+	}
+}
+
+func (ws *watchers) send(c *api.Change) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for w := range ws.watchers {
+		if w.behind || !w.matches(c.Bucket, c.Key) {
+			continue
+		}
+		select {
+		case w.ch <- watchEvent(c):
+		default:
+			w.behind = true
+			close(w.ch)
+		}
+	}
+}
+
+func watchEvent(c *api.Change) *api.WatchEvent {
+	t := api.WatchEvent_PUT
+	if c.Entry.Deleted {
+		t = api.WatchEvent_DELETE
+	}
+	return &api.WatchEvent{
+		Type:   t,
+		Bucket: c.Bucket,
+		Data: &api.KeyValue{
+			Key:       c.Key,
+			Value:     c.Entry.Value,
+			Version:   c.Entry.Version,
+			ExpiresAt: c.Entry.ExpiresAt,
+		},
+		Revision: c.Sequence,
+	}
+}
+
+// notify sends the committed change to the watchers.  The lock must be held
+// so changes are sent in commit order.
+func (s *service) notify(c *api.Change) {
+	if c == nil {
+		return
+	}
+	s.watchers.send(c)
+}
+
+// Watch streams put and delete events for the keys in the bucket matching
+// the prefix.  Events for both local writes and writes replicated from peers
+// are sent.  If a revision is specified the changes after the revision are
+// sent before any new changes.
+func (s *service) Watch(req *api.WatchRequest, srv api.Datastore_WatchServer) error {
+	w := &watcher{
+		bucket: req.Bucket,
+		prefix: req.Prefix,
+		ch:     make(chan *api.WatchEvent, watchBufferSize),
+	}
+	// register before replaying so no change is missed between the two
+	s.watchers.add(w)
+	defer s.watchers.remove(w)
+
+	last := req.Revision
+	// replayed is the last revision sent while replaying
+	replayed := uint64(0)
+	if req.Revision > 0 {
+		if err := s.db.View(func(tx engine.Tx) error {
+			first, head := s.changelogRange(tx)
+			if req.Revision+1 < first {
+				return status.Errorf(codes.OutOfRange, "revision %d has been pruned", req.Revision)
+			}
+			if err := s.changesSince(tx, req.Revision, func(c *api.Change) error {
+				if !w.matches(c.Bucket, c.Key) {
+					return nil
+				}
+				return srv.Send(watchEvent(c))
+			}); err != nil {
+				return err
+			}
+			last = head
+			replayed = head
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"bucket":   req.Bucket,
+		"prefix":   req.Prefix,
+		"revision": last,
+	}).Debug("datastore watch started")
+
+	ctx := srv.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-w.ch:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "watch fell behind; resume from revision %d", last)
+			}
+			// skip changes already sent while replaying
+			if evt.Revision <= replayed {
+				continue
+			}
+			if err := srv.Send(evt); err != nil {
+				return err
+			}
+			last = evt.Revision
+		}
+	}
+}
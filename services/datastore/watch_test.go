@@ -0,0 +1,150 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/datastore/v1"
+	"google.golang.org/grpc"
+)
+
+type testWatchServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *api.WatchEvent
+}
+
+func (t *testWatchServer) Context() context.Context {
+	return t.ctx
+}
+
+func (t *testWatchServer) Send(evt *api.WatchEvent) error {
+	t.events <- evt
+	return nil
+}
+
+func TestWatchResumeFromRevision(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo.0", Value: []byte("0")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "bar.0", Value: []byte("0")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Bucket: "test", Key: "foo.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	srv := &testWatchServer{
+		ctx:    wctx,
+		events: make(chan *api.WatchEvent, 16),
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Watch(&api.WatchRequest{Bucket: "test", Prefix: "foo.", Revision: 1}, srv)
+	}()
+
+	// the delete after the revision is replayed
+	evt := <-srv.events
+	if evt.Type != api.WatchEvent_DELETE || evt.Data.Key != "foo.0" || evt.Revision != 3 {
+		t.Fatalf("unexpected replayed event %+v", evt)
+	}
+
+	// new writes are sent once replayed
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo.1", Value: []byte("1")}); err != nil {
+		t.Fatal(err)
+	}
+	evt = <-srv.events
+	if evt.Type != api.WatchEvent_PUT || evt.Data.Key != "foo.1" || evt.Revision != 4 {
+		t.Fatalf("unexpected event %+v", evt)
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.events) != 0 {
+		t.Fatalf("unexpected events %+v", <-srv.events)
+	}
+}
+
+func TestWatchersSend(t *testing.T) {
+	ws := &watchers{}
+	w := &watcher{
+		bucket: "test",
+		prefix: "foo.",
+		ch:     make(chan *api.WatchEvent, 1),
+	}
+	ws.add(w)
+
+	ws.send(&api.Change{Sequence: 1, Bucket: "test", Key: "bar.0", Entry: &api.Entry{}})
+	ws.send(&api.Change{Sequence: 2, Bucket: "test", Key: "foo.0", Entry: &api.Entry{}})
+
+	evt := <-w.ch
+	if evt.Revision != 2 || evt.Type != api.WatchEvent_PUT {
+		t.Fatalf("unexpected event %+v", evt)
+	}
+
+	// overflow the buffer
+	ws.send(&api.Change{Sequence: 3, Bucket: "test", Key: "foo.1", Entry: &api.Entry{}})
+	ws.send(&api.Change{Sequence: 4, Bucket: "test", Key: "foo.2", Entry: &api.Entry{}})
+	<-w.ch
+	if _, ok := <-w.ch; ok {
+		t.Fatal("expected watcher to be closed after falling behind")
+	}
+	ws.remove(w)
+}
+
+func TestWatchConcurrentSetOrder(t *testing.T) {
+	s, cleanup := testService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: "foo.0", Value: []byte("0")}); err != nil {
+		t.Fatal(err)
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	srv := &testWatchServer{
+		ctx:    wctx,
+		events: make(chan *api.WatchEvent, 64),
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Watch(&api.WatchRequest{Bucket: "test", Prefix: "foo.", Revision: 1}, srv)
+	}()
+
+	const writes = 32
+	var wg sync.WaitGroup
+	for i := 0; i < writes; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := s.Set(ctx, &api.SetRequest{Bucket: "test", Key: fmt.Sprintf("foo.%d", i), Value: []byte("1")}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// every revision after the watched one is sent in order
+	for rev := uint64(2); rev <= writes+1; rev++ {
+		evt := <-srv.events
+		if evt.Revision != rev {
+			t.Fatalf("expected revision %d; received %d", rev, evt.Revision)
+		}
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
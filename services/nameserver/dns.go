@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
 	api "github.com/ehazlett/stellar/api/services/nameserver/v1"
 	"github.com/ehazlett/stellar/api/types"
 	"github.com/miekg/dns"
@@ -55,15 +56,12 @@ func (s *service) gateway() (net.IP, error) {
 		return nil, fmt.Errorf("unable to detect subnet for node")
 	}
 	logrus.Debugf("gateway cidr: %s", subnetCIDR)
-	ip, ipnet, err := net.ParseCIDR(subnetCIDR)
+	_, ipnet, err := net.ParseCIDR(subnetCIDR)
 	if err != nil {
 		return nil, err
 	}
 
-	gw := ip.Mask(ipnet.Mask)
-	gw[3]++
-
-	return gw, nil
+	return stellar.Gateway(ipnet), nil
 }
 
 func (s *service) handler(w dns.ResponseWriter, r *dns.Msg) {
@@ -119,6 +117,17 @@ func (s *service) handler(w dns.ResponseWriter, r *dns.Msg) {
 				},
 				A: ip,
 			}
+		case api.RecordType_AAAA:
+			ip := net.ParseIP(string(record.Value))
+			rr = &dns.AAAA{
+				Hdr: dns.RR_Header{
+					Name:   fqdn(name),
+					Rrtype: dns.TypeAAAA,
+					Class:  dns.ClassINET,
+					Ttl:    0,
+				},
+				AAAA: ip,
+			}
 		case api.RecordType_CNAME:
 			rr = &dns.CNAME{
 				Hdr: dns.RR_Header{
@@ -0,0 +1,398 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ehazlett/stellar"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// gcInterval is the interval between garbage collections
+	gcInterval = time.Minute * 5
+	// gcGracePeriod is how long an allocation is orphaned before it is
+	// released by default
+	gcGracePeriod = time.Minute * 10
+)
+
+var (
+	dsGCKeyPrefix = "gc."
+	// format: gc.ip.<network>.<node>.<id>
+	dsGCIPKey = "gc.ip.%s.%s.%s"
+	// format: gc.subnet.<node>
+	dsGCSubnetKey = "gc.subnet.%s"
+	// format: gc.reservation.<network>.<node>.<ip>
+	dsGCReservationKey = "gc.reservation.%s.%s.%s"
+)
+
+// GC releases the ip allocations and reservations without a live container
+// and the subnets of nodes that are no longer in the cluster.  Orphaned allocations are recorded
+// when first found and only released once they have been orphaned for the
+// grace period so allocations of containers that are being created or nodes
+// that are restarting are not released.
+func (s *service) GC(ctx context.Context, req *api.GCRequest) (*api.GCResponse, error) {
+	gracePeriod := gcGracePeriod
+	if req.GracePeriod != nil {
+		d, err := ptypes.DurationFromProto(req.GracePeriod)
+		if err != nil || d < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid grace period %s", req.GracePeriod)
+		}
+		gracePeriod = d
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if !req.DryRun {
+		// serialize with subnet allocation and the collectors on other nodes
+		owner := fmt.Sprintf("%s.%d", s.agent.Self().ID, time.Now().UnixNano())
+		lease, err := c.Datastore().AcquireLock(subnetLockName, owner, subnetLockTTL, subnetLockTimeout)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := c.Datastore().ReleaseLock(lease); err != nil {
+				logrus.Warnf("error releasing subnet lock: %s", err)
+			}
+		}()
+	}
+
+	// an error listing the containers of any node aborts the collection so
+	// allocations of unreachable nodes are not released
+	nodes, err := c.Cluster().Nodes()
+	if err != nil {
+		return nil, err
+	}
+	containers, err := c.Cluster().Containers()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]bool{}
+	for _, n := range nodes {
+		live[n.ID] = true
+	}
+	running := map[string]bool{}
+	for _, ctr := range containers {
+		if ctr.Node != nil {
+			running[ctr.Node.ID+"/"+ctr.Container.ID] = true
+		}
+	}
+
+	ips, err := s.IPs(ctx, &api.IPsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	subnets, err := s.nodeSubnets(c)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.reservations(c)
+	if err != nil {
+		return nil, err
+	}
+	orphans := findOrphans(ips.IPs, reservations, subnets, live, running)
+
+	marks := map[string]time.Time{}
+	results, err := c.Datastore().Search(dsNetworkBucketName, dsGCKeyPrefix)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	for _, kv := range results {
+		t, err := time.Parse(time.RFC3339Nano, string(kv.Value))
+		if err != nil {
+			logrus.Warnf("invalid gc mark %s: %s", kv.Key, err)
+			continue
+		}
+		marks[kv.Key] = t
+	}
+
+	now := time.Now()
+	current := map[string]bool{}
+	for _, o := range orphans {
+		key := orphanKey(o)
+		current[key] = true
+		orphanedAt, ok := marks[key]
+		if !ok {
+			orphanedAt = now
+			if !req.DryRun {
+				if err := c.Datastore().Set(dsNetworkBucketName, key, []byte(now.Format(time.RFC3339Nano)), true); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if o.OrphanedAt, err = ptypes.TimestampProto(orphanedAt); err != nil {
+			return nil, err
+		}
+		if now.Sub(orphanedAt) < gracePeriod {
+			continue
+		}
+		o.Released = true
+		if req.DryRun {
+			continue
+		}
+		if err := s.releaseOrphan(ctx, o); err != nil {
+			return nil, err
+		}
+		if err := c.Datastore().Delete(dsNetworkBucketName, key, true); err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{
+			"kind":    strings.ToLower(o.Kind.String()),
+			"network": o.Network,
+			"node":    o.Node,
+			"id":      o.ID,
+			"address": o.Address,
+		}).Info("released orphaned allocation")
+	}
+
+	if !req.DryRun {
+		// allocations that are in use again are no longer orphaned
+		for key := range marks {
+			if current[key] {
+				continue
+			}
+			if err := c.Datastore().Delete(dsNetworkBucketName, key, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &api.GCResponse{
+		Orphans: orphans,
+	}, nil
+}
+
+// gcMonitor periodically releases orphaned allocations
+func (s *service) gcMonitor() {
+	t := time.NewTicker(gcInterval)
+	for range t.C {
+		if _, err := s.GC(context.Background(), &api.GCRequest{}); err != nil {
+			logrus.WithError(err).Warn("error collecting orphaned network allocations")
+		}
+	}
+}
+
+// releaseOrphan releases the orphaned ip, reservation or subnet.  The routes
+// for the subnets of the node in all networks are removed with the node
+// subnet.
+func (s *service) releaseOrphan(ctx context.Context, o *api.Orphan) error {
+	if o.Kind == api.OrphanKind_IP {
+		_, err := s.ReleaseIP(ctx, &api.ReleaseIPRequest{
+			ID:      o.ID,
+			Node:    o.Node,
+			Network: o.Network,
+		})
+		return err
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if o.Kind == api.OrphanKind_RESERVATION {
+		// the address may have been reserved again in the meantime
+		key := fmt.Sprintf(networkIPKeys(o.Network).reservations, o.Node, o.Address)
+		_, err := c.Datastore().Txn([]*datastoreapi.Compare{
+			datastoreapi.CompareValue(dsNetworkBucketName, key, []byte(o.ID)),
+		}, []*datastoreapi.TxnOp{
+			datastoreapi.OpDelete(dsNetworkBucketName, key),
+		}, nil, true)
+		return err
+	}
+
+	cidrs := map[string]bool{o.Address: true}
+	subnet6, err := s.subnet6(o.Address)
+	if err != nil {
+		return err
+	}
+	cidrs[subnet6] = true
+	networks, err := s.networks(c)
+	if err != nil {
+		return err
+	}
+	for _, n := range networks {
+		subnets, err := s.networkSubnet(c, n.Name, o.Address)
+		if err != nil {
+			return err
+		}
+		cidrs[subnets.SubnetCIDR] = true
+		cidrs[subnets.Subnet6CIDR] = true
+	}
+
+	routes, err := s.Routes(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes.Routes {
+		if !cidrs[r.CIDR] {
+			continue
+		}
+		if err := deleteRoute(c, r.CIDR, ""); err != nil {
+			return err
+		}
+	}
+	return c.Datastore().Delete(dsNetworkBucketName, fmt.Sprintf(dsSubnetsKey, o.Node), true)
+}
+
+// nodeSubnets returns the assigned subnets by node
+func (s *service) nodeSubnets(c *client.Client) (map[string]string, error) {
+	searchKey := fmt.Sprintf(dsSubnetsKey, "")
+	results, err := c.Datastore().Search(dsNetworkBucketName, searchKey)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	subnets := make(map[string]string, len(results))
+	for _, kv := range results {
+		subnets[strings.TrimPrefix(kv.Key, searchKey)] = string(kv.Value)
+	}
+	return subnets, nil
+}
+
+// reservations returns the reserved addresses in all networks
+func (s *service) reservations(c *client.Client) ([]*api.IPAllocation, error) {
+	networks, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{stellar.DefaultNetworkName}
+	for _, n := range networks {
+		names = append(names, n.Name)
+	}
+	var reservations []*api.IPAllocation
+	for _, network := range names {
+		prefix := keyPrefix(networkIPKeys(network).reservations)
+		results, err := c.Datastore().Search(dsNetworkBucketName, prefix)
+		if err != nil {
+			err = errdefs.FromGRPC(err)
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		for _, kv := range results {
+			p := strings.SplitN(strings.TrimPrefix(kv.Key, prefix), ".", 2)
+			if len(p) < 2 {
+				logrus.Errorf("unexpected reservation key format: %s", kv.Key)
+				continue
+			}
+			r := &api.IPAllocation{
+				ID:      string(kv.Value),
+				Node:    p[0],
+				Network: network,
+			}
+			if strings.Contains(p[1], ":") {
+				r.IP6 = p[1]
+			} else {
+				r.IP = p[1]
+			}
+			reservations = append(reservations, r)
+		}
+	}
+	return reservations, nil
+}
+
+// findOrphans returns the ip allocations and reservations without a running
+// container and the subnets of nodes that are not in the cluster sorted by
+// kind, network, node and id.  Containers are keyed by <node>/<id>.
+func findOrphans(allocations, reservations []*api.IPAllocation, subnets map[string]string, nodes, containers map[string]bool) []*api.Orphan {
+	var orphans []*api.Orphan
+	for _, a := range allocations {
+		if nodes[a.Node] && containers[a.Node+"/"+a.ID] {
+			continue
+		}
+		var addrs []string
+		for _, ip := range []string{a.IP, a.IP6} {
+			if ip != "" {
+				addrs = append(addrs, ip)
+			}
+		}
+		network := a.Network
+		if network == "" {
+			network = stellar.DefaultNetworkName
+		}
+		orphans = append(orphans, &api.Orphan{
+			Kind:    api.OrphanKind_IP,
+			Network: network,
+			Node:    a.Node,
+			ID:      a.ID,
+			Address: strings.Join(addrs, ","),
+		})
+	}
+	for _, r := range reservations {
+		if nodes[r.Node] && containers[r.Node+"/"+r.ID] {
+			continue
+		}
+		address := r.IP
+		if address == "" {
+			address = r.IP6
+		}
+		orphans = append(orphans, &api.Orphan{
+			Kind:    api.OrphanKind_RESERVATION,
+			Network: r.Network,
+			Node:    r.Node,
+			ID:      r.ID,
+			Address: address,
+		})
+	}
+	for node, cidr := range subnets {
+		if nodes[node] {
+			continue
+		}
+		orphans = append(orphans, &api.Orphan{
+			Kind:    api.OrphanKind_SUBNET,
+			Network: stellar.DefaultNetworkName,
+			Node:    node,
+			Address: cidr,
+		})
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		a, b := orphans[i], orphans[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.Node != b.Node {
+			return a.Node < b.Node
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Address < b.Address
+	})
+	return orphans
+}
+
+// orphanKey returns the datastore key recording when the allocation was
+// first found orphaned
+func orphanKey(o *api.Orphan) string {
+	switch o.Kind {
+	case api.OrphanKind_SUBNET:
+		return fmt.Sprintf(dsGCSubnetKey, o.Node)
+	case api.OrphanKind_RESERVATION:
+		return fmt.Sprintf(dsGCReservationKey, o.Network, o.Node, o.Address)
+	}
+	return fmt.Sprintf(dsGCIPKey, o.Network, o.Node, o.ID)
+}
@@ -0,0 +1,55 @@
+package network
+
+import (
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+)
+
+func TestFindOrphans(t *testing.T) {
+	allocations := []*api.IPAllocation{
+		{ID: "app.0", Node: "node-00", IP: "172.16.0.2", IP6: "fd00::2"},
+		{ID: "app.1", Node: "node-00", IP: "172.16.0.3"},
+		{ID: "app.2", Node: "node-01", IP: "172.16.4.2"},
+		{ID: "app.0", Node: "node-00", Network: "backend", IP: "10.1.0.2"},
+	}
+	reservations := []*api.IPAllocation{
+		{ID: "app.0", Node: "node-00", Network: "stellar", IP: "172.16.0.2"},
+		{ID: "db.0", Node: "node-00", Network: "stellar", IP: "172.16.0.10"},
+		{ID: "db.0", Node: "node-00", Network: "stellar", IP6: "fd00::10"},
+	}
+	subnets := map[string]string{
+		"node-00": "172.16.0.0/22",
+		"node-01": "172.16.4.0/22",
+	}
+	nodes := map[string]bool{"node-00": true}
+	containers := map[string]bool{"node-00/app.0": true}
+
+	orphans := findOrphans(allocations, reservations, subnets, nodes, containers)
+	expected := []struct {
+		kind    api.OrphanKind
+		network string
+		node    string
+		id      string
+		address string
+		key     string
+	}{
+		{api.OrphanKind_IP, "stellar", "node-00", "app.1", "172.16.0.3", "gc.ip.stellar.node-00.app.1"},
+		{api.OrphanKind_IP, "stellar", "node-01", "app.2", "172.16.4.2", "gc.ip.stellar.node-01.app.2"},
+		{api.OrphanKind_SUBNET, "stellar", "node-01", "", "172.16.4.0/22", "gc.subnet.node-01"},
+		{api.OrphanKind_RESERVATION, "stellar", "node-00", "db.0", "172.16.0.10", "gc.reservation.stellar.node-00.172.16.0.10"},
+		{api.OrphanKind_RESERVATION, "stellar", "node-00", "db.0", "fd00::10", "gc.reservation.stellar.node-00.fd00::10"},
+	}
+	if len(orphans) != len(expected) {
+		t.Fatalf("expected %d orphans; received %d: %+v", len(expected), len(orphans), orphans)
+	}
+	for i, e := range expected {
+		o := orphans[i]
+		if o.Kind != e.kind || o.Network != e.network || o.Node != e.node || o.ID != e.id || o.Address != e.address {
+			t.Fatalf("unexpected orphan %d: %+v", i, o)
+		}
+		if key := orphanKey(o); key != e.key {
+			t.Fatalf("expected key %s; received %s", e.key, key)
+		}
+	}
+}
@@ -8,6 +8,10 @@ import (
 
 // adapted from https://github.com/kubernetes/kops/blob/master/upup/pkg/fi/cloudup/subnets.go
 func divideSubnet(sub *net.IPNet, maxSubnets int) ([]*net.IPNet, error) {
+	if sub.IP.To4() == nil {
+		return divideSubnet6(sub, maxSubnets)
+	}
+
 	subnetSize, _ := sub.Mask.Size()
 	length := subnetSize + subnetMaskBits
 
@@ -37,3 +41,32 @@ func divideSubnet(sub *net.IPNet, maxSubnets int) ([]*net.IPNet, error) {
 
 	return subnets, nil
 }
+
+// divideSubnet6 divides the ipv6 network the same way as ipv4 networks.  The
+// node subnets must be at least a /64 so only the network prefix is changed.
+func divideSubnet6(sub *net.IPNet, maxSubnets int) ([]*net.IPNet, error) {
+	ip6 := sub.IP.To16()
+	subnetSize, bits := sub.Mask.Size()
+	if ip6 == nil || bits != 128 {
+		return nil, fmt.Errorf("unexpected IP address type: %s", sub)
+	}
+	length := subnetSize + subnetMaskBits
+	if length > 64 {
+		return nil, fmt.Errorf("ipv6 subnet must be /%d or larger", 64-subnetMaskBits)
+	}
+
+	var subnets []*net.IPNet
+	for i := 0; i < maxSubnets; i++ {
+		n := binary.BigEndian.Uint64(ip6[:8])
+		n += uint64(i) << uint(64-length)
+		subnetIP := make(net.IP, net.IPv6len)
+		binary.BigEndian.PutUint64(subnetIP, n)
+
+		subnets = append(subnets, &net.IPNet{
+			IP:   subnetIP,
+			Mask: net.CIDRMask(length, 128),
+		})
+	}
+
+	return subnets, nil
+}
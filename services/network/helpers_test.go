@@ -286,3 +286,44 @@ func TestNetworkSubdivide192Slash24(t *testing.T) {
 		t.Fatal("expected error for /24 subnet")
 	}
 }
+
+func TestNetworkSubdivideIPv6Slash48(t *testing.T) {
+	_, ipnet, err := net.ParseCIDR("fd00:5354::/48")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxSubnets := 1024
+
+	subnets, err := divideSubnet(ipnet, maxSubnets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := len(subnets); v != maxSubnets {
+		t.Fatalf("expected %d subnets; received %d", maxSubnets, v)
+	}
+	if v := subnets[1].String(); v != "fd00:5354:0:40::/58" {
+		t.Fatalf("expected second subnet fd00:5354:0:40::/58; received %s", v)
+	}
+	testSub2 := subnets[len(subnets)-1]
+	testIP2 := net.ParseIP("fd00:5354:0:ffff::42")
+
+	if !testSub2.Contains(testIP2) {
+		t.Fatalf("expected ip %s in subnet %s", testIP2.String(), testSub2)
+	}
+}
+
+func TestNetworkSubdivideIPv6Slash56(t *testing.T) {
+	_, ipnet, err := net.ParseCIDR("fd00:5354::/56")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxSubnets := 1024
+
+	_, err = divideSubnet(ipnet, maxSubnets)
+	if err == nil {
+		t.Fatal("expected error for /56 subnet")
+	}
+}
@@ -0,0 +1,274 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Inspect compares the subnets, routes and ip allocations in the datastore
+// with the network state reported by each node.  Nodes that cannot be reached
+// are reported as issues.
+func (s *service) Inspect(ctx context.Context, _ *ptypes.Empty) (*api.InspectResponse, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	nodes, err := c.Cluster().Nodes()
+	if err != nil {
+		return nil, err
+	}
+	routes, err := s.Routes(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// routes of departed nodes are removed by reconcile
+	members := map[string]bool{}
+	for _, node := range nodes {
+		members[node.ID] = true
+	}
+	active := api.MemberRoutes(routes.Routes, members)
+	subnets, err := s.nodeSubnets(c)
+	if err != nil {
+		return nil, err
+	}
+	named, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	networks := append([]*api.ClusterNetwork{s.defaultNetwork()}, named...)
+	ips, err := s.IPs(ctx, &api.IPsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.InspectResponse{}
+	for _, node := range nodes {
+		state, err := s.nodeState(node.Address)
+		if err != nil {
+			resp.Issues = append(resp.Issues, &api.NetworkIssue{
+				Kind:    api.IssueKind_UNREACHABLE,
+				Node:    node.ID,
+				Message: fmt.Sprintf("unable to get network state: %s", err),
+			})
+			continue
+		}
+		resp.Nodes = append(resp.Nodes, state)
+
+		gateways, err := s.nodeGateways(networks, subnets[node.ID])
+		if err != nil {
+			return nil, err
+		}
+		resp.Issues = append(resp.Issues, inspectNode(node.ID, state, active, gateways)...)
+	}
+	resp.Issues = append(resp.Issues, duplicateIPs(ips.IPs)...)
+
+	return resp, nil
+}
+
+// nodeState returns the network state of the node
+func (s *service) nodeState(address string) (*api.NodeState, error) {
+	nc, err := s.client(address)
+	if err != nil {
+		return nil, err
+	}
+	defer nc.Close()
+
+	return nc.Network().State()
+}
+
+// nodeGateways returns the gateway addresses of the node subnets by network
+func (s *service) nodeGateways(networks []*api.ClusterNetwork, subnetCIDR string) (map[string][]string, error) {
+	gateways := map[string][]string{}
+	if subnetCIDR == "" {
+		return gateways, nil
+	}
+	for _, n := range networks {
+		for _, cidr := range []string{n.SubnetCIDR, n.Subnet6CIDR} {
+			if cidr == "" {
+				continue
+			}
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			subnet, err := s.pairedSubnet(network, subnetCIDR)
+			if err != nil {
+				return nil, err
+			}
+			if subnet == "" {
+				continue
+			}
+			_, ipnet, err := net.ParseCIDR(subnet)
+			if err != nil {
+				return nil, err
+			}
+			gw := &net.IPNet{IP: stellar.Gateway(ipnet), Mask: ipnet.Mask}
+			gateways[n.Name] = append(gateways[n.Name], gw.String())
+		}
+	}
+	return gateways, nil
+}
+
+// inspectNode returns the routes that are missing or stale on the node and
+// the bridges without the gateway addresses of the node subnets.  Routes to
+// the subnets of other nodes are via the node address or, in vxlan mode, via
+// the subnet gateway on the bridge.
+func inspectNode(node string, state *api.NodeState, routes []*api.Route, gateways map[string][]string) []*api.NetworkIssue {
+	var issues []*api.NetworkIssue
+	issue := func(kind api.IssueKind, network, format string, args ...interface{}) {
+		issues = append(issues, &api.NetworkIssue{
+			Kind:    kind,
+			Node:    node,
+			Network: network,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	local := map[string]bool{}
+	for _, addr := range state.Addresses {
+		local[addr] = true
+	}
+	expected := map[string]string{}
+	for _, r := range routes {
+		target := net.ParseIP(r.Target)
+		_, ipnet, err := net.ParseCIDR(r.CIDR)
+		if err != nil || target == nil || local[target.String()] {
+			continue
+		}
+		gw := target
+		if state.NetworkMode == stellar.NetworkModeVXLAN {
+			gw = stellar.Gateway(ipnet)
+		} else if (ipnet.IP.To4() == nil) != (target.To4() == nil) {
+			continue
+		}
+		expected[ipnet.String()] = gw.String()
+	}
+	kernel := map[string]string{}
+	for _, r := range state.Routes {
+		if r.Gateway != "" {
+			kernel[r.CIDR] = r.Gateway
+		}
+	}
+
+	for _, cidr := range sortedKeys(expected) {
+		gw, ok := kernel[cidr]
+		switch {
+		case !ok:
+			issue(api.IssueKind_MISSING_ROUTE, "", "route %s via %s is missing", cidr, expected[cidr])
+		case gw != expected[cidr]:
+			issue(api.IssueKind_STALE_ROUTE, "", "route %s is via %s; expected %s", cidr, gw, expected[cidr])
+		}
+	}
+	for _, cidr := range sortedKeys(kernel) {
+		if _, ok := expected[cidr]; !ok {
+			issue(api.IssueKind_STALE_ROUTE, "", "route %s via %s is not in the datastore", cidr, kernel[cidr])
+		}
+	}
+
+	for _, b := range state.Bridges {
+		want := gateways[b.Network]
+		if !b.Exists {
+			if len(want) > 0 {
+				issue(api.IssueKind_BRIDGE_ADDRESS, b.Network, "bridge %s is missing", b.Name)
+			}
+			continue
+		}
+		addrs := map[string]bool{}
+		for _, addr := range b.Addresses {
+			addrs[addr] = true
+		}
+		wanted := map[string]bool{}
+		for _, gw := range want {
+			wanted[gw] = true
+			if !addrs[gw] {
+				issue(api.IssueKind_BRIDGE_ADDRESS, b.Network, "bridge %s is missing gateway %s", b.Name, gw)
+			}
+		}
+		for _, addr := range b.Addresses {
+			if !wanted[addr] {
+				issue(api.IssueKind_BRIDGE_ADDRESS, b.Network, "bridge %s has unexpected address %s", b.Name, addr)
+			}
+		}
+	}
+
+	return issues
+}
+
+// duplicateIPs returns the addresses allocated more than once in a network
+func duplicateIPs(allocations []*api.IPAllocation) []*api.NetworkIssue {
+	owners := map[string][]string{}
+	for _, a := range allocations {
+		for _, ip := range []string{a.IP, a.IP6} {
+			if ip == "" {
+				continue
+			}
+			key := a.Network + "/" + ip
+			owners[key] = append(owners[key], a.Node+"/"+a.ID)
+		}
+	}
+	keys := make([]string, 0, len(owners))
+	for key := range owners {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var issues []*api.NetworkIssue
+	for _, key := range keys {
+		if len(owners[key]) < 2 {
+			continue
+		}
+		p := strings.SplitN(key, "/", 2)
+		issues = append(issues, &api.NetworkIssue{
+			Kind:    api.IssueKind_DUPLICATE_IP,
+			Network: p[0],
+			Message: fmt.Sprintf("ip %s is allocated to %s", p[1], strings.Join(owners[key], ", ")),
+		})
+	}
+	return issues
+}
+
+// containerBandwidths returns the bandwidth limits of the service replicas
+func containerBandwidths(containers []*runtimeapi.Container) []*api.ContainerBandwidth {
+	var bandwidths []*api.ContainerBandwidth
+	for _, ct := range containers {
+		ext, ok := ct.Extensions[stellar.StellarServiceExtension]
+		if !ok {
+			continue
+		}
+		v, err := typeurl.UnmarshalAny(ext)
+		if err != nil {
+			logrus.WithError(err).Warnf("error reading service of container %s", ct.ID)
+			continue
+		}
+		svc, ok := v.(*runtimeapi.Service)
+		if !ok || svc.Bandwidth == nil || (svc.Bandwidth.Ingress == 0 && svc.Bandwidth.Egress == 0) {
+			continue
+		}
+		bandwidths = append(bandwidths, &api.ContainerBandwidth{
+			ID:      ct.ID,
+			Ingress: svc.Bandwidth.Ingress,
+			Egress:  svc.Bandwidth.Egress,
+		})
+	}
+	return bandwidths
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,120 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	ptypes "github.com/gogo/protobuf/types"
+)
+
+func TestInspectNode(t *testing.T) {
+	routes := []*api.Route{
+		{CIDR: "172.16.0.0/22", Target: "10.0.1.70"},
+		{CIDR: "172.16.4.0/22", Target: "10.0.1.71"},
+		{CIDR: "172.16.8.0/22", Target: "10.0.1.72"},
+	}
+	state := &api.NodeState{
+		NodeID:      "node-00",
+		NetworkMode: stellar.NetworkModeRouted,
+		Addresses:   []string{"10.0.1.70"},
+		Routes: []*api.NodeRoute{
+			{CIDR: "172.16.0.0/22", Device: "stellar0"},
+			{CIDR: "172.16.8.0/22", Gateway: "10.0.1.99"},
+			{CIDR: "172.16.12.0/22", Gateway: "10.0.1.73"},
+		},
+		Bridges: []*api.NodeBridge{
+			{Name: "stellar0", Network: "stellar", Exists: true, Addresses: []string{"172.16.0.1/22", "172.16.0.9/22"}},
+			{Name: "stellar-db", Network: "db"},
+		},
+	}
+	gateways := map[string][]string{
+		"stellar": {"172.16.0.1/22"},
+		"db":      {"10.2.0.1/24"},
+	}
+
+	issues := inspectNode("node-00", state, routes, gateways)
+	expected := []api.IssueKind{
+		api.IssueKind_MISSING_ROUTE,
+		api.IssueKind_STALE_ROUTE,
+		api.IssueKind_STALE_ROUTE,
+		api.IssueKind_BRIDGE_ADDRESS,
+		api.IssueKind_BRIDGE_ADDRESS,
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues; received %+v", len(expected), issues)
+	}
+	for i, kind := range expected {
+		if issues[i].Kind != kind || issues[i].Node != "node-00" {
+			t.Fatalf("expected %s issue; received %+v", kind, issues[i])
+		}
+	}
+
+	state.Routes = []*api.NodeRoute{
+		{CIDR: "172.16.4.0/22", Gateway: "10.0.1.71"},
+		{CIDR: "172.16.8.0/22", Gateway: "10.0.1.72"},
+	}
+	state.Bridges = state.Bridges[:1]
+	state.Bridges[0].Addresses = []string{"172.16.0.1/22"}
+	if issues := inspectNode("node-00", state, routes, gateways); len(issues) != 0 {
+		t.Fatalf("expected no issues; received %+v", issues)
+	}
+}
+
+func TestInspectNodeVXLAN(t *testing.T) {
+	routes := []*api.Route{
+		{CIDR: "172.16.4.0/22", Target: "10.0.1.71"},
+	}
+	state := &api.NodeState{
+		NetworkMode: stellar.NetworkModeVXLAN,
+		Addresses:   []string{"10.0.1.70"},
+		Routes: []*api.NodeRoute{
+			{CIDR: "172.16.4.0/22", Gateway: "172.16.4.1"},
+		},
+	}
+	if issues := inspectNode("node-00", state, routes, nil); len(issues) != 0 {
+		t.Fatalf("expected no issues; received %+v", issues)
+	}
+}
+
+func TestDuplicateIPs(t *testing.T) {
+	issues := duplicateIPs([]*api.IPAllocation{
+		{ID: "app.web.0", Node: "node-00", IP: "172.16.0.2"},
+		{ID: "app.web.1", Node: "node-01", IP: "172.16.0.2"},
+		{ID: "app.db.0", Node: "node-00", IP: "172.16.0.2", Network: "db"},
+		{ID: "app.db.1", Node: "node-01", IP: "172.16.0.3", Network: "db"},
+	})
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 duplicate; received %+v", issues)
+	}
+	if issues[0].Kind != api.IssueKind_DUPLICATE_IP || issues[0].Message != "ip 172.16.0.2 is allocated to node-00/app.web.0, node-01/app.web.1" {
+		t.Fatalf("unexpected issue %+v", issues[0])
+	}
+}
+
+func TestContainerBandwidths(t *testing.T) {
+	var containers []*runtimeapi.Container
+	for id, bandwidth := range map[string]*runtimeapi.Bandwidth{
+		"app.web.0": {Ingress: 10000000},
+		"app.db.0":  nil,
+	} {
+		ext, err := typeurl.MarshalAny(&runtimeapi.Service{Bandwidth: bandwidth})
+		if err != nil {
+			t.Fatal(err)
+		}
+		containers = append(containers, &runtimeapi.Container{
+			ID: id,
+			Extensions: map[string]*ptypes.Any{
+				stellar.StellarServiceExtension: ext,
+			},
+		})
+	}
+	containers = append(containers, &runtimeapi.Container{ID: "other"})
+
+	bandwidths := containerBandwidths(containers)
+	if len(bandwidths) != 1 || bandwidths[0].ID != "app.web.0" || bandwidths[0].Ingress != 10000000 || bandwidths[0].Egress != 0 {
+		t.Fatalf("unexpected bandwidths %+v", bandwidths)
+	}
+}
@@ -5,20 +5,80 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/ehazlett/stellar"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
 	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	ErrNoAvailableIP = errors.New("IP allocation exhausted")
 	// format: ips.<node>.<id>
 	dsIPsKey = "ips.%s.%s"
+	// format: ips6.<node>.<id>
+	dsIPs6Key = "ips6.%s.%s"
+	// format: ipowners.<node>.<ip>
+	dsIPOwnersKey = "ipowners.%s.%s"
+	// static and pool addresses are reserved for the id on the node
+	// format: reservations.<node>.<ip>
+	dsReservationsKey = "reservations.%s.%s"
+	// named network allocations are prefixed with the network
+	// format: net<key>.<network>.<node>.<id|ip>
+	dsNetworkIPKeyPrefix = "net%s.%s."
 )
 
+// ipKeys are the datastore key formats for the allocations in a network
+type ipKeys struct {
+	ips          string
+	ips6         string
+	owners       string
+	reservations string
+}
+
+// networkIPKeys returns the allocation key formats for the network
+func networkIPKeys(network string) ipKeys {
+	if isDefaultNetwork(network) {
+		return ipKeys{
+			ips:          dsIPsKey,
+			ips6:         dsIPs6Key,
+			owners:       dsIPOwnersKey,
+			reservations: dsReservationsKey,
+		}
+	}
+	return ipKeys{
+		ips:          fmt.Sprintf(dsNetworkIPKeyPrefix, "ips", network) + "%s.%s",
+		ips6:         fmt.Sprintf(dsNetworkIPKeyPrefix, "ips6", network) + "%s.%s",
+		owners:       fmt.Sprintf(dsNetworkIPKeyPrefix, "ipowners", network) + "%s.%s",
+		reservations: fmt.Sprintf(dsNetworkIPKeyPrefix, "reservations", network) + "%s.%s",
+	}
+}
+
+// keyPrefix returns the static prefix of the key format
+func keyPrefix(keyFormat string) string {
+	return strings.SplitN(keyFormat, "%s", 2)[0]
+}
+
+// family returns the allocation key format for the address family
+func (k ipKeys) family(ip net.IP) string {
+	if ip.To4() == nil {
+		return k.ips6
+	}
+	return k.ips
+}
+
+// AllocateIP allocates an address for the id from the node subnet.  A
+// specific address or an address from a reservation pool is reserved for the
+// id on the node so the id gets the same address when it is restarted or
+// rescheduled to the node.  Dynamic allocations skip reserved and pool
+// addresses.
 func (s *service) AllocateIP(ctx context.Context, req *api.AllocateIPRequest) (*api.AllocateIPResponse, error) {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
@@ -26,7 +86,15 @@ func (s *service) AllocateIP(ctx context.Context, req *api.AllocateIPRequest) (*
 	}
 	defer c.Close()
 
-	reservedIPs, err := s.getIPs(ctx, req.Node)
+	ip, ipnet, err := net.ParseCIDR(req.SubnetCIDR)
+	if err != nil {
+		return nil, err
+	}
+	// ipv6 addresses are allocated separately so an id can have one of each
+	keys := networkIPKeys(req.Network)
+	ipsKey := keys.family(ip)
+
+	reservedIPs, err := s.getIPs(ctx, ipsKey, req.Node)
 	if err != nil {
 		return nil, err
 	}
@@ -42,27 +110,89 @@ func (s *service) AllocateIP(ctx context.Context, req *api.AllocateIPRequest) (*
 	for id, ip := range reservedIPs {
 		lookup[ip.String()] = id
 	}
+	reservations, err := s.getReservations(c, keys.reservations, req.Node)
+	if err != nil {
+		return nil, err
+	}
+	network := req.Network
+	if isDefaultNetwork(network) {
+		network = stellar.DefaultNetworkName
+	}
+	pools, err := s.pools(c)
+	if err != nil {
+		return nil, err
+	}
+	var networkPools []*api.IPPool
+	for _, p := range pools {
+		if p.Network == network {
+			networkPools = append(networkPools, p)
+		}
+	}
 
-	logrus.Debugf("allocating ip for %s", req.ID)
-	ip, ipnet, err := net.ParseCIDR(req.SubnetCIDR)
+	candidates, reserve, err := allocationCandidates(req, ipnet, networkPools, reservations, lookup)
 	if err != nil {
 		return nil, err
 	}
+	next := ipIterator(candidates)
+	if !reserve {
+		pooled, err := pooledIPs(networkPools, ipnet)
+		if err != nil {
+			return nil, err
+		}
+		next = dynamicCandidates(ipnet, lookup, reservations, pooled)
+	}
 
-	for ip := ip.Mask(ipnet.Mask); ipnet.Contains(ip); nextIP(ip) {
-		// filter out network, gateway and broadcast
-		if !validIP(ip) {
-			continue
+	logrus.Debugf("allocating ip for %s", req.ID)
+	ipKey := fmt.Sprintf(ipsKey, req.Node, req.ID)
+	logrus.Debugf("ip key: %s", ipKey)
+	for ip := next(); ip != nil; ip = next() {
+		// reserve the ip only if neither the ip nor the id were allocated
+		// concurrently
+		ownerKey := fmt.Sprintf(keys.owners, req.Node, ip.String())
+		compares := []*datastoreapi.Compare{
+			datastoreapi.CompareMissing(dsNetworkBucketName, ipKey),
+			datastoreapi.CompareMissing(dsNetworkBucketName, ownerKey),
 		}
-		if _, exists := lookup[ip.String()]; exists {
-			// ip already reserved
-			continue
+		ops := []*datastoreapi.TxnOp{
+			datastoreapi.OpPut(dsNetworkBucketName, ipKey, []byte(ip.String())),
+			datastoreapi.OpPut(dsNetworkBucketName, ownerKey, []byte(req.ID)),
 		}
-		ipKey := fmt.Sprintf(dsIPsKey, req.Node, req.ID)
-		logrus.Debugf("ip key: %s", ipKey)
-		if err := c.Datastore().Set(dsNetworkBucketName, ipKey, []byte(ip.String()), true); err != nil {
+		if reserve {
+			reservationKey := fmt.Sprintf(keys.reservations, req.Node, ip.String())
+			if reservations[ip.String()] == req.ID {
+				compares = append(compares, datastoreapi.CompareValue(dsNetworkBucketName, reservationKey, []byte(req.ID)))
+			} else {
+				compares = append(compares, datastoreapi.CompareMissing(dsNetworkBucketName, reservationKey))
+			}
+			ops = append(ops, datastoreapi.OpPut(dsNetworkBucketName, reservationKey, []byte(req.ID)))
+			// previous reservations of the id in the family are replaced
+			for addr, id := range reservations {
+				if id != req.ID || addr == ip.String() || (net.ParseIP(addr).To4() == nil) != (ip.To4() == nil) {
+					continue
+				}
+				ops = append(ops, datastoreapi.OpDelete(dsNetworkBucketName, fmt.Sprintf(keys.reservations, req.Node, addr)))
+			}
+		}
+		resp, err := c.Datastore().Txn(compares, ops, nil, true)
+		if err != nil {
 			return nil, err
 		}
+		if !resp.Succeeded {
+			existing, err := c.Datastore().Get(dsNetworkBucketName, ipKey)
+			if err != nil {
+				err = errdefs.FromGRPC(err)
+				if !errdefs.IsNotFound(err) {
+					return nil, err
+				}
+			}
+			if len(existing) > 0 {
+				return &api.AllocateIPResponse{
+					IP:   string(existing),
+					Node: req.Node,
+				}, nil
+			}
+			continue
+		}
 
 		logrus.Debugf("ip for %s: %s", req.ID, ip.String())
 		return &api.AllocateIPResponse{
@@ -71,9 +201,129 @@ func (s *service) AllocateIP(ctx context.Context, req *api.AllocateIPRequest) (*
 		}, nil
 	}
 
+	switch {
+	case req.IP != "":
+		return nil, status.Errorf(codes.FailedPrecondition, "ip %s is not available", req.IP)
+	case reserve:
+		return nil, status.Errorf(codes.ResourceExhausted, "no available ip in pool %s on node %s", req.Pool, req.Node)
+	}
 	return nil, ErrNoAvailableIP
 }
 
+// ipIterator returns an iterator over the addresses; nil is returned once
+// the addresses are exhausted
+func ipIterator(ips []net.IP) func() net.IP {
+	return func() net.IP {
+		if len(ips) == 0 {
+			return nil
+		}
+		ip := ips[0]
+		ips = ips[1:]
+		return ip
+	}
+}
+
+// dynamicCandidates returns an iterator over the valid addresses of the
+// subnet that are not allocated, reserved or in a pool.  The addresses are
+// generated as they are tried as an ipv6 node subnet has 2^64 addresses.
+func dynamicCandidates(subnet *net.IPNet, allocated, reservations map[string]string, pooled map[string]bool) func() net.IP {
+	ip := copyIP(subnet.IP.Mask(subnet.Mask))
+	return func() net.IP {
+		for ; subnet.Contains(ip); nextIP(ip) {
+			// filter out network, gateway and broadcast
+			if !validIP(ip, subnet) {
+				continue
+			}
+			if _, exists := allocated[ip.String()]; exists {
+				continue
+			}
+			if _, exists := reservations[ip.String()]; exists {
+				continue
+			}
+			if pooled[ip.String()] {
+				continue
+			}
+			candidate := copyIP(ip)
+			nextIP(ip)
+			return candidate
+		}
+		return nil
+	}
+}
+
+// allocationCandidates returns the addresses to reserve for a specific
+// address or pool request.  Addresses of the pool already reserved for the id
+// are first.  No candidates are returned for dynamic allocations or pools
+// without addresses in the subnet family.
+func allocationCandidates(req *api.AllocateIPRequest, subnet *net.IPNet, pools []*api.IPPool, reservations, allocated map[string]string) ([]net.IP, bool, error) {
+	if req.IP != "" {
+		ip := net.ParseIP(req.IP)
+		if ip == nil {
+			return nil, false, status.Errorf(codes.InvalidArgument, "invalid ip %q", req.IP)
+		}
+		if !subnet.Contains(ip) || !validIP(ip, subnet) {
+			return nil, false, status.Errorf(codes.InvalidArgument, "ip %s is not an available address in subnet %s on node %s", ip, subnet, req.Node)
+		}
+		if id, ok := reservations[ip.String()]; ok && id != req.ID {
+			return nil, false, status.Errorf(codes.FailedPrecondition, "ip %s is reserved for %s", ip, id)
+		}
+		if id, ok := allocated[ip.String()]; ok && id != req.ID {
+			return nil, false, status.Errorf(codes.FailedPrecondition, "ip %s is allocated to %s", ip, id)
+		}
+		for _, p := range pools {
+			ranges, err := poolRanges(p)
+			if err != nil {
+				return nil, false, err
+			}
+			for _, r := range ranges {
+				if r.Contains(ip) {
+					return nil, false, status.Errorf(codes.FailedPrecondition, "ip %s is in pool %s", ip, p.Name)
+				}
+			}
+		}
+		return []net.IP{ip}, true, nil
+	}
+	if req.Pool == "" {
+		return nil, false, nil
+	}
+
+	var pool *api.IPPool
+	for _, p := range pools {
+		if p.Name == req.Pool {
+			pool = p
+		}
+	}
+	if pool == nil {
+		return nil, false, status.Errorf(codes.NotFound, "pool %s not found in network %s", req.Pool, req.Network)
+	}
+	if !poolFamily(pool, subnet) {
+		return nil, false, nil
+	}
+	ips, err := poolIPs(pool, subnet)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(ips) == 0 {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "pool %s has no addresses in subnet %s on node %s", pool.Name, subnet, req.Node)
+	}
+	var candidates []net.IP
+	for _, ip := range ips {
+		id, ok := reservations[ip.String()]
+		switch {
+		case ok && id == req.ID:
+			candidates = append([]net.IP{ip}, candidates...)
+		case ok:
+			continue
+		default:
+			if _, exists := allocated[ip.String()]; exists {
+				continue
+			}
+			candidates = append(candidates, ip)
+		}
+	}
+	return candidates, true, nil
+}
+
 func (s *service) GetIP(ctx context.Context, req *api.GetIPRequest) (*api.GetIPResponse, error) {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
@@ -81,14 +331,24 @@ func (s *service) GetIP(ctx context.Context, req *api.GetIPRequest) (*api.GetIPR
 	}
 	defer c.Close()
 
-	ipKey := fmt.Sprintf(dsIPsKey, req.Node, req.ID)
+	keys := networkIPKeys(req.Network)
+	ipKey := fmt.Sprintf(keys.ips, req.Node, req.ID)
 	result, err := c.Datastore().Get(dsNetworkBucketName, ipKey)
 	if err != nil {
 		return nil, err
 	}
+	ip6Key := fmt.Sprintf(keys.ips6, req.Node, req.ID)
+	result6, err := c.Datastore().Get(dsNetworkBucketName, ip6Key)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
 
 	return &api.GetIPResponse{
-		IP: string(result),
+		IP:  string(result),
+		IP6: string(result6),
 	}, nil
 }
 
@@ -99,22 +359,153 @@ func (s *service) ReleaseIP(ctx context.Context, req *api.ReleaseIPRequest) (*pt
 	}
 	defer c.Close()
 
-	ipKey := fmt.Sprintf(dsIPsKey, req.Node, req.ID)
-	if err := c.Datastore().Delete(dsNetworkBucketName, ipKey, true); err != nil {
+	keys := networkIPKeys(req.Network)
+	ipKey := fmt.Sprintf(keys.ips, req.Node, req.ID)
+	ip, err := c.Datastore().Get(dsNetworkBucketName, ipKey)
+	if err != nil {
+		return nil, err
+	}
+	ownerKey := fmt.Sprintf(keys.owners, req.Node, string(ip))
+	if err := releaseIP(c, req.ID, ipKey, ownerKey); err != nil {
 		return nil, err
 	}
 
+	ip6Key := fmt.Sprintf(keys.ips6, req.Node, req.ID)
+	ip6, err := c.Datastore().Get(dsNetworkBucketName, ip6Key)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if len(ip6) > 0 {
+		owner6Key := fmt.Sprintf(keys.owners, req.Node, string(ip6))
+		if err := releaseIP(c, req.ID, ip6Key, owner6Key); err != nil {
+			return nil, err
+		}
+	}
+
 	return empty, nil
 }
 
-func (s *service) getIPs(ctx context.Context, node string) (map[string]net.IP, error) {
+// IPs returns the ip allocations in all networks for the node or all nodes
+// sorted by network, node and id
+func (s *service) IPs(ctx context.Context, req *api.IPsRequest) (*api.IPsResponse, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	networks, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{stellar.DefaultNetworkName}
+	for _, n := range networks {
+		names = append(names, n.Name)
+	}
+
+	allocations := map[string]*api.IPAllocation{}
+	for _, network := range names {
+		keys := networkIPKeys(network)
+		for _, keyFormat := range []string{keys.ips, keys.ips6} {
+			prefix := keyPrefix(keyFormat)
+			searchKey := prefix
+			if req.Node != "" {
+				searchKey = fmt.Sprintf(keyFormat, req.Node, "")
+			}
+			results, err := c.Datastore().Search(dsNetworkBucketName, searchKey)
+			if err != nil {
+				err = errdefs.FromGRPC(err)
+				if !errdefs.IsNotFound(err) {
+					return nil, err
+				}
+			}
+			for _, kv := range results {
+				p := strings.SplitN(strings.TrimPrefix(kv.Key, prefix), ".", 2)
+				if len(p) < 2 {
+					logrus.Errorf("unexpected IP key format: %s", kv.Key)
+					continue
+				}
+				node, id := p[0], p[1]
+				key := network + "/" + node + "/" + id
+				a, ok := allocations[key]
+				if !ok {
+					a = &api.IPAllocation{
+						ID:      id,
+						Node:    node,
+						Network: network,
+					}
+					allocations[key] = a
+				}
+				if keyFormat == keys.ips6 {
+					a.IP6 = string(kv.Value)
+				} else {
+					a.IP = string(kv.Value)
+				}
+			}
+		}
+	}
+
+	resp := &api.IPsResponse{}
+	for _, a := range allocations {
+		resp.IPs = append(resp.IPs, a)
+	}
+	sort.Slice(resp.IPs, func(i, j int) bool {
+		a, b := resp.IPs[i], resp.IPs[j]
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.Node != b.Node {
+			return a.Node < b.Node
+		}
+		return a.ID < b.ID
+	})
+	return resp, nil
+}
+
+// releaseIP removes the allocation for the id and the ip owner if the ip is
+// still owned by the id
+func releaseIP(c *client.Client, id, ipKey, ownerKey string) error {
+	_, err := c.Datastore().Txn([]*datastoreapi.Compare{
+		datastoreapi.CompareValue(dsNetworkBucketName, ownerKey, []byte(id)),
+	}, []*datastoreapi.TxnOp{
+		datastoreapi.OpDelete(dsNetworkBucketName, ipKey),
+		datastoreapi.OpDelete(dsNetworkBucketName, ownerKey),
+	}, []*datastoreapi.TxnOp{
+		datastoreapi.OpDelete(dsNetworkBucketName, ipKey),
+	}, true)
+	return err
+}
+
+// getReservations returns the reserved ips for the node with the id they are
+// reserved for
+func (s *service) getReservations(c *client.Client, keyFormat, node string) (map[string]string, error) {
+	searchKey := fmt.Sprintf(keyFormat, node, "")
+	results, err := c.Datastore().Search(dsNetworkBucketName, searchKey)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	reservations := make(map[string]string, len(results))
+	for _, kv := range results {
+		reservations[strings.TrimPrefix(kv.Key, searchKey)] = string(kv.Value)
+	}
+	return reservations, nil
+}
+
+// getIPs returns the allocated ips for the node by id for the key format
+func (s *service) getIPs(ctx context.Context, keyFormat, node string) (map[string]net.IP, error) {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
 		return nil, err
 	}
 	defer c.Close()
 
-	searchKey := fmt.Sprintf(dsIPsKey, node, "")
+	searchKey := fmt.Sprintf(keyFormat, node, "")
 	results, err := c.Datastore().Search(dsNetworkBucketName, searchKey)
 	if err != nil {
 		err = errdefs.FromGRPC(err)
@@ -124,12 +515,7 @@ func (s *service) getIPs(ctx context.Context, node string) (map[string]net.IP, e
 	}
 	ips := make(map[string]net.IP, len(results))
 	for _, kv := range results {
-		p := strings.Split(kv.Key, ".")
-		if len(p) < 3 {
-			logrus.Errorf("unexpected IP key format: %s", kv.Key)
-			continue
-		}
-		id := strings.Join(p[2:], ".")
+		id := strings.TrimPrefix(kv.Key, searchKey)
 		ip := net.ParseIP(string(kv.Value))
 		ips[id] = ip
 	}
@@ -146,7 +532,13 @@ func nextIP(ip net.IP) {
 	}
 }
 
-func validIP(ip net.IP) bool {
+// validIP returns false for the network, gateway and broadcast addresses of
+// the subnet.  ipv6 has no broadcast so only the network and gateway are
+// reserved.
+func validIP(ip net.IP, subnet *net.IPNet) bool {
+	if ip.To4() == nil {
+		return !ip.Equal(subnet.IP.Mask(subnet.Mask)) && !ip.Equal(stellar.Gateway(subnet))
+	}
 	v := ip[len(ip)-1]
 	switch v {
 	case 0, 1, 255:
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"net"
+	"testing"
+)
+
+func TestValidIP(t *testing.T) {
+	for cidr, expected := range map[string]map[string]bool{
+		"172.16.4.0/22": {
+			"172.16.4.0":   false,
+			"172.16.4.1":   false,
+			"172.16.4.2":   true,
+			"172.16.5.255": false,
+		},
+		"fd00:5354:0:40::/58": {
+			"fd00:5354:0:40::":   false,
+			"fd00:5354:0:40::1":  false,
+			"fd00:5354:0:40::2":  true,
+			"fd00:5354:0:41::":   true,
+			"fd00:5354:0:40::ff": true,
+		},
+	} {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for addr, valid := range expected {
+			if v := validIP(net.ParseIP(addr), subnet); v != valid {
+				t.Fatalf("expected valid=%t for %s in %s; received %t", valid, addr, cidr, v)
+			}
+		}
+	}
+}
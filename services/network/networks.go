@@ -0,0 +1,359 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBridgeNameLength is the max length of a linux interface name
+	maxBridgeNameLength = 15
+	// networkBridgePrefix is the default bridge name prefix for named networks
+	networkBridgePrefix = "stellar-"
+)
+
+var (
+	// format: networks.<name>
+	dsNetworksKey = "networks.%s"
+)
+
+// CreateNetwork validates and stores the named network.  The node bridges
+// are created by each node during reconcile.
+func (s *service) CreateNetwork(ctx context.Context, req *api.CreateNetworkRequest) (*ptypes.Empty, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	n := req.Network
+	if n != nil && n.Bridge == "" {
+		n.Bridge = networkBridgePrefix + n.Name
+	}
+	existing, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateNetwork(n, existing); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, e := range existing {
+		if e.Name == n.Name {
+			return nil, status.Errorf(codes.AlreadyExists, "network %s already exists", n.Name)
+		}
+	}
+
+	n.Default = false
+	data, err := proto.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("network", n.Name).Debug("creating network")
+	if err := c.Datastore().Set(dsNetworkBucketName, fmt.Sprintf(dsNetworksKey, n.Name), data, true); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// ListNetworks returns the default cluster network followed by the named
+// networks sorted by name
+func (s *service) ListNetworks(ctx context.Context, _ *ptypes.Empty) (*api.ListNetworksResponse, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	networks, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListNetworksResponse{
+		Networks: append([]*api.ClusterNetwork{s.defaultNetwork()}, networks...),
+	}, nil
+}
+
+// DeleteNetwork removes the named network.  Networks with allocated addresses
+// cannot be removed until the services using them are removed.
+func (s *service) DeleteNetwork(ctx context.Context, req *api.DeleteNetworkRequest) (*ptypes.Empty, error) {
+	if isDefaultNetwork(req.Name) {
+		return nil, status.Error(codes.FailedPrecondition, "the default network cannot be removed")
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := s.deleteNetwork(ctx, c, req.Name); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// deleteNetwork removes the named network along with its reservations and
+// routes.  The network must not have allocated addresses or pools.
+func (s *service) deleteNetwork(ctx context.Context, c *client.Client, name string) error {
+	n, err := s.getNetwork(c, name)
+	if err != nil {
+		return err
+	}
+
+	keys := networkIPKeys(n.Name)
+	for _, keyFormat := range []string{keys.ips, keys.ips6} {
+		results, err := c.Datastore().Search(dsNetworkBucketName, keyPrefix(keyFormat))
+		if err != nil {
+			err = errdefs.FromGRPC(err)
+			if !errdefs.IsNotFound(err) {
+				return err
+			}
+		}
+		if len(results) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "network %s has %d allocated addresses", n.Name, len(results))
+		}
+	}
+	pools, err := s.pools(c)
+	if err != nil {
+		return err
+	}
+	for _, p := range pools {
+		if p.Network == n.Name {
+			return status.Errorf(codes.FailedPrecondition, "network %s is used by pool %s", n.Name, p.Name)
+		}
+	}
+
+	// reservations are only kept for the allocations in the network
+	reservations, err := c.Datastore().Search(dsNetworkBucketName, keyPrefix(keys.reservations))
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return err
+		}
+	}
+	for _, kv := range reservations {
+		if err := c.Datastore().Delete(dsNetworkBucketName, kv.Key, true); err != nil {
+			return err
+		}
+	}
+
+	// remove the node routes for the network subnets
+	routes, err := s.Routes(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes.Routes {
+		if !networkContains(n, r.CIDR) {
+			continue
+		}
+		if err := c.Datastore().Delete(dsNetworkBucketName, fmt.Sprintf(dsRoutesKey, r.CIDR), true); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Datastore().Delete(dsNetworkBucketName, fmt.Sprintf(dsNetworksKey, n.Name), true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// networkSubnet returns the subnets of the named network for the node with
+// the default network subnet
+func (s *service) networkSubnet(c *client.Client, name, subnetCIDR string) (*api.GetSubnetResponse, error) {
+	n, err := s.getNetwork(c, name)
+	if err != nil {
+		return nil, err
+	}
+	if subnetCIDR == "" {
+		return &api.GetSubnetResponse{}, nil
+	}
+	resp := &api.GetSubnetResponse{}
+	for _, v := range []struct {
+		cidr   string
+		subnet *string
+	}{
+		{n.SubnetCIDR, &resp.SubnetCIDR},
+		{n.Subnet6CIDR, &resp.Subnet6CIDR},
+	} {
+		if v.cidr == "" {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(v.cidr)
+		if err != nil {
+			return nil, err
+		}
+		if *v.subnet, err = s.pairedSubnet(ipnet, subnetCIDR); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// defaultNetwork returns the cluster network from the configuration
+func (s *service) defaultNetwork() *api.ClusterNetwork {
+	n := &api.ClusterNetwork{
+		Name:       stellar.DefaultNetworkName,
+		SubnetCIDR: s.network.String(),
+		Bridge:     s.config.Bridge,
+		Default:    true,
+	}
+	if s.network6 != nil {
+		n.Subnet6CIDR = s.network6.String()
+	}
+	return n
+}
+
+// getNetwork returns the named network
+func (s *service) getNetwork(c *client.Client, name string) (*api.ClusterNetwork, error) {
+	data, err := c.Datastore().Get(dsNetworkBucketName, fmt.Sprintf(dsNetworksKey, name))
+	if err != nil {
+		if errdefs.IsNotFound(errdefs.FromGRPC(err)) {
+			return nil, status.Errorf(codes.NotFound, "network %s not found", name)
+		}
+		return nil, err
+	}
+	// missing keys are returned as empty values
+	if len(data) == 0 {
+		return nil, status.Errorf(codes.NotFound, "network %s not found", name)
+	}
+	var n api.ClusterNetwork
+	if err := proto.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// networks returns the named networks sorted by name
+func (s *service) networks(c *client.Client) ([]*api.ClusterNetwork, error) {
+	results, err := c.Datastore().Search(dsNetworkBucketName, fmt.Sprintf(dsNetworksKey, ""))
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	var networks []*api.ClusterNetwork
+	for _, kv := range results {
+		var n api.ClusterNetwork
+		if err := proto.Unmarshal(kv.Value, &n); err != nil {
+			logrus.Errorf("invalid network %s: %s", kv.Key, err)
+			continue
+		}
+		networks = append(networks, &n)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks, nil
+}
+
+// validateNetwork checks the network configuration and that the subnets and
+// bridge do not conflict with the cluster network or the existing networks
+func (s *service) validateNetwork(n *api.ClusterNetwork, existing []*api.ClusterNetwork) error {
+	if n == nil {
+		return fmt.Errorf("network is required")
+	}
+	if !policyNameRegex.MatchString(n.Name) {
+		return fmt.Errorf("invalid network name %q", n.Name)
+	}
+	if isDefaultNetwork(n.Name) {
+		return fmt.Errorf("network name %s is reserved", n.Name)
+	}
+	if _, ok := api.NetworkIsolation_name[int32(n.Isolation)]; !ok {
+		return fmt.Errorf("invalid network isolation %d", n.Isolation)
+	}
+	if len(n.Bridge) > maxBridgeNameLength {
+		return fmt.Errorf("bridge name %s must be at most %d characters", n.Bridge, maxBridgeNameLength)
+	}
+	if n.SubnetCIDR == "" {
+		return fmt.Errorf("subnet is required")
+	}
+
+	others := append([]*api.ClusterNetwork{s.defaultNetwork()}, existing...)
+	for _, v := range []struct {
+		cidr string
+		ipv6 bool
+	}{
+		{n.SubnetCIDR, false},
+		{n.Subnet6CIDR, true},
+	} {
+		if v.cidr == "" {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(v.cidr)
+		if err != nil {
+			return err
+		}
+		if (ipnet.IP.To4() == nil) != v.ipv6 {
+			return fmt.Errorf("subnet %s is not in the expected address family", v.cidr)
+		}
+		if _, err := divideSubnet(ipnet, maxSubnets); err != nil {
+			return fmt.Errorf("invalid subnet %s: %s", v.cidr, err)
+		}
+		for _, o := range others {
+			if o.Name == n.Name {
+				continue
+			}
+			if networkOverlaps(o, ipnet) {
+				return fmt.Errorf("subnet %s overlaps network %s", v.cidr, o.Name)
+			}
+		}
+	}
+	for _, o := range others {
+		if o.Name != n.Name && o.Bridge == n.Bridge {
+			return fmt.Errorf("bridge %s is used by network %s", n.Bridge, o.Name)
+		}
+	}
+	return nil
+}
+
+// networkOverlaps returns true if the subnet overlaps one of the network
+// subnets
+func networkOverlaps(n *api.ClusterNetwork, subnet *net.IPNet) bool {
+	for _, cidr := range []string{n.SubnetCIDR, n.Subnet6CIDR} {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipnet.Contains(subnet.IP) || subnet.Contains(ipnet.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkContains returns true if the cidr is within one of the network
+// subnets
+func networkContains(n *api.ClusterNetwork, cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	for _, c := range []string{n.SubnetCIDR, n.Subnet6CIDR} {
+		_, ipnet, err := net.ParseCIDR(c)
+		if err != nil {
+			continue
+		}
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDefaultNetwork returns true if the name refers to the cluster network
+func isDefaultNetwork(name string) bool {
+	return name == "" || name == stellar.DefaultNetworkName
+}
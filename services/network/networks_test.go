@@ -0,0 +1,151 @@
+package network
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ehazlett/stellar"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
+	ptypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testDatastore is a datastore server with the keys of the network bucket.
+// Like the datastore, missing keys are returned as empty values.
+type testDatastore struct {
+	datastoreapi.DatastoreServer
+	keys    map[string][]byte
+	deleted []string
+}
+
+func (d *testDatastore) Get(ctx context.Context, req *datastoreapi.GetRequest) (*datastoreapi.GetResponse, error) {
+	return &datastoreapi.GetResponse{
+		Bucket: req.Bucket,
+		Data:   &datastoreapi.KeyValue{Key: req.Key, Value: d.keys[req.Key]},
+	}, nil
+}
+
+func (d *testDatastore) Search(ctx context.Context, req *datastoreapi.SearchRequest) (*datastoreapi.SearchResponse, error) {
+	resp := &datastoreapi.SearchResponse{Bucket: req.Bucket}
+	for k, v := range d.keys {
+		if strings.HasPrefix(k, req.Prefix) {
+			resp.Data = append(resp.Data, &datastoreapi.KeyValue{Key: k, Value: v})
+		}
+	}
+	return resp, nil
+}
+
+func (d *testDatastore) Delete(ctx context.Context, req *datastoreapi.DeleteRequest) (*ptypes.Empty, error) {
+	d.deleted = append(d.deleted, req.Key)
+	delete(d.keys, req.Key)
+	return empty, nil
+}
+
+// testDatastoreClient returns a client for the datastore server
+func testDatastoreClient(t *testing.T, ds *testDatastore) (*client.Client, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	datastoreapi.RegisterDatastoreServer(srv, ds)
+	go srv.Serve(l)
+
+	c, err := client.NewClient(l.Addr().String())
+	if err != nil {
+		srv.Stop()
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close()
+		srv.Stop()
+	}
+}
+
+func TestValidateNetwork(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/16")
+	s := &service{
+		network: subnet,
+		config: &stellar.Config{
+			Subnet: subnet,
+			Bridge: "stellar0",
+		},
+	}
+	existing := []*api.ClusterNetwork{
+		{Name: "backend", SubnetCIDR: "10.2.0.0/16", Bridge: "stellar-backend"},
+	}
+
+	valid := &api.ClusterNetwork{
+		Name:        "frontend",
+		SubnetCIDR:  "10.1.0.0/16",
+		Subnet6CIDR: "fd00:1::/48",
+		Bridge:      "stellar-front",
+		Isolation:   api.NetworkIsolation_ISOLATED,
+	}
+	if err := s.validateNetwork(valid, existing); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, n := range map[string]*api.ClusterNetwork{
+		"name":           {Name: "front.end", SubnetCIDR: "10.1.0.0/16"},
+		"reserved":       {Name: stellar.DefaultNetworkName, SubnetCIDR: "10.1.0.0/16"},
+		"isolation":      {Name: "n", SubnetCIDR: "10.1.0.0/16", Isolation: api.NetworkIsolation(5)},
+		"bridge":         {Name: "n", SubnetCIDR: "10.1.0.0/16", Bridge: "stellar-frontend0"},
+		"subnet":         {Name: "n"},
+		"size":           {Name: "n", SubnetCIDR: "10.1.0.0/24"},
+		"family":         {Name: "n", SubnetCIDR: "10.1.0.0/16", Subnet6CIDR: "10.3.0.0/16"},
+		"default":        {Name: "n", SubnetCIDR: "10.0.128.0/17"},
+		"overlap":        {Name: "n", SubnetCIDR: "10.2.0.0/15"},
+		"duplicate":      {Name: "n", SubnetCIDR: "10.1.0.0/16", Bridge: "stellar-backend"},
+		"default bridge": {Name: "n", SubnetCIDR: "10.1.0.0/16", Bridge: "stellar0"},
+	} {
+		if err := s.validateNetwork(n, existing); err == nil {
+			t.Fatalf("expected error for invalid %s", name)
+		}
+	}
+}
+
+func TestNetworkIPKeys(t *testing.T) {
+	for _, network := range []string{"", stellar.DefaultNetworkName} {
+		if keys := networkIPKeys(network); keys.ips != dsIPsKey || keys.owners != dsIPOwnersKey {
+			t.Fatalf("expected default keys for %q; received %+v", network, keys)
+		}
+	}
+
+	keys := networkIPKeys("backend")
+	if v := keyPrefix(keys.ips); v != "netips.backend." {
+		t.Fatalf("expected prefix netips.backend.; received %s", v)
+	}
+	if v := keys.family(net.ParseIP("fd00::2")); v != "netips6.backend.%s.%s" {
+		t.Fatalf("expected ipv6 key format; received %s", v)
+	}
+}
+
+func TestDeleteUnknownNetwork(t *testing.T) {
+	ds := &testDatastore{
+		keys: map[string][]byte{
+			"reservations.10.0.0.2.app.svc": []byte("10.0.0.2"),
+		},
+	}
+	c, cleanup := testDatastoreClient(t, ds)
+	defer cleanup()
+
+	s := &service{}
+	err := s.deleteNetwork(context.Background(), c, "typo")
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected not found; received %v", err)
+	}
+	if len(ds.deleted) != 0 {
+		t.Fatalf("unexpected deleted keys %v", ds.deleted)
+	}
+
+	if _, err := s.networkSubnet(c, "typo", "10.0.1.0/24"); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected not found for subnet; received %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// format: policies.<name>
+	dsPoliciesKey = "policies.%s"
+
+	policyNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+)
+
+// CreatePolicy validates and stores the network policy replacing an existing
+// policy with the same name.  The policies are enforced by each node during
+// reconcile.
+func (s *service) CreatePolicy(ctx context.Context, req *api.CreatePolicyRequest) (*ptypes.Empty, error) {
+	if err := validatePolicy(req.Policy); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	data, err := proto.Marshal(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("policy", req.Policy.Name).Debug("creating network policy")
+	if err := c.Datastore().Set(dsNetworkBucketName, fmt.Sprintf(dsPoliciesKey, req.Policy.Name), data, true); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// Policies returns the network policies sorted by name
+func (s *service) Policies(ctx context.Context, _ *ptypes.Empty) (*api.PoliciesResponse, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	results, err := c.Datastore().Search(dsNetworkBucketName, fmt.Sprintf(dsPoliciesKey, ""))
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	var policies []*api.NetworkPolicy
+	for _, kv := range results {
+		var p api.NetworkPolicy
+		if err := proto.Unmarshal(kv.Value, &p); err != nil {
+			logrus.Errorf("invalid network policy %s: %s", kv.Key, err)
+			continue
+		}
+		policies = append(policies, &p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return &api.PoliciesResponse{
+		Policies: policies,
+	}, nil
+}
+
+// DeletePolicy removes the network policy
+func (s *service) DeletePolicy(ctx context.Context, req *api.DeletePolicyRequest) (*ptypes.Empty, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	key := fmt.Sprintf(dsPoliciesKey, req.Name)
+	if _, err := c.Datastore().Get(dsNetworkBucketName, key); err != nil {
+		if errdefs.IsNotFound(errdefs.FromGRPC(err)) {
+			return nil, status.Errorf(codes.NotFound, "network policy %s not found", req.Name)
+		}
+		return nil, err
+	}
+	if err := c.Datastore().Delete(dsNetworkBucketName, key, true); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+func validatePolicy(p *api.NetworkPolicy) error {
+	if p == nil {
+		return fmt.Errorf("policy is required")
+	}
+	if !policyNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("invalid policy name %q", p.Name)
+	}
+	if _, ok := api.PolicyDirection_name[int32(p.Direction)]; !ok {
+		return fmt.Errorf("invalid policy direction %d", p.Direction)
+	}
+	if _, ok := api.PolicyAction_name[int32(p.DefaultAction)]; !ok {
+		return fmt.Errorf("invalid default action %d", p.DefaultAction)
+	}
+	if p.Target != nil && p.Target.CIDR != "" {
+		return fmt.Errorf("policy target must select an application or service")
+	}
+	for i, r := range p.Rules {
+		if _, ok := api.PolicyAction_name[int32(r.Action)]; !ok {
+			return fmt.Errorf("rule %d: invalid action %d", i, r.Action)
+		}
+		if peer := r.Peer; peer != nil && peer.CIDR != "" {
+			if peer.Application != "" || peer.Service != "" {
+				return fmt.Errorf("rule %d: peer must select either a cidr or an application", i)
+			}
+			if _, _, err := net.ParseCIDR(peer.CIDR); err != nil {
+				return fmt.Errorf("rule %d: %s", i, err)
+			}
+		}
+		for _, port := range r.Ports {
+			switch strings.ToLower(port.Protocol) {
+			case "tcp", "udp", "sctp":
+			default:
+				return fmt.Errorf("rule %d: invalid protocol %q", i, port.Protocol)
+			}
+			if port.Port == 0 || port.Port > 65535 {
+				return fmt.Errorf("rule %d: invalid port %d", i, port.Port)
+			}
+		}
+	}
+	return nil
+}
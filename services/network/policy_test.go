@@ -0,0 +1,41 @@
+package network
+
+import (
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	valid := &api.NetworkPolicy{
+		Name:   "db-ingress",
+		Target: &api.PolicyPeer{Application: "db"},
+		Rules: []*api.PolicyRule{
+			{
+				Peer:  &api.PolicyPeer{Application: "web"},
+				Ports: []*api.PolicyPort{{Protocol: "tcp", Port: 5432}},
+			},
+			{
+				Peer: &api.PolicyPeer{CIDR: "10.0.0.0/8"},
+			},
+		},
+		DefaultAction: api.PolicyAction_DENY,
+	}
+	if err := validatePolicy(valid); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, p := range map[string]*api.NetworkPolicy{
+		"name":      {Name: "db.ingress"},
+		"target":    {Name: "p", Target: &api.PolicyPeer{CIDR: "10.0.0.0/8"}},
+		"direction": {Name: "p", Direction: api.PolicyDirection(5)},
+		"cidr":      {Name: "p", Rules: []*api.PolicyRule{{Peer: &api.PolicyPeer{CIDR: "10.0.0.0"}}}},
+		"peer":      {Name: "p", Rules: []*api.PolicyRule{{Peer: &api.PolicyPeer{CIDR: "10.0.0.0/8", Application: "web"}}}},
+		"protocol":  {Name: "p", Rules: []*api.PolicyRule{{Ports: []*api.PolicyPort{{Protocol: "icmp", Port: 1}}}}},
+		"port":      {Name: "p", Rules: []*api.PolicyRule{{Ports: []*api.PolicyPort{{Protocol: "tcp", Port: 70000}}}}},
+	} {
+		if err := validatePolicy(p); err == nil {
+			t.Fatalf("expected error for invalid %s", name)
+		}
+	}
+}
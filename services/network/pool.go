@@ -0,0 +1,272 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxPoolRangeBits limits the size of the CIDR ranges in a pool
+	maxPoolRangeBits = 16
+)
+
+var (
+	// format: pools.<name>
+	dsPoolsKey = "pools.%s"
+)
+
+// CreatePool validates and stores the reservation pool replacing an existing
+// pool with the same name
+func (s *service) CreatePool(ctx context.Context, req *api.CreatePoolRequest) (*ptypes.Empty, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	p := req.Pool
+	if p != nil && isDefaultNetwork(p.Network) {
+		p.Network = stellar.DefaultNetworkName
+	}
+	if err := s.validatePool(c, p); err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("pool", p.Name).Debug("creating ip pool")
+	if err := c.Datastore().Set(dsNetworkBucketName, fmt.Sprintf(dsPoolsKey, p.Name), data, true); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// ListPools returns the reservation pools sorted by name
+func (s *service) ListPools(ctx context.Context, _ *ptypes.Empty) (*api.ListPoolsResponse, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	pools, err := s.pools(c)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListPoolsResponse{
+		Pools: pools,
+	}, nil
+}
+
+// DeletePool removes the reservation pool.  Addresses reserved from the pool
+// are kept until they are no longer used by a container.
+func (s *service) DeletePool(ctx context.Context, req *api.DeletePoolRequest) (*ptypes.Empty, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if _, err := s.getPool(c, req.Name); err != nil {
+		return nil, err
+	}
+	if err := c.Datastore().Delete(dsNetworkBucketName, fmt.Sprintf(dsPoolsKey, req.Name), true); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// validatePool checks that the pool addresses are in the pool network and do
+// not overlap other pools
+func (s *service) validatePool(c *client.Client, p *api.IPPool) error {
+	if p == nil {
+		return status.Error(codes.InvalidArgument, "pool is required")
+	}
+	if !policyNameRegex.MatchString(p.Name) {
+		return status.Errorf(codes.InvalidArgument, "invalid pool name %q", p.Name)
+	}
+	if len(p.Addresses) == 0 {
+		return status.Error(codes.InvalidArgument, "pool must have at least one address")
+	}
+
+	network := s.defaultNetwork()
+	if !isDefaultNetwork(p.Network) {
+		n, err := s.getNetwork(c, p.Network)
+		if err != nil {
+			return err
+		}
+		network = n
+	}
+
+	ranges, err := poolRanges(p)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for i, r := range ranges {
+		if !networkContains(network, r.String()) {
+			return status.Errorf(codes.InvalidArgument, "address %s is not in network %s", p.Addresses[i], network.Name)
+		}
+		for _, o := range ranges[i+1:] {
+			if rangesOverlap(r, o) {
+				return status.Errorf(codes.InvalidArgument, "addresses %s and %s overlap", r, o)
+			}
+		}
+	}
+
+	pools, err := s.pools(c)
+	if err != nil {
+		return err
+	}
+	for _, o := range pools {
+		if o.Name == p.Name || o.Network != p.Network {
+			continue
+		}
+		others, err := poolRanges(o)
+		if err != nil {
+			continue
+		}
+		for _, r := range ranges {
+			for _, other := range others {
+				if rangesOverlap(r, other) {
+					return status.Errorf(codes.InvalidArgument, "address %s overlaps pool %s", r, o.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getPool returns the named pool
+func (s *service) getPool(c *client.Client, name string) (*api.IPPool, error) {
+	data, err := c.Datastore().Get(dsNetworkBucketName, fmt.Sprintf(dsPoolsKey, name))
+	if err != nil {
+		if errdefs.IsNotFound(errdefs.FromGRPC(err)) {
+			return nil, status.Errorf(codes.NotFound, "pool %s not found", name)
+		}
+		return nil, err
+	}
+	var p api.IPPool
+	if err := proto.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// pools returns the reservation pools sorted by name
+func (s *service) pools(c *client.Client) ([]*api.IPPool, error) {
+	results, err := c.Datastore().Search(dsNetworkBucketName, fmt.Sprintf(dsPoolsKey, ""))
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	var pools []*api.IPPool
+	for _, kv := range results {
+		var p api.IPPool
+		if err := proto.Unmarshal(kv.Value, &p); err != nil {
+			logrus.Errorf("invalid ip pool %s: %s", kv.Key, err)
+			continue
+		}
+		pools = append(pools, &p)
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Name < pools[j].Name
+	})
+	return pools, nil
+}
+
+// poolRanges returns the pool addresses as networks.  Single addresses are
+// host networks.
+func poolRanges(p *api.IPPool) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, addr := range p.Addresses {
+		if ip := net.ParseIP(addr); ip != nil {
+			bits := 128
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pool address %q", addr)
+		}
+		ones, bits := ipnet.Mask.Size()
+		if bits-ones > maxPoolRangeBits {
+			return nil, fmt.Errorf("pool range %s must be a /%d or smaller", addr, bits-maxPoolRangeBits)
+		}
+		ranges = append(ranges, ipnet)
+	}
+	return ranges, nil
+}
+
+// poolIPs returns the valid addresses of the pool in the subnet in order
+func poolIPs(p *api.IPPool, subnet *net.IPNet) ([]net.IP, error) {
+	ranges, err := poolRanges(p)
+	if err != nil {
+		return nil, err
+	}
+	var ips []net.IP
+	for _, r := range ranges {
+		for ip := copyIP(r.IP.Mask(r.Mask)); r.Contains(ip); nextIP(ip) {
+			if subnet.Contains(ip) && validIP(ip, subnet) {
+				ips = append(ips, copyIP(ip))
+			}
+		}
+	}
+	return ips, nil
+}
+
+// pooledIPs returns the addresses of the pools in the subnet
+func pooledIPs(pools []*api.IPPool, subnet *net.IPNet) (map[string]bool, error) {
+	pooled := map[string]bool{}
+	for _, p := range pools {
+		ips, err := poolIPs(p, subnet)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			pooled[ip.String()] = true
+		}
+	}
+	return pooled, nil
+}
+
+// poolFamily returns true if the pool has addresses in the family of the
+// subnet
+func poolFamily(p *api.IPPool, subnet *net.IPNet) bool {
+	ranges, err := poolRanges(p)
+	if err != nil {
+		return false
+	}
+	for _, r := range ranges {
+		if (r.IP.To4() == nil) == (subnet.IP.To4() == nil) {
+			return true
+		}
+	}
+	return false
+}
+
+func rangesOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func copyIP(ip net.IP) net.IP {
+	return append(net.IP{}, ip...)
+}
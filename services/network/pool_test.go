@@ -0,0 +1,143 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPoolIPs(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.4.0/22")
+	p := &api.IPPool{
+		Name:      "db",
+		Addresses: []string{"10.0.4.0/30", "10.0.5.20", "10.0.8.2", "fd00::10"},
+	}
+	ips, err := poolIPs(p, subnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the network and gateway addresses and addresses of other subnets are
+	// skipped
+	expected := []string{"10.0.4.2", "10.0.4.3", "10.0.5.20"}
+	if len(ips) != len(expected) {
+		t.Fatalf("expected %v; received %v", expected, ips)
+	}
+	for i, ip := range ips {
+		if ip.String() != expected[i] {
+			t.Fatalf("expected %v; received %v", expected, ips)
+		}
+	}
+
+	_, subnet6, _ := net.ParseCIDR("fd00::/64")
+	if !poolFamily(p, subnet6) {
+		t.Fatal("expected pool to have ipv6 addresses")
+	}
+	if poolFamily(&api.IPPool{Addresses: []string{"10.0.4.2"}}, subnet6) {
+		t.Fatal("expected pool to have no ipv6 addresses")
+	}
+
+	for _, addr := range []string{"10.0.4", "10.0.0.0/15", "fd00::/100"} {
+		if _, err := poolRanges(&api.IPPool{Addresses: []string{addr}}); err == nil {
+			t.Fatalf("expected error for pool address %s", addr)
+		}
+	}
+}
+
+func TestAllocationCandidates(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.4.0/22")
+	pools := []*api.IPPool{
+		{Name: "db", Network: "stellar", Addresses: []string{"10.0.4.8/30"}},
+	}
+	reservations := map[string]string{
+		"10.0.4.9":  "db.1",
+		"10.0.4.10": "db.0",
+		"10.0.4.20": "web.0",
+	}
+	allocated := map[string]string{
+		"10.0.4.8": "db.2",
+		"10.0.4.2": "web.1",
+	}
+
+	candidates, reserve, err := allocationCandidates(&api.AllocateIPRequest{ID: "db.0", Node: "node-00", Pool: "db"}, subnet, pools, reservations, allocated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the address reserved for the id is first and addresses reserved or
+	// allocated for other ids are skipped
+	if !reserve || len(candidates) != 2 || candidates[0].String() != "10.0.4.10" || candidates[1].String() != "10.0.4.11" {
+		t.Fatalf("unexpected pool candidates: %v", candidates)
+	}
+
+	candidates, reserve, err = allocationCandidates(&api.AllocateIPRequest{ID: "web.0", Node: "node-00", IP: "10.0.4.20"}, subnet, pools, reservations, allocated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reserve || len(candidates) != 1 || candidates[0].String() != "10.0.4.20" {
+		t.Fatalf("unexpected static candidates: %v", candidates)
+	}
+
+	candidates, reserve, err = allocationCandidates(&api.AllocateIPRequest{ID: "web.2", Node: "node-00"}, subnet, pools, reservations, allocated)
+	if err != nil || reserve || len(candidates) != 0 {
+		t.Fatalf("expected dynamic allocation; received %v %v %v", candidates, reserve, err)
+	}
+
+	_, subnet6, _ := net.ParseCIDR("fd00::/64")
+	if _, reserve, err := allocationCandidates(&api.AllocateIPRequest{ID: "db.0", Pool: "db"}, subnet6, pools, nil, nil); err != nil || reserve {
+		t.Fatalf("expected dynamic allocation for pool without ipv6 addresses; received %v %v", reserve, err)
+	}
+
+	for name, tc := range map[string]struct {
+		req  *api.AllocateIPRequest
+		code codes.Code
+	}{
+		"invalid":   {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.4"}, codes.InvalidArgument},
+		"subnet":    {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.8.2"}, codes.InvalidArgument},
+		"gateway":   {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.4.1"}, codes.InvalidArgument},
+		"reserved":  {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.4.20"}, codes.FailedPrecondition},
+		"allocated": {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.4.2"}, codes.FailedPrecondition},
+		"pool":      {&api.AllocateIPRequest{ID: "web.2", IP: "10.0.4.11"}, codes.FailedPrecondition},
+		"missing":   {&api.AllocateIPRequest{ID: "web.2", Pool: "cache"}, codes.NotFound},
+	} {
+		_, _, err := allocationCandidates(tc.req, subnet, pools, reservations, allocated)
+		if status.Code(err) != tc.code {
+			t.Fatalf("expected %s for %s; received %v", tc.code, name, err)
+		}
+	}
+}
+
+func TestDynamicCandidatesIPv6(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("fd00:5354:0:40::/64")
+	allocated := map[string]string{
+		"fd00:5354:0:40::2": "web.0",
+	}
+	reservations := map[string]string{
+		"fd00:5354:0:40::3": "web.1",
+	}
+	pooled := map[string]bool{
+		"fd00:5354:0:40::4": true,
+	}
+
+	// the candidates are generated lazily so the first free address of a
+	// /64 is returned without walking the subnet
+	next := dynamicCandidates(subnet, allocated, reservations, pooled)
+	for _, expected := range []string{"fd00:5354:0:40::5", "fd00:5354:0:40::6"} {
+		ip := next()
+		if ip == nil || ip.String() != expected {
+			t.Fatalf("expected %s; received %v", expected, ip)
+		}
+	}
+
+	_, subnet4, _ := net.ParseCIDR("10.0.4.0/30")
+	next = dynamicCandidates(subnet4, nil, nil, nil)
+	for _, expected := range []string{"10.0.4.2", "10.0.4.3"} {
+		if ip := next(); ip == nil || ip.String() != expected {
+			t.Fatalf("expected %s; received %v", expected, ip)
+		}
+	}
+	if ip := next(); ip != nil {
+		t.Fatalf("expected exhausted subnet; received %v", ip)
+	}
+}
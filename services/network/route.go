@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"github.com/containerd/containerd/errdefs"
+	datastoreapi "github.com/ehazlett/stellar/api/services/datastore/v1"
 	api "github.com/ehazlett/stellar/api/services/network/v1"
+	"github.com/ehazlett/stellar/client"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
 )
@@ -16,6 +18,8 @@ var (
 	ErrRouteExists = errors.New("route exists in configuration")
 	// format: routes.<cidr>
 	dsRoutesKey = "routes.%s"
+	// format: routeowners.<cidr>
+	dsRouteOwnersKey = "routeowners.%s"
 )
 
 func (s *service) AddRoute(ctx context.Context, req *api.AddRouteRequest) (*ptypes.Empty, error) {
@@ -27,7 +31,16 @@ func (s *service) AddRoute(ctx context.Context, req *api.AddRouteRequest) (*ptyp
 
 	routeData := []byte(req.CIDR + ":" + req.Target)
 	routeKey := fmt.Sprintf(dsRoutesKey, req.CIDR)
-	if err := c.Datastore().Set(dsNetworkBucketName, routeKey, routeData, true); err != nil {
+	ownerKey := fmt.Sprintf(dsRouteOwnersKey, req.CIDR)
+	ops := []*datastoreapi.TxnOp{
+		datastoreapi.OpPut(dsNetworkBucketName, routeKey, routeData),
+	}
+	if req.Node != "" {
+		ops = append(ops, datastoreapi.OpPut(dsNetworkBucketName, ownerKey, []byte(req.Node)))
+	} else {
+		ops = append(ops, datastoreapi.OpDelete(dsNetworkBucketName, ownerKey))
+	}
+	if _, err := c.Datastore().Txn(nil, ops, nil, true); err != nil {
 		return nil, err
 	}
 	return &ptypes.Empty{}, nil
@@ -40,13 +53,35 @@ func (s *service) DeleteRoute(ctx context.Context, req *api.DeleteRouteRequest)
 	}
 	defer c.Close()
 
-	routeKey := fmt.Sprintf(dsRoutesKey, req.CIDR)
-	if err := c.Datastore().Delete(dsNetworkBucketName, routeKey, true); err != nil {
+	if err := deleteRoute(c, req.CIDR, req.Node); err != nil {
 		return nil, err
 	}
 	return &ptypes.Empty{}, nil
 }
 
+// deleteRoute removes the route and its owner.  If node is set the route is
+// only removed if it is still owned by the node as the subnet may have been
+// assigned to another node.
+func deleteRoute(c *client.Client, cidr, node string) error {
+	routeKey := fmt.Sprintf(dsRoutesKey, cidr)
+	ownerKey := fmt.Sprintf(dsRouteOwnersKey, cidr)
+	var compares []*datastoreapi.Compare
+	if node != "" {
+		compares = append(compares, datastoreapi.CompareValue(dsNetworkBucketName, ownerKey, []byte(node)))
+	}
+	resp, err := c.Datastore().Txn(compares, []*datastoreapi.TxnOp{
+		datastoreapi.OpDelete(dsNetworkBucketName, routeKey),
+		datastoreapi.OpDelete(dsNetworkBucketName, ownerKey),
+	}, nil, true)
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		logrus.Debugf("route %s is not owned by %s; skipping delete", cidr, node)
+	}
+	return nil
+}
+
 func (s *service) Routes(ctx context.Context, _ *ptypes.Empty) (*api.RoutesResponse, error) {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
@@ -63,18 +98,54 @@ func (s *service) Routes(ctx context.Context, _ *ptypes.Empty) (*api.RoutesRespo
 			return nil, err
 		}
 	}
+	owners, err := routeOwners(c)
+	if err != nil {
+		return nil, err
+	}
 	for _, kv := range results {
-		rt := strings.Split(string(kv.Value), ":")
-		if len(rt) != 2 {
-			logrus.Errorf("invalid route format: %s", string(kv.Value))
+		cidr, target, err := parseRoute(string(kv.Value))
+		if err != nil {
+			logrus.Error(err)
 			continue
 		}
 		routes = append(routes, &api.Route{
-			CIDR:   rt[0],
-			Target: rt[1],
+			CIDR:   cidr,
+			Target: target,
+			Node:   owners[cidr],
 		})
 	}
 	return &api.RoutesResponse{
 		Routes: routes,
 	}, nil
 }
+
+// routeOwners returns the node that owns each route by cidr
+func routeOwners(c *client.Client) (map[string]string, error) {
+	owners := map[string]string{}
+	searchKey := fmt.Sprintf(dsRouteOwnersKey, "")
+	results, err := c.Datastore().Search(dsNetworkBucketName, searchKey)
+	if err != nil {
+		err = errdefs.FromGRPC(err)
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	for _, kv := range results {
+		owners[strings.TrimPrefix(kv.Key, searchKey)] = string(kv.Value)
+	}
+	return owners, nil
+}
+
+// parseRoute parses the <cidr>:<target> route format.  The separator is the
+// first colon after the prefix length as ipv6 addresses contain colons.
+func parseRoute(v string) (string, string, error) {
+	i := strings.Index(v, "/")
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid route format: %s", v)
+	}
+	j := strings.Index(v[i:], ":")
+	if j < 0 {
+		return "", "", fmt.Errorf("invalid route format: %s", v)
+	}
+	return v[:i+j], v[i+j+1:], nil
+}
@@ -0,0 +1,22 @@
+package network
+
+import "testing"
+
+func TestParseRoute(t *testing.T) {
+	for v, expected := range map[string][2]string{
+		"172.16.4.0/22:10.0.1.70":          {"172.16.4.0/22", "10.0.1.70"},
+		"fd00:5354:0:40::/58:10.0.1.70":    {"fd00:5354:0:40::/58", "10.0.1.70"},
+		"fd00:5354:0:40::/58:2001:db8::70": {"fd00:5354:0:40::/58", "2001:db8::70"},
+	} {
+		cidr, target, err := parseRoute(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cidr != expected[0] || target != expected[1] {
+			t.Fatalf("expected %s via %s; received %s via %s", expected[0], expected[1], cidr, target)
+		}
+	}
+	if _, _, err := parseRoute("172.16.4.0"); err == nil {
+		t.Fatal("expected error for invalid route")
+	}
+}
@@ -27,17 +27,19 @@ var (
 )
 
 type service struct {
-	network *net.IPNet
-	agent   *element.Agent
+	network  *net.IPNet
+	network6 *net.IPNet
+	agent    *element.Agent
 	//ds      datastoreapi.DatastoreServer
 	config *stellar.Config
 }
 
 func New(cfg *stellar.Config, agent *element.Agent) (services.Service, error) {
 	return &service{
-		network: cfg.Subnet,
-		agent:   agent,
-		config:  cfg,
+		network:  cfg.Subnet,
+		network6: cfg.Subnet6,
+		agent:    agent,
+		config:   cfg,
 	}, nil
 }
 
@@ -67,6 +69,7 @@ func (s *service) Info(ctx context.Context, req *api.InfoRequest) (*api.InfoResp
 }
 
 func (s *service) Start() error {
+	go s.gcMonitor()
 	return nil
 }
 
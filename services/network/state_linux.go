@@ -0,0 +1,105 @@
+package network
+
+import (
+	"context"
+	"net"
+
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/vishvananda/netlink"
+)
+
+// State returns the addresses, the routes to the cluster networks, the
+// network bridges and the container bandwidth limits of the local node
+func (s *service) State(ctx context.Context, _ *ptypes.Empty) (*api.NodeState, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	named, err := s.networks(c)
+	if err != nil {
+		return nil, err
+	}
+	networks := append([]*api.ClusterNetwork{s.defaultNetwork()}, named...)
+
+	state := &api.NodeState{
+		NodeID:      s.agent.Self().ID,
+		NetworkMode: s.config.NetworkMode,
+	}
+
+	addrs, err := netlink.AddrList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.IP.IsGlobalUnicast() {
+			state.Addresses = append(state.Addresses, addr.IP.String())
+		}
+	}
+
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		if r.Dst == nil || !inClusterNetwork(networks, r.Dst) {
+			continue
+		}
+		route := &api.NodeRoute{
+			CIDR: r.Dst.String(),
+		}
+		if r.Gw != nil {
+			route.Gateway = r.Gw.String()
+		}
+		if link, err := netlink.LinkByIndex(r.LinkIndex); err == nil {
+			route.Device = link.Attrs().Name
+		}
+		state.Routes = append(state.Routes, route)
+	}
+
+	for _, n := range networks {
+		bridge := &api.NodeBridge{
+			Name:    n.Bridge,
+			Network: n.Name,
+		}
+		state.Bridges = append(state.Bridges, bridge)
+		link, err := netlink.LinkByName(n.Bridge)
+		if err != nil {
+			if _, ok := err.(netlink.LinkNotFoundError); ok {
+				continue
+			}
+			return nil, err
+		}
+		bridge.Exists = true
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if addr.IP.IsGlobalUnicast() {
+				bridge.Addresses = append(bridge.Addresses, addr.IPNet.String())
+			}
+		}
+	}
+
+	containers, err := c.Node().Containers()
+	if err != nil {
+		return nil, err
+	}
+	state.Bandwidths = containerBandwidths(containers)
+
+	return state, nil
+}
+
+// inClusterNetwork returns true if the destination is in the subnets of the
+// networks
+func inClusterNetwork(networks []*api.ClusterNetwork, dst *net.IPNet) bool {
+	for _, n := range networks {
+		if networkContains(n, dst.String()) {
+			return true
+		}
+	}
+	return false
+}
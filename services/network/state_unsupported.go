@@ -0,0 +1,16 @@
+// +build !linux
+
+package network
+
+import (
+	"context"
+
+	api "github.com/ehazlett/stellar/api/services/network/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *service) State(ctx context.Context, _ *ptypes.Empty) (*api.NodeState, error) {
+	return nil, status.Error(codes.Unimplemented, "network state is not supported on this platform")
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/ehazlett/stellar"
 	api "github.com/ehazlett/stellar/api/services/network/v1"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
@@ -21,6 +23,13 @@ var (
 	dsSubnetsKey = "subnets.%s"
 )
 
+const (
+	// subnetLockName serializes subnet allocation across the cluster
+	subnetLockName    = "stellar.services.network.subnets"
+	subnetLockTTL     = time.Second * 10
+	subnetLockTimeout = time.Second * 30
+)
+
 func (s *service) AllocateSubnet(ctx context.Context, req *api.AllocateSubnetRequest) (*api.AllocateSubnetResponse, error) {
 	c, err := s.client(s.agent.Self().Address)
 	if err != nil {
@@ -43,6 +52,19 @@ func (s *service) AllocateSubnet(ctx context.Context, req *api.AllocateSubnetReq
 		return nil, ErrNoAvailableSubnets
 	}
 
+	// hold the cluster lock so concurrent allocations do not assign the
+	// same subnet
+	owner := fmt.Sprintf("%s.%d", s.agent.Self().ID, time.Now().UnixNano())
+	lease, err := c.Datastore().AcquireLock(subnetLockName, owner, subnetLockTTL, subnetLockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := c.Datastore().ReleaseLock(lease); err != nil {
+			logrus.Warnf("error releasing subnet lock: %s", err)
+		}
+	}()
+
 	localSubnetBytes, err := c.Datastore().Get(dsNetworkBucketName, localSubnetKey)
 	if err != nil {
 		err = errdefs.FromGRPC(err)
@@ -94,23 +116,37 @@ func (s *service) AllocateSubnet(ctx context.Context, req *api.AllocateSubnetReq
 			}
 		}
 
-		assigned := len(existingSubnets)
-		if len(subnets) < assigned {
-			return nil, fmt.Errorf("no available subnet for current node; need %d subnets", assigned)
+		// subnets of nodes that left the cluster are released so the
+		// first unassigned subnet is used
+		assigned := map[string]bool{}
+		for _, kv := range existingSubnets {
+			assigned[string(kv.Value)] = true
 		}
+		for _, sub := range subnets {
+			if !assigned[sub.CIDR] {
+				localSubnet = sub.CIDR
+				break
+			}
+		}
+		if localSubnet == "" {
+			return nil, fmt.Errorf("no available subnet for current node; %d subnets assigned", len(assigned))
+		}
+		logrus.Debugf("subnet for node: %s", localSubnet)
 
-		cidr := subnets[assigned].CIDR
-		logrus.Debugf("subnet for node: %s", cidr)
-
-		localSubnet = cidr
 		if err := c.Datastore().Set(dsNetworkBucketName, localSubnetKey, []byte(localSubnet), true); err != nil {
 			return nil, err
 		}
 	}
 
+	subnet6, err := s.subnet6(localSubnet)
+	if err != nil {
+		return nil, err
+	}
+
 	return &api.AllocateSubnetResponse{
-		SubnetCIDR: string(localSubnet),
-		Node:       req.Node,
+		SubnetCIDR:  string(localSubnet),
+		Node:        req.Node,
+		Subnet6CIDR: subnet6,
 	}, nil
 }
 
@@ -130,8 +166,16 @@ func (s *service) GetSubnet(ctx context.Context, req *api.GetSubnetRequest) (*ap
 			return nil, ErrSubnetNotFound
 		}
 	}
+	if !isDefaultNetwork(req.Network) {
+		return s.networkSubnet(c, req.Network, string(localSubnet))
+	}
+	subnet6, err := s.subnet6(string(localSubnet))
+	if err != nil {
+		return nil, err
+	}
 	return &api.GetSubnetResponse{
-		SubnetCIDR: string(localSubnet),
+		SubnetCIDR:  string(localSubnet),
+		Subnet6CIDR: subnet6,
 	}, nil
 }
 
@@ -149,17 +193,65 @@ func (s *service) Subnets(ctx context.Context, _ *ptypes.Empty) (*api.SubnetsRes
 		return nil, err
 	}
 
+	var subs6 []*net.IPNet
+	if s.network6 != nil {
+		if subs6, err = divideSubnet(s.network6, maxSubnets); err != nil {
+			return nil, err
+		}
+	}
+
 	var subnets []*api.Subnet
-	for _, subnet := range subs {
-		ip := subnet.IP
-		gw := net.IPv4(ip[0], ip[1], ip[2], 1)
-		subnets = append(subnets, &api.Subnet{
+	for i, subnet := range subs {
+		sub := &api.Subnet{
 			CIDR:    subnet.String(),
-			Gateway: gw.String(),
-		})
+			Gateway: stellar.Gateway(subnet).String(),
+		}
+		if subs6 != nil {
+			sub.CIDR6 = subs6[i].String()
+			sub.Gateway6 = stellar.Gateway(subs6[i]).String()
+		}
+		subnets = append(subnets, sub)
 	}
 
 	return &api.SubnetsResponse{
 		Subnets: subnets,
 	}, nil
 }
+
+// subnet6 returns the ipv6 subnet paired with the node ipv4 subnet.  An
+// empty string is returned if ipv6 is not enabled or the subnet is no longer
+// in the configured network.
+func (s *service) subnet6(subnetCIDR string) (string, error) {
+	if s.network6 == nil || subnetCIDR == "" {
+		return "", nil
+	}
+	subnet6, err := s.pairedSubnet(s.network6, subnetCIDR)
+	if err != nil {
+		return "", err
+	}
+	if subnet6 == "" {
+		logrus.Warnf("subnet %s is not in the cluster network; ipv6 disabled for node", subnetCIDR)
+	}
+	return subnet6, nil
+}
+
+// pairedSubnet returns the subnet of the network at the same position as the
+// node subnet in the divided cluster network.  Paired subnets do not need to
+// be stored as they are derived from the node subnet.  An empty string is
+// returned if the node subnet is not in the cluster network.
+func (s *service) pairedSubnet(network *net.IPNet, subnetCIDR string) (string, error) {
+	subs, err := divideSubnet(s.network, maxSubnets)
+	if err != nil {
+		return "", err
+	}
+	paired, err := divideSubnet(network, maxSubnets)
+	if err != nil {
+		return "", err
+	}
+	for i, sub := range subs {
+		if sub.String() == subnetCIDR {
+			return paired[i].String(), nil
+		}
+	}
+	return "", nil
+}
@@ -56,7 +56,10 @@ func (d *datastore) Servers() ([]*radiantapi.Server, error) {
 			// parse url and notify update
 			for _, ep := range svc.Endpoints {
 				if strings.ToLower(ep.Protocol.String()) != "http" {
-					logrus.Warnf("proxy: unsupported protocol %s for endpoint %s", ep.Protocol, ep.Service)
+					// tcp and udp endpoints are reachable on their published host ports
+					if ep.HostPort == 0 {
+						logrus.Warnf("proxy: unsupported protocol %s for endpoint %s", ep.Protocol, ep.Service)
+					}
 					continue
 				}
 				// lookup the ip for faster resolves
@@ -0,0 +1,150 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/ehazlett/stellar"
+	api "github.com/ehazlett/stellar/api/services/runtime/v1"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// minBandwidth is the minimum bandwidth limit in bits per second
+	minBandwidth = 8 * 1024
+	// bandwidthLatency is the maximum time a packet is queued by the limit
+	bandwidthLatency = 25 * time.Millisecond
+	// minBandwidthBurst is the minimum token bucket size in bytes
+	minBandwidthBurst = 16 * 1024
+)
+
+// SetBandwidth replaces the bandwidth limits of the running container.  The
+// limits are recorded in the container service so they are applied again if
+// the replica is moved.
+func (s *service) SetBandwidth(ctx context.Context, req *api.SetBandwidthRequest) (*ptypes.Empty, error) {
+	client, err := s.containerd()
+	if err != nil {
+		return empty, err
+	}
+	defer client.Close()
+
+	ctx = namespaces.WithNamespace(ctx, s.namespace)
+	container, err := client.LoadContainer(ctx, req.ID)
+	if err != nil {
+		return empty, err
+	}
+	svc, err := s.containerService(ctx, container)
+	if err != nil {
+		return empty, err
+	}
+	if svc == nil {
+		return empty, fmt.Errorf("container %s is not a service replica", req.ID)
+	}
+	netPath, err := s.containerNetPath(req.ID)
+	if err != nil {
+		return empty, err
+	}
+
+	if err := setContainerBandwidth(netPath, req.Bandwidth); err != nil {
+		return empty, err
+	}
+
+	svc.Bandwidth = req.Bandwidth
+	if err := container.Update(ctx, containerd.UpdateContainerOpts(containerd.WithContainerExtension(stellar.StellarServiceExtension, svc))); err != nil {
+		return empty, err
+	}
+
+	return empty, nil
+}
+
+// setContainerBandwidth limits the traffic from the container on the container
+// interfaces and the traffic to the container on the host side of the
+// interfaces.  A zero limit removes the limit.
+func setContainerBandwidth(netPath string, bw *api.Bandwidth) error {
+	if bw == nil {
+		bw = &api.Bandwidth{}
+	}
+	for _, rate := range []uint64{bw.Ingress, bw.Egress} {
+		if rate > 0 && rate < minBandwidth {
+			return fmt.Errorf("bandwidth limit must be at least %d bits per second", minBandwidth)
+		}
+	}
+
+	var peers []int
+	if err := withNetNS(netPath, func() error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			if _, ok := link.(*netlink.Veth); !ok {
+				continue
+			}
+			if err := setTBF(link, bw.Egress); err != nil {
+				return errors.Wrapf(err, "error setting egress limit on %s", link.Attrs().Name)
+			}
+			// the parent of the container interface is the host side of
+			// the veth pair
+			peers = append(peers, link.Attrs().ParentIndex)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, index := range peers {
+		link, err := netlink.LinkByIndex(index)
+		if err != nil {
+			return err
+		}
+		if err := setTBF(link, bw.Ingress); err != nil {
+			return errors.Wrapf(err, "error setting ingress limit on %s", link.Attrs().Name)
+		}
+	}
+	return nil
+}
+
+// setTBF replaces the root qdisc of the link with a token bucket filter for
+// the rate in bits per second.  The filter is removed if the rate is zero.
+func setTBF(link netlink.Link, rate uint64) error {
+	if rate > 0 {
+		return netlink.QdiscReplace(tbfQdisc(link.Attrs().Index, rate))
+	}
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return err
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent == netlink.HANDLE_ROOT && q.Type() == "tbf" {
+			return netlink.QdiscDel(q)
+		}
+	}
+	return nil
+}
+
+// tbfQdisc returns the token bucket filter for the rate in bits per second.
+// The bucket holds 10ms of traffic and the queue the traffic of the latency
+// as in the cni bandwidth plugin.
+func tbfQdisc(index int, rate uint64) *netlink.Tbf {
+	rateBytes := rate / 8
+	burst := rateBytes / 100
+	if burst < minBandwidthBurst {
+		burst = minBandwidthBurst
+	}
+	latency := float64(bandwidthLatency / time.Microsecond)
+	return &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rateBytes,
+		Limit:  uint32(float64(rateBytes)*latency/netlink.TIME_UNITS_PER_SEC) + uint32(burst),
+		Buffer: uint32(netlink.Xmittime(rateBytes, uint32(burst))),
+	}
+}
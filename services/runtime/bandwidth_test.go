@@ -0,0 +1,38 @@
+package runtime
+
+import (
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/runtime/v1"
+	"github.com/vishvananda/netlink"
+)
+
+func TestTBFQdisc(t *testing.T) {
+	for rate, burst := range map[uint64]uint32{
+		// 1 Mbit/s uses the minimum burst
+		1000000: minBandwidthBurst,
+		// 100 Mbit/s holds 10ms of traffic
+		100000000: 125000,
+	} {
+		q := tbfQdisc(3, rate)
+		if q.LinkIndex != 3 || q.Parent != netlink.HANDLE_ROOT {
+			t.Fatalf("unexpected qdisc attributes %+v", q.QdiscAttrs)
+		}
+		if q.Rate != rate/8 {
+			t.Fatalf("expected rate %d; received %d", rate/8, q.Rate)
+		}
+		// the queue holds the burst and 25ms of traffic
+		if expected := uint32(rate/8/40) + burst; q.Limit != expected {
+			t.Fatalf("expected limit %d for %d; received %d", expected, rate, q.Limit)
+		}
+		if q.Buffer == 0 {
+			t.Fatalf("expected buffer for %d", rate)
+		}
+	}
+}
+
+func TestSetContainerBandwidthMinimum(t *testing.T) {
+	if err := setContainerBandwidth("/nonexistent", &api.Bandwidth{Egress: minBandwidth - 1}); err == nil {
+		t.Fatal("expected error for bandwidth below the minimum")
+	}
+}
@@ -0,0 +1,266 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/types/current"
+	"github.com/ehazlett/stellar"
+	networkapi "github.com/ehazlett/stellar/api/services/network/v1"
+	api "github.com/ehazlett/stellar/api/services/runtime/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// containerIfPrefix is the prefix of the container network interfaces
+	containerIfPrefix = "eth"
+)
+
+// containerNetwork is a cluster network attached to a container interface
+type containerNetwork struct {
+	name   string
+	ifName string
+	conf   []byte
+	// portMappings are the host ports published on the interface
+	portMappings []portMapping
+}
+
+// portMapping is the portmap plugin runtime config for a published port
+type portMapping struct {
+	HostPort      int    `json:"hostPort"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// runtimeConf returns the cni runtime config for the network interface
+func (n *containerNetwork) runtimeConf(id, netPath string) *libcni.RuntimeConf {
+	rt := cniRuntimeConf(id, netPath, n.ifName)
+	if len(n.portMappings) > 0 {
+		rt.CapabilityArgs = map[string]interface{}{
+			"portMappings": n.portMappings,
+		}
+	}
+	return rt
+}
+
+// containerNetworks returns the cni configs for the service networks of the
+// container in interface order.  The default network is used if no networks
+// are specified.  The first network is the primary network and has the
+// default route, the reserved address or pool and the published host ports;
+// the other interfaces only route their cluster network.
+func (s *service) containerNetworks(svc *api.Service, id string) ([]*containerNetwork, error) {
+	if svc == nil {
+		svc = &api.Service{}
+	}
+	names := svc.Networks
+	ip, pool := replicaReservation(svc, id)
+	mappings := servicePortMappings(svc)
+	if len(names) == 0 {
+		names = []string{stellar.DefaultNetworkName}
+	}
+
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	clusterNetworks, err := c.Network().ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+	lookup := map[string]*networkapi.ClusterNetwork{}
+	for _, n := range clusterNetworks {
+		lookup[n.Name] = n
+	}
+
+	var networks []*containerNetwork
+	seen := map[string]bool{}
+	for i, name := range names {
+		n, ok := lookup[name]
+		if !ok {
+			return nil, fmt.Errorf("network %s not found", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("network %s is specified more than once", name)
+		}
+		seen[name] = true
+
+		conf := cniConf{
+			Name:    n.Name,
+			Bridge:  n.Bridge,
+			Network: n.Name,
+		}
+		// the default network uses the bridge from the node config and the
+		// default ipam allocations
+		if n.Default {
+			conf.Bridge = s.bridge
+			conf.Network = ""
+		}
+		var portMappings []portMapping
+		if i == 0 {
+			conf.IP = ip
+			conf.Pool = pool
+			conf.PortMap = len(mappings) > 0
+			portMappings = mappings
+		} else {
+			for _, cidr := range []string{n.SubnetCIDR, n.Subnet6CIDR} {
+				if cidr != "" {
+					conf.Routes = append(conf.Routes, cidr)
+				}
+			}
+		}
+		data, err := s.getNetworkConf(conf)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, &containerNetwork{
+			name:         n.Name,
+			ifName:       fmt.Sprintf("%s%d", containerIfPrefix, i),
+			conf:         data,
+			portMappings: portMappings,
+		})
+	}
+	return networks, nil
+}
+
+// replicaReservation returns the static address and reservation pool of the
+// service replica.  The replica index is the suffix of the container id.
+func replicaReservation(svc *api.Service, id string) (string, string) {
+	ip := ""
+	if i := strings.LastIndex(id, "."); i >= 0 {
+		if index, err := strconv.Atoi(id[i+1:]); err == nil && index >= 0 && index < len(svc.IPs) {
+			ip = svc.IPs[index]
+		}
+	}
+	return ip, svc.IPPool
+}
+
+// servicePortMappings returns the port mappings for the host ports published
+// by the service endpoints
+func servicePortMappings(svc *api.Service) []portMapping {
+	var mappings []portMapping
+	for _, ep := range svc.Endpoints {
+		if ep.HostPort == 0 {
+			continue
+		}
+		mappings = append(mappings, portMapping{
+			HostPort:      int(ep.HostPort),
+			ContainerPort: int(ep.Port),
+			Protocol:      ep.HostProtocol(),
+		})
+	}
+	return mappings
+}
+
+// attachNetworks attaches the loopback and the networks to the container
+// network namespace and returns the addresses of the primary network.  The
+// attached networks are removed if a network cannot be attached.
+func (s *service) attachNetworks(id, netPath string, networks []*containerNetwork) ([]net.IP, error) {
+	cni := &libcni.CNIConfig{Path: s.cniBinPaths}
+	lo, err := cniConfList([]byte(cniLoopbackConf))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cni.AddNetworkList(lo, cniRuntimeConf(id, netPath, "lo")); err != nil {
+		return nil, errors.Wrap(err, "error attaching loopback")
+	}
+
+	var ips []net.IP
+	for i, n := range networks {
+		list, err := cniConfList(n.conf)
+		if err != nil {
+			return nil, err
+		}
+		r, err := cni.AddNetworkList(list, n.runtimeConf(id, netPath))
+		if err != nil {
+			if err := s.detachNetworks(id, netPath, networks[:i]); err != nil {
+				logrus.Errorf("error detaching networks for %s: %s", id, err)
+			}
+			return nil, errors.Wrapf(err, "error attaching network %s", n.name)
+		}
+		result, err := current.NewResultFromResult(r)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debugf("node.createcontainer: cni result for %s on %s: %+v", n.name, n.ifName, result)
+		// the cni result has an ipv6 address as well if ipv6 is enabled
+		if i == 0 {
+			for _, cfg := range result.IPs {
+				ips = append(ips, cfg.Address.IP)
+			}
+		}
+	}
+	if len(ips) == 0 {
+		if err := s.detachNetworks(id, netPath, networks); err != nil {
+			logrus.Errorf("error detaching networks for %s: %s", id, err)
+		}
+		return nil, fmt.Errorf("no ips returned from cni")
+	}
+	return ips, nil
+}
+
+// detachNetworks removes the networks from the container network namespace
+// releasing the allocated addresses.  All networks are detached and the first
+// error is returned.
+func (s *service) detachNetworks(id, netPath string, networks []*containerNetwork) error {
+	cni := &libcni.CNIConfig{Path: s.cniBinPaths}
+	var detachErr error
+	for i := len(networks) - 1; i >= 0; i-- {
+		n := networks[i]
+		list, err := cniConfList(n.conf)
+		if err == nil {
+			err = cni.DelNetworkList(list, cniRuntimeConf(id, netPath, n.ifName))
+		}
+		if err != nil {
+			logrus.Errorf("error detaching network %s from %s: %s", n.name, id, err)
+			if detachErr == nil {
+				detachErr = err
+			}
+		}
+	}
+	return detachErr
+}
+
+func (s *service) getNetworkConf(conf cniConf) ([]byte, error) {
+	t := template.New("cni")
+	tmpl, err := t.Parse(cniConfTemplate)
+	if err != nil {
+		return nil, err
+	}
+	peerAddr, err := s.peerAddr()
+	if err != nil {
+		return nil, err
+	}
+	conf.NodeName = s.nodeName()
+	conf.PeerAddr = peerAddr
+	// container traffic is encapsulated in vxlan mode
+	if s.config.NetworkMode == stellar.NetworkModeVXLAN {
+		if iface, err := net.InterfaceByName(stellar.VXLANDeviceName); err == nil {
+			conf.MTU = iface.MTU
+		}
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, conf); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func cniConfList(data []byte) (*libcni.NetworkConfigList, error) {
+	return libcni.ConfListFromBytes(data)
+}
+
+func cniRuntimeConf(id, netPath, ifName string) *libcni.RuntimeConf {
+	return &libcni.RuntimeConf{
+		ContainerID: id,
+		NetNS:       netPath,
+		IfName:      ifName,
+	}
+}
@@ -0,0 +1,72 @@
+package runtime
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	api "github.com/ehazlett/stellar/api/services/runtime/v1"
+)
+
+func TestReplicaReservation(t *testing.T) {
+	svc := &api.Service{
+		IPs:    []string{"10.0.0.10", "10.0.0.11"},
+		IPPool: "db",
+	}
+	for id, expected := range map[string]string{
+		"app.db.0": "10.0.0.10",
+		"db.1":     "10.0.0.11",
+		"db.2":     "",
+		"db":       "",
+	} {
+		ip, pool := replicaReservation(svc, id)
+		if ip != expected || pool != "db" {
+			t.Fatalf("expected %q for %s; received %q %q", expected, id, ip, pool)
+		}
+	}
+}
+
+func TestCNIConfPortMap(t *testing.T) {
+	tmpl, err := template.New("cni").Parse(cniConfTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, portMap := range []bool{false, true} {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, cniConf{
+			Name:     "stellar",
+			Bridge:   "stellar0",
+			NodeName: "node-00",
+			PeerAddr: "127.0.0.1:9000",
+			IP:       "10.0.0.10",
+			PortMap:  portMap,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		list, err := cniConfList(b.Bytes())
+		if err != nil {
+			t.Fatalf("invalid cni config: %s\n%s", err, b.String())
+		}
+		expected := 1
+		if portMap {
+			expected = 2
+		}
+		if len(list.Plugins) != expected || list.Plugins[0].Network.Type != "bridge" {
+			t.Fatalf("expected %d plugins; received %+v", expected, list.Plugins)
+		}
+	}
+
+	if _, err := cniConfList([]byte(cniLoopbackConf)); err != nil {
+		t.Fatal(err)
+	}
+
+	mappings := servicePortMappings(&api.Service{
+		Endpoints: []*api.Endpoint{
+			{Service: "dns", Protocol: api.Protocol_UDP, Port: 5353, HostPort: 53},
+			{Service: "web", Protocol: api.Protocol_HTTP, Port: 8080},
+		},
+	})
+	if len(mappings) != 1 || mappings[0] != (portMapping{HostPort: 53, ContainerPort: 5353, Protocol: "udp"}) {
+		t.Fatalf("unexpected port mappings %+v", mappings)
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
-	gocni "github.com/containerd/go-cni"
 	"github.com/containerd/typeurl"
 	"github.com/ehazlett/stellar"
 	nameserverapi "github.com/ehazlett/stellar/api/services/nameserver/v1"
@@ -36,34 +35,52 @@ import (
 
 const (
 	defaultSnapshotter = "overlayfs"
-	defaultIfName      = "eth0"
 	cniLoopbackConf    = `{
 	"cniVersion": "0.3.1",
 	"name": "loopback",
-	"type": "loopback",
-        "ipam": {
-                "type": "static",
-		"addresses": [
-		    {
-			"address": "127.0.0.1/8"
-		    }
-		]
-        }
+	"plugins": [
+		{
+			"type": "loopback",
+			"ipam": {
+				"type": "static",
+				"addresses": [
+					{
+						"address": "127.0.0.1/8"
+					}
+				]
+			}
+		}
+	]
 }
 	`
 	cniConfTemplate = `{
         "cniVersion": "0.3.1",
-        "name": "stellar",
-        "type": "bridge",
-        "bridge": "{{.Bridge}}",
-        "isGateway": true,
-	"hairpinMode": true,
-        "ipMasq": true,
-        "ipam": {
-                "type": "stellar-cni-ipam",
-                "node_name": "{{.NodeName}}",
-                "peer_addr": "{{.PeerAddr}}"
-        }
+        "name": "{{.Name}}",
+        "plugins": [
+                {
+                        "type": "bridge",
+                        "bridge": "{{.Bridge}}",
+                        "isGateway": true,
+                        "hairpinMode": true,
+                        "ipMasq": true,{{if .MTU}}
+                        "mtu": {{.MTU}},{{end}}
+                        "ipam": {
+                                "type": "stellar-cni-ipam",
+                                "node_name": "{{.NodeName}}",
+                                "peer_addr": "{{.PeerAddr}}"{{if .Network}},
+                                "network": "{{.Network}}"{{end}}{{if .IP}},
+                                "ip": "{{.IP}}"{{end}}{{if .Pool}},
+                                "pool": "{{.Pool}}"{{end}}{{if .Routes}},
+                                "routes": [{{range $i, $r := .Routes}}{{if $i}}, {{end}}"{{$r}}"{{end}}]{{end}}
+                        }
+                }{{if .PortMap}},
+                {
+                        "type": "portmap",
+                        "capabilities": {
+                                "portMappings": true
+                        }
+                }{{end}}
+        ]
 }
 `
 	hostsTemplate = `127.0.0.1       localhost.localdomain   localhost {{.ID}}
@@ -78,9 +95,24 @@ ff02::3 ip6-allhosts
 )
 
 type cniConf struct {
+	// Name is the cni network name
+	Name string
+	// Network is the named network for ipam; empty for the default network
+	Network string
+	// Routes are the destinations routed via the interface; the default
+	// route is used if empty
+	Routes []string
+	// IP is the reserved address of the container; Pool is the reservation
+	// pool to allocate the address from
+	IP       string
+	Pool     string
 	Bridge   string
 	NodeName string
 	PeerAddr string
+	// MTU is the container interface MTU; zero uses the plugin default
+	MTU int
+	// PortMap chains the portmap plugin to publish the host ports
+	PortMap bool
 }
 
 func (s *service) Containers(ctx context.Context, req *api.ContainersRequest) (*api.ContainersResponse, error) {
@@ -155,6 +187,9 @@ func (s *service) CreateContainer(ctx context.Context, req *api.CreateContainerR
 	if appName := req.Application; appName != "" {
 		id = fmt.Sprintf("%s.%s", appName, req.ContainerID)
 	}
+	if err := s.checkPublishedPorts(ctx, client, service, id); err != nil {
+		return empty, err
+	}
 	snapshotter := defaultSnapshotter
 	if service.Snapshotter != "" {
 		snapshotter = service.Snapshotter
@@ -219,28 +254,22 @@ func (s *service) CreateContainer(ctx context.Context, req *api.CreateContainerR
 		Path: netPath,
 	}))
 
-	netConfData, err := s.getNetworkConf()
+	networks, err := s.containerNetworks(service, id)
 	if err != nil {
 		return empty, err
 	}
-	network, err := s.getNetwork(
-		gocni.WithConf([]byte(cniLoopbackConf)),
-		gocni.WithConf(netConfData),
-	)
+	ips, err := s.attachNetworks(id, netPath, networks)
 	if err != nil {
 		return empty, err
 	}
-
-	netResult, err := network.Setup(id, netPath)
-	if err != nil {
-		return empty, err
-	}
-
-	logrus.Debugf("node.createcontainer: cni result %+v", netResult)
-	if len(netResult.Interfaces[defaultIfName].IPConfigs) == 0 {
-		return empty, fmt.Errorf("no ips returned from cni")
+	if service.Bandwidth != nil {
+		if err = setContainerBandwidth(netPath, service.Bandwidth); err != nil {
+			if err := s.detachNetworks(id, netPath, networks); err != nil {
+				logrus.Errorf("error detaching networks for %s: %s", id, err)
+			}
+			return empty, errors.Wrap(err, "error setting bandwidth limits")
+		}
 	}
-	ip := netResult.Interfaces[defaultIfName].IPConfigs[0].IP.String()
 
 	cOpts = append(cOpts,
 		containerd.WithContainerLabels(convertLabels(service.Labels)),
@@ -283,11 +312,17 @@ func (s *service) CreateContainer(ctx context.Context, req *api.CreateContainerR
 	// TODO: make domain configurable
 	var records []*nameserverapi.Record
 	recordName := id + ".stellar"
-	records = append(records, &nameserverapi.Record{
-		Type:  nameserverapi.RecordType_A,
-		Name:  recordName,
-		Value: ip,
-	})
+	for _, ip := range ips {
+		recordType := nameserverapi.RecordType_A
+		if ip.To4() == nil {
+			recordType = nameserverapi.RecordType_AAAA
+		}
+		records = append(records, &nameserverapi.Record{
+			Type:  recordType,
+			Name:  recordName,
+			Value: ip.String(),
+		})
+	}
 	records = append(records, &nameserverapi.Record{
 		Type:  nameserverapi.RecordType_TXT,
 		Name:  recordName,
@@ -393,6 +428,10 @@ func (s *service) DeleteContainer(ctx context.Context, req *api.DeleteContainerR
 	if err != nil {
 		return empty, err
 	}
+	containerService, err := s.containerService(ctx, container)
+	if err != nil {
+		return empty, err
+	}
 
 	if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil {
 		return empty, err
@@ -410,16 +449,11 @@ func (s *service) DeleteContainer(ctx context.Context, req *api.DeleteContainerR
 		if err != nil {
 			return empty, err
 		}
-		netConfData, err := s.getNetworkConf()
-		if err != nil {
-			return empty, err
-		}
-		network, err := s.getNetwork(gocni.WithConf(netConfData))
+		networks, err := s.containerNetworks(containerService, req.ID)
 		if err != nil {
 			return empty, err
 		}
-
-		if err := network.Remove(req.ID, netPath); err != nil {
+		if err := s.detachNetworks(req.ID, netPath, networks); err != nil {
 			return empty, err
 		}
 
@@ -522,6 +556,62 @@ func (s *service) networkEnabled(ctx context.Context, container containerd.Conta
 	return false, nil
 }
 
+// containerService returns the service of the container or nil if the
+// container was not created for a service
+func (s *service) containerService(ctx context.Context, container containerd.Container) (*api.Service, error) {
+	extensions, err := container.Extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := extensions[stellar.StellarServiceExtension]
+	if !ok {
+		return nil, nil
+	}
+	v, err := typeurl.UnmarshalAny(&ext)
+	if err != nil {
+		return nil, err
+	}
+	if svc, ok := v.(*api.Service); ok {
+		return svc, nil
+	}
+	return nil, nil
+}
+
+// checkPublishedPorts returns an error if a host port published by the
+// service is already published by another container on the node
+func (s *service) checkPublishedPorts(ctx context.Context, client *containerd.Client, service *api.Service, id string) error {
+	ports := service.PublishedPorts()
+	if len(ports) == 0 {
+		return nil
+	}
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, container := range containers {
+		if container.ID() == id {
+			continue
+		}
+		svc, err := s.containerService(ctx, container)
+		if err != nil {
+			return err
+		}
+		if svc == nil {
+			continue
+		}
+		published := map[string]bool{}
+		for _, p := range svc.PublishedPorts() {
+			published[p] = true
+		}
+		for _, p := range ports {
+			if published[p] {
+				return fmt.Errorf("host port %s is published by %s", p, container.ID())
+			}
+		}
+	}
+	return nil
+}
+
 func (s *service) containersToProto(containers []containerd.Container) ([]*api.Container, error) {
 	var c []*api.Container
 	for _, container := range containers {
@@ -580,35 +670,6 @@ func (s *service) containerToProto(container containerd.Container) (*api.Contain
 	return ctr, nil
 }
 
-func (s *service) getNetworkConf() ([]byte, error) {
-	t := template.New("cni")
-	tmpl, err := t.Parse(cniConfTemplate)
-	if err != nil {
-		return nil, err
-	}
-	peerAddr, err := s.peerAddr()
-	if err != nil {
-		return nil, err
-	}
-	var b bytes.Buffer
-	if err := tmpl.Execute(&b, cniConf{NodeName: s.nodeName(), PeerAddr: peerAddr, Bridge: s.bridge}); err != nil {
-		return nil, err
-	}
-	return b.Bytes(), nil
-}
-
-func (s *service) getNetwork(opts ...gocni.CNIOpt) (gocni.CNI, error) {
-	network, err := gocni.New(gocni.WithPluginDir(s.cniBinPaths))
-	if err != nil {
-		return nil, err
-	}
-	// network confs
-	if err := network.Load(opts...); err != nil {
-		return nil, err
-	}
-	return network, nil
-}
-
 func (s *service) getContainerDataDir(id string) (string, error) {
 	p := filepath.Join(s.dataDir, "containers", id)
 	if err := os.MkdirAll(p, 0755); err != nil {
@@ -640,6 +701,22 @@ func (s *service) getNetPath(id string) (string, error) {
 	return netPath, nil
 }
 
+// containerNetPath returns the network namespace of the container or an error
+// if the container has no network namespace on the node
+func (s *service) containerNetPath(id string) (string, error) {
+	netPath, err := s.getNetPath(id)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(netPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("container %s has no network namespace on node %s", id, s.nodeName())
+		}
+		return "", err
+	}
+	return netPath, nil
+}
+
 func createNetNS(path string) error {
 	cmd := exec.Command("stellar", "network", "create", path)
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -752,15 +829,12 @@ func (s *service) getContainerHostsPath(id string) (string, error) {
 }
 
 func gateway(subnetCIDR string) (string, error) {
-	ip, ipnet, err := net.ParseCIDR(subnetCIDR)
+	_, ipnet, err := net.ParseCIDR(subnetCIDR)
 	if err != nil {
 		return "", err
 	}
 
-	gw := ip.Mask(ipnet.Mask)
-	gw[3]++
-
-	return gw.String(), nil
+	return stellar.Gateway(ipnet).String(), nil
 }
 
 func convertLabels(values []string) map[string]string {
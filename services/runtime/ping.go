@@ -0,0 +1,112 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+
+	api "github.com/ehazlett/stellar/api/services/runtime/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	defaultPingCount = 3
+	maxPingCount     = 100
+)
+
+var pingStatsRegex = regexp.MustCompile(`(\d+) packets transmitted, (\d+) (?:packets )?received`)
+
+// Ping runs ping for the target in the network namespace of the container
+func (s *service) Ping(ctx context.Context, req *api.PingRequest) (*api.PingResponse, error) {
+	ip := net.ParseIP(req.Target)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ping target %q", req.Target)
+	}
+	count := int(req.Count)
+	if count == 0 {
+		count = defaultPingCount
+	}
+	if count > maxPingCount {
+		return nil, fmt.Errorf("ping count must be at most %d", maxPingCount)
+	}
+
+	netPath, err := s.containerNetPath(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := pingInNetNS(netPath, ip, count)
+	if err != nil {
+		// ping exits non-zero if packets are lost
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, err
+		}
+	}
+	m := pingStatsRegex.FindSubmatch(out)
+	if m == nil {
+		return nil, errors.Errorf("error running ping: %s", out)
+	}
+	transmitted, _ := strconv.Atoi(string(m[1]))
+	received, _ := strconv.Atoi(string(m[2]))
+	return &api.PingResponse{
+		Transmitted: uint32(transmitted),
+		Received:    uint32(received),
+		Output:      string(out),
+	}, nil
+}
+
+// pingInNetNS runs ping from the network namespace
+func pingInNetNS(netPath string, ip net.IP, count int) ([]byte, error) {
+	var out []byte
+	err := withNetNS(netPath, func() error {
+		cmd := "ping"
+		if ip.To4() == nil {
+			if _, err := exec.LookPath("ping6"); err == nil {
+				cmd = "ping6"
+			}
+		}
+		o, err := exec.Command(cmd, "-c", strconv.Itoa(count), "-W", "1", ip.String()).CombinedOutput()
+		out = o
+		return err
+	})
+	return out, err
+}
+
+// withNetNS runs fn in the network namespace.  The thread is left locked if
+// the original namespace cannot be restored so it is not reused.
+func withNetNS(netPath string, fn func() error) error {
+	runtime.LockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	defer origin.Close()
+	ns, err := netns.GetFromPath(netPath)
+	if err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	defer ns.Close()
+
+	if err := netns.Set(ns); err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	defer func() {
+		if err := netns.Set(origin); err != nil {
+			logrus.Errorf("error restoring network namespace: %s", err)
+			return
+		}
+		runtime.UnlockOSThread()
+	}()
+
+	return fn()
+}
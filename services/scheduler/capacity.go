@@ -0,0 +1,305 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/containerd/typeurl"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/ehazlett/stellar"
+	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	api "github.com/ehazlett/stellar/api/services/scheduler/v1"
+)
+
+// workload is a replica currently running in the cluster
+type workload struct {
+	id          string
+	application string
+	node        string
+	service     *runtimeapi.Service
+}
+
+// capacity is the remaining reservable capacity on a node
+type capacity struct {
+	cpus   float64
+	memory int64
+}
+
+func (c *capacity) fits(r *runtimeapi.Resources) bool {
+	if r == nil {
+		return true
+	}
+	return r.Cpus <= c.cpus && r.Memory <= c.memory
+}
+
+func (c *capacity) reserve(r *runtimeapi.Resources) {
+	if r == nil {
+		return
+	}
+	c.cpus -= r.Cpus
+	c.memory -= r.Memory
+}
+
+func (c *capacity) release(r *runtimeapi.Resources) {
+	if r == nil {
+		return
+	}
+	c.cpus += r.Cpus
+	c.memory += r.Memory
+}
+
+func (c *capacity) reason(r *runtimeapi.Resources) string {
+	if r.Memory > c.memory {
+		return fmt.Sprintf("insufficient memory (requested %s, available %s)", humanize.Bytes(uint64(r.Memory)), humanize.Bytes(uint64(max64(c.memory, 0))))
+	}
+	return fmt.Sprintf("insufficient cpus (requested %.2f, available %.2f)", r.Cpus, c.cpus)
+}
+
+// clusterState is the node capacity, running workloads and published host
+// ports used to place services with resource reservations or host ports
+type clusterState struct {
+	capacity  map[string]*capacity
+	workloads []*workload
+	// ports are the published host ports by node with the replica
+	// publishing them
+	ports map[string]map[string]string
+}
+
+func newClusterState(health []*clusterapi.NodeHealth, containers []*clusterapi.Container, exclude []string) (*clusterState, error) {
+	excluded := map[string]struct{}{}
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+
+	state := &clusterState{
+		capacity: map[string]*capacity{},
+	}
+	for _, h := range health {
+		if h.Node == nil || h.Health == nil {
+			continue
+		}
+		state.capacity[h.Node.ID] = &capacity{
+			cpus:   float64(h.Health.Cpus),
+			memory: h.Health.MemoryTotal,
+		}
+	}
+
+	for _, c := range containers {
+		if _, ok := excluded[c.Container.ID]; ok {
+			continue
+		}
+		ext, ok := c.Container.Extensions[stellar.StellarServiceExtension]
+		if !ok {
+			continue
+		}
+		v, err := typeurl.UnmarshalAny(ext)
+		if err != nil {
+			return nil, err
+		}
+		svc, ok := v.(*runtimeapi.Service)
+		if !ok {
+			continue
+		}
+		state.add(&workload{
+			id:          c.Container.ID,
+			application: c.Container.Labels[stellar.StellarApplicationLabel],
+			node:        c.Node.ID,
+			service:     svc,
+		})
+	}
+
+	return state, nil
+}
+
+func (s *clusterState) add(w *workload) {
+	s.workloads = append(s.workloads, w)
+	if c, ok := s.capacity[w.node]; ok {
+		c.reserve(w.service.Resources)
+	}
+	s.reservePorts(w.node, w.id, w.service.PublishedPorts())
+}
+
+func (s *clusterState) remove(w *workload) {
+	for i, x := range s.workloads {
+		if x == w {
+			s.workloads = append(s.workloads[:i], s.workloads[i+1:]...)
+			break
+		}
+	}
+	if c, ok := s.capacity[w.node]; ok {
+		c.release(w.service.Resources)
+	}
+	s.releasePorts(w.node, w.id)
+}
+
+// fits reports whether the resources fit on the node; nodes with unknown
+// capacity are not constrained
+func (s *clusterState) fits(node string, r *runtimeapi.Resources) bool {
+	c, ok := s.capacity[node]
+	if !ok {
+		return true
+	}
+	return c.fits(r)
+}
+
+func (s *clusterState) reserve(node string, r *runtimeapi.Resources) {
+	if c, ok := s.capacity[node]; ok {
+		c.reserve(r)
+	}
+}
+
+// victims returns the lower priority workloads on the node that must be
+// evicted for the resources to fit or nil if eviction would not free enough
+func (s *clusterState) victims(node string, priority int32, r *runtimeapi.Resources) []*workload {
+	c, ok := s.capacity[node]
+	if !ok {
+		return nil
+	}
+	candidates := []*workload{}
+	for _, w := range s.workloads {
+		if w.node != node || w.service.Priority >= priority || w.service.Resources == nil {
+			continue
+		}
+		candidates = append(candidates, w)
+	}
+	// evict the lowest priority replicas first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].service.Priority == candidates[j].service.Priority {
+			return candidates[i].id < candidates[j].id
+		}
+		return candidates[i].service.Priority < candidates[j].service.Priority
+	})
+
+	free := *c
+	victims := []*workload{}
+	for _, w := range candidates {
+		if free.fits(r) {
+			break
+		}
+		free.release(w.service.Resources)
+		victims = append(victims, w)
+	}
+	if !free.fits(r) {
+		return nil
+	}
+	return victims
+}
+
+// place resolves the nodes for the replicas honoring the node capacity and
+// preempting lower priority workloads when a replica does not fit.  A node
+// only runs one replica of a service that publishes host ports.
+func (s *service) place(svc *runtimeapi.Service, nodes []*clusterapi.Node, allNodes []*clusterapi.Node, replicas uint64, state *clusterState) ([]*clusterapi.Node, []*api.FilterResult, []*api.Preemption) {
+	scheduled := []*clusterapi.Node{}
+	eliminated := []*api.FilterResult{}
+	preemptions := []*api.Preemption{}
+	reported := map[string]struct{}{}
+
+	if len(nodes) == 0 {
+		return nil, nil, nil
+	}
+
+	ports := svc.PublishedPorts()
+	next := 0
+	for i := uint64(0); i < replicas; i++ {
+		var target *clusterapi.Node
+		// round robin across the nodes that fit
+		for j := 0; j < len(nodes); j++ {
+			node := nodes[(next+j)%len(nodes)]
+			if state.portConflict(node.ID, ports) != "" {
+				continue
+			}
+			if state.fits(node.ID, svc.Resources) {
+				target = node
+				next = (next + j + 1) % len(nodes)
+				break
+			}
+			if _, ok := reported[node.ID]; !ok {
+				reported[node.ID] = struct{}{}
+				eliminated = append(eliminated, &api.FilterResult{
+					Filter: "capacity",
+					NodeID: node.ID,
+					Reason: state.capacity[node.ID].reason(svc.Resources),
+				})
+			}
+		}
+
+		if target == nil {
+			// attempt to preempt lower priority replicas on the node
+			// requiring the fewest evictions
+			var victims []*workload
+			for _, node := range nodes {
+				if state.portConflict(node.ID, ports) != "" {
+					continue
+				}
+				v := state.victims(node.ID, svc.Priority, svc.Resources)
+				if v == nil {
+					continue
+				}
+				if target == nil || len(v) < len(victims) {
+					target = node
+					victims = v
+				}
+			}
+			if target == nil {
+				eliminated = append(eliminated, &api.FilterResult{
+					Filter: "preemption",
+					Reason: fmt.Sprintf("replica %d: no lower priority replicas to preempt", i),
+				})
+				continue
+			}
+			for _, v := range victims {
+				state.remove(v)
+			}
+			state.reserve(target.ID, svc.Resources)
+			for _, v := range victims {
+				preemptions = append(preemptions, s.reschedule(v, allNodes, state))
+			}
+		} else {
+			state.reserve(target.ID, svc.Resources)
+		}
+		state.reservePorts(target.ID, fmt.Sprintf("%s.%d", svc.Name, i), ports)
+
+		scheduled = append(scheduled, target)
+	}
+
+	sort.Sort(NodeSorter(scheduled))
+	return scheduled, eliminated, preemptions
+}
+
+// reschedule places the evicted workload on another node if possible
+func (s *service) reschedule(w *workload, nodes []*clusterapi.Node, state *clusterState) *api.Preemption {
+	p := &api.Preemption{
+		ContainerID: w.id,
+		Application: w.application,
+		Service:     w.service,
+		NodeID:      w.node,
+	}
+
+	candidates := nodes
+	if pref := w.service.PlacementPreference; pref != nil && (len(pref.NodeIDs) > 0 || len(pref.Labels) > 0) {
+		candidates, _ = filterPlacement(pref, nodes)
+	}
+	for _, node := range candidates {
+		if node.ID == w.node || !state.fits(node.ID, w.service.Resources) || state.portConflict(node.ID, w.service.PublishedPorts()) != "" {
+			continue
+		}
+		p.TargetNodeID = node.ID
+		state.add(&workload{
+			id:          w.id,
+			application: w.application,
+			node:        node.ID,
+			service:     w.service,
+		})
+		break
+	}
+
+	return p
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
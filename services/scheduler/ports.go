@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"fmt"
+
+	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	api "github.com/ehazlett/stellar/api/services/scheduler/v1"
+)
+
+// reservePorts records the host ports published by the replica on the node
+func (s *clusterState) reservePorts(node, id string, ports []string) {
+	if len(ports) == 0 {
+		return
+	}
+	if s.ports == nil {
+		s.ports = map[string]map[string]string{}
+	}
+	if _, ok := s.ports[node]; !ok {
+		s.ports[node] = map[string]string{}
+	}
+	for _, p := range ports {
+		s.ports[node][p] = id
+	}
+}
+
+// releasePorts removes the host ports published by the replica on the node
+func (s *clusterState) releasePorts(node, id string) {
+	for p, owner := range s.ports[node] {
+		if owner == id {
+			delete(s.ports[node], p)
+		}
+	}
+}
+
+// portConflict returns the reason the host ports cannot be published on the
+// node or an empty string if all ports are free
+func (s *clusterState) portConflict(node string, ports []string) string {
+	for _, p := range ports {
+		if owner, ok := s.ports[node][p]; ok {
+			return fmt.Sprintf("host port %s is published by %s", p, owner)
+		}
+	}
+	return ""
+}
+
+// filterPorts returns the nodes where the host ports are free along with the
+// nodes that were eliminated
+func filterPorts(nodes []*clusterapi.Node, ports []string, state *clusterState) ([]*clusterapi.Node, []*api.FilterResult) {
+	available := []*clusterapi.Node{}
+	eliminated := []*api.FilterResult{}
+	for _, node := range nodes {
+		if reason := state.portConflict(node.ID, ports); reason != "" {
+			eliminated = append(eliminated, &api.FilterResult{
+				Filter: "ports",
+				NodeID: node.ID,
+				Reason: reason,
+			})
+			continue
+		}
+		available = append(available, node)
+	}
+	return available, eliminated
+}
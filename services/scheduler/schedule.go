@@ -2,12 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strings"
 
 	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
 	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
 	api "github.com/ehazlett/stellar/api/services/scheduler/v1"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type NodeSorter []*clusterapi.Node
@@ -17,17 +21,76 @@ func (n NodeSorter) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
 func (n NodeSorter) Less(i, j int) bool { return n[i].ID < n[j].ID }
 
 func (s *service) Schedule(ctx context.Context, req *api.ScheduleRequest) (*api.ScheduleResponse, error) {
-	nodes, err := s.schedule(req.Service, req.AvailableNodes)
+	if err := req.Service.ValidatePorts(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var state *clusterState
+	if req.Service.Resources != nil || len(req.Service.PublishedPorts()) > 0 {
+		st, err := s.clusterState(req.NodeHealth, req.ExcludeContainerIDs, req.Reservations)
+		if err != nil {
+			return nil, err
+		}
+		state = st
+	}
+
+	nodes, eliminated, preemptions, err := s.filter(req.Service, req.AvailableNodes, state)
 	if err != nil {
 		return nil, err
 	}
-	return &api.ScheduleResponse{
-		Nodes: nodes,
-	}, nil
+	resp := &api.ScheduleResponse{
+		Nodes:       nodes,
+		Preemptions: preemptions,
+	}
+	// only report the filter decisions when simulating
+	if req.DryRun {
+		resp.Eliminated = eliminated
+	}
+	return resp, nil
 }
 
 func (s *service) schedule(svc *runtimeapi.Service, nodes []*clusterapi.Node) ([]*clusterapi.Node, error) {
-	// short-circuit to skip filtering if no preference is specified
+	scheduled, _, _, err := s.filter(svc, nodes, nil)
+	return scheduled, err
+}
+
+// clusterState loads the node capacity and running workloads from the cluster
+// ignoring the excluded containers and holding the reserved capacity
+func (s *service) clusterState(health []*clusterapi.NodeHealth, exclude []string, reservations []*api.Reservation) (*clusterState, error) {
+	c, err := s.client(s.agent.Self().Address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if len(health) == 0 {
+		h, err := c.Cluster().Health()
+		if err != nil {
+			return nil, err
+		}
+		health = h
+	}
+
+	containers, err := c.Cluster().Containers()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := newClusterState(health, containers, exclude)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reservations {
+		state.reserve(r.NodeID, r.Resources)
+	}
+	return state, nil
+}
+
+// filter resolves the nodes for the service replicas and returns the nodes
+// that were eliminated along with the filter that removed them.  If the
+// cluster state is specified the node capacity and published host ports are
+// honored and lower priority replicas are preempted as needed.
+func (s *service) filter(svc *runtimeapi.Service, nodes []*clusterapi.Node, state *clusterState) ([]*clusterapi.Node, []*api.FilterResult, []*api.Preemption, error) {
 	pref := svc.PlacementPreference
 	replicas := svc.Replicas
 	if replicas == 0 {
@@ -35,71 +98,104 @@ func (s *service) schedule(svc *runtimeapi.Service, nodes []*clusterapi.Node) ([
 		replicas = uint64(1)
 	}
 
-	if pref == nil || len(pref.NodeIDs) == 0 && len(pref.Labels) == 0 {
-		return resolveNodesForReplicas(nodes, replicas), nil
+	placementNodes := nodes
+	var eliminated []*api.FilterResult
+	// skip filtering if no preference is specified
+	if pref != nil && (len(pref.NodeIDs) > 0 || len(pref.Labels) > 0) {
+		placementNodes, eliminated = filterPlacement(pref, nodes)
 	}
 
-	placementNodes := []*clusterapi.Node{}
+	logrus.WithFields(logrus.Fields{
+		"service":  svc.Name,
+		"replicas": replicas,
+	}).Debug("resolving nodes for replicas")
 
-	availableNodes := map[string]*clusterapi.Node{}
-	for _, node := range nodes {
-		availableNodes[node.ID] = node
+	if ports := svc.PublishedPorts(); state != nil && len(ports) > 0 {
+		var portsEliminated []*api.FilterResult
+		placementNodes, portsEliminated = filterPorts(placementNodes, ports, state)
+		eliminated = append(eliminated, portsEliminated...)
+		// the host ports can only be published by one replica per node
+		if available := uint64(len(placementNodes)); available < replicas {
+			reason := fmt.Sprintf("%d of %d replicas cannot be placed; host ports %s are published once per node", replicas-available, replicas, strings.Join(ports, ","))
+			logrus.WithField("service", svc.Name).Warn(reason)
+			eliminated = append(eliminated, &api.FilterResult{
+				Filter: "ports",
+				Reason: reason,
+			})
+			replicas = available
+		}
 	}
 
-	nodeIDNodes := []*clusterapi.Node{}
-	nodeLabelNodes := []*clusterapi.Node{}
+	if state == nil || svc.Resources == nil {
+		return resolveNodesForReplicas(placementNodes, replicas), eliminated, nil, nil
+	}
 
-	// filter node ids
+	scheduled, capacityEliminated, preemptions := s.place(svc, placementNodes, nodes, replicas, state)
+	return scheduled, append(eliminated, capacityEliminated...), preemptions, nil
+}
+
+// filterPlacement returns the nodes matching the placement preference by
+// node id or labels along with the nodes that were eliminated
+func filterPlacement(pref *runtimeapi.PlacementPreference, nodes []*clusterapi.Node) ([]*clusterapi.Node, []*api.FilterResult) {
+	nodeIDs := map[string]struct{}{}
 	for _, id := range pref.NodeIDs {
-		if node, ok := availableNodes[id]; ok {
-			nodeIDNodes = append(nodeIDNodes, node)
-		}
+		nodeIDs[id] = struct{}{}
 	}
 
-	// filter node labels
+	placementNodes := []*clusterapi.Node{}
+	eliminated := []*api.FilterResult{}
 	for _, node := range nodes {
-		valid := false
-		for k, v := range pref.Labels {
-			x, ok := node.Labels[k]
-			// label missing
-			if !ok {
-				valid = false
-				break
-			}
-			// label value does not match and is not empty
-			if x != "" && x != v {
-				valid = false
-				break
-			}
+		// filter node ids
+		if _, ok := nodeIDs[node.ID]; ok {
+			placementNodes = append(placementNodes, node)
+			continue
+		}
 
-			valid = true
+		// filter node labels
+		reason := matchLabels(node, pref.Labels)
+		if reason == "" {
+			placementNodes = append(placementNodes, node)
+			continue
 		}
-		if valid {
-			nodeLabelNodes = append(nodeLabelNodes, node)
+
+		if len(pref.NodeIDs) > 0 {
+			reason = "node not in placement node ids and " + reason
 		}
+		eliminated = append(eliminated, &api.FilterResult{
+			Filter: "placement",
+			NodeID: node.ID,
+			Reason: reason,
+		})
 	}
 
-	filteredNodes := map[string]*clusterapi.Node{}
-	for _, node := range nodeIDNodes {
-		if _, ok := filteredNodes[node.ID]; !ok {
-			filteredNodes[node.ID] = node
-		}
+	return placementNodes, eliminated
+}
+
+// matchLabels returns the reason the node does not satisfy the label
+// preference or an empty string if the node matches
+func matchLabels(node *clusterapi.Node, labels map[string]string) string {
+	if len(labels) == 0 {
+		return "no matching placement labels"
 	}
-	for _, node := range nodeLabelNodes {
-		if _, ok := filteredNodes[node.ID]; !ok {
-			filteredNodes[node.ID] = node
-		}
+	keys := []string{}
+	for k := range labels {
+		keys = append(keys, k)
 	}
-
-	for _, node := range filteredNodes {
-		placementNodes = append(placementNodes, node)
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := labels[k]
+		x, ok := node.Labels[k]
+		// label missing
+		if !ok {
+			return fmt.Sprintf("label %s missing", k)
+		}
+		// label value does not match and is not empty
+		if x != "" && x != v {
+			return fmt.Sprintf("label %s=%s does not match %s", k, x, v)
+		}
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"service":  svc.Name,
-		"replicas": replicas,
-	}).Debug("resolving nodes for replicas")
-	return resolveNodesForReplicas(placementNodes, replicas), nil
+	return ""
 }
 
 func resolveNodesForReplicas(nodes []*clusterapi.Node, replicas uint64) []*clusterapi.Node {
@@ -1,10 +1,16 @@
 package scheduler
 
 import (
+	"context"
 	"testing"
 
+	"github.com/containerd/typeurl"
+	"github.com/ehazlett/stellar"
 	clusterapi "github.com/ehazlett/stellar/api/services/cluster/v1"
+	healthapi "github.com/ehazlett/stellar/api/services/health/v1"
 	runtimeapi "github.com/ehazlett/stellar/api/services/runtime/v1"
+	api "github.com/ehazlett/stellar/api/services/scheduler/v1"
+	ptypes "github.com/gogo/protobuf/types"
 )
 
 func TestScheduleNoPreference(t *testing.T) {
@@ -363,3 +369,296 @@ func TestScheduleNodesByLabelMultiWithEmptyWithReplicas(t *testing.T) {
 		}
 	}
 }
+
+func TestScheduleDryRunEliminated(t *testing.T) {
+	expected := map[string]string{
+		"node-01": "label env=qa does not match prod",
+		"node-02": "label env missing",
+	}
+
+	availableNodes := []*clusterapi.Node{
+		{
+			ID:      "node-00",
+			Address: "127.0.0.1:9000",
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		{
+			ID:      "node-01",
+			Address: "127.0.0.1:9001",
+			Labels: map[string]string{
+				"env": "qa",
+			},
+		},
+		{
+			ID:      "node-02",
+			Address: "127.0.0.1:9002",
+		},
+	}
+
+	appService := &runtimeapi.Service{
+		Name: "test-service",
+		PlacementPreference: &runtimeapi.PlacementPreference{
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Replicas: uint64(1),
+	}
+
+	svc := &service{}
+	resp, err := svc.Schedule(context.Background(), &api.ScheduleRequest{
+		Service:        appService,
+		AvailableNodes: availableNodes,
+		DryRun:         true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Nodes) != 1 || resp.Nodes[0].ID != "node-00" {
+		t.Fatalf("expected node-00; received %+v", resp.Nodes)
+	}
+
+	if len(resp.Eliminated) != len(expected) {
+		t.Fatalf("expected %d eliminated nodes; received %d", len(expected), len(resp.Eliminated))
+	}
+
+	for _, e := range resp.Eliminated {
+		if v, ok := expected[e.NodeID]; !ok || v != e.Reason {
+			t.Fatalf("unexpected elimination %s: %s", e.NodeID, e.Reason)
+		}
+	}
+}
+
+func TestSchedulePreemptLowerPriority(t *testing.T) {
+	availableNodes := []*clusterapi.Node{
+		{
+			ID:      "node-00",
+			Address: "127.0.0.1:9000",
+		},
+		{
+			ID:      "node-01",
+			Address: "127.0.0.1:9001",
+		},
+	}
+
+	batch := &runtimeapi.Service{
+		Name:     "batch",
+		Priority: 0,
+		Resources: &runtimeapi.Resources{
+			Memory: 768,
+		},
+	}
+	state := &clusterState{
+		capacity: map[string]*capacity{
+			"node-00": {cpus: 2, memory: 1024},
+			"node-01": {cpus: 2, memory: 1024},
+		},
+	}
+	state.add(&workload{id: "app.batch.0", application: "app", node: "node-00", service: batch})
+	state.add(&workload{id: "app.batch.1", application: "app", node: "node-01", service: batch})
+
+	appService := &runtimeapi.Service{
+		Name:     "critical",
+		Priority: 100,
+		Resources: &runtimeapi.Resources{
+			Memory: 512,
+		},
+	}
+
+	svc := &service{}
+	nodes, _, preemptions, err := svc.filter(appService, availableNodes, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nodes) != 1 || nodes[0].ID != "node-00" {
+		t.Fatalf("expected node-00; received %+v", nodes)
+	}
+
+	if len(preemptions) != 1 {
+		t.Fatalf("expected 1 preemption; received %d", len(preemptions))
+	}
+
+	p := preemptions[0]
+	if p.ContainerID != "app.batch.0" || p.NodeID != "node-00" {
+		t.Fatalf("unexpected preemption %s on %s", p.ContainerID, p.NodeID)
+	}
+
+	// no node has capacity for the evicted replica
+	if p.TargetNodeID != "" {
+		t.Fatalf("expected preempted replica to be unschedulable; received %s", p.TargetNodeID)
+	}
+}
+
+func TestSchedulePreemptSamePriority(t *testing.T) {
+	availableNodes := []*clusterapi.Node{
+		{
+			ID:      "node-00",
+			Address: "127.0.0.1:9000",
+		},
+	}
+
+	other := &runtimeapi.Service{
+		Name:     "other",
+		Priority: 10,
+		Resources: &runtimeapi.Resources{
+			Memory: 1024,
+		},
+	}
+	state := &clusterState{
+		capacity: map[string]*capacity{
+			"node-00": {cpus: 2, memory: 1024},
+		},
+	}
+	state.add(&workload{id: "app.other.0", application: "app", node: "node-00", service: other})
+
+	appService := &runtimeapi.Service{
+		Name:     "test-service",
+		Priority: 10,
+		Resources: &runtimeapi.Resources{
+			Memory: 512,
+		},
+	}
+
+	svc := &service{}
+	nodes, eliminated, preemptions, err := svc.filter(appService, availableNodes, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nodes) != 0 {
+		t.Fatalf("expected no nodes; received %d", len(nodes))
+	}
+
+	if len(preemptions) != 0 {
+		t.Fatalf("expected no preemptions; received %d", len(preemptions))
+	}
+
+	if len(eliminated) != 2 || eliminated[0].Filter != "capacity" {
+		t.Fatalf("unexpected eliminated nodes %+v", eliminated)
+	}
+}
+
+func TestSchedulePublishedPorts(t *testing.T) {
+	availableNodes := []*clusterapi.Node{
+		{
+			ID:      "node-00",
+			Address: "127.0.0.1:9000",
+		},
+		{
+			ID:      "node-01",
+			Address: "127.0.0.1:9001",
+		},
+		{
+			ID:      "node-02",
+			Address: "127.0.0.1:9002",
+		},
+	}
+
+	dns := &runtimeapi.Service{
+		Name: "dns",
+		Endpoints: []*runtimeapi.Endpoint{
+			{Service: "dns", Protocol: runtimeapi.Protocol_UDP, Port: 53, HostPort: 53},
+		},
+	}
+	state := &clusterState{}
+	state.add(&workload{id: "app.dns.0", application: "app", node: "node-00", service: dns})
+
+	appService := &runtimeapi.Service{
+		Name:     "test-service",
+		Replicas: uint64(3),
+		Endpoints: []*runtimeapi.Endpoint{
+			{Service: "dns", Protocol: runtimeapi.Protocol_UDP, Port: 5353, HostPort: 53},
+			{Service: "api", Protocol: runtimeapi.Protocol_TCP, Port: 8080},
+		},
+	}
+
+	svc := &service{}
+	nodes, eliminated, _, err := svc.filter(appService, availableNodes, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a node only publishes the host port for one replica
+	if len(nodes) != 2 || nodes[0].ID != "node-01" || nodes[1].ID != "node-02" {
+		t.Fatalf("expected node-01 and node-02; received %+v", nodes)
+	}
+
+	if len(eliminated) != 2 || eliminated[0].NodeID != "node-00" || eliminated[0].Reason != "host port udp/53 is published by app.dns.0" || eliminated[1].Filter != "ports" {
+		t.Fatalf("unexpected eliminated nodes %+v", eliminated)
+	}
+
+	// the tcp port of the same number is free
+	appService.Endpoints[0].Protocol = runtimeapi.Protocol_TCP
+	appService.Replicas = uint64(1)
+	if nodes, _, _, err = svc.filter(appService, availableNodes, state); err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "node-00" {
+		t.Fatalf("expected node-00; received %+v", nodes)
+	}
+}
+
+func TestValidatePublishedPorts(t *testing.T) {
+	for name, endpoints := range map[string][]*runtimeapi.Endpoint{
+		"range":     {{Service: "a", Port: 80, HostPort: 70000}},
+		"port":      {{Service: "a", HostPort: 80}},
+		"duplicate": {{Service: "a", Port: 80, HostPort: 80}, {Service: "b", Protocol: runtimeapi.Protocol_HTTP, Port: 81, HostPort: 80}},
+	} {
+		svc := &runtimeapi.Service{Name: "test-service", Endpoints: endpoints}
+		if err := svc.ValidatePorts(); err == nil {
+			t.Fatalf("expected error for invalid %s", name)
+		}
+	}
+}
+
+func TestClusterStateExclude(t *testing.T) {
+	web := &runtimeapi.Service{
+		Name: "web",
+		Resources: &runtimeapi.Resources{
+			Memory: 512,
+		},
+	}
+	ext, err := typeurl.MarshalAny(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	health := []*clusterapi.NodeHealth{
+		{
+			Node:   &clusterapi.Node{ID: "node-00"},
+			Health: &healthapi.NodeHealth{Cpus: 2, MemoryTotal: 1024},
+		},
+	}
+	var containers []*clusterapi.Container
+	for _, id := range []string{"app.web.0", "app.web.1"} {
+		containers = append(containers, &clusterapi.Container{
+			Container: &runtimeapi.Container{
+				ID: id,
+				Extensions: map[string]*ptypes.Any{
+					stellar.StellarServiceExtension: ext,
+				},
+			},
+			Node: &clusterapi.Node{ID: "node-00"},
+		})
+	}
+
+	state, err := newClusterState(health, containers, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.fits("node-00", web.Resources) {
+		t.Fatal("expected node-00 to be full")
+	}
+
+	// excluded replicas do not reserve capacity
+	state, err = newClusterState(health, containers, []string{"app.web.0", "app.web.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.workloads) != 0 || state.capacity["node-00"].memory != 1024 {
+		t.Fatalf("unexpected cluster state %+v", state.capacity["node-00"])
+	}
+}
@@ -15,11 +15,13 @@ const (
 
 type service struct {
 	config *stellar.Config
+	agent  *element.Agent
 }
 
-func New(cfg *stellar.Config, _ *element.Agent) (services.Service, error) {
+func New(cfg *stellar.Config, agent *element.Agent) (services.Service, error) {
 	return &service{
 		config: cfg,
+		agent:  agent,
 	}, nil
 }
 
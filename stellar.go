@@ -11,4 +11,13 @@ const (
 	StellarRestartLabel     = "stellar.io/restart"
 	StellarExtensionID      = "stellar.io/extensions"
 	StellarServiceExtension = StellarExtensionID + "/Service"
+
+	// DefaultNetworkName is the name of the network configured on the nodes
+	DefaultNetworkName = "stellar"
+	// NetworkModeRouted routes the node subnets via the node bind addresses
+	NetworkModeRouted = "routed"
+	// NetworkModeVXLAN connects the node bridges with a VXLAN overlay
+	NetworkModeVXLAN = "vxlan"
+	// VXLANDeviceName is the name of the node VTEP device in vxlan mode
+	VXLANDeviceName = "stellar-vxlan"
 )